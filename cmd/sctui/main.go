@@ -2,46 +2,161 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
-	"regexp"
-	"strings"
+	"os/signal"
+	"path/filepath"
+	"syscall"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/config"
+	"soundcloud-tui/internal/daemon"
+	"soundcloud-tui/internal/history"
+	"soundcloud-tui/internal/playlist"
 	"soundcloud-tui/internal/soundcloud"
 	"soundcloud-tui/internal/ui/app"
 	"soundcloud-tui/internal/ui/components/player"
+	"soundcloud-tui/internal/ui/styles"
+	"soundcloud-tui/internal/version"
 )
 
 func main() {
 	var (
 		searchFlag = flag.String("search", "", "Search for tracks")
 		trackFlag  = flag.String("track", "", "Get info for a specific track URL")
-		playFlag   = flag.String("play", "", "Play a specific track URL directly")
+		playFlag   = flag.String("play", "", "Play a specific track URL or local audio file directly")
+		repeatFlag = flag.Bool("repeat", false, "With -play, loop the track until you quit")
+		noGUIFlag  = flag.Bool("nogui", false, "With -play, play without the TUI until the track ends or Ctrl+C")
+		openFlag   = flag.String("open", "", "Start the full TUI in the player view, playing a track URL or local audio file")
+		playlistFileFlag = flag.String("playlist-file", "", "Queue and play the tracks in an M3U or JSON playlist, or a file of track URLs")
 		testAudioFlag = flag.String("test-audio", "", "Test audio playback without TUI")
 		testTuiFlag   = flag.String("test-tui", "", "Test TUI message flow without interactive mode")
+		noColorFlag   = flag.Bool("no-color", false, "Disable colors and emoji icons")
+		jsonFlag      = flag.Bool("json", false, "Print -search and -track results as JSON")
+		playerFlag    = flag.String("player", string(audio.DefaultPlayerKind), "Audio player implementation: beep or buffered")
+		qualityFlag   = flag.String("quality", audio.DefaultQuality, "With -play, -test-audio or -daemon, the stream to prefer: progressive or hls")
+		daemonFlag    = flag.Bool("daemon", false, "Run the player without the TUI, controlled over a local socket")
+		ctlFlag       = flag.String("ctl", "", "Send a command to a running daemon, e.g. \"pause\" or \"play URL\"")
+		socketFlag    = flag.String("socket", daemon.DefaultSocketPath(), "Control socket for -daemon and -ctl")
+		outputDevicesFlag = flag.Bool("output-devices", false, "List the audio output devices for the output_device setting")
+		offlineFlag = flag.Bool("offline", false, "Search and play only the tracks in the local track cache")
+		historyFlag = flag.Bool("history", false, "Print recently played and most played tracks")
+		versionFlag = flag.Bool("version", false, "Print the version and exit")
 		helpFlag   = flag.Bool("help", false, "Show help")
 	)
 	flag.Parse()
 
+	// Honor -no-color and the NO_COLOR convention (https://no-color.org)
+	if *noColorFlag || os.Getenv("NO_COLOR") != "" {
+		styles.SetNoColor(true)
+	}
+
 	if *helpFlag {
 		showHelp()
 		return
 	}
 
-	// Show disclaimer on first run
-	showDisclaimer()
+	if *versionFlag {
+		fmt.Println(version.String())
+		return
+	}
+
+	playerKind, err := audio.ParsePlayerKind(*playerFlag)
+	if err != nil {
+		log.Fatalf("Invalid -player: %v", err)
+	}
+	
+	quality, err := audio.ParseQuality(*qualityFlag)
+	if err != nil {
+		log.Fatalf("Invalid -quality: %v", err)
+	}
+	
+	if *outputDevicesFlag {
+		if err := listOutputDevices(playerKind); err != nil {
+			log.Fatalf("Failed to list output devices: %v", err)
+		}
+		return
+	}
+	
+	// Controlling a daemon needs neither SoundCloud nor the disclaimer
+	if *ctlFlag != "" {
+		if err := sendControlCommand(*socketFlag, *ctlFlag); err != nil {
+			log.Fatalf("Control command failed: %v", err)
+		}
+		return
+	}
+
+	// The listening history is local, so it needs no SoundCloud either
+	if *historyFlag {
+		if err := printPlayHistory(*jsonFlag); err != nil {
+			if *jsonFlag {
+				exitWithJSONError(err)
+			}
+			log.Fatalf("Failed to show history: %v", err)
+		}
+		return
+	}
+
+	// Show disclaimer on first run; keep stdout clean for JSON output
+	if *jsonFlag {
+		showDisclaimer(os.Stderr)
+	} else {
+		showDisclaimer(os.Stdout)
+	}
+
+	// Local files need no SoundCloud client, so they also play offline
+	if *playFlag != "" && audio.IsLocalStream(*playFlag) {
+		play := playTrackFromURL
+		if *noGUIFlag {
+			play = playHeadless
+		}
+		if err := play(nil, playerKind, quality, *playFlag, *repeatFlag); err != nil {
+			log.Fatalf("Failed to play track: %v", err)
+		}
+		return
+	}
+
+	// Offline mode plays from the track cache without SoundCloud
+	if *offlineFlag {
+		application, err := app.NewAppWithNamedBackend(app.OfflineBackend, playerKind)
+		if err != nil {
+			log.Fatalf("Failed to start offline: %v", err)
+		}
+		if err := runApp(application); err != nil {
+			log.Fatalf("Failed to start TUI: %v", err)
+		}
+		return
+	}
 
 	client, err := soundcloud.NewClient()
 	if err != nil {
+		if *jsonFlag {
+			exitWithJSONError(fmt.Errorf("failed to create SoundCloud client: %w", err))
+		}
 		log.Fatalf("Failed to create SoundCloud client: %v", err)
 	}
 
 	if *searchFlag != "" {
+		if *jsonFlag {
+			tracks, err := client.Search(*searchFlag)
+			if err != nil && !errors.Is(err, soundcloud.ErrNoResults) {
+				exitWithJSONError(fmt.Errorf("search failed: %w", err))
+			}
+			if tracks == nil {
+				tracks = []soundcloud.Track{} // Print [] rather than null
+			}
+			if err := writeJSON(os.Stdout, tracks); err != nil {
+				exitWithJSONError(err)
+			}
+			return
+		}
 		if err := searchTracks(client, *searchFlag); err != nil {
 			log.Fatalf("Search failed: %v", err)
 		}
@@ -49,47 +164,104 @@ func main() {
 	}
 
 	if *trackFlag != "" {
+		if *jsonFlag {
+			track, err := client.GetTrackInfo(*trackFlag)
+			if err != nil {
+				exitWithJSONError(fmt.Errorf("failed to get track info: %w", err))
+			}
+			if err := writeJSON(os.Stdout, track); err != nil {
+				exitWithJSONError(err)
+			}
+			return
+		}
 		if err := getTrackInfo(client, *trackFlag); err != nil {
 			log.Fatalf("Failed to get track info: %v", err)
 		}
 		return
 	}
 
+	if *playFlag != "" && *noGUIFlag {
+		if err := playHeadless(client, playerKind, quality, *playFlag, *repeatFlag); err != nil {
+			log.Fatalf("Failed to play track: %v", err)
+		}
+		return
+	}
+
 	if *playFlag != "" {
-		if err := playTrackFromURL(client, *playFlag); err != nil {
+		if err := playTrackFromURL(client, playerKind, quality, *playFlag, *repeatFlag); err != nil {
 			log.Fatalf("Failed to play track: %v", err)
 		}
 		return
 	}
 
+	if *daemonFlag {
+		if err := runDaemon(client, playerKind, quality, *socketFlag); err != nil {
+			log.Fatalf("Daemon failed: %v", err)
+		}
+		return
+	}
+	
+	if *openFlag != "" {
+		if err := openInApp(client, playerKind, *openFlag); err != nil {
+			log.Fatalf("Failed to open track: %v", err)
+		}
+		return
+	}
+	
+	if *playlistFileFlag != "" {
+		if err := playPlaylistFile(client, playerKind, *playlistFileFlag); err != nil {
+			log.Fatalf("Failed to play playlist file: %v", err)
+		}
+		return
+	}
+
 	if *testAudioFlag != "" {
-		if err := testAudioPlayback(client, *testAudioFlag); err != nil {
+		if err := testAudioPlayback(client, playerKind, quality, *testAudioFlag); err != nil {
 			log.Fatalf("Failed to test audio: %v", err)
 		}
 		return
 	}
 
 	if *testTuiFlag != "" {
-		if err := testTuiPlayback(client, *testTuiFlag); err != nil {
+		if err := testTuiPlayback(client, playerKind, *testTuiFlag); err != nil {
 			log.Fatalf("Failed to test TUI: %v", err)
 		}
 		return
 	}
 
 	// Start TUI application
-	application := app.NewApp()
-	program := tea.NewProgram(application, tea.WithAltScreen())
-	
-	if _, err := program.Run(); err != nil {
+	application := app.NewAppWithPlayer(playerKind)
+	if err := runApp(application); err != nil {
 		log.Fatalf("Failed to start TUI: %v", err)
 	}
 }
 
+// runApp runs the full TUI until the user quits or the process gets SIGINT or
+// SIGTERM. A signal cancels the root context, which tells the program to quit;
+// either way audio is stopped and the session saved before returning.
+func runApp(application *app.App) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	
+	// Bubble Tea would end the program on SIGINT itself, skipping the teardown
+	program := tea.NewProgram(application, tea.WithAltScreen(), tea.WithReportFocus(), tea.WithoutSignalHandler())
+	go func() {
+		<-ctx.Done()
+		// A second signal kills the process if the teardown hangs
+		stop()
+		program.Quit()
+	}()
+	
+	_, err := program.Run()
+	application.Shutdown()
+	return err
+}
+
 func searchTracks(client *soundcloud.Client, query string) error {
 	fmt.Printf("🔍 Searching for: %s\n\n", query)
 	
-	tracks, err := client.Search(query)
-	if err != nil {
+	tracks, total, err := client.SearchWithTotal(query)
+	if err != nil && !errors.Is(err, soundcloud.ErrNoResults) {
 		return fmt.Errorf("search failed: %w", err)
 	}
 
@@ -98,8 +270,9 @@ func searchTracks(client *soundcloud.Client, query string) error {
 		return nil
 	}
 
-	fmt.Printf("Found %d tracks:\n\n", len(tracks))
-	for i, track := range tracks[:min(10, len(tracks))] {
+	shown := min(10, len(tracks))
+	fmt.Printf("Found %d tracks, showing %d:\n\n", total, shown)
+	for i, track := range tracks[:shown] {
 		duration := formatDuration(track.Duration)
 		fmt.Printf("%2d. %s\n", i+1, track.Title)
 		fmt.Printf("    by %s\n", track.User.FullName())
@@ -129,6 +302,78 @@ func getTrackInfo(client *soundcloud.Client, url string) error {
 	return nil
 }
 
+// Plays shown by -history
+const (
+	historyRecentPlays = 20
+	historyTopTracks   = 10
+)
+
+// printPlayHistory prints the most recent plays and the most played tracks
+// from the listening history, as JSON when asJSON is set
+func printPlayHistory(asJSON bool) error {
+	path := filepath.Join(config.ConfigDir(), history.PlaysFileName)
+	recorder, err := history.LoadRecorder(path, history.DefaultMaxPlays)
+	if err != nil {
+		return err
+	}
+	
+	recent := recorder.Recent(historyRecentPlays)
+	top := recorder.TopTracks(historyTopTracks)
+	if asJSON {
+		return writeJSON(os.Stdout, struct {
+			Recent []history.Play       `json:"recent"`
+			Top    []history.TrackPlays `json:"top"`
+		}{recent, top})
+	}
+	
+	if len(recent) == 0 {
+		fmt.Printf("No plays recorded yet in %s\n", path)
+		return nil
+	}
+	
+	fmt.Println("Recently played:")
+	for _, play := range recent {
+		fmt.Printf("  %s  %s\n", play.PlayedAt.Local().Format("2006-01-02 15:04"), describePlay(play))
+	}
+	
+	fmt.Println("\nMost played:")
+	for i, track := range top {
+		plays := "plays"
+		if track.Count == 1 {
+			plays = "play"
+		}
+		fmt.Printf("%2d. %s (%d %s, last %s)\n", i+1, describePlay(track.Play), track.Count, plays, track.PlayedAt.Local().Format("2006-01-02"))
+	}
+	
+	return nil
+}
+
+// describePlay names the track played, with its artist when known
+func describePlay(play history.Play) string {
+	if play.Artist == "" || play.Artist == soundcloud.UnknownArtist {
+		return play.Title
+	}
+	return play.Title + " — " + play.Artist
+}
+
+// writeJSON prints v as indented JSON
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return nil
+}
+
+// exitWithJSONError prints err as a JSON object on stderr and exits non-zero
+func exitWithJSONError(err error) {
+	_ = writeJSON(os.Stderr, struct {
+		Error string `json:"error"`
+	}{Error: err.Error()})
+	os.Exit(1)
+}
+
 func formatDuration(ms int64) string {
 	seconds := ms / 1000
 	minutes := seconds / 60
@@ -143,61 +388,43 @@ func min(a, b int) int {
 	return b
 }
 
-func showDisclaimer() {
-	fmt.Println("⚠️  IMPORTANT DISCLAIMER ⚠️")
-	fmt.Println()
-	fmt.Println("This application uses SoundCloud's undocumented internal API")
-	fmt.Println("through a reverse-engineered Go library. This may violate")
-	fmt.Println("SoundCloud's Terms of Service.")
-	fmt.Println()
-	fmt.Println("By using this software, you acknowledge:")
-	fmt.Println("• This is for educational/personal use only")
-	fmt.Println("• You assume full responsibility for ToS compliance")
-	fmt.Println("• The functionality may break if SoundCloud changes their API")
-	fmt.Println("• Consider supporting artists through official channels")
-	fmt.Println()
-	fmt.Println("Use at your own discretion and risk.")
-	fmt.Println("═══════════════════════════════════════════════════════════")
-	fmt.Println()
+func showDisclaimer(w io.Writer) {
+	fmt.Fprintln(w, "⚠️  IMPORTANT DISCLAIMER ⚠️")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "This application uses SoundCloud's undocumented internal API")
+	fmt.Fprintln(w, "through a reverse-engineered Go library. This may violate")
+	fmt.Fprintln(w, "SoundCloud's Terms of Service.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "By using this software, you acknowledge:")
+	fmt.Fprintln(w, "• This is for educational/personal use only")
+	fmt.Fprintln(w, "• You assume full responsibility for ToS compliance")
+	fmt.Fprintln(w, "• The functionality may break if SoundCloud changes their API")
+	fmt.Fprintln(w, "• Consider supporting artists through official channels")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Use at your own discretion and risk.")
+	fmt.Fprintln(w, "═══════════════════════════════════════════════════════════")
+	fmt.Fprintln(w)
 }
 
-// validateSoundCloudURL validates and normalizes a SoundCloud URL
-func validateSoundCloudURL(url string) error {
-	// Remove whitespace and common prefixes
-	url = strings.TrimSpace(url)
-	
-	// Ensure it's a valid SoundCloud URL
-	soundcloudPattern := regexp.MustCompile(`^https?://(www\.|m\.)?soundcloud\.com/[^/]+/[^/?]+`)
-	if !soundcloudPattern.MatchString(url) {
-		return fmt.Errorf("invalid SoundCloud URL format. Expected: https://soundcloud.com/artist/track")
-	}
-	
-	return nil
-}
-
-// playTrackFromURL plays a track directly from a SoundCloud URL
-func playTrackFromURL(client *soundcloud.Client, url string) error {
+// playTrackFromURL plays a track directly from a SoundCloud URL, looping it
+// when repeat is set. quality is the stream protocol to prefer.
+func playTrackFromURL(client *soundcloud.Client, playerKind audio.PlayerKind, quality string, url string, repeat bool) error {
 	fmt.Printf("🎵 Loading track from: %s\n\n", url)
 	
-	// Validate URL format
-	if err := validateSoundCloudURL(url); err != nil {
-		return err
-	}
-	
-	// Get track information
-	track, err := client.GetTrackInfo(url)
+	track, err := resolvePlayURL(client, url)
 	if err != nil {
-		return fmt.Errorf("failed to get track info: %w", err)
+		return err
 	}
+	printNowPlaying(track)
 	
-	fmt.Printf("Now playing: %s by %s\n", track.Title, track.User.FullName())
-	fmt.Printf("Duration: %s\n\n", formatDuration(track.Duration))
-	
-	// Create audio components with enhanced buffered streaming
-	audioPlayer := audio.NewBufferedBeepPlayer()
+	// Create audio components
+	audioPlayer := audio.NewPlayer(playerKind)
 	defer audioPlayer.Close()
 	
 	streamExtractor := audio.NewRealSoundCloudStreamExtractor(client)
+	if err := streamExtractor.SetPreferredQuality(quality); err != nil {
+		return err
+	}
 	
 	// Create player-only TUI
 	playerComponent := player.NewPlayerComponent(audioPlayer, streamExtractor)
@@ -206,6 +433,7 @@ func playTrackFromURL(client *soundcloud.Client, url string) error {
 	playApp := &DirectPlayApp{
 		player: playerComponent,
 		track:  track,
+		repeat: repeat,
 	}
 	
 	// Start the player TUI
@@ -216,10 +444,289 @@ func playTrackFromURL(client *soundcloud.Client, url string) error {
 	return err
 }
 
+// Headless (-nogui) playback timing
+const (
+	headlessPollInterval   = 250 * time.Millisecond // How often the track is checked for its end
+	headlessStatusInterval = 5 * time.Second        // How often a status line is printed
+	trackEndMargin         = 2 * time.Second        // A stop this close to the end finishes the track
+)
+
+// playHeadless plays the track at url without any interface, printing a
+// status line every few seconds, until it ends (looping when repeat is set)
+// or SIGINT or SIGTERM arrives. Playback is stopped before returning.
+func playHeadless(client *soundcloud.Client, playerKind audio.PlayerKind, quality string, url string, repeat bool) error {
+	fmt.Printf("🎵 Loading track from: %s\n\n", url)
+	
+	track, err := resolvePlayURL(client, url)
+	if err != nil {
+		return err
+	}
+	printNowPlaying(track)
+	
+	audioPlayer := audio.NewPlayer(playerKind)
+	defer audioPlayer.Close()
+	
+	// Local files play straight from disk; SoundCloud tracks need a stream
+	streamURL := track.StreamURL
+	expected := time.Duration(track.Duration) * time.Millisecond
+	if !audio.IsLocalStream(track.StreamURL) {
+		streamExtractor := audio.NewRealSoundCloudStreamExtractor(client)
+		if err := streamExtractor.SetPreferredQuality(quality); err != nil {
+			return err
+		}
+		
+		info, err := streamExtractor.ExtractStreamURL(context.Background(), track.ID)
+		if err != nil {
+			return fmt.Errorf("failed to extract stream URL: %w", err)
+		}
+		streamURL = info.URL
+		if info.Duration > 0 {
+			expected = time.Duration(info.Duration) * time.Millisecond
+		}
+		audioPlayer.SetExpectedDuration(expected)
+		audioPlayer.SetExpectedFormat(info.Format)
+	}
+	
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	
+	for {
+		if err := audioPlayer.Play(ctx, streamURL); err != nil {
+			if ctx.Err() != nil {
+				return nil // Interrupted while loading
+			}
+			return fmt.Errorf("failed to start playback: %w", err)
+		}
+		
+		err := waitForTrackEnd(ctx, audioPlayer, expected)
+		if ctx.Err() != nil {
+			fmt.Println("\nInterrupted, stopping playback")
+			return audioPlayer.Stop()
+		}
+		if err != nil {
+			return err
+		}
+		if !repeat {
+			fmt.Println("✅ Finished")
+			return nil
+		}
+		fmt.Println("🔁 Repeating")
+	}
+}
+
+// waitForTrackEnd blocks until the player stops or ctx is cancelled, printing
+// a status line every headlessStatusInterval. A stop well before the expected
+// duration, such as after a network drop, is reported as an error.
+func waitForTrackEnd(ctx context.Context, audioPlayer audio.Player, expected time.Duration) error {
+	poll := time.NewTicker(headlessPollInterval)
+	defer poll.Stop()
+	
+	printPlaybackStatus(audioPlayer, expected)
+	lastStatus := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-poll.C:
+		}
+		
+		if audioPlayer.GetState() == audio.StateStopped {
+			position := audioPlayer.GetPosition()
+			if expected > 0 && position < expected-trackEndMargin {
+				return fmt.Errorf("playback stopped at %s of %s",
+					formatDuration(position.Milliseconds()), formatDuration(expected.Milliseconds()))
+			}
+			return nil
+		}
+		
+		if time.Since(lastStatus) >= headlessStatusInterval {
+			printPlaybackStatus(audioPlayer, expected)
+			lastStatus = time.Now()
+		}
+	}
+}
+
+// printPlaybackStatus prints the player's state and position on one line
+func printPlaybackStatus(audioPlayer audio.Player, expected time.Duration) {
+	duration := audioPlayer.GetDuration()
+	if duration <= 0 {
+		duration = expected
+	}
+	fmt.Printf("[%s] %s / %s\n", audioPlayer.GetState(),
+		formatDuration(audioPlayer.GetPosition().Milliseconds()), formatDuration(duration.Milliseconds()))
+}
+
+// openInApp starts the full TUI on the player view, playing the track at url
+// with search and the queue still at hand
+func openInApp(client *soundcloud.Client, playerKind audio.PlayerKind, url string) error {
+	fmt.Printf("🎵 Loading track from: %s\n\n", url)
+	
+	track, err := resolvePlayURL(client, url)
+	if err != nil {
+		return err
+	}
+	printNowPlaying(track)
+	
+	application := app.NewAppWithPlayer(playerKind)
+	application.LoadQueue([]soundcloud.Track{*track})
+	return runApp(application)
+}
+
+// resolvePlayURL returns the track to play for a SoundCloud URL or a local
+// audio file
+func resolvePlayURL(client *soundcloud.Client, url string) (*soundcloud.Track, error) {
+	if audio.IsLocalStream(url) {
+		// Local files play straight from disk, without SoundCloud metadata
+		if _, err := os.Stat(audio.LocalPath(url)); err != nil {
+			return nil, fmt.Errorf("failed to open local file: %w", err)
+		}
+		return player.LocalTrack(url), nil
+	}
+	
+	// Validate URL format
+	url, err := soundcloud.NormalizeURL(url)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Get track information
+	track, err := client.GetTrackInfo(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get track info: %w", err)
+	}
+	return track, nil
+}
+
+// printNowPlaying prints the track about to play
+func printNowPlaying(track *soundcloud.Track) {
+	if audio.IsLocalStream(track.StreamURL) {
+		fmt.Printf("Now playing: %s (local file)\n\n", track.Title)
+		return
+	}
+	fmt.Printf("Now playing: %s by %s\n", track.Title, track.User.FullName())
+	fmt.Printf("Duration: %s\n\n", formatDuration(track.Duration))
+}
+
+// runDaemon plays audio without the TUI, taking commands on the control
+// socket until interrupted
+func runDaemon(client *soundcloud.Client, playerKind audio.PlayerKind, quality string, socketPath string) error {
+	audioPlayer := audio.NewPlayer(playerKind)
+	defer audioPlayer.Close()
+	
+	streamExtractor := audio.NewRealSoundCloudStreamExtractor(client)
+	if err := streamExtractor.SetPreferredQuality(quality); err != nil {
+		return err
+	}
+	
+	listener, err := daemon.Listen(socketPath)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(socketPath)
+	
+	// Stop listening on Ctrl+C or kill, which ends Serve
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		listener.Close()
+	}()
+	
+	fmt.Printf("🎧 Daemon listening on %s (send commands with -ctl)\n", socketPath)
+	return daemon.Serve(listener, daemon.NewDispatcher(client, audioPlayer, streamExtractor))
+}
+
+// listOutputDevices prints the audio outputs the player can be routed to
+func listOutputDevices(playerKind audio.PlayerKind) error {
+	audioPlayer := audio.NewPlayer(playerKind)
+	defer audioPlayer.Close()
+	
+	selector, ok := audioPlayer.(audio.OutputDeviceSelector)
+	if !ok {
+		return audio.ErrOutputDeviceUnsupported
+	}
+	
+	devices, err := selector.ListOutputDevices()
+	if err != nil {
+		return err
+	}
+	for _, device := range devices {
+		fmt.Println(device)
+	}
+	return nil
+}
+
+// sendControlCommand sends command to the daemon and prints its response as
+// JSON, failing when the daemon rejected the command
+func sendControlCommand(socketPath, command string) error {
+	response, err := daemon.Send(socketPath, command)
+	if err != nil {
+		return err
+	}
+	if !response.OK {
+		return errors.New(response.Error)
+	}
+	return writeJSON(os.Stdout, response.Status)
+}
+
+// playPlaylistFile resolves every entry in a playlist file, queues the
+// tracks and starts the TUI. Entries that fail to resolve are reported and
+// skipped.
+func playPlaylistFile(client *soundcloud.Client, playerKind audio.PlayerKind, path string) error {
+	entries, err := playlist.Read(path)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("no tracks found in %s", path)
+	}
+	
+	fmt.Printf("📋 Loading %d tracks from: %s\n\n", len(entries), path)
+	
+	var tracks []soundcloud.Track
+	failed := 0
+	for i, entry := range entries {
+		track, err := playlist.Resolve(entry, client)
+		if err != nil {
+			fmt.Printf("❌ Entry %d: %s: %v\n", i+1, playlistEntryName(entry), err)
+			failed++
+			continue
+		}
+		
+		fmt.Printf("✅ %s by %s\n", track.Title, track.User.FullName())
+		tracks = append(tracks, *track)
+	}
+	
+	fmt.Printf("\nResolved %d of %d tracks", len(tracks), len(entries))
+	if failed > 0 {
+		fmt.Printf(" (%d failed)", failed)
+	}
+	fmt.Println()
+	
+	if len(tracks) == 0 {
+		return fmt.Errorf("none of the tracks in %s could be resolved", path)
+	}
+	
+	// Start the full TUI with the tracks queued
+	application := app.NewAppWithPlayer(playerKind)
+	application.LoadQueue(tracks)
+	return runApp(application)
+}
+
+// playlistEntryName names a playlist entry by its link, or its title when it
+// has none
+func playlistEntryName(entry soundcloud.Track) string {
+	if entry.PermalinkURL != "" {
+		return entry.PermalinkURL
+	}
+	return entry.Title
+}
+
 // DirectPlayApp is a minimal TUI app for direct URL playback
 type DirectPlayApp struct {
 	player *player.PlayerComponent
 	track  *soundcloud.Track
+	repeat bool // Replay the track whenever it completes
 	width  int
 	height int
 }
@@ -262,6 +769,15 @@ func (a *DirectPlayApp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		fmt.Printf("\n❌ Playback failed: %v\n", msg.Error)
 		return a, tea.Quit
 		
+	case player.PlaybackCompletedMsg:
+		// Loop in repeat mode, otherwise stay idle on the finished track
+		if !a.repeat {
+			return a, nil
+		}
+		updatedPlayer, cmd := a.player.Update(player.PlayTrackMsg{Track: a.track})
+		a.player = updatedPlayer.(*player.PlayerComponent)
+		return a, cmd
+		
 	default:
 		// Pass all other messages to player
 		updatedPlayer, cmd := a.player.Update(msg)
@@ -273,6 +789,9 @@ func (a *DirectPlayApp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (a *DirectPlayApp) View() string {
 	// Simple header
 	header := fmt.Sprintf("SoundCloud TUI - Direct Play Mode (Press 'q' or Ctrl+C to quit)")
+	if a.repeat {
+		header += " [repeat]"
+	}
 	
 	// Player view
 	playerView := a.player.View()
@@ -281,11 +800,12 @@ func (a *DirectPlayApp) View() string {
 }
 
 // testAudioPlayback tests audio playback without TUI interface
-func testAudioPlayback(client *soundcloud.Client, url string) error {
+func testAudioPlayback(client *soundcloud.Client, playerKind audio.PlayerKind, quality string, url string) error {
 	fmt.Printf("🔧 Testing audio playback without TUI for: %s\n\n", url)
 	
 	// Validate URL format
-	if err := validateSoundCloudURL(url); err != nil {
+	url, err := soundcloud.NormalizeURL(url)
+	if err != nil {
 		return err
 	}
 	
@@ -298,14 +818,18 @@ func testAudioPlayback(client *soundcloud.Client, url string) error {
 	fmt.Printf("Track: %s by %s\n", track.Title, track.User.FullName())
 	fmt.Printf("Duration: %s\n\n", formatDuration(track.Duration))
 	
-	// Create audio components with enhanced buffered streaming
-	audioPlayer := audio.NewBufferedBeepPlayer()
+	// Create audio components
+	fmt.Printf("Player: %s\n", playerKind)
+	audioPlayer := audio.NewPlayer(playerKind)
 	defer audioPlayer.Close()
 	
 	streamExtractor := audio.NewRealSoundCloudStreamExtractor(client)
+	if err := streamExtractor.SetPreferredQuality(quality); err != nil {
+		return err
+	}
 	
 	// Extract stream URL
-	fmt.Printf("Extracting stream URL...\n")
+	fmt.Printf("Extracting stream URL (preferring %s)...\n", quality)
 	streamInfo, err := streamExtractor.ExtractStreamURL(context.Background(), track.ID)
 	if err != nil {
 		return fmt.Errorf("failed to extract stream URL: %w", err)
@@ -351,11 +875,12 @@ func testAudioPlayback(client *soundcloud.Client, url string) error {
 }
 
 // testTuiPlayback simulates TUI message flow to test for differences vs direct audio
-func testTuiPlayback(client *soundcloud.Client, url string) error {
+func testTuiPlayback(client *soundcloud.Client, playerKind audio.PlayerKind, url string) error {
 	fmt.Printf("🔧 Testing TUI message flow for: %s\n\n", url)
 	
 	// Validate URL format
-	if err := validateSoundCloudURL(url); err != nil {
+	url, err := soundcloud.NormalizeURL(url)
+	if err != nil {
 		return err
 	}
 	
@@ -369,7 +894,8 @@ func testTuiPlayback(client *soundcloud.Client, url string) error {
 	fmt.Printf("Duration: %s\n\n", formatDuration(track.Duration))
 	
 	// Create audio components (same as TUI)
-	audioPlayer := audio.NewBeepPlayer()
+	fmt.Printf("Player: %s\n", playerKind)
+	audioPlayer := audio.NewPlayer(playerKind)
 	defer audioPlayer.Close()
 	
 	streamExtractor := audio.NewRealSoundCloudStreamExtractor(client)
@@ -478,20 +1004,49 @@ Usage:
 Flags:
   -search "query"    Search for tracks by keyword
   -track "url"       Get information for a specific track URL
-  -play "url"        Play a specific track URL directly
+  -play "url"        Play a specific track URL, or a local audio file path, directly
+  -repeat            With -play, loop the track until you quit
+  -nogui             With -play, play without the TUI, printing progress until the track
+                     ends or Ctrl+C (works in scripts)
+  -open "url"        Start the full TUI in the player view, playing a track URL or local file
+  -playlist-file "path"  Queue and play an M3U or JSON playlist (as saved with s in the queue view)
+                          or a file of track URLs (one per line, # for comments)
   -test-audio "url"  Test audio playback without TUI (debug mode)
   -test-tui "url"    Test TUI message flow without interactive mode
+  -no-color          Disable colors and emoji icons (also honors NO_COLOR)
+  -json              With -search or -track, print JSON (errors go to stderr as JSON)
+  -player kind       Audio player for playback and the test modes: buffered (default) or beep
+  -quality q         With -play, -test-audio or -daemon, prefer the progressive (default) or hls stream
+  -daemon            Run the player without the TUI, controlled over a local socket
+  -ctl "command"     Send a command to a running daemon: play URL, pause, resume, stop,
+                     volume 0-100 or status (prints the player status as JSON)
+  -socket "path"     Control socket for -daemon and -ctl (default $XDG_RUNTIME_DIR/sctui.sock)
+  -output-devices    List the audio outputs for "output_device" under "playback" in settings.json
+  -offline           Search and play only the tracks in the track cache, without SoundCloud
+  -history           Print recently played and most played tracks (with -json, as JSON)
+  -version           Print the version, commit and Go version, then exit
   -help              Show this help message
 
 Examples:
   %s -search "lofi hip hop"
   %s -track "https://soundcloud.com/artist/track"
   %s -play "https://soundcloud.com/artist/track"
+  %s -play "https://soundcloud.com/artist/track" -repeat
+  %s -play "https://soundcloud.com/artist/track" -nogui
+  %s -play ~/Music/song.mp3
+  %s -open "https://soundcloud.com/artist/track"
+  %s -playlist-file urls.txt
+  %s -daemon &
+  %s -ctl "play https://soundcloud.com/artist/track"
+  %s -ctl status
+  %s -json -search "lofi hip hop"
   %s -test-audio "https://soundcloud.com/artist/track"
+  %s -play "https://soundcloud.com/artist/track" -quality hls
   %s -test-tui "https://soundcloud.com/artist/track"
+  %s -test-tui "https://soundcloud.com/artist/track" -player beep
   %s                 # Start interactive TUI
 
 Note: This application uses SoundCloud's undocumented API.
 See disclaimer above for important legal considerations.
-`, os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0])
+`, os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0])
 }
\ No newline at end of file
@@ -7,10 +7,14 @@ import (
 	"time"
 )
 
+// ErrReaderClosed is returned when reading from a closed BufferReader
+var ErrReaderClosed = errors.New("buffer reader closed")
+
 // BufferReader implements io.Reader for the StreamBuffer
 type BufferReader struct {
 	buffer   *StreamBuffer
 	position int64
+	closed   bool // Closed by a decoder; Reset reopens it
 	mu       sync.RWMutex
 }
 
@@ -27,6 +31,15 @@ func (br *BufferReader) Read(p []byte) (n int, err error) {
 	br.mu.Lock()
 	defer br.mu.Unlock()
 	
+	if br.closed {
+		return 0, ErrReaderClosed
+	}
+	
+	// The window may have moved past us, e.g. when a decoder rewinds
+	if !br.buffer.fetchFrom(br.position) {
+		return 0, io.EOF
+	}
+	
 	// Check if we have data available
 	br.buffer.mu.RLock()
 	available := br.buffer.writePos - br.position
@@ -64,21 +77,30 @@ func (br *BufferReader) Read(p []byte) (n int, err error) {
 		toCopy = available
 	}
 	
-	copy(p, br.buffer.data[br.position:br.position+toCopy])
+	offset := br.position - br.buffer.base
+	copy(p, br.buffer.data[offset:offset+toCopy])
 	br.buffer.mu.RUnlock()
 	
 	br.position += toCopy
+	br.buffer.setReadPos(br.position)
 	return int(toCopy), nil
 }
 
-// Reset resets the reader position to the beginning
+// Reset rewinds the reader to the start of the stream so the buffered bytes
+// are replayed, whatever a previous decoder did with it: read to the end of
+// the data, seeked, or closed it after rejecting the stream. The download
+// starts over if a seek moved it away from the start.
 func (br *BufferReader) Reset() {
 	br.mu.Lock()
 	defer br.mu.Unlock()
 	br.position = 0
+	br.closed = false
+	br.buffer.fetchFrom(0)
+	br.buffer.setReadPos(0)
 }
 
-// Seek implements seeking within the buffer
+// Seek implements seeking within the stream. Seeking outside the downloaded
+// bytes restarts the download at the target with a Range request.
 func (br *BufferReader) Seek(offset int64, whence int) (int64, error) {
 	br.mu.Lock()
 	defer br.mu.Unlock()
@@ -102,18 +124,20 @@ func (br *BufferReader) Seek(offset int64, whence int) (int64, error) {
 		return br.position, errors.New("invalid seek")
 	}
 	
-	br.buffer.mu.RLock()
-	if newPos > br.buffer.writePos {
-		br.buffer.mu.RUnlock()
+	if !br.buffer.fetchFrom(newPos) {
 		return br.position, errors.New("invalid seek")
 	}
-	br.buffer.mu.RUnlock()
 	
 	br.position = newPos
+	br.buffer.setReadPos(newPos)
 	return br.position, nil
 }
 
-// Close implements io.Closer (BufferReader doesn't need to close anything)
+// Close implements io.Closer. Reads fail until the reader is Reset; the
+// buffer itself is left alone.
 func (br *BufferReader) Close() error {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	br.closed = true
 	return nil
 }
\ No newline at end of file
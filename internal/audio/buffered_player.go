@@ -2,6 +2,7 @@ package audio
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -10,9 +11,7 @@ import (
 
 	"github.com/gopxl/beep"
 	"github.com/gopxl/beep/effects"
-	"github.com/gopxl/beep/mp3"
 	"github.com/gopxl/beep/speaker"
-	"github.com/gopxl/beep/wav"
 )
 
 // BufferedStreamPlayer implements Player with advanced buffering and streaming capabilities
@@ -26,24 +25,38 @@ type BufferedStreamPlayer struct {
 	format          beep.Format
 	ctrl            *beep.Ctrl
 	volumeCtrl      *effects.Volume
+	fader           *volumeFader
+	eq              *Equalizer
+	normalizer      *Normalizer
+	levels          *LevelMeter
+	
+	// Equalizer band gains in dB, applied to every new stream
+	eqLow, eqMid, eqHigh float64
+	
+	// Loudness normalization, applied to every new stream
+	normalize bool
 	
 	// Speaker management
 	speakerInit     sync.Once
 	speakerInitErr  error
+	speakerRate     beep.SampleRate // Rate the speaker was initialized at
 	
 	// Stream information
-	streamURL       string
-	httpClient      *http.Client
+	streamURL        string
+	expectedDuration time.Duration // From track metadata
+	expectedFormat   string        // From track metadata, "" to detect it
+	httpClient       *http.Client
+	httpOptions      HTTPOptions
 	
 	// Buffer management
+	ctx             context.Context    // Downloads run until Close cancels it
+	cancel          context.CancelFunc
 	buffer          *StreamBuffer
 	bufferSize      int64
 	preloadSize     int64
+	preloadTimeout  time.Duration
 	
 	// Error recovery and robustness
-	retryCount      int
-	maxRetries      int
-	backoffDuration time.Duration
 	reconnectDelay  time.Duration
 	isRecovering    bool
 	lastError       error
@@ -54,13 +67,18 @@ type BufferedStreamPlayer struct {
 	// Callbacks
 	onStateChange   func(PlayerState)
 	onError         func(error)
+	onFormatChange  func(beep.Format)
 }
 
-// StreamBuffer manages progressive audio streaming with buffering
+// StreamBuffer manages progressive audio streaming with buffering. It holds
+// a window of the stream starting at byte base; readPos and writePos are
+// stream offsets. A reader seeking outside the downloaded bytes restarts the
+// download at its target, moving the window there.
 type StreamBuffer struct {
 	mu           sync.RWMutex
 	data         []byte
 	size         int64
+	base         int64
 	readPos      int64
 	writePos     int64
 	preloaded    bool
@@ -69,37 +87,76 @@ type StreamBuffer struct {
 	ctx          context.Context
 	cancel       context.CancelFunc
 	downloadDone chan bool
+	contentType  string // Content-Type of the first response
+	
+	// Downloading
+	open       streamOpener       // Opens the stream at an offset; nil for data already in memory
+	generation int                // Current download; writes from replaced ones are dropped
+	stopFetch  context.CancelFunc // Stops the current download
+	backoff    time.Duration      // Delay before the first retry, growing with each attempt
+	onFailure  func(error)        // Called when a download gives up
 }
 
-// PositionTracker provides accurate position tracking
+// ErrPlayerClosed is returned when playing on a player that has been closed
+var ErrPlayerClosed = errors.New("player is closed")
+
+// streamOpener opens a stream at a byte offset and reports its Content-Type
+type streamOpener func(ctx context.Context, offset int64) (io.ReadCloser, string, error)
+
+// downloadAttempts is how often a download is tried before giving up
+const downloadAttempts = 5
+
+// PositionTracker provides accurate position tracking. The position is the
+// time played since startTime, less the time spent paused, from basePosition on.
 type PositionTracker struct {
 	mu           sync.RWMutex
+	clock        Clock
 	startTime    time.Time
 	pausedTime   time.Time
 	totalPaused  time.Duration
+	basePosition time.Duration // Position at startTime, moved by seeks
 	lastPosition time.Duration
 	sampleRate   beep.SampleRate
 }
 
+// Clock tells the time for a PositionTracker, so tests can control it
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the real time
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// NewPositionTracker creates a position tracker timed by clock, or by the
+// system clock when clock is nil
+func NewPositionTracker(clock Clock) *PositionTracker {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	return &PositionTracker{clock: clock}
+}
+
 // NewBufferedStreamPlayer creates a new buffered streaming audio player
-func NewBufferedStreamPlayer() *BufferedStreamPlayer {
+func NewBufferedStreamPlayer(opts ...Option) *BufferedStreamPlayer {
+	httpOptions := buildHTTPOptions(opts)
+	ctx, cancel := context.WithCancel(context.Background())
 	return &BufferedStreamPlayer{
+		ctx:             ctx,
+		cancel:          cancel,
 		state:           StateStopped,
 		volume:          1.0,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-			Transport: &http.Transport{
-				MaxIdleConns:        10,
-				IdleConnTimeout:     30 * time.Second,
-				DisableCompression:  false,
-			},
-		},
+		httpClient:      newHTTPClient(httpOptions),
+		httpOptions:     httpOptions,
+		fader:           newVolumeFader(),
 		bufferSize:      4 * 1024 * 1024, // 4MB buffer for more robustness
-		preloadSize:     1024 * 1024,     // 1MB preload for smoother start
-		maxRetries:      5,               // More retry attempts
-		backoffDuration: 1 * time.Second, // Faster initial retry
+		preloadSize:     httpOptions.PreloadSize,
+		preloadTimeout:  httpOptions.PreloadTimeout,
 		reconnectDelay:  5 * time.Second, // Delay before reconnection attempts
-		positionTracker: &PositionTracker{},
+		positionTracker: NewPositionTracker(nil),
 	}
 }
 
@@ -112,6 +169,9 @@ func (p *BufferedStreamPlayer) Play(ctx context.Context, streamURL string) error
 	if streamURL == "" {
 		return fmt.Errorf("stream URL cannot be empty")
 	}
+	if p.ctx.Err() != nil {
+		return ErrPlayerClosed
+	}
 	
 	// Stop any existing playback
 	if err := p.stopLocked(); err != nil {
@@ -119,72 +179,67 @@ func (p *BufferedStreamPlayer) Play(ctx context.Context, streamURL string) error
 	}
 	
 	p.streamURL = streamURL
-	p.retryCount = 0
-	
-	// Initialize stream buffer
-	bufferCtx, bufferCancel := context.WithCancel(ctx)
-	p.buffer = &StreamBuffer{
-		data:         make([]byte, p.bufferSize),
-		size:         p.bufferSize,
-		minBuffer:    p.preloadSize,
-		ctx:          bufferCtx,
-		cancel:       bufferCancel,
-		downloadDone: make(chan bool, 1),
-	}
-	
-	// Start progressive download
-	go p.downloadStream()
 	
-	// Wait for initial buffer to fill with shorter timeout
-	preloadCtx, preloadCancel := context.WithTimeout(ctx, 5*time.Second)
-	defer preloadCancel()
-	
-	if err := p.waitForPreload(preloadCtx); err != nil {
-		bufferCancel()
-		return fmt.Errorf("failed to preload audio data: %w", err)
-	}
-	
-	// Create audio stream from buffer
-	streamer, format, err := p.createStreamFromBuffer()
+	streamer, format, err := p.openStreamLocked(ctx, streamURL)
 	if err != nil {
-		bufferCancel()
-		return fmt.Errorf("failed to create audio stream: %w", err)
+		return err
 	}
 	
 	// Initialize speaker if needed
 	p.speakerInit.Do(func() {
 		p.speakerInitErr = speaker.Init(format.SampleRate, format.SampleRate.N(time.Second/10))
+		p.speakerRate = format.SampleRate
+		if p.speakerInitErr == nil {
+			speakerStarted.Store(true)
+		}
 	})
 	if p.speakerInitErr != nil {
 		streamer.Close()
-		bufferCancel()
+		if p.buffer != nil {
+			p.buffer.Close()
+		}
 		return fmt.Errorf("failed to initialize speaker: %w", p.speakerInitErr)
 	}
 	
 	// Set up audio pipeline
 	p.streamer = streamer
 	p.format = format
+	if p.onFormatChange != nil {
+		go p.onFormatChange(format)
+	}
 	
+	// Even out loudness before the volume control, so the user's volume
+	// scales the normalized level rather than being compensated for
+	p.normalizer = NewNormalizer(p.streamer, format.SampleRate)
+	p.normalizer.SetEnabled(p.normalize)
+
 	// Create volume control
 	p.volumeCtrl = &effects.Volume{
-		Streamer: p.streamer,
+		Streamer: p.normalizer,
 		Base:     2,
 		Volume:   p.volumeToBeepVolume(p.volume),
-		Silent:   p.volume == 0,
+		Silent:   p.volume == 0 || p.fader.enabled(), // Start silent when fading in
 	}
 	
+	// Apply the equalizer after the volume control
+	p.eq = NewEqualizer(p.volumeCtrl, format.SampleRate)
+	p.eq.SetGains(p.eqLow, p.eqMid, p.eqHigh)
+	
 	// Create playback control
 	p.ctrl = &beep.Ctrl{
-		Streamer: p.volumeCtrl,
+		Streamer: p.eq,
 		Paused:   false,
 	}
 	
 	// Start position tracking
 	p.positionTracker.Start(format.SampleRate)
 	
+	// Tap the output, at the speaker's sample rate, for the level meters
+	p.levels = NewLevelMeter(MatchSampleRate(p.ctrl, format.SampleRate, p.speakerRate))
+	
 	// Start playback with callback
 	done := make(chan bool)
-	speaker.Play(beep.Seq(p.ctrl, beep.Callback(func() {
+	speaker.Play(beep.Seq(p.levels, beep.Callback(func() {
 		p.mu.Lock()
 		p.state = StateStopped
 		p.positionTracker.Stop()
@@ -197,6 +252,11 @@ func (p *BufferedStreamPlayer) Play(ctx context.Context, streamURL string) error
 	
 	p.state = StatePlaying
 	
+	// Ramp up from silence to avoid a click at the start
+	if p.fader.enabled() {
+		p.fader.start(p.volumeCtrl, p.volume, nil)
+	}
+	
 	if p.onStateChange != nil {
 		go p.onStateChange(p.state)
 	}
@@ -207,146 +267,167 @@ func (p *BufferedStreamPlayer) Play(ctx context.Context, streamURL string) error
 	return nil
 }
 
-// downloadStream downloads the audio stream progressively with retry logic
-func (p *BufferedStreamPlayer) downloadStream() {
-	defer func() {
-		p.buffer.downloadDone <- true
-	}()
-	
-	for attempt := 0; attempt < p.maxRetries; attempt++ {
-		if attempt > 0 {
-			// Wait before retry with exponential backoff
-			delay := time.Duration(attempt) * p.backoffDuration
-			select {
-			case <-p.buffer.ctx.Done():
-				return
-			case <-time.After(delay):
-			}
-		}
-		
-		if p.downloadStreamAttempt() {
-			return // Success
-		}
-		
-		// If this was the last attempt, mark as failed
-		if attempt == p.maxRetries-1 {
-			p.mu.Lock()
-			p.lastError = fmt.Errorf("failed to download stream after %d attempts", p.maxRetries)
-			if p.onError != nil {
-				go p.onError(p.lastError)
+// reportDownloadFailure records a download that gave up and passes it on
+func (p *BufferedStreamPlayer) reportDownloadFailure(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.lastError = err
+	if p.onError != nil {
+		go p.onError(err)
+	}
+}
+
+// streamOpenerFor opens streamURL at an offset, reading local files from
+// disk and requesting remote streams over HTTP
+func streamOpenerFor(client *http.Client, options HTTPOptions, streamURL string) streamOpener {
+	return func(ctx context.Context, offset int64) (io.ReadCloser, string, error) {
+		if IsLocalStream(streamURL) {
+			file, err := openLocalFile(streamURL, offset)
+			if err != nil {
+				return nil, "", err
 			}
-			p.mu.Unlock()
+			return file, "", nil
 		}
+		return openHTTPStream(ctx, client, options, streamURL, offset)
 	}
 }
 
-// downloadStreamAttempt makes a single attempt to download the stream
-func (p *BufferedStreamPlayer) downloadStreamAttempt() bool {
-	req, err := http.NewRequestWithContext(p.buffer.ctx, "GET", p.streamURL, nil)
+// openHTTPStream requests streamURL from byte offset on. A server that
+// ignores the Range header sends the whole stream, whose start is skipped.
+func openHTTPStream(ctx context.Context, client *http.Client, options HTTPOptions, streamURL string, offset int64) (io.ReadCloser, string, error) {
+	req, err := newStreamRequest(ctx, streamURL, options)
 	if err != nil {
-		return false
+		return nil, "", err
 	}
 	
-	// Add range header if we're resuming from a previous position
-	p.buffer.mu.RLock()
-	resumeFrom := p.buffer.writePos
-	p.buffer.mu.RUnlock()
-	
-	if resumeFrom > 0 {
-		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", resumeFrom))
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
 	}
 	
-	resp, err := p.httpClient.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
-		return false
+		return nil, "", err
 	}
-	defer resp.Body.Close()
-	
-	// Accept both 200 (full content) and 206 (partial content)
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
-		return false
-	}
-	
-	
-	// Read data in chunks with improved error handling
-	chunk := make([]byte, 32*1024) // 32KB chunks
-	consecutiveErrors := 0
-	maxConsecutiveErrors := 3
 	
-	for {
-		select {
-		case <-p.buffer.ctx.Done():
-			return false // Context cancelled
-		default:
-		}
-		
-		n, err := resp.Body.Read(chunk)
-		if n > 0 {
-			p.buffer.write(chunk[:n])
-			consecutiveErrors = 0 // Reset error count on successful read
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		if start, ok := contentRangeStart(resp.Header.Get("Content-Range")); ok && start != offset {
+			resp.Body.Close()
+			return nil, "", fmt.Errorf("server sent the stream from byte %d instead of %d", start, offset)
 		}
-		
-		if err == io.EOF {
-			p.buffer.mu.Lock()
-			p.buffer.completed = true
-			p.buffer.mu.Unlock()
-			return true // Success
-		}
-		
-		if err != nil {
-			consecutiveErrors++
-			if consecutiveErrors >= maxConsecutiveErrors {
-				return false // Too many consecutive errors
+	case http.StatusOK:
+		if offset > 0 {
+			if _, err := io.CopyN(io.Discard, resp.Body, offset); err != nil {
+				resp.Body.Close()
+				return nil, "", fmt.Errorf("failed to skip to byte %d: %w", offset, err)
 			}
-			// Brief pause before continuing
-			time.Sleep(100 * time.Millisecond)
-			continue
 		}
+	default:
+		resp.Body.Close()
+		return nil, "", fmt.Errorf("HTTP error: %d %s", resp.StatusCode, resp.Status)
 	}
+	
+	return resp.Body, resp.Header.Get("Content-Type"), nil
 }
 
-// waitForPreload waits for the initial buffer to fill
+// contentRangeStart returns the first byte of a "bytes start-end/size"
+// Content-Range header, and false if it can't be parsed
+func contentRangeStart(header string) (int64, bool) {
+	var start int64
+	if _, err := fmt.Sscanf(header, "bytes %d-", &start); err != nil {
+		return 0, false
+	}
+	return start, true
+}
+
+// waitForPreload waits until there is enough data to start playback: the full
+// preload, the whole stream, or, once the download has proven slow, the
+// minimum needed to start decoding. It gives up after the preload timeout.
 func (p *BufferedStreamPlayer) waitForPreload(ctx context.Context) error {
-	timeout := time.NewTimer(10 * time.Second)
-	defer timeout.Stop()
+	if p.preloadTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.preloadTimeout)
+		defer cancel()
+	}
 	
-	ticker := time.NewTicker(100 * time.Millisecond)
+	start := time.Now()
+	ticker := time.NewTicker(50 * time.Millisecond)
 	defer ticker.Stop()
 	
 	for {
+		if p.buffer.readyToStart(time.Since(start) >= preloadPatience) {
+			return nil
+		}
+		
 		select {
 		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("preload timeout after %v", p.preloadTimeout)
+			}
 			return ctx.Err()
-		case <-timeout.C:
-			return fmt.Errorf("preload timeout")
 		case <-ticker.C:
-			if p.buffer.isPreloaded() {
-				// BufferedStreamPlayer.waitForPreload: Preload completed")
-				return nil
-			}
 		}
 	}
 }
 
+// openStreamLocked starts loading streamURL and decodes its start. HLS
+// playlists are fetched a segment at a time; anything else is downloaded
+// progressively into the stream buffer.
+func (p *BufferedStreamPlayer) openStreamLocked(ctx context.Context, streamURL string) (beep.StreamSeekCloser, beep.Format, error) {
+	if isHLSStream(p.expectedFormat, streamURL) {
+		p.buffer = nil
+		
+		// Likewise, later segments are fetched until the track stops or the
+		// player is closed
+		stream, format, err := openHLSStream(ctx, p.ctx, newResourceFetcher(p.httpClient, p.httpOptions), streamURL)
+		if err != nil {
+			return nil, beep.Format{}, fmt.Errorf("failed to open HLS stream: %w", err)
+		}
+		stream.onFailure = p.reportDownloadFailure
+		return stream, format, nil
+	}
+	
+	// Start progressive download. ctx only bounds getting started: the
+	// download outlives this call and stops with the track or on Close.
+	p.buffer = newStreamBuffer(p.ctx, p.bufferSize, p.preloadSize, streamOpenerFor(p.httpClient, p.httpOptions, streamURL))
+	p.buffer.onFailure = p.reportDownloadFailure
+	p.buffer.startDownload(0)
+	
+	// Wait for initial buffer to fill
+	if err := p.waitForPreload(ctx); err != nil {
+		p.buffer.Close()
+		return nil, beep.Format{}, fmt.Errorf("failed to preload audio data: %w", err)
+	}
+	
+	// Create audio stream from buffer
+	streamer, format, err := p.createStreamFromBuffer()
+	if err != nil {
+		p.buffer.Close()
+		return nil, beep.Format{}, fmt.Errorf("failed to create audio stream: %w", err)
+	}
+	
+	return streamer, format, nil
+}
+
 // createStreamFromBuffer creates a beep stream from the buffered data
 func (p *BufferedStreamPlayer) createStreamFromBuffer() (beep.StreamSeekCloser, beep.Format, error) {
 	// Create a reader that reads from our buffer
 	reader := NewBufferReader(p.buffer)
 	
-	// Try to decode as MP3 first, then WAV
-	if streamer, format, err := mp3.Decode(reader); err == nil {
-		// BufferedStreamPlayer.createStreamFromBuffer: Successfully decoded as MP3")
-		return streamer, format, nil
+	// Try the format from metadata, else the Content-Type or URL, first, then
+	// what the data itself looks like, then the remaining decoders
+	declared := p.expectedFormat
+	if declared == "" {
+		declared = detectFormat(p.buffer.getContentType(), p.streamURL)
 	}
+	header := make([]byte, sniffSize)
+	n, _ := io.ReadFull(reader, header)
+	sniffed := sniffFormat(header[:n])
 	
-	// Reset reader position for WAV attempt
-	reader.Reset()
-	if streamer, format, err := wav.Decode(reader); err == nil {
-		// BufferedStreamPlayer.createStreamFromBuffer: Successfully decoded as WAV")
-		return streamer, format, nil
-	}
-	
-	return nil, beep.Format{}, fmt.Errorf("unsupported audio format")
+	return decodeWithFallback(decodeCandidates(declared, sniffed), func() (io.ReadCloser, error) {
+		reader.Reset()
+		return reader, nil
+	})
 }
 
 // Pause pauses the current playback
@@ -354,14 +435,19 @@ func (p *BufferedStreamPlayer) Pause() error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 	
-	if p.state != StatePlaying {
+	if p.state != StatePlaying && p.state != StateBuffering {
 		return fmt.Errorf("cannot pause: player is %s", p.state)
 	}
 	
-	if p.ctrl != nil {
-		speaker.Lock()
-		p.ctrl.Paused = true
-		speaker.Unlock()
+	// While buffering the output is already held and the tracker already paused
+	if p.ctrl != nil && p.state == StatePlaying {
+		// Fade out, then pause once the volume reaches zero
+		ctrl := p.ctrl
+		p.fader.start(p.volumeCtrl, 0, func() {
+			speaker.Lock()
+			ctrl.Paused = true
+			speaker.Unlock()
+		})
 		p.positionTracker.Pause()
 	}
 	
@@ -383,10 +469,18 @@ func (p *BufferedStreamPlayer) Resume() error {
 	}
 	
 	if p.ctrl != nil {
+		// Finish any pending fade-out before unpausing
+		p.fader.stop()
+		
 		speaker.Lock()
+		if p.fader.enabled() {
+			p.volumeCtrl.Silent = true
+		}
 		p.ctrl.Paused = false
 		speaker.Unlock()
 		p.positionTracker.Resume()
+		
+		p.fader.start(p.volumeCtrl, p.volume, nil)
 	}
 	
 	p.state = StatePlaying
@@ -431,16 +525,42 @@ func (p *BufferedStreamPlayer) GetPosition() time.Duration {
 	return p.format.SampleRate.D(position)
 }
 
-// GetDuration returns total track duration
+// GetDuration returns total track duration. While the decoded length is still
+// growing it reports the expected duration from track metadata instead.
 func (p *BufferedStreamPlayer) GetDuration() time.Duration {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
-	
+	return p.durationLocked()
+}
+
+// SetExpectedDuration sets the authoritative track length from metadata (0 to clear)
+func (p *BufferedStreamPlayer) SetExpectedDuration(d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.expectedDuration = d
+}
+
+// SetExpectedFormat sets the stream format from metadata ("" to detect it)
+func (p *BufferedStreamPlayer) SetExpectedFormat(format string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.expectedFormat = format
+}
+
+// durationLocked returns the effective duration (caller must hold lock)
+func (p *BufferedStreamPlayer) durationLocked() time.Duration {
 	if p.streamer == nil || p.format.SampleRate == 0 {
-		return 0
+		return p.expectedDuration
 	}
 	
-	return p.format.SampleRate.D(p.streamer.Len())
+	decoded := p.format.SampleRate.D(p.streamer.Len())
+	
+	// Once the whole stream is buffered the decoded length is exact
+	if p.buffer != nil && p.buffer.isCompleted() {
+		return decoded
+	}
+	
+	return EffectiveDuration(decoded, p.expectedDuration)
 }
 
 // SetVolume sets playback volume (0.0 to 1.0)
@@ -455,6 +575,9 @@ func (p *BufferedStreamPlayer) SetVolume(volume float64) error {
 	p.volume = volume
 	
 	if p.volumeCtrl != nil {
+		// An explicit volume change overrides any fade in progress
+		p.fader.stop()
+		
 		speaker.Lock()
 		p.volumeCtrl.Volume = p.volumeToBeepVolume(volume)
 		p.volumeCtrl.Silent = volume == 0
@@ -471,6 +594,96 @@ func (p *BufferedStreamPlayer) GetVolume() float64 {
 	return p.volume
 }
 
+// SetEQ sets the equalizer band gains in dB (0 leaves a band flat)
+func (p *BufferedStreamPlayer) SetEQ(low, mid, high float64) error {
+	if err := ValidateEQGains(low, mid, high); err != nil {
+		return err
+	}
+	
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	
+	p.eqLow, p.eqMid, p.eqHigh = low, mid, high
+	
+	if p.eq != nil {
+		speaker.Lock()
+		p.eq.SetGains(low, mid, high)
+		speaker.Unlock()
+	}
+	
+	return nil
+}
+
+// GetEQ returns the equalizer band gains in dB
+func (p *BufferedStreamPlayer) GetEQ() (low, mid, high float64) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.eqLow, p.eqMid, p.eqHigh
+}
+
+// SetNormalization turns loudness normalization on or off
+func (p *BufferedStreamPlayer) SetNormalization(enabled bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	
+	p.normalize = enabled
+	
+	if p.normalizer != nil {
+		speaker.Lock()
+		p.normalizer.SetEnabled(enabled)
+		speaker.Unlock()
+	}
+}
+
+// GetNormalization reports whether loudness normalization is on
+func (p *BufferedStreamPlayer) GetNormalization() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.normalize
+}
+
+// BufferHealth reports the unplayed data in the stream buffer
+func (p *BufferedStreamPlayer) BufferHealth() (available, total int64, completed bool) {
+	p.mu.RLock()
+	buffer := p.buffer
+	p.mu.RUnlock()
+	
+	if buffer == nil {
+		return 0, 0, true
+	}
+	
+	return buffer.getBufferHealth()
+}
+
+// GetSampleRate returns the sample rate of the loaded stream
+func (p *BufferedStreamPlayer) GetSampleRate() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	
+	if p.streamer == nil {
+		return 0
+	}
+	return int(p.format.SampleRate)
+}
+
+// GetLevels returns the current output level of each channel
+func (p *BufferedStreamPlayer) GetLevels() (left, right float64) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	
+	if p.levels == nil || p.state != StatePlaying {
+		return 0, 0
+	}
+	
+	return p.levels.Levels()
+}
+
+// SetFadeDuration sets how long volume ramps take on play, pause and stop.
+// Zero disables fading.
+func (p *BufferedStreamPlayer) SetFadeDuration(d time.Duration) {
+	p.fader.setDuration(d)
+}
+
 // Seek sets playback position with buffer management
 func (p *BufferedStreamPlayer) Seek(position time.Duration) error {
 	p.mu.Lock()
@@ -484,7 +697,7 @@ func (p *BufferedStreamPlayer) Seek(position time.Duration) error {
 		return fmt.Errorf("no audio stream loaded")
 	}
 	
-	duration := p.GetDuration()
+	duration := p.durationLocked()
 	if position > duration {
 		return fmt.Errorf("position %s exceeds duration %s", position, duration)
 	}
@@ -492,6 +705,20 @@ func (p *BufferedStreamPlayer) Seek(position time.Duration) error {
 	// Convert time position to sample position
 	samplePos := p.format.SampleRate.N(position)
 	
+	// Seeking an HLS stream may download a segment; don't block the
+	// player's other calls meanwhile
+	if stream, ok := p.streamer.(*HLSStream); ok {
+		p.mu.Unlock()
+		err := stream.Seek(samplePos)
+		p.mu.Lock()
+		
+		// The stream may have been replaced or stopped during the fetch
+		if err == nil && p.streamer == stream && p.positionTracker != nil {
+			p.positionTracker.SetPosition(position)
+		}
+		return err
+	}
+	
 	speaker.Lock()
 	err := p.streamer.Seek(samplePos)
 	speaker.Unlock()
@@ -503,11 +730,13 @@ func (p *BufferedStreamPlayer) Seek(position time.Duration) error {
 	return err
 }
 
-// Close releases player resources
+// Close releases player resources and stops any download still running. The
+// player can't play again afterwards.
 func (p *BufferedStreamPlayer) Close() error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 	
+	p.cancel()
 	if err := p.stopLocked(); err != nil {
 		return err
 	}
@@ -533,11 +762,24 @@ func (p *BufferedStreamPlayer) SetErrorCallback(callback func(error)) {
 	p.onError = callback
 }
 
+// SetFormatChangeCallback sets a callback for the format of each new stream
+func (p *BufferedStreamPlayer) SetFormatChangeCallback(callback func(beep.Format)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.onFormatChange = callback
+}
+
 // Helper methods
 
 // stopLocked stops playback without acquiring lock (caller must hold lock)
 func (p *BufferedStreamPlayer) stopLocked() error {
 	if p.ctrl != nil {
+		// Fade out audible playback before cutting it off
+		if p.state == StatePlaying {
+			<-p.fader.start(p.volumeCtrl, 0, nil)
+		}
+		p.fader.stop()
+		
 		speaker.Lock()
 		p.ctrl.Paused = true
 		speaker.Unlock()
@@ -560,6 +802,9 @@ func (p *BufferedStreamPlayer) stopLocked() error {
 	
 	p.ctrl = nil
 	p.volumeCtrl = nil
+	p.eq = nil
+	p.normalizer = nil
+	p.levels = nil
 	p.streamURL = ""
 	p.state = StateStopped
 	
@@ -572,21 +817,12 @@ func (p *BufferedStreamPlayer) stopLocked() error {
 
 // volumeToBeepVolume converts linear volume (0-1) to Beep's logarithmic volume
 func (p *BufferedStreamPlayer) volumeToBeepVolume(linearVolume float64) float64 {
-	if linearVolume <= 0 {
-		return -10 // Very quiet
-	}
-	if linearVolume >= 1 {
-		return 0 // Unity gain
-	}
-	
-	// Convert linear to dB: 20 * log10(volume)
-	// Beep uses base-2 logarithmic scale, so we adjust
-	return (linearVolume - 1.0) * 2.0 // Simple approximation
+	return linearToBeepVolume(linearVolume)
 }
 
 // trackPositionWithBuffer tracks position and manages buffer health
 func (p *BufferedStreamPlayer) trackPositionWithBuffer(done <-chan bool) {
-	ticker := time.NewTicker(100 * time.Millisecond)
+	ticker := time.NewTicker(p.httpOptions.PositionInterval)
 	defer ticker.Stop()
 	
 	bufferHealthTicker := time.NewTicker(1 * time.Second)
@@ -613,7 +849,8 @@ func (p *BufferedStreamPlayer) trackPositionWithBuffer(done <-chan bool) {
 	}
 }
 
-// attemptBufferRecovery tries to recover from buffer underrun
+// attemptBufferRecovery tries to recover from buffer underrun. Playback is
+// held in StateBuffering until the buffer is healthy again.
 func (p *BufferedStreamPlayer) attemptBufferRecovery() {
 	p.mu.Lock()
 	if p.isRecovering {
@@ -621,43 +858,287 @@ func (p *BufferedStreamPlayer) attemptBufferRecovery() {
 		return
 	}
 	p.isRecovering = true
-	p.mu.Unlock()
 	
-	defer func() {
-		p.mu.Lock()
+	// Only stall audible playback; a user pause or stop wins
+	if p.ctrl == nil || p.buffer == nil || (p.state != StatePlaying && p.state != StateBuffering) {
 		p.isRecovering = false
 		p.mu.Unlock()
-	}()
+		return
+	}
 	
-	// Pause playback temporarily
-	if p.ctrl != nil {
+	// A finished download has nothing left to wait for
+	if p.state == StatePlaying && p.buffer.isCompleted() {
+		p.isRecovering = false
+		p.mu.Unlock()
+		return
+	}
+	
+	if p.state == StatePlaying {
 		speaker.Lock()
-		wasPlaying := !p.ctrl.Paused
 		p.ctrl.Paused = true
 		speaker.Unlock()
+		if p.positionTracker != nil {
+			p.positionTracker.Pause()
+		}
 		
-		// Wait for buffer to recover
-		time.Sleep(p.reconnectDelay)
-		
-		// Check if buffer is healthier now
-		if p.buffer != nil && p.buffer.isHealthy() && wasPlaying {
-			speaker.Lock()
-			p.ctrl.Paused = false
-			speaker.Unlock()
+		p.state = StateBuffering
+		if p.onStateChange != nil {
+			go p.onStateChange(p.state)
 		}
 	}
+	delay := p.reconnectDelay
+	p.mu.Unlock()
+	
+	// Wait for buffer to recover
+	time.Sleep(delay)
+	
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.isRecovering = false
+	
+	// Stay buffering until a later health check finds the buffer refilled
+	if p.state != StateBuffering || p.ctrl == nil || p.buffer == nil {
+		return
+	}
+	if !p.buffer.isHealthy() && !p.buffer.isCompleted() {
+		return
+	}
+	
+	speaker.Lock()
+	p.ctrl.Paused = false
+	speaker.Unlock()
+	if p.positionTracker != nil {
+		p.positionTracker.Resume()
+	}
+	
+	p.state = StatePlaying
+	if p.onStateChange != nil {
+		go p.onStateChange(p.state)
+	}
+}
+
+// minPreloadSize is the least data playback starts with when the download is
+// too slow to fill the whole preload quickly
+const minPreloadSize = 64 * 1024
+
+// preloadPatience is how long to wait for the full preload before settling for
+// minPreloadSize
+const preloadPatience = time.Second
+
+// LowBufferBytes is the amount of unplayed data below which a stream that is
+// still downloading is considered to be underrunning (a few seconds of audio)
+const LowBufferBytes = 64 * 1024
+
+// RecoveredBufferBytes is the amount of unplayed data an underrunning stream
+// needs before it is considered recovered. It sits above LowBufferBytes so a
+// buffer hovering around the low mark doesn't flip in and out of buffering.
+const RecoveredBufferBytes = 2 * LowBufferBytes
+
+// BufferLow reports whether BufferHealth metrics indicate an underrun
+func BufferLow(available, total int64, completed bool) bool {
+	return !completed && total > 0 && available < LowBufferBytes
+}
+
+// BufferRecovered reports whether BufferHealth metrics show an underrunning
+// stream has refilled enough to play on
+func BufferRecovered(available, total int64, completed bool) bool {
+	return completed || total == 0 || available >= RecoveredBufferBytes
 }
 
 // StreamBuffer methods
 
-func (b *StreamBuffer) write(data []byte) {
+// newStreamBuffer creates an empty buffer of size bytes, filled from open once
+// a download is started. It counts as preloaded after minBuffer bytes.
+func newStreamBuffer(ctx context.Context, size, minBuffer int64, open streamOpener) *StreamBuffer {
+	bufferCtx, cancel := context.WithCancel(ctx)
+	return &StreamBuffer{
+		data:         make([]byte, size),
+		size:         size,
+		minBuffer:    minBuffer,
+		ctx:          bufferCtx,
+		cancel:       cancel,
+		downloadDone: make(chan bool, 1),
+		open:         open,
+		backoff:      time.Second,
+	}
+}
+
+// OpenStreamBuffer starts downloading streamURL, a remote stream or a local
+// file, into a buffer of size bytes. Read it with a BufferReader, and Close
+// it to stop the download.
+func OpenStreamBuffer(ctx context.Context, streamURL string, size int64, opts ...Option) *StreamBuffer {
+	options := buildHTTPOptions(opts)
+	buffer := newStreamBuffer(ctx, size, options.PreloadSize, streamOpenerFor(newHTTPClient(options), options, streamURL))
+	buffer.startDownload(0)
+	return buffer
+}
+
+// NewCompletedStreamBuffer returns a buffer holding data as a finished
+// download, for reading audio that is already in memory
+func NewCompletedStreamBuffer(data []byte) *StreamBuffer {
+	return &StreamBuffer{
+		data:         data,
+		size:         int64(len(data)),
+		writePos:     int64(len(data)),
+		preloaded:    true,
+		completed:    true,
+		downloadDone: make(chan bool, 1),
+	}
+}
+
+// Close stops the download
+func (b *StreamBuffer) Close() {
+	if b.cancel != nil {
+		b.cancel()
+	}
+}
+
+// startDownload starts downloading at offset, replacing any running download
+// and dropping the data buffered so far
+func (b *StreamBuffer) startDownload(offset int64) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
+	b.restartLocked(offset)
+}
+
+// restartLocked moves the window to offset and downloads from there (caller
+// must hold lock)
+func (b *StreamBuffer) restartLocked(offset int64) {
+	if b.stopFetch != nil {
+		b.stopFetch()
+	}
+	
+	b.base, b.readPos, b.writePos = offset, offset, offset
+	b.completed = false
+	b.generation++
+	
+	ctx, cancel := context.WithCancel(b.ctx)
+	b.stopFetch = cancel
+	go b.download(ctx, b.generation)
+}
+
+// fetchFrom makes pos readable, restarting the download there when it lies
+// outside the downloaded bytes. It reports false when pos can't be fetched:
+// past the end of a finished download, or with nothing to download from.
+func (b *StreamBuffer) fetchFrom(pos int64) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	
+	if pos >= b.base && pos <= b.writePos {
+		return true
+	}
+	if b.open == nil || (b.completed && pos > b.writePos) {
+		return false
+	}
+	
+	b.restartLocked(pos)
+	return true
+}
+
+// download fills the buffer, retrying from where an attempt stopped with
+// growing delays. It gives up after downloadAttempts failures.
+func (b *StreamBuffer) download(ctx context.Context, generation int) {
+	defer func() {
+		// Nobody may be waiting, and a replaced download must not block
+		select {
+		case b.downloadDone <- true:
+		default:
+		}
+	}()
+	
+	for attempt := 0; attempt < downloadAttempts; attempt++ {
+		if attempt > 0 {
+			// Wait before retry with exponential backoff
+			delay := time.Duration(attempt) * b.backoff
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+		}
+		
+		if b.downloadAttempt(ctx, generation) {
+			return // Success
+		}
+		if ctx.Err() != nil {
+			return // Stopped or replaced by a download elsewhere
+		}
+	}
+	
+	if b.onFailure != nil {
+		b.onFailure(fmt.Errorf("failed to download stream after %d attempts", downloadAttempts))
+	}
+}
+
+// downloadAttempt makes a single attempt to download the stream
+func (b *StreamBuffer) downloadAttempt(ctx context.Context, generation int) bool {
+	// Resume from a previous position if an earlier attempt got partway
+	b.mu.RLock()
+	resumeFrom := b.writePos
+	b.mu.RUnlock()
+	
+	body, contentType, err := b.open(ctx, resumeFrom)
+	if err != nil {
+		return false
+	}
+	defer body.Close()
+	b.setContentType(contentType)
+	
+	// Read data in chunks with improved error handling
+	chunk := make([]byte, 32*1024) // 32KB chunks
+	consecutiveErrors := 0
+	maxConsecutiveErrors := 3
+	
+	for {
+		select {
+		case <-ctx.Done():
+			return false // Context cancelled
+		default:
+		}
+		
+		n, err := body.Read(chunk)
+		if n > 0 {
+			if !b.write(generation, chunk[:n]) {
+				return false // Replaced by a download elsewhere
+			}
+			consecutiveErrors = 0 // Reset error count on successful read
+		}
+		
+		if err == io.EOF {
+			b.mu.Lock()
+			if generation == b.generation {
+				b.completed = true
+			}
+			b.mu.Unlock()
+			return true // Success
+		}
+		
+		if err != nil {
+			consecutiveErrors++
+			if consecutiveErrors >= maxConsecutiveErrors {
+				return false // Too many consecutive errors
+			}
+			// Brief pause before continuing
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+	}
+}
+
+// write appends data from the download of the given generation, reporting
+// false once that download has been replaced
+func (b *StreamBuffer) write(generation int, data []byte) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	
+	if generation != b.generation {
+		return false
+	}
 	
 	// Check if we have space
-	availableSpace := b.size - b.writePos
+	availableSpace := b.size - (b.writePos - b.base)
 	if availableSpace <= 0 {
-		return // Buffer full
+		return true // Buffer full
 	}
 	
 	// Limit write to available space
@@ -667,19 +1148,55 @@ func (b *StreamBuffer) write(data []byte) {
 	}
 	
 	// Copy data into buffer
-	copy(b.data[b.writePos:], toWrite)
+	copy(b.data[b.writePos-b.base:], toWrite)
 	b.writePos += int64(len(toWrite))
 	
 	// Mark as preloaded when we reach minimum buffer
-	if !b.preloaded && b.writePos >= b.minBuffer {
+	if !b.preloaded && b.writePos-b.base >= b.minBuffer {
 		b.preloaded = true
 	}
+	return true
 }
 
-func (b *StreamBuffer) isPreloaded() bool {
+// setReadPos records how far the decoder has consumed the buffer
+func (b *StreamBuffer) setReadPos(pos int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.readPos = pos
+}
+
+func (b *StreamBuffer) setContentType(contentType string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	
+	// Keep the first response's type; resumed range requests may differ
+	if b.contentType == "" {
+		b.contentType = contentType
+	}
+}
+
+func (b *StreamBuffer) getContentType() string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.contentType
+}
+
+func (b *StreamBuffer) isCompleted() bool {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
-	return b.preloaded
+	return b.completed
+}
+
+// readyToStart reports whether playback can begin. A slow download only has
+// to reach minPreloadSize instead of the full preload.
+func (b *StreamBuffer) readyToStart(slow bool) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	
+	if b.preloaded || (b.completed && b.writePos > 0) {
+		return true
+	}
+	return slow && b.writePos-b.base >= minPreloadSize
 }
 
 func (b *StreamBuffer) isHealthy() bool {
@@ -707,12 +1224,24 @@ func (b *StreamBuffer) getBufferHealth() (available, total int64, completed bool
 
 // PositionTracker methods
 
+// now reads the tracker's clock, falling back to the system clock for a zero
+// PositionTracker
+func (pt *PositionTracker) now() time.Time {
+	if pt.clock == nil {
+		return time.Now()
+	}
+	return pt.clock.Now()
+}
+
 func (pt *PositionTracker) Start(sampleRate beep.SampleRate) {
 	pt.mu.Lock()
 	defer pt.mu.Unlock()
-	pt.startTime = time.Now()
+	pt.startTime = pt.now()
 	pt.sampleRate = sampleRate
 	pt.totalPaused = 0
+	pt.pausedTime = time.Time{}
+	pt.basePosition = 0
+	pt.lastPosition = 0
 }
 
 func (pt *PositionTracker) Stop() {
@@ -721,17 +1250,21 @@ func (pt *PositionTracker) Stop() {
 	pt.startTime = time.Time{}
 }
 
+// Pause stops the position advancing. Pausing again while paused keeps the
+// time of the first pause.
 func (pt *PositionTracker) Pause() {
 	pt.mu.Lock()
 	defer pt.mu.Unlock()
-	pt.pausedTime = time.Now()
+	if pt.pausedTime.IsZero() {
+		pt.pausedTime = pt.now()
+	}
 }
 
 func (pt *PositionTracker) Resume() {
 	pt.mu.Lock()
 	defer pt.mu.Unlock()
 	if !pt.pausedTime.IsZero() {
-		pt.totalPaused += time.Since(pt.pausedTime)
+		pt.totalPaused += pt.now().Sub(pt.pausedTime)
 		pt.pausedTime = time.Time{}
 	}
 }
@@ -744,12 +1277,13 @@ func (pt *PositionTracker) Update() {
 		return
 	}
 	
-	elapsed := time.Since(pt.startTime) - pt.totalPaused
+	now := pt.now()
+	elapsed := now.Sub(pt.startTime) - pt.totalPaused
 	if !pt.pausedTime.IsZero() {
-		elapsed -= time.Since(pt.pausedTime)
+		elapsed -= now.Sub(pt.pausedTime)
 	}
 	
-	pt.lastPosition = elapsed
+	pt.lastPosition = pt.basePosition + elapsed
 }
 
 func (pt *PositionTracker) GetPosition() time.Duration {
@@ -758,11 +1292,17 @@ func (pt *PositionTracker) GetPosition() time.Duration {
 	return pt.lastPosition
 }
 
+// SetPosition moves the position, as after a seek. A paused tracker stays
+// paused.
 func (pt *PositionTracker) SetPosition(position time.Duration) {
 	pt.mu.Lock()
 	defer pt.mu.Unlock()
+	now := pt.now()
 	pt.lastPosition = position
-	pt.startTime = time.Now()
+	pt.basePosition = position
+	pt.startTime = now
 	pt.totalPaused = 0
-	pt.pausedTime = time.Time{}
-}
\ No newline at end of file
+	if !pt.pausedTime.IsZero() {
+		pt.pausedTime = now
+	}
+}
@@ -0,0 +1,178 @@
+package audio
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/gopxl/beep"
+)
+
+// MaxEQGain is the largest boost or cut (in dB) allowed for an equalizer band
+const MaxEQGain = 12.0
+
+// Equalizer band corner/center frequencies in Hz
+const (
+	eqLowFrequency  = 250.0
+	eqMidFrequency  = 1000.0
+	eqHighFrequency = 4000.0
+	eqMidQ          = 0.7
+)
+
+// Equalizer is a 3-band (low shelf, mid peak, high shelf) tone control.
+// It passes samples through untouched while all gains are zero.
+type Equalizer struct {
+	Streamer   beep.Streamer
+	sampleRate beep.SampleRate
+
+	low, mid, high float64
+	filters        [3]biquad
+}
+
+// NewEqualizer creates a bypassed equalizer wrapping streamer
+func NewEqualizer(streamer beep.Streamer, sampleRate beep.SampleRate) *Equalizer {
+	return &Equalizer{
+		Streamer:   streamer,
+		sampleRate: sampleRate,
+	}
+}
+
+// ValidateEQGains checks that all band gains are within ±MaxEQGain
+func ValidateEQGains(low, mid, high float64) error {
+	for _, gain := range []float64{low, mid, high} {
+		if math.IsNaN(gain) || gain < -MaxEQGain || gain > MaxEQGain {
+			return fmt.Errorf("EQ gain must be between %.0f and %.0f dB, got %f", -MaxEQGain, MaxEQGain, gain)
+		}
+	}
+	return nil
+}
+
+// SetGains sets the band gains in dB. While the equalizer is playing the
+// caller must hold the speaker lock.
+func (e *Equalizer) SetGains(low, mid, high float64) {
+	wasBypassed := e.Bypassed()
+	e.low, e.mid, e.high = low, mid, high
+
+	if e.Bypassed() {
+		return
+	}
+
+	rate := float64(e.sampleRate)
+	e.filters[0].setCoefficients(lowShelf(eqLowFrequency, low, rate))
+	e.filters[1].setCoefficients(peaking(eqMidFrequency, eqMidQ, mid, rate))
+	e.filters[2].setCoefficients(highShelf(eqHighFrequency, high, rate))
+
+	// Start from a clean state instead of replaying stale history
+	if wasBypassed {
+		for i := range e.filters {
+			e.filters[i].reset()
+		}
+	}
+}
+
+// Gains returns the band gains in dB
+func (e *Equalizer) Gains() (low, mid, high float64) {
+	return e.low, e.mid, e.high
+}
+
+// Bypassed reports whether all gains are zero and filtering is skipped
+func (e *Equalizer) Bypassed() bool {
+	return e.low == 0 && e.mid == 0 && e.high == 0
+}
+
+// Stream filters samples from the wrapped streamer
+func (e *Equalizer) Stream(samples [][2]float64) (n int, ok bool) {
+	n, ok = e.Streamer.Stream(samples)
+	if e.Bypassed() || e.sampleRate == 0 {
+		return n, ok
+	}
+
+	for i := range samples[:n] {
+		for ch := 0; ch < 2; ch++ {
+			x := samples[i][ch]
+			for f := range e.filters {
+				x = e.filters[f].process(ch, x)
+			}
+			samples[i][ch] = x
+		}
+	}
+	return n, ok
+}
+
+// Err propagates errors from the wrapped streamer
+func (e *Equalizer) Err() error {
+	return e.Streamer.Err()
+}
+
+// biquad is a second-order IIR filter with independent state per channel
+type biquad struct {
+	b0, b1, b2, a1, a2 float64
+	x1, x2, y1, y2     [2]float64
+}
+
+// setCoefficients applies coefficients normalized by a0
+func (f *biquad) setCoefficients(b0, b1, b2, a0, a1, a2 float64) {
+	f.b0, f.b1, f.b2 = b0/a0, b1/a0, b2/a0
+	f.a1, f.a2 = a1/a0, a2/a0
+}
+
+// reset clears the filter history
+func (f *biquad) reset() {
+	f.x1, f.x2, f.y1, f.y2 = [2]float64{}, [2]float64{}, [2]float64{}, [2]float64{}
+}
+
+// process filters one sample of the given channel
+func (f *biquad) process(ch int, x float64) float64 {
+	y := f.b0*x + f.b1*f.x1[ch] + f.b2*f.x2[ch] - f.a1*f.y1[ch] - f.a2*f.y2[ch]
+	f.x2[ch], f.x1[ch] = f.x1[ch], x
+	f.y2[ch], f.y1[ch] = f.y1[ch], y
+	return y
+}
+
+// Filter designs follow the RBJ audio EQ cookbook with a shelf slope of 1
+
+func lowShelf(frequency, gainDB, sampleRate float64) (b0, b1, b2, a0, a1, a2 float64) {
+	a := math.Pow(10, gainDB/40)
+	w0 := 2 * math.Pi * frequency / sampleRate
+	cos, sin := math.Cos(w0), math.Sin(w0)
+	alpha := sin / 2 * math.Sqrt2
+	sqrtA := 2 * math.Sqrt(a) * alpha
+
+	b0 = a * ((a + 1) - (a-1)*cos + sqrtA)
+	b1 = 2 * a * ((a - 1) - (a+1)*cos)
+	b2 = a * ((a + 1) - (a-1)*cos - sqrtA)
+	a0 = (a + 1) + (a-1)*cos + sqrtA
+	a1 = -2 * ((a - 1) + (a+1)*cos)
+	a2 = (a + 1) + (a-1)*cos - sqrtA
+	return
+}
+
+func highShelf(frequency, gainDB, sampleRate float64) (b0, b1, b2, a0, a1, a2 float64) {
+	a := math.Pow(10, gainDB/40)
+	w0 := 2 * math.Pi * frequency / sampleRate
+	cos, sin := math.Cos(w0), math.Sin(w0)
+	alpha := sin / 2 * math.Sqrt2
+	sqrtA := 2 * math.Sqrt(a) * alpha
+
+	b0 = a * ((a + 1) + (a-1)*cos + sqrtA)
+	b1 = -2 * a * ((a - 1) + (a+1)*cos)
+	b2 = a * ((a + 1) + (a-1)*cos - sqrtA)
+	a0 = (a + 1) - (a-1)*cos + sqrtA
+	a1 = 2 * ((a - 1) - (a+1)*cos)
+	a2 = (a + 1) - (a-1)*cos - sqrtA
+	return
+}
+
+func peaking(frequency, q, gainDB, sampleRate float64) (b0, b1, b2, a0, a1, a2 float64) {
+	a := math.Pow(10, gainDB/40)
+	w0 := 2 * math.Pi * frequency / sampleRate
+	cos, sin := math.Cos(w0), math.Sin(w0)
+	alpha := sin / (2 * q)
+
+	b0 = 1 + alpha*a
+	b1 = -2 * cos
+	b2 = 1 - alpha*a
+	a0 = 1 + alpha/a
+	a1 = -2 * cos
+	a2 = 1 - alpha/a
+	return
+}
@@ -0,0 +1,176 @@
+package audio
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/gopxl/beep/effects"
+	"github.com/gopxl/beep/speaker"
+)
+
+// DefaultFadeDuration is how long volume ramps take when starting, pausing or stopping
+const DefaultFadeDuration = 150 * time.Millisecond
+
+// fadeInterval is how often the volume is adjusted during a fade
+const fadeInterval = 10 * time.Millisecond
+
+// linearToBeepVolume converts linear volume (0-1) to Beep's logarithmic volume
+func linearToBeepVolume(linearVolume float64) float64 {
+	if linearVolume <= 0 {
+		return -10 // Very quiet
+	}
+	if linearVolume >= 1 {
+		return 0 // Unity gain
+	}
+
+	// Convert linear to dB: 20 * log10(volume)
+	// Beep uses base-2 logarithmic scale, so we adjust
+	return (linearVolume - 1.0) * 2.0 // Simple approximation
+}
+
+// EffectiveGain returns the amplitude multiplier a volume control currently applies
+func EffectiveGain(ctrl *effects.Volume) float64 {
+	if ctrl.Silent {
+		return 0
+	}
+	return math.Pow(ctrl.Base, ctrl.Volume)
+}
+
+// FadeVolume ramps ctrl from its current gain to the given linear volume over duration.
+// The ramp is linear in amplitude so it starts and ends without a click. It blocks
+// until the ramp completes or ctx is cancelled; a zero duration applies the volume at once.
+func FadeVolume(ctx context.Context, ctrl *effects.Volume, volume float64, duration time.Duration) {
+	if ctrl == nil {
+		return
+	}
+
+	speaker.Lock()
+	if ctrl.Base == 0 {
+		ctrl.Base = 2
+	}
+	from := EffectiveGain(ctrl)
+	speaker.Unlock()
+
+	to := 0.0
+	if volume > 0 {
+		to = math.Pow(ctrl.Base, linearToBeepVolume(volume))
+	}
+
+	steps := int(duration / fadeInterval)
+	if steps > 0 {
+		ticker := time.NewTicker(fadeInterval)
+		defer ticker.Stop()
+
+		for step := 1; step < steps; step++ {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			gain := from + (to-from)*float64(step)/float64(steps)
+			setGain(ctrl, gain)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+
+	// Land exactly on the requested volume so the user's level is preserved
+	speaker.Lock()
+	ctrl.Volume = linearToBeepVolume(volume)
+	ctrl.Silent = volume == 0
+	speaker.Unlock()
+}
+
+// setGain applies an amplitude multiplier to a volume control
+func setGain(ctrl *effects.Volume, gain float64) {
+	speaker.Lock()
+	defer speaker.Unlock()
+
+	if gain <= 0 {
+		ctrl.Silent = true
+		return
+	}
+	ctrl.Silent = false
+	ctrl.Volume = math.Log(gain) / math.Log(ctrl.Base)
+}
+
+// volumeFader runs at most one volume fade at a time for a player
+type volumeFader struct {
+	mu       sync.Mutex
+	duration time.Duration
+	cancel   context.CancelFunc
+	finished chan struct{}
+}
+
+// newVolumeFader creates a fader using the default fade duration
+func newVolumeFader() *volumeFader {
+	return &volumeFader{duration: DefaultFadeDuration}
+}
+
+// setDuration changes the fade length; zero disables fading
+func (f *volumeFader) setDuration(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.duration = d
+}
+
+// enabled reports whether fades are turned on
+func (f *volumeFader) enabled() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.duration > 0
+}
+
+// start cancels any running fade and ramps ctrl towards volume in the background.
+// done, if set, runs once the fade ends, even if it was cancelled. The returned
+// channel is closed after done has run.
+func (f *volumeFader) start(ctrl *effects.Volume, volume float64, done func()) <-chan struct{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.stopLocked()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	finished := make(chan struct{})
+	f.cancel = cancel
+	f.finished = finished
+
+	duration := f.duration
+	go func() {
+		defer close(finished)
+		FadeVolume(ctx, ctrl, volume, duration)
+		if done != nil {
+			done()
+		}
+	}()
+
+	return finished
+}
+
+// stop cancels any running fade and waits for it to finish
+func (f *volumeFader) stop() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stopLocked()
+}
+
+// stopLocked cancels the running fade (caller must hold lock)
+func (f *volumeFader) stopLocked() {
+	if f.cancel != nil {
+		f.cancel()
+		<-f.finished
+		f.cancel = nil
+		f.finished = nil
+	}
+}
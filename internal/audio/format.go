@@ -0,0 +1,235 @@
+package audio
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/gopxl/beep"
+	"github.com/gopxl/beep/mp3"
+	"github.com/gopxl/beep/vorbis"
+	"github.com/gopxl/beep/wav"
+)
+
+// Stream formats reported in StreamInfo.Format and used to pick a decoder
+const (
+	FormatMP3  = "mp3"
+	FormatWAV  = "wav"
+	FormatOgg  = "ogg"  // Ogg Vorbis
+	FormatOpus = "opus" // Ogg Opus
+	FormatHLS  = "hls"
+)
+
+// ErrOpusUnsupported is returned when a stream uses the Opus codec, which has no
+// pure-Go decoder available to the players
+var ErrOpusUnsupported = errors.New("opus streams are not supported")
+
+// FormatFromMimeType maps a MIME type such as a transcoding's mime_type or an HTTP
+// Content-Type to a stream format. It returns "" for unknown types.
+func FormatFromMimeType(mimeType string) string {
+	mimeType = strings.ToLower(mimeType)
+
+	switch {
+	case strings.Contains(mimeType, "opus"):
+		return FormatOpus
+	case strings.HasPrefix(mimeType, "audio/ogg"), strings.HasPrefix(mimeType, "application/ogg"):
+		return FormatOgg
+	case strings.HasPrefix(mimeType, "audio/mpeg"), strings.HasPrefix(mimeType, "audio/mp3"):
+		return FormatMP3
+	case strings.HasPrefix(mimeType, "audio/wav"), strings.HasPrefix(mimeType, "audio/x-wav"), strings.HasPrefix(mimeType, "audio/wave"):
+		return FormatWAV
+	case strings.Contains(mimeType, "mpegurl"):
+		return FormatHLS
+	default:
+		return ""
+	}
+}
+
+// detectFormat picks a format from the Content-Type, falling back to the URL extension
+func detectFormat(contentType, streamURL string) string {
+	if format := FormatFromMimeType(contentType); format != "" {
+		return format
+	}
+
+	streamURL = strings.ToLower(streamURL)
+	switch {
+	case strings.Contains(streamURL, ".opus"):
+		return FormatOpus
+	case strings.Contains(streamURL, ".ogg"):
+		return FormatOgg
+	case strings.Contains(streamURL, ".wav"):
+		return FormatWAV
+	case strings.Contains(streamURL, ".mp3"):
+		return FormatMP3
+	case strings.Contains(streamURL, ".m3u8"):
+		return FormatHLS
+	default:
+		return ""
+	}
+}
+
+// isHLSStream reports whether a stream is an HLS playlist, going by the
+// format from metadata or, without one, the URL
+func isHLSStream(expectedFormat, streamURL string) bool {
+	if expectedFormat != "" {
+		return expectedFormat == FormatHLS
+	}
+	return detectFormat("", streamURL) == FormatHLS
+}
+
+// sniffSize is how many leading bytes sniffFormat looks at
+const sniffSize = 128
+
+// sniffFormat identifies a format from the first bytes of a stream.
+// It returns "" when the data isn't recognized.
+func sniffFormat(header []byte) string {
+	switch {
+	case bytes.HasPrefix(header, []byte("OggS")):
+		// The first Ogg page carries the codec identification header
+		if bytes.Contains(header, []byte("OpusHead")) {
+			return FormatOpus
+		}
+		return FormatOgg
+	case bytes.HasPrefix(header, []byte("RIFF")):
+		return FormatWAV
+	case bytes.HasPrefix(header, []byte("ID3")), len(header) > 1 && header[0] == 0xFF && header[1]&0xE0 == 0xE0:
+		return FormatMP3
+	default:
+		return ""
+	}
+}
+
+// Decode decodes rc with the decoder for format. Unknown formats are decoded as MP3.
+func Decode(format string, rc io.ReadCloser) (beep.StreamSeekCloser, beep.Format, error) {
+	switch format {
+	case FormatOgg:
+		return vorbis.Decode(rc)
+	case FormatWAV:
+		return wav.Decode(rc)
+	case FormatOpus:
+		return nil, beep.Format{}, ErrOpusUnsupported
+	case FormatHLS:
+		return nil, beep.Format{}, fmt.Errorf("HLS playlists must be resolved before decoding")
+	default:
+		return mp3.Decode(rc)
+	}
+}
+
+// decodeCandidates lists the formats to try, in order, for a stream declared
+// as declared whose first bytes look like sniffed: the declared format first,
+// then the sniffed one, then the remaining decoders. Opus and HLS streams
+// can't be decoded by guessing, so they are tried on their own.
+func decodeCandidates(declared, sniffed string) []string {
+	switch {
+	case declared == FormatHLS:
+		return []string{FormatHLS}
+	case sniffed == FormatOpus, declared == FormatOpus && sniffed == "":
+		return []string{FormatOpus}
+	}
+
+	var candidates []string
+	for _, format := range []string{declared, sniffed, FormatMP3, FormatWAV, FormatOgg} {
+		if format != "" && format != FormatOpus && !slices.Contains(candidates, format) {
+			candidates = append(candidates, format)
+		}
+	}
+	return candidates
+}
+
+// decodeWithFallback decodes the stream as each candidate format in turn and
+// returns the first that succeeds. open is called before every attempt and
+// must return the stream from its start.
+func decodeWithFallback(candidates []string, open func() (io.ReadCloser, error)) (beep.StreamSeekCloser, beep.Format, error) {
+	var errs []error
+	for _, candidate := range candidates {
+		rc, err := open()
+		if err != nil {
+			return nil, beep.Format{}, err
+		}
+
+		streamer, format, err := Decode(candidate, rc)
+		if err == nil {
+			return streamer, format, nil
+		}
+		errs = append(errs, err)
+	}
+
+	if len(errs) == 1 {
+		return nil, beep.Format{}, errs[0]
+	}
+	return nil, beep.Format{}, fmt.Errorf("unsupported audio format: %w", errors.Join(errs...))
+}
+
+// DecodeWithFallback decodes a stream that can't seek, trying the declared
+// format first (which may be ""), then what the stream's first bytes look
+// like, then the remaining decoders
+func DecodeWithFallback(declared string, rc io.ReadCloser) (beep.StreamSeekCloser, beep.Format, error) {
+	body := newReplayReader(rc)
+	header := make([]byte, sniffSize)
+	n, _ := io.ReadFull(body, header)
+
+	streamer, format, err := decodeWithFallback(decodeCandidates(declared, sniffFormat(header[:n])), func() (io.ReadCloser, error) {
+		body.rewind()
+		return body, nil
+	})
+	if err != nil {
+		return nil, beep.Format{}, err
+	}
+	body.settle()
+	return streamer, format, nil
+}
+
+// replayReader records the start of a stream that can't seek, so that it can
+// be decoded again after a decoder rejects it. Decoders may close their input
+// when they fail, so closing is ignored until a decoder has been settled on.
+type replayReader struct {
+	src      io.ReadCloser
+	recorded []byte
+	offset   int
+	settled  bool
+}
+
+func newReplayReader(src io.ReadCloser) *replayReader {
+	return &replayReader{src: src}
+}
+
+func (r *replayReader) Read(p []byte) (int, error) {
+	if r.offset < len(r.recorded) {
+		n := copy(p, r.recorded[r.offset:])
+		r.offset += n
+		return n, nil
+	}
+	if r.settled {
+		// Drop the recording once it has been replayed for the last time
+		r.recorded, r.offset = nil, 0
+	}
+
+	n, err := r.src.Read(p)
+	if !r.settled {
+		r.recorded = append(r.recorded, p[:n]...)
+		r.offset += n
+	}
+	return n, err
+}
+
+// rewind starts the stream over from its first byte
+func (r *replayReader) rewind() {
+	r.offset = 0
+}
+
+// settle stops recording once a decoder has accepted the stream
+func (r *replayReader) settle() {
+	r.settled = true
+}
+
+// Close closes the stream once settled; earlier closes come from rejecting
+// decoders and are ignored
+func (r *replayReader) Close() error {
+	if !r.settled {
+		return nil
+	}
+	return r.src.Close()
+}
@@ -0,0 +1,518 @@
+package audio
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gopxl/beep"
+)
+
+// HLSSegment is one media segment of an HLS playlist
+type HLSSegment struct {
+	URL      string
+	Start    time.Duration // Position of the segment's first sample in the track
+	Duration time.Duration
+}
+
+// HLSPlaylist is a parsed HLS media playlist, its segments in play order
+type HLSPlaylist struct {
+	Segments []HLSSegment
+}
+
+// ParseHLSPlaylist reads an m3u8 media playlist, resolving segment URIs
+// against base when they are relative. base may be nil.
+func ParseHLSPlaylist(r io.Reader, base *url.URL) (*HLSPlaylist, error) {
+	playlist := &HLSPlaylist{}
+	var start time.Duration
+	var pending time.Duration
+	hasDuration := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "#EXT-X-STREAM-INF"):
+			return nil, errors.New("HLS master playlists are not supported")
+		case strings.HasPrefix(line, "#EXTINF:"):
+			value, _, _ := strings.Cut(strings.TrimPrefix(line, "#EXTINF:"), ",")
+			seconds, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || seconds < 0 {
+				return nil, fmt.Errorf("invalid segment duration %q", value)
+			}
+			pending = time.Duration(seconds * float64(time.Second))
+			hasDuration = true
+		case strings.HasPrefix(line, "#"):
+			// Other tags don't affect segment timing
+			continue
+		default:
+			if !hasDuration {
+				return nil, fmt.Errorf("segment %q has no duration", line)
+			}
+			segmentURL := line
+			if base != nil {
+				ref, err := url.Parse(line)
+				if err != nil {
+					return nil, fmt.Errorf("invalid segment URI %q: %w", line, err)
+				}
+				segmentURL = base.ResolveReference(ref).String()
+			}
+			playlist.Segments = append(playlist.Segments, HLSSegment{URL: segmentURL, Start: start, Duration: pending})
+			start += pending
+			hasDuration = false
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read HLS playlist: %w", err)
+	}
+
+	if len(playlist.Segments) == 0 {
+		return nil, errors.New("HLS playlist has no segments")
+	}
+
+	return playlist, nil
+}
+
+// Duration returns the total length of the playlist's segments
+func (p *HLSPlaylist) Duration() time.Duration {
+	if len(p.Segments) == 0 {
+		return 0
+	}
+	last := p.Segments[len(p.Segments)-1]
+	return last.Start + last.Duration
+}
+
+// SegmentAt returns the index of the segment containing position and how
+// far into that segment position is. The end of the track maps to the end
+// of the last segment.
+func (p *HLSPlaylist) SegmentAt(position time.Duration) (int, time.Duration, error) {
+	if position < 0 {
+		return 0, 0, fmt.Errorf("position cannot be negative")
+	}
+
+	duration := p.Duration()
+	if position > duration || len(p.Segments) == 0 {
+		return 0, 0, fmt.Errorf("position %s exceeds duration %s", position, duration)
+	}
+
+	for i, segment := range p.Segments {
+		if position < segment.Start+segment.Duration {
+			return i, position - segment.Start, nil
+		}
+	}
+
+	last := len(p.Segments) - 1
+	return last, position - p.Segments[last].Start, nil
+}
+
+// Segment downloads are retried a few times, each attempt bounded so a
+// stalled connection can't hold up playback for good
+const (
+	segmentAttempts = 3
+	segmentTimeout  = 30 * time.Second
+)
+
+// segmentFetcher downloads a playlist or one of its segments
+type segmentFetcher func(ctx context.Context, resourceURL string) ([]byte, error)
+
+// HLSStream plays an HLS media playlist, decoding each segment as playback
+// reaches it. Segments are downloaded in the background, one ahead of the
+// playing one; Stream never waits on the network and plays silence when a
+// download falls behind.
+type HLSStream struct {
+	mu       sync.Mutex
+	ctx      context.Context // Fetches stop once Close cancels it
+	cancel   context.CancelFunc
+	playlist *HLSPlaylist
+	fetch    segmentFetcher
+	format   beep.Format
+
+	index   int                   // Segment being played, or waited for
+	current beep.StreamSeekCloser // Its decoded audio; nil while waiting for it
+	start   int                   // Its first sample in the track
+	next    *pendingSegment       // Background fetch of the following segment
+	ended   bool                  // Past the last segment, or stopped by an error
+	err     error
+
+	onFailure func(error) // Called when a segment can't be fetched or decoded
+}
+
+// pendingSegment is a segment fetch running in the background
+type pendingSegment struct {
+	index int
+	done  chan struct{}
+	data  []byte
+	err   error
+}
+
+// NewHLSStream fetches the media playlist at playlistURL and its first
+// segment. Later segments are fetched until ctx is cancelled or the stream
+// closed.
+func NewHLSStream(ctx context.Context, playlistURL string, opts ...Option) (*HLSStream, beep.Format, error) {
+	options := buildHTTPOptions(opts)
+	return openHLSStream(ctx, ctx, newResourceFetcher(newHTTPClient(options), options), playlistURL)
+}
+
+// newResourceFetcher returns a fetcher reading local files from disk and
+// requesting remote resources over HTTP, retrying failed downloads
+func newResourceFetcher(client *http.Client, options HTTPOptions) segmentFetcher {
+	return func(ctx context.Context, resourceURL string) ([]byte, error) {
+		var lastErr error
+		for attempt := 0; attempt < segmentAttempts; attempt++ {
+			if attempt > 0 {
+				select {
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				case <-time.After(time.Duration(attempt) * 500 * time.Millisecond):
+				}
+			}
+
+			data, err := fetchResource(ctx, client, options, resourceURL)
+			if err == nil {
+				return data, nil
+			}
+			lastErr = err
+
+			// Waiting won't make a local file readable
+			if ctx.Err() != nil || IsLocalStream(resourceURL) {
+				break
+			}
+		}
+		return nil, lastErr
+	}
+}
+
+// fetchResource reads a local file, or makes one attempt at downloading a
+// remote resource
+func fetchResource(ctx context.Context, client *http.Client, options HTTPOptions, resourceURL string) ([]byte, error) {
+	if IsLocalStream(resourceURL) {
+		return os.ReadFile(LocalPath(resourceURL))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, segmentTimeout)
+	defer cancel()
+
+	req, err := newStreamRequest(ctx, resourceURL, options)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP error: %d %s", resp.StatusCode, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// openHLSStream fetches and parses the playlist, then loads its first
+// segment. Later fetches run until parent is cancelled or the stream closed.
+func openHLSStream(ctx, parent context.Context, fetch segmentFetcher, playlistURL string) (*HLSStream, beep.Format, error) {
+	data, err := fetch(ctx, playlistURL)
+	if err != nil {
+		return nil, beep.Format{}, fmt.Errorf("failed to fetch HLS playlist: %w", err)
+	}
+
+	base, err := url.Parse(playlistURL)
+	if err != nil {
+		return nil, beep.Format{}, fmt.Errorf("invalid HLS playlist URL: %w", err)
+	}
+	playlist, err := ParseHLSPlaylist(bytes.NewReader(data), base)
+	if err != nil {
+		return nil, beep.Format{}, err
+	}
+
+	data, err = fetch(ctx, playlist.Segments[0].URL)
+	if err != nil {
+		return nil, beep.Format{}, fmt.Errorf("failed to fetch segment 0: %w", err)
+	}
+	segment, format, err := decodeSegment(0, data)
+	if err != nil {
+		return nil, beep.Format{}, err
+	}
+
+	streamCtx, cancel := context.WithCancel(parent)
+	s := &HLSStream{
+		ctx:      streamCtx,
+		cancel:   cancel,
+		playlist: playlist,
+		fetch:    fetch,
+		format:   format,
+		current:  segment,
+	}
+	s.prefetchLocked(1)
+	return s, format, nil
+}
+
+// segmentReader reads a downloaded segment. The decoders need it to seek.
+type segmentReader struct {
+	*bytes.Reader
+}
+
+func (segmentReader) Close() error {
+	return nil
+}
+
+// decodeSegment decodes a downloaded segment, whatever its format
+func decodeSegment(index int, data []byte) (beep.StreamSeekCloser, beep.Format, error) {
+	sniffed := sniffFormat(data[:min(len(data), sniffSize)])
+	streamer, format, err := decodeWithFallback(decodeCandidates("", sniffed), func() (io.ReadCloser, error) {
+		return segmentReader{bytes.NewReader(data)}, nil
+	})
+	if err != nil {
+		return nil, beep.Format{}, fmt.Errorf("failed to decode segment %d: %w", index, err)
+	}
+	return streamer, format, nil
+}
+
+// Stream implements beep.Streamer, moving on to the next segment whenever
+// one runs out. It runs on the speaker's goroutine, so rather than wait for
+// a segment that hasn't downloaded yet it fills in silence.
+func (s *HLSStream) Stream(samples [][2]float64) (n int, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for n < len(samples) && !s.ended {
+		if s.current == nil && !s.takeNextLocked() {
+			if s.ended {
+				break
+			}
+			clear(samples[n:])
+			return len(samples), true
+		}
+
+		filled, ok := s.current.Stream(samples[n:])
+		n += filled
+		if ok {
+			continue
+		}
+		if err := s.current.Err(); err != nil {
+			s.failLocked(fmt.Errorf("failed to decode segment %d: %w", s.index, err))
+			break
+		}
+		s.finishSegmentLocked()
+	}
+	return n, n > 0
+}
+
+// finishSegmentLocked moves past the segment that just ran out. Playback
+// ends after the last one.
+func (s *HLSStream) finishSegmentLocked() {
+	s.current.Close()
+	s.current = nil
+
+	if s.index+1 >= len(s.playlist.Segments) {
+		s.ended = true
+		return
+	}
+	s.index++
+	s.start = s.format.SampleRate.N(s.playlist.Segments[s.index].Start)
+}
+
+// takeNextLocked swaps in the awaited segment once its download has
+// finished, reporting whether there's a segment to play. A segment that
+// can't be loaded ends playback.
+func (s *HLSStream) takeNextLocked() bool {
+	pending := s.next
+	if pending == nil || pending.index != s.index {
+		s.prefetchLocked(s.index)
+		return false
+	}
+
+	select {
+	case <-pending.done:
+	default:
+		return false
+	}
+
+	if pending.err != nil {
+		s.failLocked(fmt.Errorf("failed to fetch segment %d: %w", s.index, pending.err))
+		return false
+	}
+	segment, err := s.decode(s.index, pending.data)
+	if err != nil {
+		s.failLocked(err)
+		return false
+	}
+
+	s.current = segment
+	s.prefetchLocked(s.index + 1)
+	return true
+}
+
+// decode decodes a later segment, which must match the stream's sample rate
+func (s *HLSStream) decode(index int, data []byte) (beep.StreamSeekCloser, error) {
+	segment, format, err := decodeSegment(index, data)
+	if err != nil {
+		return nil, err
+	}
+	if format.SampleRate != s.format.SampleRate {
+		segment.Close()
+		return nil, fmt.Errorf("segment %d changes the sample rate from %d to %d", index, s.format.SampleRate, format.SampleRate)
+	}
+	return segment, nil
+}
+
+// prefetchLocked starts fetching segment index in the background
+func (s *HLSStream) prefetchLocked(index int) {
+	s.next = nil
+	if index >= len(s.playlist.Segments) {
+		return
+	}
+
+	pending := &pendingSegment{index: index, done: make(chan struct{})}
+	s.next = pending
+	segmentURL := s.playlist.Segments[index].URL
+	go func() {
+		defer close(pending.done)
+		pending.data, pending.err = s.fetch(s.ctx, segmentURL)
+	}()
+}
+
+// failLocked ends playback with err. Fetches cut short by Close aren't
+// failures.
+func (s *HLSStream) failLocked(err error) {
+	s.ended = true
+	if s.current != nil {
+		s.current.Close()
+		s.current = nil
+	}
+	if s.ctx.Err() != nil {
+		return
+	}
+
+	s.err = err
+	if s.onFailure != nil {
+		go s.onFailure(err)
+	}
+}
+
+// Err returns the error that ended playback early, if any
+func (s *HLSStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Len returns the length of the whole playlist in samples
+func (s *HLSStream) Len() int {
+	return s.format.SampleRate.N(s.playlist.Duration())
+}
+
+// Position returns the current position in the whole playlist in samples.
+// It holds still while a segment downloads.
+func (s *HLSStream) Position() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case s.ended:
+		return s.Len()
+	case s.current == nil:
+		return s.start
+	default:
+		return s.start + s.current.Position()
+	}
+}
+
+// Seek moves to sample p of the whole playlist. Seeking into another
+// segment fetches and decodes it first without holding the stream's lock,
+// so the playing segment carries on meanwhile and callers shouldn't hold
+// the speaker lock either.
+func (s *HLSStream) Seek(p int) error {
+	if p < 0 || p > s.Len() {
+		return fmt.Errorf("seek position %d out of range [0, %d]", p, s.Len())
+	}
+
+	index, _, err := s.playlist.SegmentAt(s.format.SampleRate.D(p))
+	if err != nil {
+		return err
+	}
+	start := s.format.SampleRate.N(s.playlist.Segments[index].Start)
+
+	s.mu.Lock()
+	if s.current != nil && s.index == index {
+		err := s.current.Seek(min(p-start, s.current.Len()))
+		s.mu.Unlock()
+		return err
+	}
+	pending := s.next
+	s.mu.Unlock()
+
+	var data []byte
+	if pending != nil && pending.index == index {
+		select {
+		case <-pending.done:
+			data, err = pending.data, pending.err
+		case <-s.ctx.Done():
+			err = s.ctx.Err()
+		}
+	} else {
+		data, err = s.fetch(s.ctx, s.playlist.Segments[index].URL)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch segment %d: %w", index, err)
+	}
+
+	segment, err := s.decode(index, data)
+	if err != nil {
+		return err
+	}
+	// Rounding can put p a sample either side of the segment
+	if err := segment.Seek(min(max(p-start, 0), segment.Len())); err != nil {
+		segment.Close()
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ctx.Err() != nil {
+		segment.Close()
+		return errors.New("HLS stream is closed")
+	}
+	if s.current != nil {
+		s.current.Close()
+	}
+	s.index = index
+	s.current = segment
+	s.start = start
+	s.ended = false
+	s.err = nil
+	if s.next == nil || s.next.index != index+1 {
+		s.prefetchLocked(index + 1)
+	}
+	return nil
+}
+
+// Close stops fetching segments and releases the playing one
+func (s *HLSStream) Close() error {
+	s.cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ended = true
+	if s.current == nil {
+		return nil
+	}
+	err := s.current.Close()
+	s.current = nil
+	return err
+}
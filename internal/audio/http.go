@@ -0,0 +1,191 @@
+package audio
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"soundcloud-tui/internal/version"
+)
+
+// DefaultUserAgent is sent with every stream request unless overridden
+var DefaultUserAgent = version.UserAgent()
+
+// Buffered streaming defaults
+const (
+	DefaultPreloadSize    = 1024 * 1024 // 1MB preload for smoother start
+	DefaultPreloadTimeout = 15 * time.Second
+)
+
+// DefaultPositionInterval is how often the players update the playback
+// position by default
+const DefaultPositionInterval = 100 * time.Millisecond
+
+// HTTPOptions configures how the players fetch audio streams
+type HTTPOptions struct {
+	// ConnectTimeout bounds dialing, the TLS handshake and waiting for response headers
+	ConnectTimeout time.Duration
+
+	// StreamTimeout bounds the whole request including reading the body.
+	// Zero means no limit; streams are cancelled through their context instead,
+	// since a long track can take far longer to read than to connect.
+	StreamTimeout time.Duration
+
+	// UserAgent is set on all outgoing stream requests
+	UserAgent string
+
+	// MaxIdleConns limits how many keep-alive connections are kept for reuse
+	MaxIdleConns int
+
+	// IdleConnTimeout is how long an unused keep-alive connection stays open
+	IdleConnTimeout time.Duration
+
+	// Transport overrides the HTTP transport (used by tests)
+	Transport http.RoundTripper
+
+	// PreloadSize is how much data the buffered player collects before it
+	// starts playback on a fast connection
+	PreloadSize int64
+
+	// PreloadTimeout bounds how long the buffered player waits for enough
+	// data to start playback
+	PreloadTimeout time.Duration
+
+	// PositionInterval is how often the players update the playback position;
+	// longer intervals use less CPU
+	PositionInterval time.Duration
+}
+
+// Option customizes HTTPOptions when constructing a player
+type Option func(*HTTPOptions)
+
+// DefaultHTTPOptions returns the options used when no Option is given
+func DefaultHTTPOptions() HTTPOptions {
+	return HTTPOptions{
+		ConnectTimeout:   30 * time.Second,
+		StreamTimeout:    0,
+		UserAgent:        DefaultUserAgent,
+		MaxIdleConns:     10,
+		IdleConnTimeout:  30 * time.Second,
+		PreloadSize:      DefaultPreloadSize,
+		PreloadTimeout:   DefaultPreloadTimeout,
+		PositionInterval: DefaultPositionInterval,
+	}
+}
+
+// WithConnectTimeout sets the timeout for establishing a stream connection
+func WithConnectTimeout(timeout time.Duration) Option {
+	return func(o *HTTPOptions) {
+		o.ConnectTimeout = timeout
+	}
+}
+
+// WithStreamTimeout sets the overall timeout for a stream request, including the body
+func WithStreamTimeout(timeout time.Duration) Option {
+	return func(o *HTTPOptions) {
+		o.StreamTimeout = timeout
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with stream requests
+func WithUserAgent(userAgent string) Option {
+	return func(o *HTTPOptions) {
+		o.UserAgent = userAgent
+	}
+}
+
+// WithMaxIdleConns sets how many idle keep-alive connections are kept for reuse
+func WithMaxIdleConns(n int) Option {
+	return func(o *HTTPOptions) {
+		o.MaxIdleConns = n
+	}
+}
+
+// WithIdleConnTimeout sets how long idle keep-alive connections stay open
+func WithIdleConnTimeout(timeout time.Duration) Option {
+	return func(o *HTTPOptions) {
+		o.IdleConnTimeout = timeout
+	}
+}
+
+// WithTransport replaces the HTTP transport used for stream requests
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *HTTPOptions) {
+		o.Transport = transport
+	}
+}
+
+// WithPreloadSize sets how much data is buffered before playback starts
+func WithPreloadSize(size int64) Option {
+	return func(o *HTTPOptions) {
+		o.PreloadSize = size
+	}
+}
+
+// WithPreloadTimeout sets how long to wait for the initial buffer before giving up
+func WithPreloadTimeout(timeout time.Duration) Option {
+	return func(o *HTTPOptions) {
+		o.PreloadTimeout = timeout
+	}
+}
+
+// WithPositionInterval sets how often the playback position is updated.
+// Non-positive intervals keep the default.
+func WithPositionInterval(interval time.Duration) Option {
+	return func(o *HTTPOptions) {
+		if interval > 0 {
+			o.PositionInterval = interval
+		}
+	}
+}
+
+// buildHTTPOptions applies options on top of the defaults
+func buildHTTPOptions(opts []Option) HTTPOptions {
+	options := DefaultHTTPOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// newHTTPClient creates the client used to fetch streams
+func newHTTPClient(options HTTPOptions) *http.Client {
+	transport := options.Transport
+	if transport == nil {
+		dialer := &net.Dialer{
+			Timeout:   options.ConnectTimeout,
+			KeepAlive: 30 * time.Second,
+		}
+		transport = &http.Transport{
+			Proxy:                 http.ProxyFromEnvironment,
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   options.ConnectTimeout,
+			ResponseHeaderTimeout: options.ConnectTimeout,
+			MaxIdleConns:          options.MaxIdleConns,
+			IdleConnTimeout:       options.IdleConnTimeout,
+			MaxConnsPerHost:       5,
+			DisableCompression:    false,
+		}
+	}
+
+	return &http.Client{
+		Timeout:   options.StreamTimeout,
+		Transport: transport,
+	}
+}
+
+// newStreamRequest creates a GET request for a stream with the configured headers
+func newStreamRequest(ctx context.Context, streamURL string, options HTTPOptions) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", streamURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if options.UserAgent != "" {
+		req.Header.Set("User-Agent", options.UserAgent)
+	}
+
+	return req, nil
+}
@@ -0,0 +1,68 @@
+package audio
+
+import (
+	"math"
+	"sync/atomic"
+
+	"github.com/gopxl/beep"
+)
+
+// levelDecay is the share of the previous level kept for each streamed block,
+// so the meter rises instantly and falls off smoothly
+const levelDecay = 0.85
+
+// LevelMeter passes samples through unchanged while tracking a decaying RMS
+// level for each channel. Levels can be read from any goroutine.
+type LevelMeter struct {
+	Streamer beep.Streamer
+
+	// math.Float64bits of the current levels, written only by Stream
+	left, right atomic.Uint64
+}
+
+// NewLevelMeter creates a level meter tapping streamer
+func NewLevelMeter(streamer beep.Streamer) *LevelMeter {
+	return &LevelMeter{Streamer: streamer}
+}
+
+// Stream streams from the wrapped streamer and measures the samples
+func (m *LevelMeter) Stream(samples [][2]float64) (n int, ok bool) {
+	n, ok = m.Streamer.Stream(samples)
+
+	var sumLeft, sumRight float64
+	for _, sample := range samples[:n] {
+		sumLeft += sample[0] * sample[0]
+		sumRight += sample[1] * sample[1]
+	}
+
+	var rmsLeft, rmsRight float64
+	if n > 0 {
+		rmsLeft = math.Sqrt(sumLeft / float64(n))
+		rmsRight = math.Sqrt(sumRight / float64(n))
+	}
+
+	updateLevel(&m.left, rmsLeft)
+	updateLevel(&m.right, rmsRight)
+
+	return n, ok
+}
+
+// Err propagates the wrapped streamer's errors
+func (m *LevelMeter) Err() error {
+	return m.Streamer.Err()
+}
+
+// Levels returns the current RMS amplitude of each channel (0 is silence,
+// 1 is a full-scale square wave)
+func (m *LevelMeter) Levels() (left, right float64) {
+	return math.Float64frombits(m.left.Load()), math.Float64frombits(m.right.Load())
+}
+
+// updateLevel stores rms, or the decayed previous level if that's higher
+func updateLevel(level *atomic.Uint64, rms float64) {
+	decayed := math.Float64frombits(level.Load()) * levelDecay
+	if rms < decayed {
+		rms = decayed
+	}
+	level.Store(math.Float64bits(rms))
+}
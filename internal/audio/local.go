@@ -0,0 +1,97 @@
+package audio
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gopxl/beep"
+)
+
+// localExtensions are the file extensions a bare path needs to be played as a
+// local file
+var localExtensions = []string{".mp3", ".wav", ".ogg", ".opus"}
+
+// IsLocalStream reports whether streamURL names a local file rather than a
+// remote stream: a file:// URL, or a path without a scheme ending in a known
+// audio extension
+func IsLocalStream(streamURL string) bool {
+	if strings.HasPrefix(streamURL, "file://") {
+		return true
+	}
+	if strings.Contains(streamURL, "://") {
+		return false
+	}
+
+	ext := strings.ToLower(filepath.Ext(streamURL))
+	for _, known := range localExtensions {
+		if ext == known {
+			return true
+		}
+	}
+	return false
+}
+
+// LocalPath returns the file system path of a local stream
+func LocalPath(streamURL string) string {
+	if !strings.HasPrefix(streamURL, "file://") {
+		return streamURL
+	}
+
+	parsed, err := url.Parse(streamURL)
+	if err != nil || parsed.Path == "" {
+		return strings.TrimPrefix(streamURL, "file://")
+	}
+	return parsed.Path
+}
+
+// openLocalFile opens a local stream for reading, positioned at offset
+func openLocalFile(streamURL string, offset int64) (*os.File, error) {
+	file, err := os.Open(LocalPath(streamURL))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open local file: %w", err)
+	}
+
+	if offset > 0 {
+		if _, err := file.Seek(offset, io.SeekStart); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to seek local file: %w", err)
+		}
+	}
+	return file, nil
+}
+
+// decodeLocalFile opens and decodes a local stream, trying the format of the
+// file extension first, then what the file's first bytes look like
+func decodeLocalFile(streamURL string) (beep.StreamSeekCloser, beep.Format, error) {
+	file, err := openLocalFile(streamURL, 0)
+	if err != nil {
+		return nil, beep.Format{}, err
+	}
+	header := make([]byte, sniffSize)
+	n, _ := io.ReadFull(file, header)
+	file.Close()
+
+	declared := detectFormat("", LocalPath(streamURL))
+	var attempt *os.File
+	streamer, format, err := decodeWithFallback(decodeCandidates(declared, sniffFormat(header[:n])), func() (io.ReadCloser, error) {
+		// Decoders may close the file when they reject it, so every attempt
+		// gets a fresh one
+		if attempt != nil {
+			attempt.Close()
+		}
+		file, err := openLocalFile(streamURL, 0)
+		attempt = file
+		return file, err
+	})
+	if err != nil {
+		if attempt != nil {
+			attempt.Close()
+		}
+		return nil, beep.Format{}, fmt.Errorf("failed to decode audio: %w", err)
+	}
+	return streamer, format, nil
+}
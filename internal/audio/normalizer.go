@@ -0,0 +1,129 @@
+package audio
+
+import (
+	"math"
+	"time"
+
+	"github.com/gopxl/beep"
+)
+
+// NormalizationTarget is the short-term RMS level, in dBFS, that loudness
+// normalization steers tracks towards
+const NormalizationTarget = -18.0
+
+// Loudness normalization limits and timing
+const (
+	maxNormalizationBoost = 9.0   // dB; quiet tracks are raised at most this much
+	maxNormalizationCut   = -15.0 // dB
+	normalizationSilence  = -50.0 // dBFS; quieter passages leave the gain alone
+	normalizationCeiling  = 0.95  // Highest peak the gained signal may reach
+
+	loudnessWindow = 3 * time.Second // Time constant of the loudness measurement
+	gainSmoothing  = time.Second     // Time constant of gain changes
+)
+
+// Normalizer evens out loudness between tracks. It measures the short-term
+// RMS level of the wrapped streamer and moves its gain towards bringing that
+// level to NormalizationTarget. Boosts and cuts are bounded, and the gain
+// drops whenever a peak would otherwise clip. While disabled it passes
+// samples through untouched but keeps measuring.
+type Normalizer struct {
+	Streamer   beep.Streamer
+	sampleRate beep.SampleRate
+
+	enabled    bool
+	measured   bool
+	meanSquare float64 // Running mean of the squared samples
+	gain       float64 // Linear gain applied while enabled
+}
+
+// NewNormalizer creates a disabled normalizer wrapping streamer
+func NewNormalizer(streamer beep.Streamer, sampleRate beep.SampleRate) *Normalizer {
+	return &Normalizer{
+		Streamer:   streamer,
+		sampleRate: sampleRate,
+		gain:       1,
+	}
+}
+
+// SetEnabled turns normalization on or off. While the normalizer is playing
+// the caller must hold the speaker lock.
+func (n *Normalizer) SetEnabled(enabled bool) {
+	n.enabled = enabled
+	if !enabled {
+		n.gain = 1
+	}
+}
+
+// Enabled reports whether normalization is applied
+func (n *Normalizer) Enabled() bool {
+	return n.enabled
+}
+
+// Gain returns the gain currently applied, in dB
+func (n *Normalizer) Gain() float64 {
+	return 20 * math.Log10(n.gain)
+}
+
+// Stream streams from the wrapped streamer, measuring and adjusting the samples
+func (n *Normalizer) Stream(samples [][2]float64) (count int, ok bool) {
+	count, ok = n.Streamer.Stream(samples)
+	if count == 0 || n.sampleRate == 0 {
+		return count, ok
+	}
+
+	rate := float64(n.sampleRate)
+	loudnessRate := 1 / (loudnessWindow.Seconds() * rate)
+	gainRate := 1 / (gainSmoothing.Seconds() * rate)
+
+	// Start from the level of the first block rather than from silence
+	if !n.measured {
+		var sum float64
+		for _, sample := range samples[:count] {
+			sum += (sample[0]*sample[0] + sample[1]*sample[1]) / 2
+		}
+		n.meanSquare = sum / float64(count)
+		n.measured = true
+	}
+
+	for i := range samples[:count] {
+		left, right := samples[i][0], samples[i][1]
+		n.meanSquare += loudnessRate * ((left*left+right*right)/2 - n.meanSquare)
+		if !n.enabled {
+			continue
+		}
+
+		n.gain += gainRate * (n.targetGain() - n.gain)
+
+		// Hold peaks below clipping; the lowered gain recovers smoothly
+		if peak := math.Max(math.Abs(left), math.Abs(right)); peak*n.gain > normalizationCeiling {
+			n.gain = normalizationCeiling / peak
+		}
+
+		samples[i][0] = left * n.gain
+		samples[i][1] = right * n.gain
+	}
+
+	return count, ok
+}
+
+// targetGain returns the linear gain that brings the measured level to the
+// target, keeping the current gain through silence
+func (n *Normalizer) targetGain() float64 {
+	if n.meanSquare <= 0 {
+		return n.gain
+	}
+
+	level := 10 * math.Log10(n.meanSquare)
+	if level < normalizationSilence {
+		return n.gain
+	}
+
+	gainDB := math.Max(maxNormalizationCut, math.Min(maxNormalizationBoost, NormalizationTarget-level))
+	return math.Pow(10, gainDB/20)
+}
+
+// Err propagates the wrapped streamer's errors
+func (n *Normalizer) Err() error {
+	return n.Streamer.Err()
+}
@@ -0,0 +1,70 @@
+package audio
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// OutputDeviceSelector is implemented by players that can choose which audio
+// output plays the sound. Check for it with a type assertion; the choice has
+// to be made before the first track plays, because the speaker can only be
+// set up once per run.
+type OutputDeviceSelector interface {
+	// ListOutputDevices returns the names of the available outputs
+	ListOutputDevices() ([]string, error)
+
+	// SetOutputDevice routes playback to the output with the given name
+	SetOutputDevice(name string) error
+}
+
+// ErrOutputDeviceUnsupported is returned when the platform's audio backend
+// offers no way to choose an output device
+var ErrOutputDeviceUnsupported = errors.New("choosing an output device is not supported on this system")
+
+// ErrSpeakerStarted is returned when an output device is chosen after the
+// speaker was already set up for playback
+var ErrSpeakerStarted = errors.New("the output device can only be chosen before playback starts")
+
+// speakerStarted records that the speaker has been initialized, after which
+// the output device is fixed for the rest of the run
+var speakerStarted atomic.Bool
+
+// ListOutputDevices returns the output devices the platform backend knows
+func (p *BeepPlayer) ListOutputDevices() ([]string, error) {
+	return listOutputDevices()
+}
+
+// SetOutputDevice routes playback to the named output device
+func (p *BeepPlayer) SetOutputDevice(name string) error {
+	return setOutputDevice(name)
+}
+
+// ListOutputDevices returns the output devices the platform backend knows
+func (p *BufferedStreamPlayer) ListOutputDevices() ([]string, error) {
+	return listOutputDevices()
+}
+
+// SetOutputDevice routes playback to the named output device
+func (p *BufferedStreamPlayer) SetOutputDevice(name string) error {
+	return setOutputDevice(name)
+}
+
+// setOutputDevice checks that name is a known device and that the speaker
+// is not yet running, then selects the device
+func setOutputDevice(name string) error {
+	if speakerStarted.Load() {
+		return ErrSpeakerStarted
+	}
+
+	devices, err := listOutputDevices()
+	if err != nil {
+		return err
+	}
+	for _, device := range devices {
+		if device == name {
+			return selectOutputDevice(name)
+		}
+	}
+	return fmt.Errorf("unknown output device %q", name)
+}
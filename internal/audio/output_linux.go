@@ -0,0 +1,51 @@
+//go:build linux
+
+package audio
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// On Linux the speaker plays through ALSA's default device, which on desktops
+// is routed to PulseAudio or PipeWire. Their sinks are the output devices:
+// pactl lists them, and PULSE_SINK picks the one a new stream plays on.
+
+// listOutputDevices returns the names of the PulseAudio or PipeWire sinks
+func listOutputDevices() ([]string, error) {
+	out, err := exec.Command("pactl", "list", "short", "sinks").Output()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, fmt.Errorf("%w: pactl not found (needs PulseAudio or PipeWire)", ErrOutputDeviceUnsupported)
+		}
+		return nil, fmt.Errorf("failed to list output devices: %w", err)
+	}
+	return parseSinks(out), nil
+}
+
+// parseSinks reads sink names from the tab separated output of
+// "pactl list short sinks": index, name, driver, sample spec, state
+func parseSinks(out []byte) []string {
+	var sinks []string
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) >= 2 && fields[1] != "" {
+			sinks = append(sinks, fields[1])
+		}
+	}
+	return sinks
+}
+
+// selectOutputDevice makes the speaker's stream play on the named sink
+func selectOutputDevice(name string) error {
+	if err := os.Setenv("PULSE_SINK", name); err != nil {
+		return fmt.Errorf("failed to select output device: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+//go:build !linux
+
+package audio
+
+// The speaker always plays on the system default output on macOS (Core
+// Audio) and Windows (WASAPI); the audio backend has no device selection.
+
+func listOutputDevices() ([]string, error) {
+	return nil, ErrOutputDeviceUnsupported
+}
+
+func selectOutputDevice(name string) error {
+	return ErrOutputDeviceUnsupported
+}
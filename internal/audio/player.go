@@ -4,15 +4,12 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"strings"
 	"sync"
 	"time"
 
 	"github.com/gopxl/beep"
 	"github.com/gopxl/beep/effects"
-	"github.com/gopxl/beep/mp3"
 	"github.com/gopxl/beep/speaker"
-	"github.com/gopxl/beep/wav"
 )
 
 // PlayerState represents the current state of the audio player
@@ -22,6 +19,7 @@ const (
 	StateStopped PlayerState = iota
 	StatePlaying
 	StatePaused
+	StateBuffering // Playback is stalled waiting for stream data
 )
 
 func (s PlayerState) String() string {
@@ -32,6 +30,8 @@ func (s PlayerState) String() string {
 		return "playing"
 	case StatePaused:
 		return "paused"
+	case StateBuffering:
+		return "buffering"
 	default:
 		return "unknown"
 	}
@@ -60,12 +60,45 @@ type Player interface {
 	// GetDuration returns total track duration
 	GetDuration() time.Duration
 
+	// SetExpectedDuration sets the track length from metadata, used until the
+	// decoded length is known to be reliable
+	SetExpectedDuration(d time.Duration)
+
+	// SetExpectedFormat sets the stream format from metadata (one of the
+	// Format constants, "" to detect it), decoded first on the next Play
+	SetExpectedFormat(format string)
+
 	// SetVolume sets playback volume (0.0 to 1.0)
 	SetVolume(volume float64) error
 
 	// GetVolume returns current volume level
 	GetVolume() float64
 
+	// SetEQ sets the equalizer band gains in dB (each within ±MaxEQGain)
+	SetEQ(low, mid, high float64) error
+
+	// GetEQ returns the equalizer band gains in dB
+	GetEQ() (low, mid, high float64)
+
+	// SetNormalization turns loudness normalization on or off
+	SetNormalization(enabled bool)
+
+	// GetNormalization reports whether loudness normalization is on
+	GetNormalization() bool
+
+	// GetLevels returns the current output level of each channel (RMS
+	// amplitude, 0-1). Players that can't measure output return zeros.
+	GetLevels() (left, right float64)
+
+	// BufferHealth reports how many downloaded bytes are waiting to be played,
+	// the buffer capacity, and whether the download has finished. Players
+	// without a download buffer report completed.
+	BufferHealth() (available, total int64, completed bool)
+
+	// GetSampleRate returns the sample rate the stream decodes at in Hz, or 0
+	// with nothing loaded
+	GetSampleRate() int
+
 	// Seek sets playback position
 	Seek(position time.Duration) error
 
@@ -73,6 +106,33 @@ type Player interface {
 	Close() error
 }
 
+// ErrorReporter is implemented by players that can fail after Play has
+// returned, such as when a background download gives up. Check for it with a
+// type assertion.
+type ErrorReporter interface {
+	// SetErrorCallback sets the function called, on its own goroutine, with
+	// each such failure
+	SetErrorCallback(callback func(error))
+}
+
+// FormatReporter is implemented by players that can tell when the format of
+// the audio being played changes. Check for it with a type assertion.
+type FormatReporter interface {
+	// SetFormatChangeCallback sets the function called, on its own goroutine,
+	// with the sample rate and channels of each newly decoded stream
+	SetFormatChangeCallback(callback func(beep.Format))
+}
+
+// EffectiveDuration picks the duration to report for a stream. A progressively
+// decoded length only grows as data arrives, so the expected duration from
+// metadata wins until the decoded length exceeds it.
+func EffectiveDuration(decoded, expected time.Duration) time.Duration {
+	if expected > 0 && decoded < expected {
+		return expected
+	}
+	return decoded
+}
+
 // BeepPlayer implements Player using the Beep audio library
 type BeepPlayer struct {
 	mu              sync.RWMutex
@@ -84,42 +144,82 @@ type BeepPlayer struct {
 	format          beep.Format
 	ctrl            *beep.Ctrl
 	volumeCtrl      *effects.Volume
+	fader           *volumeFader
+	eq              *Equalizer
+	normalizer      *Normalizer
+	levels          *LevelMeter
+	
+	// Equalizer band gains in dB, applied to every new stream
+	eqLow, eqMid, eqHigh float64
+	
+	// Loudness normalization, applied to every new stream
+	normalize bool
 	
 	// Speaker management
 	speakerInit     sync.Once
 	speakerInitErr  error
+	speakerRate     beep.SampleRate // Rate the speaker was initialized at
 	
 	// Stream information
-	streamURL       string
-	httpClient      *http.Client
+	streamURL        string
+	expectedDuration time.Duration // From track metadata
+	expectedFormat   string        // From track metadata, "" to detect it
+	httpClient       *http.Client
+	httpOptions      HTTPOptions
+	
+	// Called with the format of each newly decoded stream
+	onFormatChange   func(beep.Format)
 }
 
 // NewBeepPlayer creates a new Beep-based audio player
-func NewBeepPlayer() *BeepPlayer {
+func NewBeepPlayer(opts ...Option) *BeepPlayer {
+	httpOptions := buildHTTPOptions(opts)
 	return &BeepPlayer{
-		state:      StateStopped,
-		volume:     1.0, // Default full volume
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-			Transport: &http.Transport{
-				MaxIdleConns:        10,
-				IdleConnTimeout:     30 * time.Second,
-				DisableCompression:  false,
-				MaxConnsPerHost:     5,
-			},
-		},
+		state:       StateStopped,
+		volume:      1.0, // Default full volume
+		httpClient:  newHTTPClient(httpOptions),
+		httpOptions: httpOptions,
+		fader:       newVolumeFader(),
 	}
 }
 
-// NewBufferedBeepPlayer creates a new buffered streaming audio player with fallback
-func NewBufferedBeepPlayer() Player {
-	// Try the BufferedStreamPlayer first, fallback to BeepPlayer if needed
-	return NewBufferedStreamPlayer()
+// PlayerKind names a Player implementation
+type PlayerKind string
+
+const (
+	// PlayerBeep loads the whole stream before decoding it
+	PlayerBeep PlayerKind = "beep"
+	// PlayerBuffered starts once a preload is buffered and streams the rest
+	PlayerBuffered PlayerKind = "buffered"
+)
+
+// DefaultPlayerKind is the implementation the app uses unless told otherwise.
+// Buffered streaming starts sooner and recovers from network stalls.
+const DefaultPlayerKind = PlayerBuffered
+
+// PlayerKinds lists the accepted player kinds
+var PlayerKinds = []PlayerKind{PlayerBeep, PlayerBuffered}
+
+// ParsePlayerKind validates a player kind given by name, such as a flag value
+func ParsePlayerKind(name string) (PlayerKind, error) {
+	for _, kind := range PlayerKinds {
+		if PlayerKind(name) == kind {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("unknown player %q (want %s or %s)", name, PlayerBeep, PlayerBuffered)
 }
 
-// NewAdvancedBufferedPlayer creates the advanced buffered player (experimental)
-func NewAdvancedBufferedPlayer() Player {
-	return NewBufferedStreamPlayer()
+// NewPlayer creates the Player implementation named by kind. It is the one
+// place callers should construct players so the app and the debug modes
+// agree; unknown kinds get the default.
+func NewPlayer(kind PlayerKind, opts ...Option) Player {
+	switch kind {
+	case PlayerBeep:
+		return NewBeepPlayer(opts...)
+	default:
+		return NewBufferedStreamPlayer(opts...)
+	}
 }
 
 // Play starts or resumes playback from a streaming URL
@@ -153,6 +253,10 @@ func (p *BeepPlayer) Play(ctx context.Context, streamURL string) error {
 	// Initialize speaker if needed
 	p.speakerInit.Do(func() {
 		p.speakerInitErr = speaker.Init(format.SampleRate, format.SampleRate.N(time.Second/10))
+		p.speakerRate = format.SampleRate
+		if p.speakerInitErr == nil {
+			speakerStarted.Store(true)
+		}
 	})
 	if p.speakerInitErr != nil {
 		streamer.Close()
@@ -163,24 +267,39 @@ func (p *BeepPlayer) Play(ctx context.Context, streamURL string) error {
 	p.streamer = streamer
 	p.format = format
 	p.streamURL = streamURL
+	if p.onFormatChange != nil {
+		go p.onFormatChange(format)
+	}
+
+	// Even out loudness before the volume control, so the user's volume
+	// scales the normalized level rather than being compensated for
+	p.normalizer = NewNormalizer(p.streamer, format.SampleRate)
+	p.normalizer.SetEnabled(p.normalize)
 
 	// Create volume control
 	p.volumeCtrl = &effects.Volume{
-		Streamer: p.streamer,
+		Streamer: p.normalizer,
 		Base:     2,
 		Volume:   p.volumeToBeepVolume(p.volume),
-		Silent:   p.volume == 0,
+		Silent:   p.volume == 0 || p.fader.enabled(), // Start silent when fading in
 	}
 
+	// Apply the equalizer after the volume control
+	p.eq = NewEqualizer(p.volumeCtrl, format.SampleRate)
+	p.eq.SetGains(p.eqLow, p.eqMid, p.eqHigh)
+	
 	// Create playback control
 	p.ctrl = &beep.Ctrl{
-		Streamer: p.volumeCtrl,
+		Streamer: p.eq,
 		Paused:   false,
 	}
 
+	// Tap the output, at the speaker's sample rate, for the level meters
+	p.levels = NewLevelMeter(MatchSampleRate(p.ctrl, format.SampleRate, p.speakerRate))
+	
 	// Start playback
 	done := make(chan bool)
-	speaker.Play(beep.Seq(p.ctrl, beep.Callback(func() {
+	speaker.Play(beep.Seq(p.levels, beep.Callback(func() {
 		p.mu.Lock()
 		p.state = StateStopped
 		p.mu.Unlock()
@@ -189,6 +308,11 @@ func (p *BeepPlayer) Play(ctx context.Context, streamURL string) error {
 
 	p.state = StatePlaying
 	
+	// Ramp up from silence to avoid a click at the start
+	if p.fader.enabled() {
+		p.fader.start(p.volumeCtrl, p.volume, nil)
+	}
+	
 	// Start position tracking
 	go p.trackPosition(done)
 
@@ -205,9 +329,13 @@ func (p *BeepPlayer) Pause() error {
 	}
 
 	if p.ctrl != nil {
-		speaker.Lock()
-		p.ctrl.Paused = true
-		speaker.Unlock()
+		// Fade out, then pause once the volume reaches zero
+		ctrl := p.ctrl
+		p.fader.start(p.volumeCtrl, 0, func() {
+			speaker.Lock()
+			ctrl.Paused = true
+			speaker.Unlock()
+		})
 	}
 
 	p.state = StatePaused
@@ -224,9 +352,17 @@ func (p *BeepPlayer) Resume() error {
 	}
 
 	if p.ctrl != nil {
+		// Finish any pending fade-out before unpausing
+		p.fader.stop()
+		
 		speaker.Lock()
+		if p.fader.enabled() {
+			p.volumeCtrl.Silent = true
+		}
 		p.ctrl.Paused = false
 		speaker.Unlock()
+		
+		p.fader.start(p.volumeCtrl, p.volume, nil)
 	}
 
 	p.state = StatePlaying
@@ -263,16 +399,36 @@ func (p *BeepPlayer) GetPosition() time.Duration {
 	return p.format.SampleRate.D(position)
 }
 
-// GetDuration returns total track duration
+// GetDuration returns total track duration. While the decoded length is still
+// growing it reports the expected duration from track metadata instead.
 func (p *BeepPlayer) GetDuration() time.Duration {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
-	
+	return p.durationLocked()
+}
+
+// SetExpectedDuration sets the authoritative track length from metadata (0 to clear)
+func (p *BeepPlayer) SetExpectedDuration(d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.expectedDuration = d
+}
+
+// SetExpectedFormat sets the stream format from metadata ("" to detect it)
+func (p *BeepPlayer) SetExpectedFormat(format string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.expectedFormat = format
+}
+
+// durationLocked returns the effective duration (caller must hold lock)
+func (p *BeepPlayer) durationLocked() time.Duration {
 	if p.streamer == nil || p.format.SampleRate == 0 {
-		return 0
+		return p.expectedDuration
 	}
 	
-	return p.format.SampleRate.D(p.streamer.Len())
+	decoded := p.format.SampleRate.D(p.streamer.Len())
+	return EffectiveDuration(decoded, p.expectedDuration)
 }
 
 // SetVolume sets playback volume (0.0 to 1.0)
@@ -287,6 +443,9 @@ func (p *BeepPlayer) SetVolume(volume float64) error {
 	p.volume = volume
 	
 	if p.volumeCtrl != nil {
+		// An explicit volume change overrides any fade in progress
+		p.fader.stop()
+		
 		speaker.Lock()
 		p.volumeCtrl.Volume = p.volumeToBeepVolume(volume)
 		p.volumeCtrl.Silent = volume == 0
@@ -303,6 +462,89 @@ func (p *BeepPlayer) GetVolume() float64 {
 	return p.volume
 }
 
+// SetEQ sets the equalizer band gains in dB (0 leaves a band flat)
+func (p *BeepPlayer) SetEQ(low, mid, high float64) error {
+	if err := ValidateEQGains(low, mid, high); err != nil {
+		return err
+	}
+	
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	
+	p.eqLow, p.eqMid, p.eqHigh = low, mid, high
+	
+	if p.eq != nil {
+		speaker.Lock()
+		p.eq.SetGains(low, mid, high)
+		speaker.Unlock()
+	}
+	
+	return nil
+}
+
+// GetEQ returns the equalizer band gains in dB
+func (p *BeepPlayer) GetEQ() (low, mid, high float64) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.eqLow, p.eqMid, p.eqHigh
+}
+
+// SetNormalization turns loudness normalization on or off
+func (p *BeepPlayer) SetNormalization(enabled bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	
+	p.normalize = enabled
+	
+	if p.normalizer != nil {
+		speaker.Lock()
+		p.normalizer.SetEnabled(enabled)
+		speaker.Unlock()
+	}
+}
+
+// GetNormalization reports whether loudness normalization is on
+func (p *BeepPlayer) GetNormalization() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.normalize
+}
+
+// BufferHealth reports a completed buffer since BeepPlayer decodes straight
+// from the HTTP response
+func (p *BeepPlayer) BufferHealth() (available, total int64, completed bool) {
+	return 0, 0, true
+}
+
+// GetSampleRate returns the sample rate of the loaded stream
+func (p *BeepPlayer) GetSampleRate() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	
+	if p.streamer == nil {
+		return 0
+	}
+	return int(p.format.SampleRate)
+}
+
+// GetLevels returns the current output level of each channel
+func (p *BeepPlayer) GetLevels() (left, right float64) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	
+	if p.levels == nil || p.state != StatePlaying {
+		return 0, 0
+	}
+	
+	return p.levels.Levels()
+}
+
+// SetFadeDuration sets how long volume ramps take on play, pause and stop.
+// Zero disables fading.
+func (p *BeepPlayer) SetFadeDuration(d time.Duration) {
+	p.fader.setDuration(d)
+}
+
 // Seek sets playback position
 func (p *BeepPlayer) Seek(position time.Duration) error {
 	p.mu.Lock()
@@ -316,7 +558,7 @@ func (p *BeepPlayer) Seek(position time.Duration) error {
 		return fmt.Errorf("no audio stream loaded")
 	}
 	
-	duration := p.GetDuration()
+	duration := p.durationLocked()
 	if position > duration {
 		return fmt.Errorf("position %s exceeds duration %s", position, duration)
 	}
@@ -324,6 +566,14 @@ func (p *BeepPlayer) Seek(position time.Duration) error {
 	// Convert time position to sample position
 	samplePos := p.format.SampleRate.N(position)
 	
+	// Seeking an HLS stream may download a segment; don't block the
+	// player's other calls meanwhile
+	if stream, ok := p.streamer.(*HLSStream); ok {
+		p.mu.Unlock()
+		defer p.mu.Lock()
+		return stream.Seek(samplePos)
+	}
+	
 	speaker.Lock()
 	err := p.streamer.Seek(samplePos)
 	speaker.Unlock()
@@ -347,11 +597,24 @@ func (p *BeepPlayer) Close() error {
 	return nil
 }
 
+// SetFormatChangeCallback sets a callback for the format of each new stream
+func (p *BeepPlayer) SetFormatChangeCallback(callback func(beep.Format)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.onFormatChange = callback
+}
+
 // Helper methods
 
 // stopLocked stops playback without acquiring lock (caller must hold lock)
 func (p *BeepPlayer) stopLocked() error {
 	if p.ctrl != nil {
+		// Fade out audible playback before cutting it off
+		if p.state == StatePlaying {
+			<-p.fader.start(p.volumeCtrl, 0, nil)
+		}
+		p.fader.stop()
+		
 		speaker.Lock()
 		p.ctrl.Paused = true
 		speaker.Unlock()
@@ -366,6 +629,9 @@ func (p *BeepPlayer) stopLocked() error {
 	
 	p.ctrl = nil
 	p.volumeCtrl = nil
+	p.eq = nil
+	p.normalizer = nil
+	p.levels = nil
 	p.streamURL = ""
 	p.state = StateStopped
 	
@@ -395,8 +661,9 @@ func (p *BeepPlayer) loadAudioStreamWithRetry(ctx context.Context, streamURL str
 		
 		lastErr = err
 		
-		// Don't retry on context cancellation or certain errors
-		if ctx.Err() != nil {
+		// Don't retry on context cancellation, or a local file: waiting
+		// won't make it readable
+		if ctx.Err() != nil || IsLocalStream(streamURL) {
 			return nil, beep.Format{}, err
 		}
 	}
@@ -404,12 +671,26 @@ func (p *BeepPlayer) loadAudioStreamWithRetry(ctx context.Context, streamURL str
 	return nil, beep.Format{}, fmt.Errorf("failed to load audio stream after %d attempts: %w", maxRetries, lastErr)
 }
 
-// loadAudioStream downloads and decodes an audio stream from URL
+// loadAudioStream downloads and decodes an audio stream from URL or a local file
 func (p *BeepPlayer) loadAudioStream(ctx context.Context, streamURL string) (beep.StreamSeekCloser, beep.Format, error) {
-	// Create HTTP request  
-	req, err := http.NewRequestWithContext(ctx, "GET", streamURL, nil)
+	// HLS playlists are fetched and decoded a segment at a time
+	if isHLSStream(p.expectedFormat, streamURL) {
+		stream, format, err := openHLSStream(ctx, ctx, newResourceFetcher(p.httpClient, p.httpOptions), streamURL)
+		if err != nil {
+			return nil, beep.Format{}, fmt.Errorf("failed to open HLS stream: %w", err)
+		}
+		return stream, format, nil
+	}
+	
+	// Local files are decoded straight from disk
+	if IsLocalStream(streamURL) {
+		return decodeLocalFile(streamURL)
+	}
+	
+	// Create HTTP request with the configured User-Agent
+	req, err := newStreamRequest(ctx, streamURL, p.httpOptions)
 	if err != nil {
-		return nil, beep.Format{}, fmt.Errorf("failed to create request: %w", err)
+		return nil, beep.Format{}, err
 	}
 	
 	// Download stream
@@ -423,21 +704,13 @@ func (p *BeepPlayer) loadAudioStream(ctx context.Context, streamURL string) (bee
 		return nil, beep.Format{}, fmt.Errorf("HTTP error: %d %s", resp.StatusCode, resp.Status)
 	}
 	
-	// Detect format and decode
-	contentType := resp.Header.Get("Content-Type")
-	streamURL = strings.ToLower(streamURL)
-	
-	var streamer beep.StreamSeekCloser
-	var format beep.Format
-	
-	if strings.Contains(contentType, "audio/mpeg") || strings.Contains(streamURL, ".mp3") {
-		streamer, format, err = mp3.Decode(resp.Body)
-	} else if strings.Contains(contentType, "audio/wav") || strings.Contains(streamURL, ".wav") {
-		streamer, format, err = wav.Decode(resp.Body)
-	} else {
-		// Default to MP3 for unknown formats
-		streamer, format, err = mp3.Decode(resp.Body)
+	// Try the format from metadata, else the Content-Type (or URL), first,
+	// then fall back to what the data itself looks like
+	streamFormat := p.expectedFormat
+	if streamFormat == "" {
+		streamFormat = detectFormat(resp.Header.Get("Content-Type"), streamURL)
 	}
+	streamer, format, err := DecodeWithFallback(streamFormat, resp.Body)
 	
 	if err != nil {
 		resp.Body.Close()
@@ -449,21 +722,12 @@ func (p *BeepPlayer) loadAudioStream(ctx context.Context, streamURL string) (bee
 
 // volumeToBeepVolume converts linear volume (0-1) to Beep's logarithmic volume
 func (p *BeepPlayer) volumeToBeepVolume(linearVolume float64) float64 {
-	if linearVolume <= 0 {
-		return -10 // Very quiet
-	}
-	if linearVolume >= 1 {
-		return 0 // Unity gain
-	}
-	
-	// Convert linear to dB: 20 * log10(volume)
-	// Beep uses base-2 logarithmic scale, so we adjust
-	return (linearVolume - 1.0) * 2.0 // Simple approximation
+	return linearToBeepVolume(linearVolume)
 }
 
 // trackPosition runs in a goroutine to track playback position
 func (p *BeepPlayer) trackPosition(done <-chan bool) {
-	ticker := time.NewTicker(100 * time.Millisecond)
+	ticker := time.NewTicker(p.httpOptions.PositionInterval)
 	defer ticker.Stop()
 	
 	for {
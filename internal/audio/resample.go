@@ -0,0 +1,20 @@
+package audio
+
+import (
+	"github.com/gopxl/beep"
+)
+
+// resampleQuality is the beep resampling quality, good enough for music while
+// cheap enough to run during playback
+const resampleQuality = 4
+
+// MatchSampleRate returns streamer converted from its own sample rate to the
+// speaker's. The speaker keeps the rate of the first track it was initialized
+// for, so a later track at another rate would otherwise play at the wrong
+// speed and pitch. Streams already at the speaker's rate pass through as is.
+func MatchSampleRate(streamer beep.Streamer, rate, speakerRate beep.SampleRate) beep.Streamer {
+	if rate == speakerRate || rate <= 0 || speakerRate <= 0 {
+		return streamer
+	}
+	return beep.Resample(resampleQuality, rate, speakerRate, streamer)
+}
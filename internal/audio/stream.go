@@ -2,19 +2,25 @@ package audio
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	soundcloudapi "github.com/zackradisic/soundcloud-api"
+
+	"soundcloud-tui/internal/soundcloud/retry"
 )
 
 // StreamInfo represents information about an audio stream
 type StreamInfo struct {
 	URL      string
-	Format   string
+	Format   string // One of the Format* constants
 	Quality  string
+	Preset   string // Transcoding preset, e.g. "mp3_1_0"; "" when not from SoundCloud
+	Bitrate  int    // In kbps, 0 when unknown
 	Duration int64
 }
 
@@ -41,6 +47,13 @@ type RealSoundCloudAPI interface {
 	GetDownloadURL(trackURL string, format string) (string, error)
 }
 
+// TranscodingResolver resolves a track transcoding to the URL of its audio.
+// With an API that implements it, the extractor streams the best transcoding
+// of the chosen protocol; GetDownloadURL only ever returns the first one.
+type TranscodingResolver interface {
+	GetMediaURL(transcodingURL string) (string, error)
+}
+
 // SoundCloudStreamExtractor implements StreamExtractor for SoundCloud
 type SoundCloudStreamExtractor struct {
 	api SoundCloudAPI
@@ -78,18 +91,66 @@ func NewSoundCloudStreamExtractorWithAPI(api SoundCloudAPI) *SoundCloudStreamExt
 	}
 }
 
+// Stream qualities, named after the transcoding protocol they select
+const (
+	// QualityProgressive is a single file download
+	QualityProgressive = "progressive"
+	// QualityHLS is a segmented HTTP Live Streaming playlist
+	QualityHLS = "hls"
+)
+
+// DefaultQuality is the quality extractors prefer unless told otherwise.
+// Progressive streams work with every audio player.
+const DefaultQuality = QualityProgressive
+
+// Qualities lists the accepted stream qualities
+var Qualities = []string{QualityProgressive, QualityHLS}
+
+// ParseQuality validates a stream quality given by name, such as a flag value
+func ParseQuality(name string) (string, error) {
+	for _, quality := range Qualities {
+		if name == quality {
+			return quality, nil
+		}
+	}
+	return "", fmt.Errorf("unknown quality %q (want %s or %s)", name, QualityProgressive, QualityHLS)
+}
+
+// ErrGeoBlocked reports a track SoundCloud won't stream in the listener's region
+var ErrGeoBlocked = errors.New("track is not available in your region")
+
+// ErrPurchaseRequired reports a track that only plays in full once bought or
+// with a Go+ subscription
+var ErrPurchaseRequired = errors.New("track requires a purchase or Go+ subscription")
+
+// subscriptionModel is the monetization model of tracks reserved for Go+
+const subscriptionModel = "SUB_HIGH_TIER"
+
 // RealSoundCloudStreamExtractor implements StreamExtractor with actual API calls
 type RealSoundCloudStreamExtractor struct {
-	api RealSoundCloudAPI
+	api     RealSoundCloudAPI
+	quality string // Preferred transcoding protocol
 }
 
 // NewRealSoundCloudStreamExtractor creates a new real SoundCloud stream extractor
 func NewRealSoundCloudStreamExtractor(api RealSoundCloudAPI) *RealSoundCloudStreamExtractor {
 	return &RealSoundCloudStreamExtractor{
-		api: api,
+		api:     api,
+		quality: DefaultQuality,
 	}
 }
 
+// SetPreferredQuality makes the extractor choose quality over the other
+// protocol whenever a track offers both
+func (e *RealSoundCloudStreamExtractor) SetPreferredQuality(quality string) error {
+	quality, err := ParseQuality(quality)
+	if err != nil {
+		return err
+	}
+	e.quality = quality
+	return nil
+}
+
 // ExtractStreamURL extracts real streaming URLs from SoundCloud using GetDownloadURL
 func (e *RealSoundCloudStreamExtractor) ExtractStreamURL(ctx context.Context, trackID int64) (*StreamInfo, error) {
 	// Check for context cancellation
@@ -134,46 +195,50 @@ func (e *RealSoundCloudStreamExtractor) ExtractStreamURL(ctx context.Context, tr
 		return nil, fmt.Errorf("no transcodings available for track %d", trackID)
 	}
 	
-	// Determine best format - prefer progressive over HLS for better audio player compatibility
+	// Determine best format - the preferred quality (progressive unless set
+	// otherwise, for better audio player compatibility), else the other one
 	var preferredFormat string
 	var selectedTranscoding *soundcloudapi.Transcoding
 	
-	// First pass: look for progressive format
-	for _, transcoding := range track.Media.Transcodings {
-		if transcoding.Format.Protocol == "progressive" {
-			preferredFormat = "progressive"
-			selectedTranscoding = &transcoding
-			break
-		}
-	}
-	
-	// Second pass: fallback to HLS if no progressive available
-	if selectedTranscoding == nil {
+	for _, protocol := range e.protocolOrder() {
 		for _, transcoding := range track.Media.Transcodings {
-			if transcoding.Format.Protocol == "hls" {
-				preferredFormat = "hls"
+			if transcoding.Format.Protocol == protocol {
+				preferredFormat = protocol
 				selectedTranscoding = &transcoding
 				break
 			}
 		}
+		if selectedTranscoding != nil {
+			break
+		}
 	}
 	
 	if selectedTranscoding == nil {
 		return nil, fmt.Errorf("no supported transcoding formats available for track %d", trackID)
 	}
 	
-	// Get the actual download URL using the SoundCloud API
-	streamURL, err := e.api.GetDownloadURL(track.PermalinkURL, preferredFormat)
+	var streamURL string
+	if resolver, ok := e.api.(TranscodingResolver); ok {
+		// Stream the best encoding of the same codec, e.g. mp3_1_0 over mp3_0_0
+		selectedTranscoding = bestTranscoding(track.Media.Transcodings, *selectedTranscoding)
+		streamURL, err = resolver.GetMediaURL(selectedTranscoding.URL)
+	} else {
+		// Get the actual download URL using the SoundCloud API
+		streamURL, err = e.api.GetDownloadURL(track.PermalinkURL, preferredFormat)
+	}
 	if err != nil {
+		if denied := streamDenied(track, err); denied != nil {
+			return nil, fmt.Errorf("%w: %w", denied, err)
+		}
 		return nil, fmt.Errorf("failed to get download URL: %w", err)
 	}
 	
-	// Determine format from transcoding
-	format := "mp3" // Default
-	if selectedTranscoding.Format.MimeType == "audio/ogg" {
-		format = "ogg"
-	} else if selectedTranscoding.Format.Protocol == "hls" {
-		format = "hls"
+	// Determine format from transcoding (e.g. audio/ogg; codecs="opus" is Opus, not Vorbis)
+	format := FormatFromMimeType(selectedTranscoding.Format.MimeType)
+	if selectedTranscoding.Format.Protocol == "hls" {
+		format = FormatHLS
+	} else if format == "" {
+		format = FormatMP3 // Default
 	}
 	
 	// Create StreamInfo
@@ -181,12 +246,126 @@ func (e *RealSoundCloudStreamExtractor) ExtractStreamURL(ctx context.Context, tr
 		URL:      streamURL,
 		Format:   format,
 		Quality:  preferredFormat,
+		Preset:   selectedTranscoding.Preset,
+		Bitrate:  presetBitrate(selectedTranscoding.Preset),
 		Duration: track.DurationMS,
 	}
 	
 	return streamInfo, nil
 }
 
+// streamDenied returns ErrGeoBlocked or ErrPurchaseRequired when SoundCloud
+// refused the track's stream because of where the listener is or what they
+// paid for, and nil for other failures. A plain 403 means a purchase for Go+
+// tracks and tracks offering only previews, and a region block otherwise.
+func streamDenied(track soundcloudapi.Track, err error) error {
+	switch retry.Status(err) {
+	case http.StatusPaymentRequired:
+		return ErrPurchaseRequired
+	case http.StatusUnavailableForLegalReasons:
+		return ErrGeoBlocked
+	case http.StatusForbidden:
+		if track.MonetizationModel == subscriptionModel || onlySnippets(track.Media.Transcodings) {
+			return ErrPurchaseRequired
+		}
+		return ErrGeoBlocked
+	}
+	return nil
+}
+
+// onlySnippets reports whether every transcoding is a short preview
+func onlySnippets(transcodings []soundcloudapi.Transcoding) bool {
+	for _, transcoding := range transcodings {
+		if !transcoding.Snipped {
+			return false
+		}
+	}
+	return len(transcodings) > 0
+}
+
+// bestTranscoding returns the transcoding with the best preset among those
+// sharing first's protocol and MIME type, preferring first on a tie
+func bestTranscoding(transcodings []soundcloudapi.Transcoding, first soundcloudapi.Transcoding) *soundcloudapi.Transcoding {
+	best := first
+	for _, transcoding := range transcodings {
+		if transcoding.Format != first.Format {
+			continue
+		}
+		if betterPreset(transcoding.Preset, best.Preset) {
+			best = transcoding
+		}
+	}
+	return &best
+}
+
+// betterPreset reports whether preset a promises higher quality than b: a
+// higher bitrate or, at the same bitrate, a newer encoding such as mp3_1_0
+// over mp3_0_0
+func betterPreset(a, b string) bool {
+	if bitrateA, bitrateB := presetBitrate(a), presetBitrate(b); bitrateA != bitrateB {
+		return bitrateA > bitrateB
+	}
+	
+	_, versionA, _ := strings.Cut(a, "_")
+	_, versionB, _ := strings.Cut(b, "_")
+	return versionA > versionB
+}
+
+// presetCodecs names the codecs of SoundCloud transcoding presets
+var presetCodecs = map[string]string{
+	"mp3":  "MP3",
+	"opus": "Opus",
+	"aac":  "AAC",
+}
+
+// PresetLabel returns a short label for a transcoding preset: the codec and,
+// when known, the bitrate, e.g. "MP3 128" for "mp3_1_0". It returns "" for an
+// empty preset.
+func PresetLabel(preset string) string {
+	if preset == "" {
+		return ""
+	}
+	
+	codec, _, _ := strings.Cut(preset, "_")
+	name, ok := presetCodecs[codec]
+	if !ok {
+		name = strings.ToUpper(codec)
+	}
+	
+	if kbps := presetBitrate(preset); kbps > 0 {
+		return fmt.Sprintf("%s %d", name, kbps)
+	}
+	return name
+}
+
+// presetBitrate returns the bitrate in kbps of a SoundCloud transcoding
+// preset such as "mp3_1_0", "opus_0_0" or "aac_160k", or 0 when unknown
+func presetBitrate(preset string) int {
+	codec, rest, _ := strings.Cut(preset, "_")
+	if digits, ok := strings.CutSuffix(rest, "k"); ok {
+		if kbps, err := strconv.Atoi(digits); err == nil {
+			return kbps
+		}
+	}
+	
+	switch codec {
+	case "mp3":
+		return 128
+	case "opus":
+		return 64
+	default:
+		return 0
+	}
+}
+
+// protocolOrder lists the transcoding protocols to look for, preferred first
+func (e *RealSoundCloudStreamExtractor) protocolOrder() []string {
+	if e.quality == QualityHLS {
+		return []string{QualityHLS, QualityProgressive}
+	}
+	return []string{QualityProgressive, QualityHLS}
+}
+
 // GetAvailableQualities returns available qualities for track using real API
 func (e *RealSoundCloudStreamExtractor) GetAvailableQualities(ctx context.Context, trackID int64) ([]string, error) {
 	// Check for context cancellation
@@ -0,0 +1,52 @@
+package clipboard
+
+import (
+	"fmt"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbletea"
+)
+
+// Clipboard writes text to a clipboard
+type Clipboard interface {
+	Copy(text string) error
+}
+
+// SystemClipboard copies text to the operating system clipboard
+type SystemClipboard struct{}
+
+// NewSystemClipboard creates a clipboard backed by the system clipboard
+func NewSystemClipboard() *SystemClipboard {
+	return &SystemClipboard{}
+}
+
+// Copy places text on the system clipboard. It fails when no clipboard is
+// available, e.g. in headless or SSH sessions.
+func (c *SystemClipboard) Copy(text string) error {
+	if clipboard.Unsupported {
+		return fmt.Errorf("no clipboard available")
+	}
+
+	if err := clipboard.WriteAll(text); err != nil {
+		return fmt.Errorf("failed to copy to clipboard: %w", err)
+	}
+	return nil
+}
+
+// CopiedMsg reports the result of copying text to the clipboard
+type CopiedMsg struct {
+	Text  string
+	Error error
+}
+
+// CopyCmd copies text in the background and reports the result as a CopiedMsg.
+// It returns nil for empty text or a nil clipboard.
+func CopyCmd(c Clipboard, text string) tea.Cmd {
+	if c == nil || text == "" {
+		return nil
+	}
+
+	return func() tea.Msg {
+		return CopiedMsg{Text: text, Error: c.Copy(text)}
+	}
+}
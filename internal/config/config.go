@@ -45,7 +45,7 @@ func Load() (*Config, error) {
 		},
 		
 		// FileBackend config for fallback
-		FileDir: getConfigDir(),
+		FileDir: ConfigDir(),
 		FilePasswordFunc: keyring.FixedStringPrompt("Please enter a password to encrypt your tokens"),
 	})
 	if err != nil {
@@ -99,7 +99,8 @@ func (c *Config) ClearToken() error {
 	return nil
 }
 
-func getConfigDir() string {
+// ConfigDir returns the directory used for persisted application state
+func ConfigDir() string {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return os.TempDir()
@@ -0,0 +1,183 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// SettingsFileName is the file name of the user settings inside the config dir
+const SettingsFileName = "settings.json"
+
+// EQSettings holds the equalizer band gains in dB
+type EQSettings struct {
+	Low  float64 `json:"low"`
+	Mid  float64 `json:"mid"`
+	High float64 `json:"high"`
+}
+
+// StreamingSettings tunes buffered streaming. Zero values use the player defaults.
+type StreamingSettings struct {
+	PreloadKB             int64 `json:"preload_kb,omitempty"`
+	PreloadTimeoutSeconds int   `json:"preload_timeout_seconds,omitempty"`
+
+	// PositionIntervalMS is how often the audio player updates the playback
+	// position, in milliseconds
+	PositionIntervalMS int `json:"position_interval_ms,omitempty"`
+}
+
+// PreloadSize returns the configured preload in bytes, or 0 for the default
+func (s StreamingSettings) PreloadSize() int64 {
+	if s.PreloadKB <= 0 {
+		return 0
+	}
+	return s.PreloadKB * 1024
+}
+
+// PreloadTimeout returns the configured preload timeout, or 0 for the default
+func (s StreamingSettings) PreloadTimeout() time.Duration {
+	if s.PreloadTimeoutSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(s.PreloadTimeoutSeconds) * time.Second
+}
+
+// PositionInterval returns the configured position update interval, or 0 for
+// the default
+func (s StreamingSettings) PositionInterval() time.Duration {
+	if s.PositionIntervalMS <= 0 {
+		return 0
+	}
+	return time.Duration(s.PositionIntervalMS) * time.Millisecond
+}
+
+// PlaybackSettings holds opt-in playback behaviors
+type PlaybackSettings struct {
+	// PauseOnFocusLoss pauses when the terminal loses focus and resumes on
+	// return; it needs a terminal that reports focus events
+	PauseOnFocusLoss bool `json:"pause_on_focus_loss,omitempty"`
+
+	// AutoResume restarts a stream that stops before the end of the track,
+	// e.g. after a network drop, and continues where it left off
+	AutoResume bool `json:"auto_resume,omitempty"`
+
+	// ProgressIntervalMS is how often the progress bar refreshes during
+	// playback, in milliseconds; it refreshes far less often while paused
+	ProgressIntervalMS int `json:"progress_interval_ms,omitempty"`
+
+	// OutputDevice names the audio output to play on, as listed by
+	// sctui -output-devices; empty uses the system default
+	OutputDevice string `json:"output_device,omitempty"`
+
+	// Normalize evens out loudness so quiet and loud tracks play at a
+	// similar level
+	Normalize bool `json:"normalize,omitempty"`
+
+	// RememberVolume restores the volume last set for a track, or for
+	// another track by the same artist, when it plays again
+	RememberVolume bool `json:"remember_volume,omitempty"`
+
+	// IdleTimeoutMinutes quits the app after this many minutes without a
+	// key press while nothing is playing, e.g. on a kiosk; 0 never quits
+	IdleTimeoutMinutes int `json:"idle_timeout_minutes,omitempty"`
+}
+
+// ProgressInterval returns the configured progress refresh interval, or 0 for
+// the default
+func (s PlaybackSettings) ProgressInterval() time.Duration {
+	if s.ProgressIntervalMS <= 0 {
+		return 0
+	}
+	return time.Duration(s.ProgressIntervalMS) * time.Millisecond
+}
+
+// IdleTimeout returns how long the app may sit idle before quitting, or 0 to
+// never quit
+func (s PlaybackSettings) IdleTimeout() time.Duration {
+	if s.IdleTimeoutMinutes <= 0 {
+		return 0
+	}
+	return time.Duration(s.IdleTimeoutMinutes) * time.Minute
+}
+
+// SearchSettings tunes searching. Zero values use the client defaults.
+type SearchSettings struct {
+	// MaxResults caps how many tracks a search returns
+	MaxResults int `json:"max_results,omitempty"`
+
+	// MinTitleWidth and MaxTitleWidth bound the title column of result rows,
+	// which otherwise grows and shrinks with the terminal
+	MinTitleWidth int `json:"min_title_width,omitempty"`
+	MaxTitleWidth int `json:"max_title_width,omitempty"`
+}
+
+// Settings holds user preferences that persist between sessions
+type Settings struct {
+	EQ        EQSettings        `json:"eq"`
+	Streaming StreamingSettings `json:"streaming"`
+	Playback  PlaybackSettings  `json:"playback"`
+	Search    SearchSettings    `json:"search"`
+
+	path string
+}
+
+// SettingsPath returns the default location of the settings file
+func SettingsPath() string {
+	return filepath.Join(ConfigDir(), SettingsFileName)
+}
+
+// DefaultSettings returns the settings used when nothing has been saved yet
+func DefaultSettings() *Settings {
+	return &Settings{}
+}
+
+// LoadSettings reads settings from path. A missing file is not an error and
+// yields the defaults; the returned settings are always usable and save to path.
+func LoadSettings(path string) (*Settings, error) {
+	settings := DefaultSettings()
+	settings.path = path
+	if path == "" {
+		return settings, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return settings, nil
+		}
+		return settings, fmt.Errorf("failed to read settings: %w", err)
+	}
+
+	if err := json.Unmarshal(data, settings); err != nil {
+		// Don't keep half-parsed values
+		settings = DefaultSettings()
+		settings.path = path
+		return settings, fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// Save writes the settings to disk
+func (s *Settings) Save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal settings: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	if err := os.WriteFile(s.path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write settings: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+// Package daemon runs the audio engine without the TUI and lets scripts
+// control it over a local Unix socket.
+//
+// The protocol is line based. A request is one line of text: a command name,
+// then its argument if it takes one.
+//
+//	play <url>      play a SoundCloud track URL or a local audio file
+//	pause           pause playback
+//	resume          resume paused playback
+//	stop            stop playback
+//	volume <0-100>  set the volume in percent
+//	status          report what is playing
+//
+// Every request is answered with one line of JSON. Successful commands report
+// the player status afterwards:
+//
+//	{"ok":true,"status":{"state":"playing","title":"...","position_ms":1200,...}}
+//
+// Failed commands report why:
+//
+//	{"ok":false,"error":"unknown command \"skip\""}
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/soundcloud"
+)
+
+// Commands understood by the daemon
+const (
+	CommandPlay   = "play"
+	CommandPause  = "pause"
+	CommandResume = "resume"
+	CommandStop   = "stop"
+	CommandVolume = "volume"
+	CommandStatus = "status"
+)
+
+// Response answers a single request
+type Response struct {
+	OK     bool    `json:"ok"`
+	Error  string  `json:"error,omitempty"`
+	Status *Status `json:"status,omitempty"`
+}
+
+// Status describes the player after a command
+type Status struct {
+	State      string `json:"state"`
+	Title      string `json:"title,omitempty"`
+	Artist     string `json:"artist,omitempty"`
+	URL        string `json:"url,omitempty"`
+	PositionMS int64  `json:"position_ms"`
+	DurationMS int64  `json:"duration_ms"`
+	Volume     int    `json:"volume"` // Percent
+}
+
+// Dispatcher carries out requests on an audio player. It is safe to use from
+// several connections at once; requests run one at a time.
+type Dispatcher struct {
+	mu        sync.Mutex
+	client    soundcloud.ClientInterface
+	player    audio.Player
+	extractor audio.StreamExtractor
+	track     *soundcloud.Track // Last track started, nil when stopped
+	url       string            // What the play command was given
+}
+
+// NewDispatcher creates a dispatcher that resolves tracks with client and
+// plays them on player
+func NewDispatcher(client soundcloud.ClientInterface, player audio.Player, extractor audio.StreamExtractor) *Dispatcher {
+	return &Dispatcher{
+		client:    client,
+		player:    player,
+		extractor: extractor,
+	}
+}
+
+// Dispatch parses a request line and carries it out
+func (d *Dispatcher) Dispatch(line string) Response {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	command, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+	arg = strings.TrimSpace(arg)
+
+	var err error
+	switch strings.ToLower(command) {
+	case CommandPlay:
+		err = d.play(arg)
+	case CommandPause:
+		err = d.player.Pause()
+	case CommandResume:
+		err = d.player.Resume()
+	case CommandStop:
+		err = d.player.Stop()
+		d.track, d.url = nil, ""
+	case CommandVolume:
+		err = d.setVolume(arg)
+	case CommandStatus:
+	case "":
+		err = fmt.Errorf("empty command")
+	default:
+		err = fmt.Errorf("unknown command %q", command)
+	}
+
+	if err != nil {
+		return Response{Error: err.Error()}
+	}
+	return Response{OK: true, Status: d.status()}
+}
+
+// play resolves target to a stream and starts it
+func (d *Dispatcher) play(target string) error {
+	if target == "" {
+		return fmt.Errorf("play needs a track URL or file path")
+	}
+
+	// Local files play straight from disk, without SoundCloud metadata
+	if audio.IsLocalStream(target) {
+		name := filepath.Base(audio.LocalPath(target))
+		track := &soundcloud.Track{
+			Title:     strings.TrimSuffix(name, filepath.Ext(name)),
+			StreamURL: target,
+		}
+		d.player.SetExpectedDuration(0)
+		d.player.SetExpectedFormat("")
+		if err := d.player.Play(context.Background(), target); err != nil {
+			return fmt.Errorf("failed to play %s: %w", target, err)
+		}
+		d.track, d.url = track, target
+		return nil
+	}
+
+	if d.client == nil || d.extractor == nil {
+		return fmt.Errorf("SoundCloud is not available")
+	}
+
+	track, err := d.client.GetTrackInfo(target)
+	if err != nil {
+		return fmt.Errorf("failed to get track info: %w", err)
+	}
+
+	info, err := d.extractor.ExtractStreamURL(context.Background(), track.ID)
+	if err != nil {
+		return fmt.Errorf("failed to extract stream URL: %w", err)
+	}
+
+	d.player.SetExpectedDuration(time.Duration(info.Duration) * time.Millisecond)
+	d.player.SetExpectedFormat(info.Format)
+	if err := d.player.Play(context.Background(), info.URL); err != nil {
+		return fmt.Errorf("failed to play stream: %w", err)
+	}
+	d.track, d.url = track, target
+	return nil
+}
+
+// setVolume sets the volume from a percentage
+func (d *Dispatcher) setVolume(arg string) error {
+	percent, err := strconv.Atoi(arg)
+	if err != nil || percent < 0 || percent > 100 {
+		return fmt.Errorf("volume must be a number from 0 to 100, got %q", arg)
+	}
+	return d.player.SetVolume(float64(percent) / 100)
+}
+
+// status reports the player's state and the track it is on
+func (d *Dispatcher) status() *Status {
+	status := &Status{
+		State:      d.player.GetState().String(),
+		PositionMS: d.player.GetPosition().Milliseconds(),
+		DurationMS: d.player.GetDuration().Milliseconds(),
+		Volume:     int(d.player.GetVolume()*100 + 0.5),
+	}
+	if d.track != nil {
+		status.Title = d.track.Title
+		status.URL = d.url
+		if !audio.IsLocalStream(d.url) {
+			status.Artist = d.track.Artist()
+		}
+	}
+	return status
+}
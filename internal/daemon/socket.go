@@ -0,0 +1,105 @@
+package daemon
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"soundcloud-tui/internal/config"
+)
+
+// SocketName is the file name of the control socket
+const SocketName = "sctui.sock"
+
+// dialTimeout bounds connecting to the daemon
+const dialTimeout = 2 * time.Second
+
+// DefaultSocketPath returns where the daemon listens unless told otherwise:
+// in $XDG_RUNTIME_DIR when set, else in the config directory
+func DefaultSocketPath() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, SocketName)
+	}
+	return filepath.Join(config.ConfigDir(), SocketName)
+}
+
+// Listen creates the control socket at path, replacing one left behind by a
+// daemon that is no longer running. Only the current user may connect.
+func Listen(path string) (net.Listener, error) {
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.DialTimeout("unix", path, dialTimeout); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("a daemon is already listening on %s", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create socket directory: %w", err)
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to restrict socket permissions: %w", err)
+	}
+	return listener, nil
+}
+
+// Serve answers requests from connections on listener until it is closed.
+// A connection may send any number of requests, one per line.
+func Serve(listener net.Listener, dispatcher *Dispatcher) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+		go serveConn(conn, dispatcher)
+	}
+}
+
+// serveConn answers the requests sent on conn until it is closed
+func serveConn(conn net.Conn, dispatcher *Dispatcher) {
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(conn)
+	encoder := json.NewEncoder(conn)
+	for scanner.Scan() {
+		if err := encoder.Encode(dispatcher.Dispatch(scanner.Text())); err != nil {
+			return
+		}
+	}
+}
+
+// Send connects to the daemon listening at socketPath, sends command and
+// returns its response
+func Send(socketPath, command string) (*Response, error) {
+	conn, err := net.DialTimeout("unix", socketPath, dialTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to daemon (is sctui -daemon running?): %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := fmt.Fprintln(conn, command); err != nil {
+		return nil, fmt.Errorf("failed to send command: %w", err)
+	}
+
+	var response Response
+	if err := json.NewDecoder(conn).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return &response, nil
+}
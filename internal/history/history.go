@@ -0,0 +1,125 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// DefaultMaxEntries is the default number of queries kept in the search history
+const DefaultMaxEntries = 50
+
+// SearchFileName is the file name of the search history inside the config dir
+const SearchFileName = "search_history.json"
+
+// Store keeps a deduplicated, capped list of submitted search queries
+type Store struct {
+	mu         sync.RWMutex
+	path       string
+	maxEntries int
+	queries    []string // Most recent first
+}
+
+// NewStore creates an empty history store persisted at path.
+// An empty path keeps the history in memory only.
+func NewStore(path string, maxEntries int) *Store {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+
+	return &Store{
+		path:       path,
+		maxEntries: maxEntries,
+		queries:    []string{},
+	}
+}
+
+// Load creates a store and reads any previously saved history from path.
+// A missing file is not an error and yields an empty history.
+func Load(path string, maxEntries int) (*Store, error) {
+	store := NewStore(path, maxEntries)
+	if path == "" {
+		return store, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return store, nil
+		}
+		return store, fmt.Errorf("failed to read search history: %w", err)
+	}
+
+	var queries []string
+	if err := json.Unmarshal(data, &queries); err != nil {
+		return store, fmt.Errorf("failed to parse search history: %w", err)
+	}
+
+	// Re-add oldest first so dedup and cap rules apply to the loaded data
+	for i := len(queries) - 1; i >= 0; i-- {
+		store.Add(queries[i])
+	}
+
+	return store, nil
+}
+
+// Add records a query as the most recent entry, removing older duplicates
+// and dropping the oldest entries beyond the cap
+func (s *Store) Add(query string) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queries := make([]string, 0, len(s.queries)+1)
+	queries = append(queries, query)
+	for _, existing := range s.queries {
+		if !strings.EqualFold(existing, query) {
+			queries = append(queries, existing)
+		}
+	}
+
+	if len(queries) > s.maxEntries {
+		queries = queries[:s.maxEntries]
+	}
+
+	s.queries = queries
+}
+
+// Recent returns the stored queries, most recent first
+func (s *Store) Recent() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	recent := make([]string, len(s.queries))
+	copy(recent, s.queries)
+	return recent
+}
+
+// Save writes the history to disk
+func (s *Store) Save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(s.Recent(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal search history: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	if err := os.WriteFile(s.path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write search history: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"soundcloud-tui/internal/soundcloud"
+)
+
+// DefaultMaxPlays is the default number of plays kept in the listening history
+const DefaultMaxPlays = 5000
+
+// PlaysFileName is the file name of the listening history inside the config dir
+const PlaysFileName = "play_history.json"
+
+// Play is one listen of a track
+type Play struct {
+	TrackID  int64     `json:"track_id,omitempty"`
+	Title    string    `json:"title"`
+	Artist   string    `json:"artist,omitempty"`
+	URL      string    `json:"url,omitempty"` // Permalink, or the path of a local file
+	PlayedAt time.Time `json:"played_at"`
+}
+
+// key identifies the track played: its ID, or the file of a local track
+func (p Play) key() string {
+	if p.TrackID != 0 {
+		return strconv.FormatInt(p.TrackID, 10)
+	}
+	return p.URL
+}
+
+// TrackPlays is how often a track was played, and when it last was
+type TrackPlays struct {
+	Play      // The most recent play
+	Count int `json:"count"`
+}
+
+// Recorder keeps a capped log of the tracks listened to, most recent first
+type Recorder struct {
+	mu       sync.RWMutex
+	saveMu   sync.Mutex // Keeps concurrent saves from interleaving writes
+	path     string
+	maxPlays int
+	plays    []Play // Most recent first
+}
+
+// NewRecorder creates an empty listening history persisted at path.
+// An empty path keeps the history in memory only.
+func NewRecorder(path string, maxPlays int) *Recorder {
+	if maxPlays <= 0 {
+		maxPlays = DefaultMaxPlays
+	}
+
+	return &Recorder{
+		path:     path,
+		maxPlays: maxPlays,
+		plays:    []Play{},
+	}
+}
+
+// LoadRecorder creates a recorder and reads any previously saved plays from
+// path. A missing file is not an error and yields an empty history.
+func LoadRecorder(path string, maxPlays int) (*Recorder, error) {
+	recorder := NewRecorder(path, maxPlays)
+	if path == "" {
+		return recorder, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return recorder, nil
+		}
+		return recorder, fmt.Errorf("failed to read play history: %w", err)
+	}
+
+	var plays []Play
+	if err := json.Unmarshal(data, &plays); err != nil {
+		return recorder, fmt.Errorf("failed to parse play history: %w", err)
+	}
+
+	if len(plays) > recorder.maxPlays {
+		plays = plays[:recorder.maxPlays]
+	}
+	recorder.plays = plays
+
+	return recorder, nil
+}
+
+// Record logs a play of track at the given time, dropping the oldest plays
+// beyond the cap. Tracks with neither an ID nor a location are ignored.
+func (r *Recorder) Record(track soundcloud.Track, at time.Time) {
+	play := Play{
+		TrackID:  track.ID,
+		Title:    track.Title,
+		Artist:   track.Artist(),
+		URL:      track.PermalinkURL,
+		PlayedAt: at,
+	}
+	if play.URL == "" {
+		play.URL = track.StreamURL
+	}
+	if play.key() == "" {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	plays := make([]Play, 0, min(len(r.plays)+1, r.maxPlays))
+	plays = append(plays, play)
+	plays = append(plays, r.plays[:min(len(r.plays), r.maxPlays-1)]...)
+	r.plays = plays
+}
+
+// Recent returns up to n plays, most recent first; n <= 0 returns them all
+func (r *Recorder) Recent(n int) []Play {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if n <= 0 || n > len(r.plays) {
+		n = len(r.plays)
+	}
+	recent := make([]Play, n)
+	copy(recent, r.plays)
+	return recent
+}
+
+// TopTracks returns up to n of the most played tracks, most played first and
+// ties broken by the most recently played; n <= 0 returns them all
+func (r *Recorder) TopTracks(n int) []TrackPlays {
+	r.mu.RLock()
+	counts := make(map[string]*TrackPlays)
+	var order []*TrackPlays
+	for _, play := range r.plays {
+		if track, ok := counts[play.key()]; ok {
+			track.Count++
+			continue
+		}
+		// Plays are newest first, so the first seen is the latest
+		track := &TrackPlays{Play: play, Count: 1}
+		counts[play.key()] = track
+		order = append(order, track)
+	}
+	r.mu.RUnlock()
+
+	// Stable keeps the most recently played first among equal counts
+	sort.SliceStable(order, func(i, j int) bool {
+		return order[i].Count > order[j].Count
+	})
+
+	if n <= 0 || n > len(order) {
+		n = len(order)
+	}
+	top := make([]TrackPlays, n)
+	for i := range top {
+		top[i] = *order[i]
+	}
+	return top
+}
+
+// Len returns the number of plays in the history
+func (r *Recorder) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.plays)
+}
+
+// Save writes the listening history to disk
+func (r *Recorder) Save() error {
+	if r.path == "" {
+		return nil
+	}
+
+	r.saveMu.Lock()
+	defer r.saveMu.Unlock()
+
+	data, err := json.MarshalIndent(r.Recent(0), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal play history: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	if err := os.WriteFile(r.path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write play history: %w", err)
+	}
+
+	return nil
+}
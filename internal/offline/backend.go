@@ -0,0 +1,132 @@
+package offline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/soundcloud"
+)
+
+// ErrNotCached is returned for a track whose audio isn't in the cache
+var ErrNotCached = errors.New("track is not cached")
+
+// Provider lists cached tracks in place of SoundCloud searches
+type Provider struct {
+	index *Index
+}
+
+// NewProvider creates a provider searching index
+func NewProvider(index *Index) *Provider {
+	return &Provider{index: index}
+}
+
+// SearchWithTotal returns the cached tracks matching query
+func (p *Provider) SearchWithTotal(query string) ([]soundcloud.Track, int, error) {
+	tracks := p.index.Search(query)
+	if len(tracks) == 0 {
+		return nil, 0, soundcloud.ErrNoResults
+	}
+	return tracks, len(tracks), nil
+}
+
+// GetArtistTracks returns the cached tracks by user
+func (p *Provider) GetArtistTracks(user soundcloud.User) ([]soundcloud.Track, error) {
+	return p.index.ByArtist(user.ID), nil
+}
+
+// GetUserTracks returns a page of the cached tracks by the user with userID
+func (p *Provider) GetUserTracks(userID int64, limit, offset int) ([]soundcloud.Track, error) {
+	tracks := p.index.ByArtist(userID)
+	if offset >= len(tracks) {
+		return nil, nil
+	}
+	tracks = tracks[offset:]
+	if limit > 0 && limit < len(tracks) {
+		tracks = tracks[:limit]
+	}
+	return tracks, nil
+}
+
+// GetRelatedTracks returns the other cached tracks by the same artist, the
+// closest the cache has to SoundCloud's recommendations
+func (p *Provider) GetRelatedTracks(trackID int64) ([]soundcloud.Track, error) {
+	track, _, ok := p.index.Lookup(trackID)
+	if !ok {
+		return nil, nil
+	}
+
+	var related []soundcloud.Track
+	for _, other := range p.index.ByArtist(track.User.ID) {
+		if other.ID != trackID {
+			related = append(related, other)
+		}
+	}
+	return related, nil
+}
+
+// Extractor streams cached tracks from disk as file:// URLs
+type Extractor struct {
+	index *Index
+}
+
+// NewExtractor creates an extractor for the tracks in index
+func NewExtractor(index *Index) *Extractor {
+	return &Extractor{index: index}
+}
+
+// ExtractStreamURL returns the file:// URL of a cached track's audio
+func (e *Extractor) ExtractStreamURL(ctx context.Context, trackID int64) (*audio.StreamInfo, error) {
+	track, path, ok := e.index.Lookup(trackID)
+	if !ok {
+		return nil, fmt.Errorf("%w: track %d", ErrNotCached, trackID)
+	}
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotCached, err)
+	}
+
+	return &audio.StreamInfo{
+		URL:      (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String(),
+		Format:   formatOf(path),
+		Quality:  audio.QualityProgressive,
+		Duration: track.Duration,
+	}, nil
+}
+
+// GetAvailableQualities reports the single quality a cached track has
+func (e *Extractor) GetAvailableQualities(ctx context.Context, trackID int64) ([]string, error) {
+	if _, _, ok := e.index.Lookup(trackID); !ok {
+		return nil, fmt.Errorf("%w: track %d", ErrNotCached, trackID)
+	}
+	return []string{audio.QualityProgressive}, nil
+}
+
+// ValidateStreamURL checks that the cached file is still there
+func (e *Extractor) ValidateStreamURL(ctx context.Context, streamURL string) (bool, error) {
+	if _, err := os.Stat(audio.LocalPath(streamURL)); err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+// formatOf names the stream format of a cached file from its extension, or
+// "" to let the player detect it
+func formatOf(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".mp3":
+		return audio.FormatMP3
+	case ".wav":
+		return audio.FormatWAV
+	case ".ogg":
+		return audio.FormatOgg
+	case ".opus":
+		return audio.FormatOpus
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,119 @@
+package offline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/soundcloud"
+)
+
+// ErrNotCacheable is returned for streams the cache can't store as a single
+// file, such as HLS playlists
+var ErrNotCacheable = errors.New("stream can't be cached")
+
+// downloadTimeout bounds fetching one track into the cache
+const downloadTimeout = 5 * time.Minute
+
+// Cache stores the audio of played tracks in the cache directory and
+// records them in the index, so the offline backend can play them later
+type Cache struct {
+	index  *Index
+	client *http.Client
+}
+
+// NewCache creates a cache filling index and its directory
+func NewCache(index *Index) *Cache {
+	return &Cache{
+		index:  index,
+		client: &http.Client{Timeout: downloadTimeout},
+	}
+}
+
+// Store downloads the stream described by info as the audio of track, then
+// records it in the index and saves the index. A track that is already
+// cached isn't downloaded again.
+func (c *Cache) Store(ctx context.Context, track soundcloud.Track, info *audio.StreamInfo) error {
+	if c.index.dir == "" {
+		return errors.New("track cache has no directory")
+	}
+	if info == nil || info.Format == audio.FormatHLS || audio.IsLocalStream(info.URL) {
+		return ErrNotCacheable
+	}
+	if _, path, ok := c.index.Lookup(track.ID); ok {
+		if _, err := os.Stat(path); err == nil {
+			return nil
+		}
+	}
+
+	name := strconv.FormatInt(track.ID, 10) + extensionOf(info.Format)
+	if err := c.download(ctx, info.URL, filepath.Join(c.index.dir, name)); err != nil {
+		return err
+	}
+
+	c.index.Add(track, name)
+	return c.index.Save()
+}
+
+// download writes the resource at streamURL to path. A partial download
+// never replaces the file at path.
+func (c *Cache) download(ctx context.Context, streamURL, path string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, streamURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download stream: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download stream: HTTP %d", resp.StatusCode)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create track cache directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.part")
+	if err != nil {
+		return fmt.Errorf("failed to create cache file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, resp.Body); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to download stream: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	return nil
+}
+
+// extensionOf returns the file extension for audio in format, the inverse
+// of formatOf. Unknown formats get none and are detected when played.
+func extensionOf(format string) string {
+	switch format {
+	case audio.FormatMP3:
+		return ".mp3"
+	case audio.FormatWAV:
+		return ".wav"
+	case audio.FormatOgg:
+		return ".ogg"
+	case audio.FormatOpus:
+		return ".opus"
+	default:
+		return ""
+	}
+}
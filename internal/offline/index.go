@@ -0,0 +1,190 @@
+package offline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"soundcloud-tui/internal/soundcloud"
+)
+
+// DirName is the name of the track cache directory inside the config dir
+const DirName = "cache"
+
+// IndexFileName is the file name of the cached track metadata inside the
+// cache directory
+const IndexFileName = "index.json"
+
+// Entry is a cached track: its metadata and where its audio is stored
+type Entry struct {
+	Track soundcloud.Track `json:"track"`
+	Path  string           `json:"path"` // Relative to the cache directory, or absolute
+}
+
+// Index holds the metadata of the tracks in the cache, searchable without
+// a connection to SoundCloud
+type Index struct {
+	mu      sync.RWMutex
+	dir     string
+	entries []Entry // Most recently cached first
+}
+
+// NewIndex creates an empty index of the cache in dir.
+// An empty dir keeps the index in memory only.
+func NewIndex(dir string) *Index {
+	return &Index{
+		dir:     dir,
+		entries: []Entry{},
+	}
+}
+
+// Load creates an index and reads the cached track metadata in dir.
+// A missing index is not an error and yields an empty index.
+func Load(dir string) (*Index, error) {
+	index := NewIndex(dir)
+	if dir == "" {
+		return index, nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, IndexFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return index, nil
+		}
+		return index, fmt.Errorf("failed to read track cache index: %w", err)
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return index, fmt.Errorf("failed to parse track cache index: %w", err)
+	}
+
+	// Re-add oldest first so duplicates resolve to the most recent entry
+	for i := len(entries) - 1; i >= 0; i-- {
+		index.Add(entries[i].Track, entries[i].Path)
+	}
+
+	return index, nil
+}
+
+// Add records that the audio of track is cached at path, replacing any
+// earlier entry for the track
+func (x *Index) Add(track soundcloud.Track, path string) {
+	if track.ID == 0 || path == "" {
+		return
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	entries := make([]Entry, 0, len(x.entries)+1)
+	entries = append(entries, Entry{Track: track, Path: path})
+	for _, existing := range x.entries {
+		if existing.Track.ID != track.ID {
+			entries = append(entries, existing)
+		}
+	}
+	x.entries = entries
+}
+
+// Lookup returns the cached track with the given ID and the absolute path of
+// its audio
+func (x *Index) Lookup(trackID int64) (soundcloud.Track, string, bool) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	for _, entry := range x.entries {
+		if entry.Track.ID == trackID {
+			return entry.Track, x.resolve(entry.Path), true
+		}
+	}
+	return soundcloud.Track{}, "", false
+}
+
+// Search returns the cached tracks matching every word of query in their
+// title, artist or genre, most recently cached first. An empty query
+// matches every track.
+func (x *Index) Search(query string) []soundcloud.Track {
+	words := strings.Fields(strings.ToLower(query))
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	var tracks []soundcloud.Track
+	for _, entry := range x.entries {
+		if matches(entry.Track, words) {
+			tracks = append(tracks, entry.Track)
+		}
+	}
+	return tracks
+}
+
+// ByArtist returns the cached tracks uploaded by the user with userID, most
+// recently cached first
+func (x *Index) ByArtist(userID int64) []soundcloud.Track {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	var tracks []soundcloud.Track
+	for _, entry := range x.entries {
+		if entry.Track.User.ID == userID {
+			tracks = append(tracks, entry.Track)
+		}
+	}
+	return tracks
+}
+
+// Len returns the number of cached tracks
+func (x *Index) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	return len(x.entries)
+}
+
+// Save writes the index to the cache directory
+func (x *Index) Save() error {
+	if x.dir == "" {
+		return nil
+	}
+
+	x.mu.RLock()
+	data, err := json.MarshalIndent(x.entries, "", "  ")
+	x.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal track cache index: %w", err)
+	}
+
+	if err := os.MkdirAll(x.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create track cache directory: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(x.dir, IndexFileName), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write track cache index: %w", err)
+	}
+
+	return nil
+}
+
+// resolve returns the absolute path of audio stored at path
+func (x *Index) resolve(path string) string {
+	if filepath.IsAbs(path) || x.dir == "" {
+		return path
+	}
+	return filepath.Join(x.dir, path)
+}
+
+// matches reports whether every word appears in the track's title, artist
+// or genre
+func matches(track soundcloud.Track, words []string) bool {
+	text := strings.ToLower(strings.Join([]string{track.Title, track.User.Username, track.Artist(), track.Genre}, " "))
+	for _, word := range words {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,52 @@
+package opener
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/pkg/browser"
+)
+
+// Opener opens a URL outside of the terminal
+type Opener interface {
+	Open(url string) error
+}
+
+// BrowserOpener opens URLs in the system's default web browser
+type BrowserOpener struct{}
+
+// NewBrowserOpener creates an opener backed by the default browser
+func NewBrowserOpener() *BrowserOpener {
+	return &BrowserOpener{}
+}
+
+// Open launches the default browser with the given URL
+func (b *BrowserOpener) Open(url string) error {
+	// The browser command's output would corrupt the TUI, so discard it
+	browser.Stdout = io.Discard
+	browser.Stderr = io.Discard
+
+	if err := browser.OpenURL(url); err != nil {
+		return fmt.Errorf("failed to open browser: %w", err)
+	}
+	return nil
+}
+
+// OpenedMsg reports the result of opening a URL
+type OpenedMsg struct {
+	URL   string
+	Error error
+}
+
+// OpenCmd opens the URL in the background and reports the result as an OpenedMsg.
+// It returns nil for an empty URL or a nil opener.
+func OpenCmd(o Opener, url string) tea.Cmd {
+	if o == nil || url == "" {
+		return nil
+	}
+
+	return func() tea.Msg {
+		return OpenedMsg{URL: url, Error: o.Open(url)}
+	}
+}
@@ -0,0 +1,203 @@
+package playlist
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/soundcloud"
+)
+
+// QueueFileName is the file name the queue is saved to inside the config dir
+const QueueFileName = "queue.m3u"
+
+// Playlist files are written as JSON when their name ends in this extension,
+// and as M3U otherwise
+const jsonExtension = ".json"
+
+// m3uHeader starts an extended M3U file
+const m3uHeader = "#EXTM3U"
+
+// extinfPrefix starts the "#EXTINF:seconds,Artist - Title" line describing the
+// entry that follows
+const extinfPrefix = "#EXTINF:"
+
+// Resolver looks up a SoundCloud track by its link
+type Resolver interface {
+	GetTrackInfo(url string) (*soundcloud.Track, error)
+}
+
+// Write saves tracks to path, as a JSON array of tracks when path ends in
+// .json and as an extended M3U of links otherwise. M3U files can only refer to
+// tracks with a SoundCloud link or a local file; others are left out. It
+// returns how many tracks were written.
+func Write(path string, tracks []soundcloud.Track) (int, error) {
+	var data []byte
+	written := len(tracks)
+	if isJSON(path) {
+		encoded, err := json.MarshalIndent(tracks, "", "  ")
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal playlist: %w", err)
+		}
+		data = encoded
+	} else {
+		data, written = encodeM3U(tracks)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return 0, fmt.Errorf("failed to create playlist directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return 0, fmt.Errorf("failed to write playlist: %w", err)
+	}
+
+	return written, nil
+}
+
+// Read loads the entries of a playlist file written by Write. JSON files
+// hold full tracks; M3U entries, like plain lists of links, carry only the
+// link (or local file) and the #EXTINF title and duration, and need Resolve
+// before they can be played.
+func Read(path string) ([]soundcloud.Track, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read playlist: %w", err)
+	}
+
+	if isJSON(path) {
+		var tracks []soundcloud.Track
+		if err := json.Unmarshal(data, &tracks); err != nil {
+			return nil, fmt.Errorf("failed to parse playlist: %w", err)
+		}
+		return tracks, nil
+	}
+
+	return decodeM3U(data)
+}
+
+// Resolve returns the playable track for an entry read from a playlist.
+// Entries that only carry a link are looked up through resolver, which may be
+// nil when SoundCloud isn't available; full tracks and local files are
+// returned as they are.
+func Resolve(entry soundcloud.Track, resolver Resolver) (*soundcloud.Track, error) {
+	if entry.ID != 0 || audio.IsLocalStream(entry.StreamURL) {
+		return &entry, nil
+	}
+	if entry.PermalinkURL == "" {
+		return nil, errors.New("entry has no track link")
+	}
+
+	url, err := soundcloud.NormalizeURL(entry.PermalinkURL)
+	if err != nil {
+		return nil, err
+	}
+	if resolver == nil {
+		return nil, fmt.Errorf("cannot look up %s without SoundCloud", url)
+	}
+	return resolver.GetTrackInfo(url)
+}
+
+// isJSON reports whether the playlist at path is stored as JSON
+func isJSON(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), jsonExtension)
+}
+
+// encodeM3U writes an extended M3U entry for every track with a link or a
+// local file, returning the file and the number of entries
+func encodeM3U(tracks []soundcloud.Track) ([]byte, int) {
+	var buf bytes.Buffer
+	buf.WriteString(m3uHeader + "\n")
+
+	written := 0
+	for _, track := range tracks {
+		location := track.PermalinkURL
+		if audio.IsLocalStream(track.StreamURL) {
+			location = track.StreamURL
+		}
+		if location == "" {
+			continue
+		}
+
+		seconds := int64(-1) // Unknown length
+		if track.Duration > 0 {
+			seconds = track.Duration / 1000
+		}
+		display := track.Title
+		if artist := track.Artist(); artist != soundcloud.UnknownArtist {
+			display = artist + " - " + track.Title
+		}
+
+		fmt.Fprintf(&buf, "%s%d,%s\n%s\n", extinfPrefix, seconds, display, location)
+		written++
+	}
+
+	return buf.Bytes(), written
+}
+
+// decodeM3U reads the entries of an M3U file, or of a plain list of links
+// with # comments
+func decodeM3U(data []byte) ([]soundcloud.Track, error) {
+	var tracks []soundcloud.Track
+	var info soundcloud.Track // From the #EXTINF line before the next entry
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, extinfPrefix) {
+			info = parseExtinf(strings.TrimPrefix(line, extinfPrefix))
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		entry := info
+		if audio.IsLocalStream(line) {
+			entry.StreamURL = line
+			if entry.Title == "" {
+				name := filepath.Base(audio.LocalPath(line))
+				entry.Title = strings.TrimSuffix(name, filepath.Ext(name))
+			}
+		} else {
+			entry.PermalinkURL = line
+		}
+		tracks = append(tracks, entry)
+		info = soundcloud.Track{}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read playlist: %w", err)
+	}
+
+	return tracks, nil
+}
+
+// parseExtinf reads the duration and "Artist - Title" display name of an
+// #EXTINF line
+func parseExtinf(value string) soundcloud.Track {
+	var track soundcloud.Track
+
+	seconds, display, found := strings.Cut(value, ",")
+	if !found {
+		return track
+	}
+	if n, err := strconv.ParseInt(strings.TrimSpace(seconds), 10, 64); err == nil && n > 0 {
+		track.Duration = n * 1000
+	}
+
+	if artist, title, found := strings.Cut(display, " - "); found {
+		track.User.Username = artist
+		track.Title = title
+	} else {
+		track.Title = display
+	}
+	return track
+}
@@ -0,0 +1,149 @@
+package prefetch
+
+import (
+	"context"
+	"sync"
+
+	"soundcloud-tui/internal/soundcloud"
+)
+
+// DefaultConcurrency is how many tracks are fetched at once by default
+const DefaultConcurrency = 3
+
+// MaxCachedTracks caps how many fetched tracks are kept; the oldest are
+// dropped first
+const MaxCachedTracks = 200
+
+// Fetcher looks up the full metadata of a track from a listing, such as
+// Client.EnrichTrack
+type Fetcher func(track *soundcloud.Track) (*soundcloud.Track, error)
+
+// Manager fetches full track metadata in the background ahead of need and
+// caches it, so picking a track that was warmed needs no lookup
+type Manager struct {
+	fetch Fetcher
+	slots chan struct{} // Bounds the fetches running at once
+
+	mu       sync.Mutex
+	cache    map[int64]*soundcloud.Track
+	order    []int64        // Cached IDs, oldest first
+	fetching map[int64]bool // Fetches under way
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+// NewManager creates a manager that runs at most concurrency fetches at once
+func NewManager(fetch Fetcher, concurrency int) *Manager {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	return &Manager{
+		fetch:    fetch,
+		slots:    make(chan struct{}, concurrency),
+		cache:    make(map[int64]*soundcloud.Track),
+		fetching: make(map[int64]bool),
+	}
+}
+
+// Warm starts fetching the tracks that aren't cached yet and returns without
+// waiting. It replaces the previous warm-up: tracks that one was still
+// waiting to fetch are dropped, while fetches under way finish and are cached.
+func (m *Manager) Warm(tracks []soundcloud.Track) {
+	m.mu.Lock()
+	if m.cancel != nil {
+		m.cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+
+	queued := make(map[int64]bool)
+	var todo []soundcloud.Track
+	for _, track := range tracks {
+		if track.ID == 0 || queued[track.ID] || m.cache[track.ID] != nil {
+			continue
+		}
+		queued[track.ID] = true
+		todo = append(todo, track)
+	}
+	m.wg.Add(len(todo))
+	m.mu.Unlock()
+
+	for _, track := range todo {
+		go m.fetchTrack(ctx, track)
+	}
+}
+
+// Cancel drops the tracks the current warm-up is still waiting to fetch, as
+// when the search they came from is replaced
+func (m *Manager) Cancel() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.cancel != nil {
+		m.cancel()
+		m.cancel = nil
+	}
+}
+
+// Wait blocks until every started warm-up has finished or been dropped
+func (m *Manager) Wait() {
+	m.wg.Wait()
+}
+
+// Get returns the cached full metadata of the track with id
+func (m *Manager) Get(id int64) (*soundcloud.Track, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	track, ok := m.cache[id]
+	if !ok {
+		return nil, false
+	}
+	cached := *track
+	return &cached, true
+}
+
+// fetchTrack fetches track once a slot is free, unless ctx is cancelled
+// first or the track was fetched meanwhile. Failed fetches aren't cached.
+func (m *Manager) fetchTrack(ctx context.Context, track soundcloud.Track) {
+	defer m.wg.Done()
+
+	select {
+	case m.slots <- struct{}{}:
+	case <-ctx.Done():
+		return
+	}
+	defer func() { <-m.slots }()
+
+	m.mu.Lock()
+	if ctx.Err() != nil || m.cache[track.ID] != nil || m.fetching[track.ID] {
+		m.mu.Unlock()
+		return
+	}
+	m.fetching[track.ID] = true
+	m.mu.Unlock()
+
+	full, err := m.fetch(&track)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.fetching, track.ID)
+	if err == nil && full != nil {
+		m.storeLocked(track.ID, full)
+	}
+}
+
+// storeLocked caches track under id, dropping the oldest entries beyond
+// MaxCachedTracks (caller must hold lock)
+func (m *Manager) storeLocked(id int64, track *soundcloud.Track) {
+	if m.cache[id] == nil {
+		m.order = append(m.order, id)
+	}
+	m.cache[id] = track
+
+	for len(m.order) > MaxCachedTracks {
+		delete(m.cache, m.order[0])
+		m.order = m.order[1:]
+	}
+}
@@ -0,0 +1,99 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FileName is the file name of the saved session inside the config dir
+const FileName = "session.json"
+
+// SaveInterval is how often the playing position is saved during playback
+const SaveInterval = 10 * time.Second
+
+// Session is where playback left off
+type Session struct {
+	TrackID  int64
+	Position time.Duration
+}
+
+// sessionFile is the on-disk form of a Session
+type sessionFile struct {
+	TrackID    int64 `json:"track_id"`
+	PositionMS int64 `json:"position_ms"`
+}
+
+// Store persists the last played track and position between runs
+type Store struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewStore creates a session store persisted at path.
+// An empty path makes saving a no-op and loading find nothing.
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// SaveSession records trackID as the last played track, stopped at pos
+func (s *Store) SaveSession(trackID int64, pos time.Duration) error {
+	if s.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(sessionFile{
+		TrackID:    trackID,
+		PositionMS: pos.Milliseconds(),
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create session directory: %w", err)
+	}
+
+	if err := os.WriteFile(s.path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+
+	return nil
+}
+
+// LoadSession returns the saved session, or nil if there is none
+func (s *Store) LoadSession() (*Session, error) {
+	if s.path == "" {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+
+	var saved sessionFile
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("failed to parse session: %w", err)
+	}
+	if saved.TrackID == 0 {
+		return nil, nil
+	}
+
+	return &Session{
+		TrackID:  saved.TrackID,
+		Position: time.Duration(saved.PositionMS) * time.Millisecond,
+	}, nil
+}
@@ -1,16 +1,65 @@
 package soundcloud
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	soundcloudapi "github.com/zackradisic/soundcloud-api"
+
+	"soundcloud-tui/internal/soundcloud/retry"
 )
 
+// API is the part of the SoundCloud API library the client relies on
+type API interface {
+	ClientID() string
+	GetTrackInfo(options soundcloudapi.GetTrackInfoOptions) ([]soundcloudapi.Track, error)
+	Search(options soundcloudapi.SearchOptions) (*soundcloudapi.PaginatedQuery, error)
+	GetDownloadURL(url string, streamType string) (string, error)
+}
+
+// APIFactory creates the underlying API. It is called again when the
+// auto-fetched client ID is rejected and needs refreshing.
+type APIFactory func() (API, error)
+
+// ErrNoResults is returned by Search when the query matched no tracks
+var ErrNoResults = errors.New("no results found")
+
+// ErrSearchUnavailable is returned by Search when SoundCloud kept failing
+// with transient errors; trying again later may succeed
+var ErrSearchUnavailable = errors.New("search temporarily unavailable")
+
 // Client wraps the SoundCloud API client
 type Client struct {
-	api *soundcloudapi.API
+	api         API
+	newAPI      APIFactory
+	httpClient  *http.Client // For endpoints the API library doesn't cover
+	retryPolicy retry.Policy
+	maxResults  int // Cap on tracks returned by a search
 }
 
+// DefaultMaxResults is how many tracks a search returns unless configured otherwise
+const DefaultMaxResults = 50
+
+// relatedTracksURL is the api-v2 endpoint listing tracks related to a track ID
+const relatedTracksURL = "https://api-v2.soundcloud.com/tracks/%d/related"
+
+// relatedTracksLimit is how many related tracks are requested at once
+const relatedTracksLimit = 20
+
+// userTracksURL is the api-v2 endpoint listing the public tracks of a user ID
+const userTracksURL = "https://api-v2.soundcloud.com/users/%d/tracks"
+
+// UserTracksPageSize is how many of an artist's tracks are fetched at once
+const UserTracksPageSize = 50
+
 // Track represents a SoundCloud track
 type Track struct {
 	ID          int64  `json:"id"`
@@ -20,6 +69,12 @@ type Track struct {
 	ArtworkURL  string `json:"artwork_url"`
 	StreamURL   string `json:"stream_url"`
 	PermalinkURL string `json:"permalink_url"`
+	PlaybackCount int64 `json:"playback_count"`
+	LikesCount  int64  `json:"likes_count"`
+	CommentCount int64 `json:"comment_count"`
+	RepostsCount int64 `json:"reposts_count"`
+	Genre       string `json:"genre"`
+	CreatedAt   time.Time `json:"created_at"`
 	User        User   `json:"user"`
 }
 
@@ -49,15 +104,22 @@ type User struct {
 	LastName  string `json:"last_name"`
 }
 
-// FullName returns the combined first and last name
+// UnknownArtist is shown for a user without any name
+const UnknownArtist = "Unknown Artist"
+
+// FullName returns the combined first and last name, else the username, else
+// UnknownArtist so that an artist never shows up blank
 func (u User) FullName() string {
-	if u.FirstName == "" && u.LastName == "" {
+	if strings.TrimSpace(u.FirstName) == "" && strings.TrimSpace(u.LastName) == "" {
+		if strings.TrimSpace(u.Username) == "" {
+			return UnknownArtist
+		}
 		return u.Username
 	}
-	if u.FirstName == "" {
+	if strings.TrimSpace(u.FirstName) == "" {
 		return u.LastName
 	}
-	if u.LastName == "" {
+	if strings.TrimSpace(u.LastName) == "" {
 		return u.FirstName
 	}
 	return u.FirstName + " " + u.LastName
@@ -66,98 +128,417 @@ func (u User) FullName() string {
 // ClientInterface defines the interface for SoundCloud client
 type ClientInterface interface {
 	Search(query string) ([]Track, error)
+	SearchWithTotal(query string) ([]Track, int, error)
 	GetTrackInfo(url string) (*Track, error)
+	GetTrackByID(id int64) (*Track, error)
 	GetDownloadURL(trackURL string, format string) (string, error)
+	GetArtistTracks(user User) ([]Track, error)
+	GetUserTracks(userID int64, limit, offset int) ([]Track, error)
+	GetRelatedTracks(trackID int64) ([]Track, error)
+	EnrichTrack(track *Track) (*Track, error)
 }
 
 // NewClient creates a new SoundCloud client
 func NewClient() (*Client, error) {
-	api, err := soundcloudapi.New(soundcloudapi.APIOptions{})
+	return NewClientWithAPI(newDefaultAPI)
+}
+
+// NewClientWithAPI creates a client whose API comes from newAPI
+func NewClientWithAPI(newAPI APIFactory) (*Client, error) {
+	api, err := newAPI()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create SoundCloud API client: %w", err)
 	}
 
 	return &Client{
-		api: api,
+		api:         api,
+		newAPI:      newAPI,
+		httpClient:  &http.Client{Timeout: 15 * time.Second},
+		retryPolicy: retry.DefaultPolicy(),
+		maxResults:  DefaultMaxResults,
 	}, nil
 }
 
-// GetTrackInfo retrieves track information by URL
+// newDefaultAPI creates the library API with a freshly fetched client ID
+func newDefaultAPI() (API, error) {
+	api, err := soundcloudapi.New(soundcloudapi.APIOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return api, nil
+}
+
+// GetTrackInfo retrieves track information by URL, which is normalized with
+// NormalizeURL first. When the API returns several tracks, the one whose
+// permalink matches url is preferred.
 func (c *Client) GetTrackInfo(url string) (*Track, error) {
-	tracks, err := c.api.GetTrackInfo(soundcloudapi.GetTrackInfoOptions{
-		URL: url,
+	url, err := NormalizeURL(url)
+	if err != nil {
+		return nil, err
+	}
+
+	tracks, err := c.ResolveTracks(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tracks[0], nil
+}
+
+// GetTrackByID retrieves track information by its numeric SoundCloud ID
+func (c *Client) GetTrackByID(id int64) (*Track, error) {
+	var tracks []soundcloudapi.Track
+	err := retry.Do(context.Background(), c.retryPolicy, func() error {
+		var err error
+		tracks, err = c.api.GetTrackInfo(soundcloudapi.GetTrackInfoOptions{
+			ID: []int64{id},
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get track %d: %w", id, err)
+	}
+
+	for _, track := range tracks {
+		if track.ID == id {
+			result := convertTrack(track)
+			return &result, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no track found with ID %d", id)
+}
+
+// EnrichTrack fetches the full metadata of a track from a search or listing,
+// whose description, artwork and counts may be truncated or missing. Anything
+// the full lookup leaves empty keeps the value from track.
+func (c *Client) EnrichTrack(track *Track) (*Track, error) {
+	if track == nil || track.ID == 0 {
+		return nil, fmt.Errorf("track has no ID to look up")
+	}
+
+	full, err := c.GetTrackByID(track.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enrich track: %w", err)
+	}
+
+	enriched := mergeTrack(*track, *full)
+	return &enriched, nil
+}
+
+// mergeTrack returns full with its empty fields filled in from partial
+func mergeTrack(partial, full Track) Track {
+	merged := full
+	if merged.Title == "" {
+		merged.Title = partial.Title
+	}
+	if merged.Description == "" {
+		merged.Description = partial.Description
+	}
+	if merged.Duration == 0 {
+		merged.Duration = partial.Duration
+	}
+	if merged.ArtworkURL == "" {
+		merged.ArtworkURL = partial.ArtworkURL
+	}
+	if merged.StreamURL == "" {
+		merged.StreamURL = partial.StreamURL
+	}
+	if merged.PermalinkURL == "" {
+		merged.PermalinkURL = partial.PermalinkURL
+	}
+	if merged.Genre == "" {
+		merged.Genre = partial.Genre
+	}
+	if merged.CreatedAt.IsZero() {
+		merged.CreatedAt = partial.CreatedAt
+	}
+	if merged.User.Username == "" {
+		merged.User = partial.User
+	}
+	return merged
+}
+
+// ResolveTracks retrieves the tracks meant by input. A permalink match or URL
+// yields a single track; search-style input may yield several to choose from.
+func (c *Client) ResolveTracks(input string) ([]Track, error) {
+	var tracks []soundcloudapi.Track
+	err := retry.Do(context.Background(), c.retryPolicy, func() error {
+		var err error
+		tracks, err = c.api.GetTrackInfo(soundcloudapi.GetTrackInfoOptions{
+			URL: input,
+		})
+		return err
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get track info: %w", err)
 	}
 
 	if len(tracks) == 0 {
-		return nil, fmt.Errorf("no track found for URL: %s", url)
+		return nil, fmt.Errorf("no track found for URL: %s", input)
+	}
+
+	result := make([]Track, len(tracks))
+	for i, track := range tracks {
+		result[i] = convertTrack(track)
+	}
+
+	return MatchTracks(input, result), nil
+}
+
+// Search searches for tracks on SoundCloud. It returns ErrNoResults when
+// nothing matched and ErrSearchUnavailable when SoundCloud kept failing.
+func (c *Client) Search(query string) ([]Track, error) {
+	tracks, _, err := c.SearchWithTotal(query)
+	return tracks, err
+}
+
+// SearchWithTotal searches like Search and also returns how many tracks
+// SoundCloud has for query in total, which may exceed the tracks returned.
+// The total is the number of tracks returned when SoundCloud doesn't say.
+func (c *Client) SearchWithTotal(query string) ([]Track, int, error) {
+	var paginatedQuery *soundcloudapi.PaginatedQuery
+	err := c.withFreshClientID(func() error {
+		var err error
+		paginatedQuery, err = c.api.Search(soundcloudapi.SearchOptions{
+			Query:  query,
+			Kind:   soundcloudapi.KindTrack, // Search only for tracks
+			Limit:  c.maxResults,
+			Offset: 0, // Start from beginning
+		})
+		return err
+	})
+	if err != nil {
+		if retryable, _ := retry.Retryable(err); retryable {
+			return nil, 0, fmt.Errorf("%w: %w", ErrSearchUnavailable, err)
+		}
+		return nil, 0, fmt.Errorf("failed to search: %w", err)
+	}
+
+	tracks, err := paginatedQuery.GetTracks()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get tracks from search: %w", err)
+	}
+	if len(tracks) == 0 {
+		return nil, 0, fmt.Errorf("%w for %q", ErrNoResults, query)
+	}
+
+	// The API doesn't always honor the limit
+	if len(tracks) > c.maxResults {
+		tracks = tracks[:c.maxResults]
+	}
+
+	// Convert to our Track structs
+	result := make([]Track, len(tracks))
+	for i, track := range tracks {
+		result[i] = convertTrack(track)
+	}
+
+	total := paginatedQuery.TotalResults
+	if total < len(result) {
+		total = len(result)
 	}
 
-	// Use the first track from the results
-	track := tracks[0]
+	return result, total, nil
+}
+
+// withFreshClientID runs fn under the retry policy. If the API rejects the
+// client ID, the API is recreated once to fetch a new one and fn runs again.
+func (c *Client) withFreshClientID(fn func() error) error {
+	err := retry.Do(context.Background(), c.retryPolicy, fn)
+	if err == nil || !retry.AuthFailure(err) || c.newAPI == nil {
+		return err
+	}
 
-	// Convert to our Track struct
-	return &Track{
+	api, apiErr := c.newAPI()
+	if apiErr != nil {
+		return fmt.Errorf("failed to refresh client ID: %w", apiErr)
+	}
+	c.api = api
+
+	return retry.Do(context.Background(), c.retryPolicy, fn)
+}
+
+// convertTrack converts an API track to our Track struct
+func convertTrack(track soundcloudapi.Track) Track {
+	return Track{
 		ID:          track.ID,
 		Title:       track.Title,
 		Description: track.Description,
 		Duration:    track.DurationMS, // Use DurationMS field
 		ArtworkURL:  track.ArtworkURL,
 		PermalinkURL: track.PermalinkURL,
+		PlaybackCount: track.PlaybackCount,
+		LikesCount:  track.LikesCount,
+		CommentCount: track.CommentCount,
+		RepostsCount: track.RepostsCount,
+		Genre:       track.Genre,
+		CreatedAt:   parseTimestamp(track.CreatedAt),
 		User: User{
 			ID:        track.User.ID,
 			Username:  track.User.Username,
 			FirstName: track.User.FirstName,
 			LastName:  track.User.LastName,
 		},
-	}, nil
+	}
 }
 
-// Search searches for tracks on SoundCloud
-func (c *Client) Search(query string) ([]Track, error) {
-	paginatedQuery, err := c.api.Search(soundcloudapi.SearchOptions{
-		Query:  query,
-		Kind:   soundcloudapi.KindTrack, // Search only for tracks
-		Limit:  50,                      // Increase limit for more results
-		Offset: 0,                       // Start from beginning
+// parseTimestamp parses an API timestamp, yielding the zero time when it is
+// missing or malformed
+func parseTimestamp(value string) time.Time {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return parsed
+}
+
+// GetArtistTracks returns the first page of tracks uploaded by user. Without
+// a user ID it falls back to searching by username and keeping the tracks
+// owned by that user.
+func (c *Client) GetArtistTracks(user User) ([]Track, error) {
+	if user.ID != 0 {
+		return c.GetUserTracks(user.ID, UserTracksPageSize, 0)
+	}
+	if user.Username == "" {
+		return nil, fmt.Errorf("artist has no username")
+	}
+
+	results, err := c.Search(user.Username)
+	if errors.Is(err, ErrNoResults) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artist tracks: %w", err)
+	}
+
+	var tracks []Track
+	for _, track := range results {
+		if track.User.ID == user.ID {
+			tracks = append(tracks, track)
+		}
+	}
+
+	// Without an ID match the plain search results are still the best guess
+	if len(tracks) == 0 {
+		return results, nil
+	}
+
+	return tracks, nil
+}
+
+// GetRelatedTracks returns tracks SoundCloud recommends alongside trackID.
+// No related tracks is not an error and yields an empty slice.
+func (c *Client) GetRelatedTracks(trackID int64) ([]Track, error) {
+	query := url.Values{}
+	query.Set("client_id", c.api.ClientID())
+	query.Set("limit", strconv.Itoa(relatedTracksLimit))
+	endpoint := fmt.Sprintf(relatedTracksURL, trackID) + "?" + query.Encode()
+
+	var data []byte
+	err := retry.Do(context.Background(), c.retryPolicy, func() error {
+		var err error
+		data, err = c.get(endpoint)
+		return err
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to search: %w", err)
+		return nil, fmt.Errorf("failed to get related tracks: %w", err)
 	}
 
-	tracks, err := paginatedQuery.GetTracks()
+	return ParseRelatedTracks(data)
+}
+
+// GetUserTracks returns up to limit public tracks of the user with userID,
+// newest first, skipping the first offset. A user without public tracks
+// yields an empty slice.
+func (c *Client) GetUserTracks(userID int64, limit, offset int) ([]Track, error) {
+	query := url.Values{}
+	query.Set("client_id", c.api.ClientID())
+	query.Set("limit", strconv.Itoa(limit))
+	query.Set("offset", strconv.Itoa(offset))
+	endpoint := fmt.Sprintf(userTracksURL, userID) + "?" + query.Encode()
+
+	var data []byte
+	err := retry.Do(context.Background(), c.retryPolicy, func() error {
+		var err error
+		data, err = c.get(endpoint)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tracks from search: %w", err)
+		return nil, fmt.Errorf("failed to get artist tracks: %w", err)
+	}
+
+	tracks, err := parseTrackPage(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse artist tracks: %w", err)
+	}
+	return tracks, nil
+}
+
+// ParseRelatedTracks decodes a related-tracks response, skipping any
+// collection entries that aren't tracks
+func ParseRelatedTracks(data []byte) ([]Track, error) {
+	tracks, err := parseTrackPage(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse related tracks: %w", err)
+	}
+	return tracks, nil
+}
+
+// parseTrackPage decodes a page of an api-v2 collection, skipping any entries
+// that aren't tracks
+func parseTrackPage(data []byte) ([]Track, error) {
+	var page soundcloudapi.PaginatedQuery
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, err
+	}
+
+	tracks, err := page.GetTracks()
+	if err != nil {
+		return nil, err
 	}
 
-	// Convert to our Track structs
 	result := make([]Track, len(tracks))
 	for i, track := range tracks {
-		result[i] = Track{
-			ID:          track.ID,
-			Title:       track.Title,
-			Description: track.Description,
-			Duration:    track.DurationMS, // Use DurationMS field
-			ArtworkURL:  track.ArtworkURL,
-			PermalinkURL: track.PermalinkURL,
-			User: User{
-				ID:        track.User.ID,
-				Username:  track.User.Username,
-				FirstName: track.User.FirstName,
-				LastName:  track.User.LastName,
-			},
-		}
+		result[i] = convertTrack(track)
 	}
 
 	return result, nil
 }
 
+// get fetches endpoint, reporting non-2xx responses as retry.StatusError
+func (c *Client) get(endpoint string) ([]byte, error) {
+	resp, err := c.httpClient.Get(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, &retry.StatusError{
+			StatusCode: resp.StatusCode,
+			RetryAfter: retry.ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+			Err:        fmt.Errorf("request failed with status %s", resp.Status),
+		}
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	return data, nil
+}
+
 // GetDownloadURL gets a downloadable/streamable URL for a track
 func (c *Client) GetDownloadURL(trackURL string, format string) (string, error) {
 	// Use the SoundCloud API's GetDownloadURL method
-	downloadURL, err := c.api.GetDownloadURL(trackURL, format)
+	var downloadURL string
+	err := retry.Do(context.Background(), c.retryPolicy, func() error {
+		var err error
+		downloadURL, err = c.api.GetDownloadURL(trackURL, format)
+		return err
+	})
 	if err != nil {
 		return "", fmt.Errorf("failed to get download URL: %w", err)
 	}
@@ -165,9 +546,66 @@ func (c *Client) GetDownloadURL(trackURL string, format string) (string, error)
 	return downloadURL, nil
 }
 
+// GetMediaURL resolves the URL of a track transcoding, as listed in its
+// media, to the URL its audio streams from
+func (c *Client) GetMediaURL(transcodingURL string) (string, error) {
+	endpoint, err := url.Parse(transcodingURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid transcoding URL: %w", err)
+	}
+	query := endpoint.Query()
+	query.Set("client_id", c.api.ClientID())
+	endpoint.RawQuery = query.Encode()
+
+	var data []byte
+	err = retry.Do(context.Background(), c.retryPolicy, func() error {
+		var err error
+		data, err = c.get(endpoint.String())
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get media URL: %w", err)
+	}
+
+	var media soundcloudapi.MediaURLResponse
+	if err := json.Unmarshal(data, &media); err != nil {
+		return "", fmt.Errorf("failed to parse media URL: %w", err)
+	}
+	if media.URL == "" {
+		return "", fmt.Errorf("no media URL for transcoding %s", transcodingURL)
+	}
+
+	return media.URL, nil
+}
+
+// SetMaxResults caps how many tracks a search returns. Non-positive values
+// restore DefaultMaxResults.
+func (c *Client) SetMaxResults(n int) {
+	if n <= 0 {
+		n = DefaultMaxResults
+	}
+	c.maxResults = n
+}
+
+// SetHTTPClient replaces the HTTP client used for endpoints the API library
+// doesn't cover
+func (c *Client) SetHTTPClient(client *http.Client) {
+	c.httpClient = client
+}
+
+// SetRetryPolicy changes how API calls are retried on rate limits and server errors
+func (c *Client) SetRetryPolicy(policy retry.Policy) {
+	c.retryPolicy = policy
+}
+
 // GetTrackInfoWithOptions gets track info using SoundCloud API options (for RealSoundCloudAPI compatibility)
 func (c *Client) GetTrackInfoWithOptions(options soundcloudapi.GetTrackInfoOptions) ([]soundcloudapi.Track, error) {
-	tracks, err := c.api.GetTrackInfo(options)
+	var tracks []soundcloudapi.Track
+	err := retry.Do(context.Background(), c.retryPolicy, func() error {
+		var err error
+		tracks, err = c.api.GetTrackInfo(options)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get track info: %w", err)
 	}
@@ -0,0 +1,50 @@
+package soundcloud
+
+import (
+	"strings"
+)
+
+// NormalizePermalink reduces a SoundCloud URL to a comparable form: no scheme,
+// no www./m. prefix, no query or fragment, no trailing slash, lower case.
+func NormalizePermalink(url string) string {
+	url = strings.ToLower(strings.TrimSpace(url))
+
+	if i := strings.Index(url, "://"); i >= 0 {
+		url = url[i+3:]
+	}
+	if i := strings.IndexAny(url, "?#"); i >= 0 {
+		url = url[:i]
+	}
+	url = strings.TrimPrefix(url, "www.")
+	url = strings.TrimPrefix(url, "m.")
+
+	return strings.TrimRight(url, "/")
+}
+
+// MatchTracks picks the tracks meant by input out of an API response. A track
+// whose permalink matches input wins; otherwise a URL falls back to the first
+// result, while search-style input keeps every result for the caller to choose.
+func MatchTracks(input string, tracks []Track) []Track {
+	if len(tracks) == 0 {
+		return nil
+	}
+
+	want := NormalizePermalink(input)
+	for _, track := range tracks {
+		if track.PermalinkURL != "" && NormalizePermalink(track.PermalinkURL) == want {
+			return []Track{track}
+		}
+	}
+
+	if isURL(input) {
+		return tracks[:1]
+	}
+
+	return tracks
+}
+
+// isURL reports whether input looks like a link rather than search text
+func isURL(input string) bool {
+	input = strings.ToLower(strings.TrimSpace(input))
+	return strings.HasPrefix(input, "http://") || strings.HasPrefix(input, "https://")
+}
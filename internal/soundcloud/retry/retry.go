@@ -0,0 +1,165 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	soundcloudapi "github.com/zackradisic/soundcloud-api"
+)
+
+// Policy controls how often and how long Do retries a failing call
+type Policy struct {
+	MaxAttempts    int           // Total attempts including the first; <= 1 disables retries
+	InitialBackoff time.Duration // Wait before the first retry, doubled after each attempt
+	MaxBackoff     time.Duration // Cap on any single wait, including Retry-After
+}
+
+// DefaultPolicy returns the policy used for SoundCloud API calls
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts:    4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+	}
+}
+
+// StatusError is an HTTP failure that may carry a server-requested
+// Retry-After delay
+type StatusError struct {
+	StatusCode int
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *StatusError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("request failed with status %d", e.StatusCode)
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+// Retryable reports whether err is a rate limit (429) or server (5xx) error,
+// along with any Retry-After delay the server asked for
+func Retryable(err error) (bool, time.Duration) {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return retryableStatus(statusErr.StatusCode), statusErr.RetryAfter
+	}
+
+	var apiErr *soundcloudapi.FailedRequestError
+	if errors.As(err, &apiErr) {
+		return retryableStatus(apiErr.Status), 0
+	}
+
+	return false, 0
+}
+
+// AuthFailure reports whether err is a 401 or 403 response, which for
+// anonymous API calls usually means the client ID has gone stale
+func AuthFailure(err error) bool {
+	return authStatus(Status(err))
+}
+
+// Status returns the HTTP status of the failed request behind err, or 0 when
+// err isn't an error response
+func Status(err error) int {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode
+	}
+
+	var apiErr *soundcloudapi.FailedRequestError
+	if errors.As(err, &apiErr) {
+		return apiErr.Status
+	}
+
+	return 0
+}
+
+// authStatus reports whether an HTTP status rejects the request's credentials
+func authStatus(status int) bool {
+	return status == http.StatusUnauthorized || status == http.StatusForbidden
+}
+
+// retryableStatus reports whether an HTTP status is worth retrying
+func retryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// ParseRetryAfter parses a Retry-After header given in seconds or as an HTTP
+// date. It returns 0 when the header is missing or invalid.
+func ParseRetryAfter(header string, now time.Time) time.Duration {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(header); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+
+	return 0
+}
+
+// Do calls fn until it succeeds, returns a non-retryable error, or the policy's
+// attempts run out. Waits back off exponentially unless the server asked for a
+// specific delay, and are cut short if ctx is cancelled.
+func Do(ctx context.Context, policy Policy, fn func() error) error {
+	attempts := policy.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	backoff := policy.InitialBackoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn()
+		if err == nil {
+			return nil
+		}
+
+		retryable, retryAfter := Retryable(err)
+		if !retryable {
+			return err
+		}
+		if attempt >= attempts {
+			break
+		}
+
+		wait := backoff
+		if retryAfter > 0 {
+			wait = retryAfter
+		}
+		if policy.MaxBackoff > 0 && wait > policy.MaxBackoff {
+			wait = policy.MaxBackoff
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("retry cancelled: %w", err)
+		case <-timer.C:
+		}
+
+		backoff *= 2
+	}
+
+	return fmt.Errorf("giving up after %d attempts: %w", attempts, err)
+}
@@ -0,0 +1,103 @@
+package soundcloud
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrInvalidTrackURL is returned by NormalizeURL for input that is not a link
+// to a single SoundCloud track
+var ErrInvalidTrackURL = errors.New("invalid SoundCloud track URL")
+
+// soundcloudHosts are the hosts a track link may use; all serve the same pages
+var soundcloudHosts = map[string]bool{
+	"soundcloud.com":     true,
+	"www.soundcloud.com": true,
+	"m.soundcloud.com":   true,
+}
+
+// reservedPaths are first path segments that name SoundCloud pages rather
+// than users
+var reservedPaths = map[string]bool{
+	"charts":        true,
+	"discover":      true,
+	"feed":          true,
+	"messages":      true,
+	"notifications": true,
+	"pages":         true,
+	"people":        true,
+	"search":        true,
+	"settings":      true,
+	"stations":      true,
+	"stream":        true,
+	"terms-of-use":  true,
+	"upload":        true,
+	"you":           true,
+}
+
+// profilePages are second path segments that name a user's pages rather
+// than one of their tracks
+var profilePages = map[string]bool{
+	"albums":         true,
+	"comments":       true,
+	"followers":      true,
+	"following":      true,
+	"likes":          true,
+	"popular-tracks": true,
+	"reposts":        true,
+	"sets":           true,
+	"tracks":         true,
+}
+
+// NormalizeURL checks that raw links to a single SoundCloud track and returns
+// its canonical form: https://soundcloud.com/<user>/<track>, keeping the
+// secret token of a private link. The scheme may be missing, www. and m.
+// hosts are accepted, and query parameters such as share tracking (?si=...,
+// ?utm_source=...) and fragments are dropped. Playlists, profiles and other
+// pages are rejected with ErrInvalidTrackURL.
+func NormalizeURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", fmt.Errorf("%w: empty URL", ErrInvalidTrackURL)
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidTrackURL, err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", fmt.Errorf("%w: unsupported scheme %q", ErrInvalidTrackURL, parsed.Scheme)
+	}
+	if !soundcloudHosts[strings.ToLower(parsed.Host)] {
+		return "", fmt.Errorf("%w: %q is not a SoundCloud link", ErrInvalidTrackURL, parsed.Host)
+	}
+
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(segments) < 2 || segments[0] == "" {
+		return "", fmt.Errorf("%w: expected https://soundcloud.com/artist/track", ErrInvalidTrackURL)
+	}
+	if reservedPaths[strings.ToLower(segments[0])] {
+		return "", fmt.Errorf("%w: %q is not a track page", ErrInvalidTrackURL, "/"+segments[0])
+	}
+	if strings.EqualFold(segments[1], "sets") {
+		return "", fmt.Errorf("%w: playlist links are not supported", ErrInvalidTrackURL)
+	}
+	if profilePages[strings.ToLower(segments[1])] {
+		return "", fmt.Errorf("%w: profile pages are not tracks", ErrInvalidTrackURL)
+	}
+
+	switch {
+	case len(segments) == 2:
+	case len(segments) == 3 && strings.HasPrefix(segments[2], "s-"):
+		// Private tracks are shared with a secret token after the permalink
+	default:
+		return "", fmt.Errorf("%w: expected https://soundcloud.com/artist/track", ErrInvalidTrackURL)
+	}
+
+	return "https://soundcloud.com/" + strings.Join(segments, "/"), nil
+}
@@ -0,0 +1,355 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/soundcloud"
+)
+
+// ErrOutOfRange is returned by MockAudioPlayer for volumes and seek positions
+// a real player would reject
+var ErrOutOfRange = errors.New("value out of range")
+
+// Call is a method call recorded by MockAudioPlayer
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// MockAudioPlayer implements audio.Player for testing. Its exported fields
+// are the state the player reports; every method call is appended to Calls.
+type MockAudioPlayer struct {
+	mu sync.Mutex
+
+	State     audio.PlayerState
+	Volume    float64
+	Position  time.Duration
+	Duration  time.Duration
+	EQ        [3]float64
+	Normalize bool
+	Levels    [2]float64
+
+	// Buffer health reported by BufferHealth; a zero total means no buffer
+	BufferAvailable int64
+	BufferTotal     int64
+	BufferCompleted bool
+
+	// SampleRate is reported by GetSampleRate
+	SampleRate int
+
+	ExpectedDuration time.Duration
+	ExpectedFormat   string
+
+	// Calls lists the methods called and their arguments, in order
+	Calls []Call
+}
+
+// NewMockAudioPlayer creates a stopped mock player at full volume, matching a
+// freshly created real player
+func NewMockAudioPlayer() *MockAudioPlayer {
+	return &MockAudioPlayer{
+		State:  audio.StateStopped,
+		Volume: 1.0,
+	}
+}
+
+func (m *MockAudioPlayer) record(method string, args ...interface{}) {
+	m.Calls = append(m.Calls, Call{Method: method, Args: args})
+}
+
+// CallCount returns how many times method was called
+func (m *MockAudioPlayer) CallCount(method string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	count := 0
+	for _, call := range m.Calls {
+		if call.Method == method {
+			count++
+		}
+	}
+	return count
+}
+
+// LastCall returns the most recent call to method, and false if it was
+// never called
+func (m *MockAudioPlayer) LastCall(method string) (Call, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := len(m.Calls) - 1; i >= 0; i-- {
+		if m.Calls[i].Method == method {
+			return m.Calls[i], true
+		}
+	}
+	return Call{}, false
+}
+
+func (m *MockAudioPlayer) Play(ctx context.Context, streamURL string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.record("Play", streamURL)
+	m.State = audio.StatePlaying
+	return nil
+}
+
+func (m *MockAudioPlayer) Pause() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.record("Pause")
+	m.State = audio.StatePaused
+	return nil
+}
+
+func (m *MockAudioPlayer) Resume() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.record("Resume")
+	if m.State == audio.StatePaused {
+		m.State = audio.StatePlaying
+	}
+	return nil
+}
+
+func (m *MockAudioPlayer) Stop() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.record("Stop")
+	m.State = audio.StateStopped
+	m.Position = 0
+	return nil
+}
+
+func (m *MockAudioPlayer) GetState() audio.PlayerState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.State
+}
+
+func (m *MockAudioPlayer) SetVolume(volume float64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.record("SetVolume", volume)
+	if volume < 0 || volume > 1 {
+		return ErrOutOfRange
+	}
+	m.Volume = volume
+	return nil
+}
+
+func (m *MockAudioPlayer) GetVolume() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.Volume
+}
+
+func (m *MockAudioPlayer) SetEQ(low, mid, high float64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.record("SetEQ", low, mid, high)
+	if err := audio.ValidateEQGains(low, mid, high); err != nil {
+		return err
+	}
+	m.EQ = [3]float64{low, mid, high}
+	return nil
+}
+
+func (m *MockAudioPlayer) GetEQ() (low, mid, high float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.EQ[0], m.EQ[1], m.EQ[2]
+}
+
+func (m *MockAudioPlayer) Seek(position time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.record("Seek", position)
+	if position < 0 || position > m.Duration {
+		return ErrOutOfRange
+	}
+	m.Position = position
+	return nil
+}
+
+func (m *MockAudioPlayer) GetPosition() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.Position
+}
+
+func (m *MockAudioPlayer) GetDuration() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.Duration
+}
+
+func (m *MockAudioPlayer) SetNormalization(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.record("SetNormalization", enabled)
+	m.Normalize = enabled
+}
+
+func (m *MockAudioPlayer) GetNormalization() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.Normalize
+}
+
+func (m *MockAudioPlayer) GetLevels() (left, right float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.Levels[0], m.Levels[1]
+}
+
+func (m *MockAudioPlayer) BufferHealth() (available, total int64, completed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.BufferAvailable, m.BufferTotal, m.BufferCompleted
+}
+
+func (m *MockAudioPlayer) GetSampleRate() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.SampleRate
+}
+
+func (m *MockAudioPlayer) SetExpectedDuration(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.record("SetExpectedDuration", d)
+	m.ExpectedDuration = d
+}
+
+func (m *MockAudioPlayer) SetExpectedFormat(format string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.record("SetExpectedFormat", format)
+	m.ExpectedFormat = format
+}
+
+func (m *MockAudioPlayer) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.record("Close")
+	m.State = audio.StateStopped
+	return nil
+}
+
+// MockStreamExtractor implements audio.StreamExtractor for testing. Without
+// an ExtractFunc it resolves every track to a fixed MP3 stream.
+type MockStreamExtractor struct {
+	ExtractFunc func(ctx context.Context, trackID int64) (*audio.StreamInfo, error)
+}
+
+func (m *MockStreamExtractor) ExtractStreamURL(ctx context.Context, trackID int64) (*audio.StreamInfo, error) {
+	if m.ExtractFunc != nil {
+		return m.ExtractFunc(ctx, trackID)
+	}
+	return &audio.StreamInfo{
+		URL:      "https://example.com/stream.mp3",
+		Format:   "mp3",
+		Quality:  "sq",
+		Duration: 240000,
+	}, nil
+}
+
+func (m *MockStreamExtractor) GetAvailableQualities(ctx context.Context, trackID int64) ([]string, error) {
+	return []string{"sq", "hq"}, nil
+}
+
+func (m *MockStreamExtractor) ValidateStreamURL(ctx context.Context, streamURL string) (bool, error) {
+	return true, nil
+}
+
+// MockSoundCloudClient implements soundcloud.ClientInterface for testing.
+// Lookups without a func set return no tracks, or a placeholder track, and
+// enrichment returns the track unchanged.
+type MockSoundCloudClient struct {
+	SearchFunc       func(query string) ([]soundcloud.Track, error)
+	ArtistTracksFunc func(user soundcloud.User) ([]soundcloud.Track, error)
+	UserTracksFunc   func(userID int64, limit, offset int) ([]soundcloud.Track, error)
+	RelatedFunc      func(trackID int64) ([]soundcloud.Track, error)
+	TrackByIDFunc    func(id int64) (*soundcloud.Track, error)
+	TrackInfoFunc    func(url string) (*soundcloud.Track, error)
+	EnrichFunc       func(track *soundcloud.Track) (*soundcloud.Track, error)
+
+	// SearchTotal is the total SearchWithTotal reports when it exceeds the
+	// number of tracks found
+	SearchTotal int
+}
+
+func (m *MockSoundCloudClient) Search(query string) ([]soundcloud.Track, error) {
+	if m.SearchFunc != nil {
+		return m.SearchFunc(query)
+	}
+	return []soundcloud.Track{}, nil
+}
+
+func (m *MockSoundCloudClient) SearchWithTotal(query string) ([]soundcloud.Track, int, error) {
+	tracks, err := m.Search(query)
+	total := len(tracks)
+	if m.SearchTotal > total {
+		total = m.SearchTotal
+	}
+	return tracks, total, err
+}
+
+func (m *MockSoundCloudClient) GetArtistTracks(user soundcloud.User) ([]soundcloud.Track, error) {
+	if m.ArtistTracksFunc != nil {
+		return m.ArtistTracksFunc(user)
+	}
+	return []soundcloud.Track{}, nil
+}
+
+func (m *MockSoundCloudClient) GetUserTracks(userID int64, limit, offset int) ([]soundcloud.Track, error) {
+	if m.UserTracksFunc != nil {
+		return m.UserTracksFunc(userID, limit, offset)
+	}
+	return []soundcloud.Track{}, nil
+}
+
+func (m *MockSoundCloudClient) GetRelatedTracks(trackID int64) ([]soundcloud.Track, error) {
+	if m.RelatedFunc != nil {
+		return m.RelatedFunc(trackID)
+	}
+	return []soundcloud.Track{}, nil
+}
+
+func (m *MockSoundCloudClient) GetTrackInfo(url string) (*soundcloud.Track, error) {
+	if m.TrackInfoFunc != nil {
+		return m.TrackInfoFunc(url)
+	}
+	return &soundcloud.Track{
+		ID:    123,
+		Title: "Test Track",
+		User:  soundcloud.User{Username: "Test Artist"},
+	}, nil
+}
+
+func (m *MockSoundCloudClient) GetTrackByID(id int64) (*soundcloud.Track, error) {
+	if m.TrackByIDFunc != nil {
+		return m.TrackByIDFunc(id)
+	}
+	return &soundcloud.Track{
+		ID:    id,
+		Title: "Test Track",
+		User:  soundcloud.User{Username: "Test Artist"},
+	}, nil
+}
+
+func (m *MockSoundCloudClient) EnrichTrack(track *soundcloud.Track) (*soundcloud.Track, error) {
+	if m.EnrichFunc != nil {
+		return m.EnrichFunc(track)
+	}
+	enriched := *track
+	return &enriched, nil
+}
+
+func (m *MockSoundCloudClient) GetDownloadURL(trackURL string, format string) (string, error) {
+	return "", errors.New("downloads not supported by mock client")
+}
@@ -0,0 +1,154 @@
+package trackvolume
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// DefaultMaxEntries is the default number of tracks whose volume is remembered
+const DefaultMaxEntries = 200
+
+// FileName is the file name of the remembered volumes inside the config dir
+const FileName = "track_volumes.json"
+
+// entry is the volume last used for a track
+type entry struct {
+	TrackID  int64   `json:"track_id"`
+	ArtistID int64   `json:"artist_id,omitempty"`
+	Volume   float64 `json:"volume"`
+}
+
+// Store remembers the volume last set for each track, capped to the most
+// recently adjusted tracks
+type Store struct {
+	mu         sync.RWMutex
+	path       string
+	maxEntries int
+	entries    []entry // Most recent first
+}
+
+// NewStore creates an empty store persisted at path.
+// An empty path keeps the volumes in memory only.
+func NewStore(path string, maxEntries int) *Store {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+
+	return &Store{
+		path:       path,
+		maxEntries: maxEntries,
+		entries:    []entry{},
+	}
+}
+
+// Load creates a store and reads any previously saved volumes from path.
+// A missing file is not an error and yields an empty store.
+func Load(path string, maxEntries int) (*Store, error) {
+	store := NewStore(path, maxEntries)
+	if path == "" {
+		return store, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return store, nil
+		}
+		return store, fmt.Errorf("failed to read track volumes: %w", err)
+	}
+
+	var entries []entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return store, fmt.Errorf("failed to parse track volumes: %w", err)
+	}
+
+	// Re-add oldest first so dedup and cap rules apply to the loaded data
+	for i := len(entries) - 1; i >= 0; i-- {
+		store.Remember(entries[i].TrackID, entries[i].ArtistID, entries[i].Volume)
+	}
+
+	return store, nil
+}
+
+// Remember records volume for a track by the artist with artistID (0 when
+// unknown), dropping the least recently adjusted tracks beyond the cap
+func (s *Store) Remember(trackID, artistID int64, volume float64) {
+	if trackID == 0 {
+		return
+	}
+	volume = min(max(volume, 0), 1)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := make([]entry, 0, len(s.entries)+1)
+	entries = append(entries, entry{TrackID: trackID, ArtistID: artistID, Volume: volume})
+	for _, existing := range s.entries {
+		if existing.TrackID != trackID {
+			entries = append(entries, existing)
+		}
+	}
+
+	if len(entries) > s.maxEntries {
+		entries = entries[:s.maxEntries]
+	}
+
+	s.entries = entries
+}
+
+// Lookup returns the volume remembered for a track, or failing that the one
+// most recently set for another track by the same artist
+func (s *Store) Lookup(trackID, artistID int64) (float64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, existing := range s.entries {
+		if existing.TrackID == trackID {
+			return existing.Volume, true
+		}
+	}
+	if artistID == 0 {
+		return 0, false
+	}
+	for _, existing := range s.entries {
+		if existing.ArtistID == artistID {
+			return existing.Volume, true
+		}
+	}
+	return 0, false
+}
+
+// Len returns the number of remembered tracks
+func (s *Store) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.entries)
+}
+
+// Save writes the remembered volumes to disk
+func (s *Store) Save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	s.mu.RLock()
+	data, err := json.MarshalIndent(s.entries, "", "  ")
+	s.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal track volumes: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create track volume directory: %w", err)
+	}
+
+	if err := os.WriteFile(s.path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write track volumes: %w", err)
+	}
+
+	return nil
+}
@@ -1,12 +1,30 @@
 package app
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"time"
+
 	"github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/clipboard"
+	"soundcloud-tui/internal/config"
+	"soundcloud-tui/internal/history"
+	"soundcloud-tui/internal/offline"
+	"soundcloud-tui/internal/opener"
+	"soundcloud-tui/internal/playlist"
+	"soundcloud-tui/internal/prefetch"
+	"soundcloud-tui/internal/session"
 	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/trackvolume"
 	"soundcloud-tui/internal/ui/components/player"
+	"soundcloud-tui/internal/ui/components/queue"
 	"soundcloud-tui/internal/ui/components/search"
 	"soundcloud-tui/internal/ui/styles"
 )
@@ -34,6 +52,72 @@ func (v ViewType) String() string {
 	}
 }
 
+// DefaultNotificationDuration is how long a toast notification stays visible
+const DefaultNotificationDuration = 4 * time.Second
+
+// DefaultErrorBannerDuration is how long a playback failure stays shown in the
+// search view unless a later success clears it sooner
+const DefaultErrorBannerDuration = 15 * time.Second
+
+// MaxQueueFailures is how many queue entries in a row may fail to play before
+// the queue stops skipping ahead
+const MaxQueueFailures = 3
+
+// nowPlayingBarWidth is the width of the progress bar in the now-playing line
+const nowPlayingBarWidth = 20
+
+// minAppHeight is the shortest terminal with room for the header, the
+// footer and a few lines of the current view
+const minAppHeight = 8
+
+// shutdownTimeout bounds how long quitting waits for the audio player to close
+const shutdownTimeout = 2 * time.Second
+
+// Search results this far behind and ahead of the selection are prefetched
+const (
+	prefetchBehind = 2
+	prefetchAhead  = 4
+)
+
+// idleCheckMsg wakes the app to check whether it has been idle for the idle
+// timeout
+type idleCheckMsg struct{}
+
+// notificationExpiredMsg dismisses the notification that expires at the given time
+type notificationExpiredMsg struct {
+	expiry time.Time
+}
+
+// radioTracksMsg delivers related tracks fetched to refill the queue
+type radioTracksMsg struct {
+	seed   soundcloud.Track
+	tracks []soundcloud.Track
+	err    error
+}
+
+// queueSavedMsg reports the outcome of saving the queue file
+type queueSavedMsg struct {
+	path  string
+	count int
+	err   error
+}
+
+// queueLoadedMsg delivers the tracks read from the queue file, and how many
+// of its entries couldn't be looked up
+type queueLoadedMsg struct {
+	path   string
+	tracks []soundcloud.Track
+	failed int
+	err    error
+}
+
+// resumeOfferMsg delivers the track of the saved session so the search view
+// can offer to resume it
+type resumeOfferMsg struct {
+	track    *soundcloud.Track
+	position time.Duration
+}
+
 // App represents the main application model
 type App struct {
 	// Window size
@@ -44,12 +128,69 @@ type App struct {
 	currentView ViewType
 	quitting    bool
 	
+	// Toast notification shown above the footer until it expires
+	notification         string
+	notificationIsError  bool
+	notificationExpiry   time.Time
+	notificationDuration time.Duration
+	
+	// Why the last selection failed to play, shown inline in the search view
+	// until errorBannerExpiry or the next successful action
+	errorBanner         string
+	errorBannerExpiry   time.Time
+	errorBannerDuration time.Duration
+	
 	// Components
 	searchComponent *search.SearchComponent
 	playerComponent *player.PlayerComponent
+	queueComponent  *queue.QueueComponent
+	
+	// Start the queue as soon as the program runs
+	autoplayQueue bool
+	
+	// Playback was paused because the terminal lost focus
+	autoPaused bool
+	
+	// Tracks started this session, which radio mode never queues again
+	playedIDs map[int64]bool
+	
+	// Queue entries that failed to play since the last one that started
+	queueFailures int
+	
+	// Persisted user preferences, search history and listening history
+	settings      *config.Settings
+	searchHistory *history.Store
+	playHistory   *history.Recorder
+	
+	// Last played track and position, saved periodically and on quit, and
+	// the offer to resume it shown at startup
+	sessionStore    *session.Store
+	lastSessionSave time.Time
+	resumeOffer     *resumeOfferMsg
+	
+	// Where 's' and 'l' in the queue view save and load the queue
+	queueFile string
+	
+	// Ensures audio teardown and state flushing run only once
+	shutdownOnce *sync.Once
+	
+	// Quit after idleTimeout without key input or playback; 0 never quits
+	idleTimeout  time.Duration
+	lastActivity time.Time
+	
+	// Why the configured output device couldn't be used, shown at startup
+	outputDeviceErr error
+	
+	// Full metadata of the search results around the selection, fetched
+	// ahead so picking one needs no lookup; nil without SoundCloud
+	prefetcher *prefetch.Manager
+	
+	// Stores listened-to tracks for offline play; nil when not caching
+	trackCache *offline.Cache
 	
 	// Dependencies
-	soundCloudClient soundcloud.ClientInterface
+	provider         search.Provider
+	soundCloudClient soundcloud.ClientInterface // Set when the provider is SoundCloud, for lookups by ID
 	audioPlayer      audio.Player
 	streamExtractor  audio.StreamExtractor
 }
@@ -57,38 +198,178 @@ type App struct {
 
 // NewApp creates a new application instance
 func NewApp() *App {
-	// Initialize SoundCloud client
-	client, _ := soundcloud.NewClient()
+	return NewAppWithPlayer(audio.DefaultPlayerKind)
+}
+
+// NewAppWithPlayer creates a new application instance on the default
+// backend that plays through the given kind of audio player
+func NewAppWithPlayer(kind audio.PlayerKind) *App {
+	// Restore saved preferences (a missing or unreadable file uses defaults)
+	settings, _ := config.LoadSettings(config.SettingsPath())
 	
-	// Initialize audio player with buffered streaming for better responsiveness
-	audioPlayer := audio.NewBufferedBeepPlayer()
+	backend, err := OpenBackend(DefaultBackend, settings)
+	if err != nil {
+		// Without SoundCloud the app still starts; searches and streams
+		// report the problem
+		backend = Backend{Extractor: audio.NewRealSoundCloudStreamExtractor(nil)}
+	}
 	
-	// Initialize real stream extractor with the SoundCloud client
-	streamExtractor := audio.NewRealSoundCloudStreamExtractor(client)
+	audioPlayer := audio.NewPlayer(kind, streamingOptions(settings.Streaming)...)
+	
+	return newApp(backend, audioPlayer, settings)
+}
+
+// NewAppWithNamedBackend creates an application instance on the backend
+// registered under name, such as OfflineBackend, that plays through the given
+// kind of audio player
+func NewAppWithNamedBackend(name string, kind audio.PlayerKind) (*App, error) {
+	// Restore saved preferences (a missing or unreadable file uses defaults)
+	settings, _ := config.LoadSettings(config.SettingsPath())
+	
+	backend, err := OpenBackend(name, settings)
+	if err != nil {
+		return nil, err
+	}
+	
+	audioPlayer := audio.NewPlayer(kind, streamingOptions(settings.Streaming)...)
+	
+	return newApp(backend, audioPlayer, settings), nil
+}
+
+// NewAppWithBackend creates an application instance that finds and streams
+// tracks through backend, such as one created by OpenBackend
+func NewAppWithBackend(backend Backend, audioPlayer audio.Player) *App {
+	// Restore saved preferences (a missing or unreadable file uses defaults)
+	settings, _ := config.LoadSettings(config.SettingsPath())
+	
+	return newApp(backend, audioPlayer, settings)
+}
+
+// NewAppWithDependencies creates an application instance around the given
+// client, audio player and stream extractor
+func NewAppWithDependencies(client soundcloud.ClientInterface, audioPlayer audio.Player, streamExtractor audio.StreamExtractor) *App {
+	return NewAppWithBackend(Backend{Provider: client, Extractor: streamExtractor}, audioPlayer)
+}
+
+// newApp wires the components around the backend and loaded settings
+func newApp(backend Backend, audioPlayer audio.Player, settings *config.Settings) *App {
+	// Lookups by track ID, used for enrichment and resuming, need SoundCloud
+	client, _ := backend.Provider.(soundcloud.ClientInterface)
+	streamExtractor := backend.Extractor
 	
 	// Initialize components
-	searchComponent := search.NewSearchComponent(client)
+	searchComponent := search.NewSearchComponent(backend.Provider)
+	searchComponent.SetTitleWidthRange(settings.Search.MinTitleWidth, settings.Search.MaxTitleWidth)
+	searchComponent.SetOffline(backend.Offline)
+	
+	// Load persisted search history (a missing or unreadable file starts fresh)
+	searchHistory, _ := history.Load(filepath.Join(config.ConfigDir(), history.SearchFileName), history.DefaultMaxEntries)
+	searchComponent.SetHistory(searchHistory)
+	
+	// Load the listening history (a missing or unreadable file starts fresh)
+	playHistory, _ := history.LoadRecorder(filepath.Join(config.ConfigDir(), history.PlaysFileName), history.DefaultMaxPlays)
 	playerComponent := player.NewPlayerComponent(audioPlayer, streamExtractor)
+	playerComponent.SetAutoResume(settings.Playback.AutoResume)
+	playerComponent.SetTickInterval(settings.Playback.ProgressInterval())
+	if settings.Playback.RememberVolume {
+		// A missing or unreadable file starts with no remembered volumes
+		volumes, _ := trackvolume.Load(filepath.Join(config.ConfigDir(), trackvolume.FileName), trackvolume.DefaultMaxEntries)
+		playerComponent.SetVolumeMemory(volumes)
+	}
+	
+	// Apply the saved equalizer and loudness normalization
+	_ = audioPlayer.SetEQ(settings.EQ.Low, settings.EQ.Mid, settings.EQ.High)
+	audioPlayer.SetNormalization(settings.Playback.Normalize)
+	
+	// Route sound to the saved output device where the platform supports it
+	var outputDeviceErr error
+	if selector, ok := audioPlayer.(audio.OutputDeviceSelector); ok && settings.Playback.OutputDevice != "" {
+		outputDeviceErr = selector.SetOutputDevice(settings.Playback.OutputDevice)
+	}
 	
 	return &App{
-		width:            80,
-		height:           24,
-		currentView:      ViewSearch,
-		quitting:         false,
-		searchComponent:  searchComponent,
-		playerComponent:  playerComponent,
-		soundCloudClient: client,
-		audioPlayer:      audioPlayer,
-		streamExtractor:  streamExtractor,
+		width:                80,
+		height:               24,
+		currentView:          ViewSearch,
+		quitting:             false,
+		searchComponent:      searchComponent,
+		playerComponent:      playerComponent,
+		queueComponent:       queue.NewQueueComponent(),
+		playedIDs:            make(map[int64]bool),
+		settings:             settings,
+		searchHistory:        searchHistory,
+		playHistory:          playHistory,
+		sessionStore:         session.NewStore(filepath.Join(config.ConfigDir(), session.FileName)),
+		queueFile:            filepath.Join(config.ConfigDir(), playlist.QueueFileName),
+		shutdownOnce:         &sync.Once{},
+		idleTimeout:          settings.Playback.IdleTimeout(),
+		lastActivity:         time.Now(),
+		provider:             backend.Provider,
+		soundCloudClient:     client,
+		prefetcher:           newPrefetcher(client),
+		trackCache:           backend.Cache,
+		audioPlayer:          audioPlayer,
+		streamExtractor:      streamExtractor,
+		notificationDuration: DefaultNotificationDuration,
+		errorBannerDuration:  DefaultErrorBannerDuration,
+		outputDeviceErr:      outputDeviceErr,
+	}
+}
+
+// streamingOptions turns saved streaming settings into audio player options
+func streamingOptions(streaming config.StreamingSettings) []audio.Option {
+	var opts []audio.Option
+	if size := streaming.PreloadSize(); size > 0 {
+		opts = append(opts, audio.WithPreloadSize(size))
+	}
+	if timeout := streaming.PreloadTimeout(); timeout > 0 {
+		opts = append(opts, audio.WithPreloadTimeout(timeout))
+	}
+	if interval := streaming.PositionInterval(); interval > 0 {
+		opts = append(opts, audio.WithPositionInterval(interval))
 	}
+	return opts
 }
 
 // Init initializes the application
 func (a *App) Init() tea.Cmd {
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		a.searchComponent.Init(),
 		a.playerComponent.Init(),
-	)
+		a.queueComponent.Init(),
+	}
+	
+	if a.autoplayQueue {
+		cmds = append(cmds, func() tea.Msg {
+			return player.NextTrackMsg{}
+		})
+	} else {
+		cmds = append(cmds, a.offerResume())
+	}
+	
+	if a.outputDeviceErr != nil {
+		cmds = append(cmds, a.showError(fmt.Sprintf("Output device: %v", a.outputDeviceErr)))
+	}
+	
+	if a.idleTimeout > 0 {
+		a.lastActivity = time.Now()
+		cmds = append(cmds, scheduleIdleCheck(a.idleTimeout))
+	}
+	
+	return tea.Batch(cmds...)
+}
+
+// LoadQueue appends tracks to the queue and starts playing them from the
+// player view once the program runs
+func (a *App) LoadQueue(tracks []soundcloud.Track) {
+	for _, track := range tracks {
+		a.queueComponent.Add(track)
+	}
+	
+	if len(tracks) > 0 {
+		a.autoplayQueue = true
+		a.currentView = ViewPlayer
+	}
 }
 
 // Update handles messages and updates the application state
@@ -97,11 +378,13 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		a.lastActivity = time.Now()
+		
 		// Global key handling
 		switch msg.Type {
 		case tea.KeyCtrlC:
 			a.quitting = true
-			return a, tea.Quit
+			return a, a.shutdown()
 			
 		case tea.KeyTab:
 			a.nextView()
@@ -112,6 +395,9 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, nil
 			
 		case tea.KeySpace:
+			// A manual play/pause overrides any pending auto-resume
+			a.autoPaused = false
+			
 			// Always pass space key to player component for play/pause
 			updatedPlayer, playerCmd := a.playerComponent.Update(msg)
 			a.playerComponent = updatedPlayer.(*player.PlayerComponent)
@@ -130,6 +416,12 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, tea.Batch(cmds...)
 			
 		case tea.KeyRunes:
+			// Jump straight to a view by number
+			if view, ok := viewForKey(string(msg.Runes)); ok && (msg.Alt || a.digitsSwitchViews()) {
+				a.currentView = view
+				return a, nil
+			}
+			
 			// Handle volume controls globally
 			if len(msg.Runes) > 0 {
 				switch string(msg.Runes) {
@@ -148,14 +440,22 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Pass key messages to current view
 		switch a.currentView {
 		case ViewSearch:
+			if cmd, handled := a.handleResumeOfferKey(msg); handled {
+				return a, cmd
+			}
+			
 			updatedSearch, cmd := a.searchComponent.Update(msg)
 			a.searchComponent = updatedSearch.(*search.SearchComponent)
 			if cmd != nil {
 				cmds = append(cmds, cmd)
 			}
+			a.warmAroundSelection()
 			
 			// Handle track selection from search
 			if selectedTrack := a.searchComponent.GetSelectedTrack(); selectedTrack != nil {
+				// Playing from search takes over from the queue
+				a.queueComponent.ClearCurrent()
+				
 				// Don't clear selection immediately - wait for playback result
 				playCmd := player.PlayTrackMsg{Track: selectedTrack}
 				updatedPlayer, playerCmd := a.playerComponent.Update(playCmd)
@@ -163,6 +463,9 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if playerCmd != nil {
 					cmds = append(cmds, playerCmd)
 				}
+				if enrichCmd := a.enrichTrack(selectedTrack); enrichCmd != nil {
+					cmds = append(cmds, enrichCmd)
+				}
 			}
 			
 		case ViewPlayer:
@@ -171,6 +474,13 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if cmd != nil {
 				cmds = append(cmds, cmd)
 			}
+			
+		case ViewQueue:
+			updatedQueue, cmd := a.queueComponent.Update(msg)
+			a.queueComponent = updatedQueue.(*queue.QueueComponent)
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
 		}
 		
 	case tea.WindowSizeMsg:
@@ -178,13 +488,20 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.height = msg.Height
 		
 		// Update component sizes
-		a.searchComponent.SetSize(msg.Width, msg.Height-4) // Reserve space for header/footer
-		a.playerComponent.SetSize(msg.Width, msg.Height-4)
+		a.searchComponent.SetSize(msg.Width, msg.Height-5) // Reserve space for header, now playing and footer
+		a.playerComponent.SetSize(msg.Width, msg.Height-5)
+		a.queueComponent.SetSize(msg.Width, msg.Height-5)
 		
 	case player.PlaybackStartedMsg:
 		// Playback started successfully - reset search state
+		a.clearErrorBanner()
+		a.queueFailures = 0
+		a.resumeOffer = nil
 		a.searchComponent.ClearSelection()
 		a.searchComponent.ResetToResults()
+		if msg.Track != nil {
+			a.playedIDs[msg.Track.ID] = true
+		}
 		// Switch to player view to show playback
 		a.currentView = ViewPlayer
 		return a, nil
@@ -193,8 +510,190 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Playback failed - reset search state and show error
 		a.searchComponent.ClearSelection()
 		a.searchComponent.ResetToResults()
+		
+		// Tracks SoundCloud won't stream here get a plain reason
+		err := msg.Error
+		if reason := unavailableReason(err); reason != nil {
+			err = reason
+		}
+		
 		// Stay in search view to let user try another track
-		// The error will be shown in the player component
+		// and surface the error inline instead of a full-screen view
+		a.setErrorBanner(msg.Track, err)
+		
+		if a.isCurrentQueueEntry(msg.Track) {
+			return a, a.skipFailedQueueEntry(msg.Track, err)
+		}
+		// The search view shows the banner, so a toast would repeat it
+		if a.currentView == ViewSearch {
+			return a, nil
+		}
+		return a, a.showError(fmt.Sprintf("Playback failed: %v", err))
+		
+	case search.SearchResultsMsg:
+		updatedSearch, searchCmd := a.searchComponent.Update(msg)
+		a.searchComponent = updatedSearch.(*search.SearchComponent)
+		if searchCmd != nil {
+			cmds = append(cmds, searchCmd)
+		}
+		if msg.Error != nil {
+			cmds = append(cmds, a.showError(fmt.Sprintf("Search failed: %v", msg.Error)))
+		} else {
+			a.clearErrorBanner()
+		}
+		a.warmAroundSelection()
+		
+	case player.StreamInfoMsg, player.ProgressUpdateMsg:
+		// Playback messages only concern the player
+		updatedPlayer, playerCmd := a.playerComponent.Update(msg)
+		a.playerComponent = updatedPlayer.(*player.PlayerComponent)
+		if playerCmd != nil {
+			cmds = append(cmds, playerCmd)
+		}
+		if saveCmd := a.saveSessionPeriodically(); saveCmd != nil {
+			cmds = append(cmds, saveCmd)
+		}
+		if a.playbackActive() {
+			a.lastActivity = time.Now()
+		}
+		
+	case player.TrackEnrichedMsg:
+		// Full metadata only concerns the player
+		updatedPlayer, playerCmd := a.playerComponent.Update(msg)
+		a.playerComponent = updatedPlayer.(*player.PlayerComponent)
+		return a, playerCmd
+		
+	case resumeOfferMsg:
+		// Only offer to resume while nothing else has started playing
+		if a.playerComponent.GetCurrentTrack() == nil {
+			a.resumeOffer = &msg
+		}
+		
+	case opener.OpenedMsg:
+		if msg.Error != nil {
+			return a, a.showError(fmt.Sprintf("Could not open browser: %v", msg.Error))
+		}
+		return a, a.showInfo("Opened in browser: " + msg.URL)
+		
+	case clipboard.CopiedMsg:
+		if msg.Error != nil {
+			// Without a clipboard, show the link so it can be copied by hand
+			return a, a.showInfo("Link: " + msg.Text)
+		}
+		return a, a.showInfo("Copied!")
+		
+	case search.AddToQueueMsg:
+		a.queueComponent.Add(msg.Track)
+		return a, a.showInfo(fmt.Sprintf("Added to queue: %s", msg.Track.Title))
+		
+	case search.AddTracksToQueueMsg:
+		added := a.queueComponent.AddAll(msg.Tracks)
+		if added == 0 {
+			return a, a.showInfo("All tracks are already in the queue")
+		}
+		if added == 1 {
+			return a, a.showInfo("Added 1 track to queue")
+		}
+		return a, a.showInfo(fmt.Sprintf("Added %d tracks to queue", added))
+		
+	case player.NextTrackMsg:
+		track, ok := a.queueComponent.Next()
+		if !ok {
+			// Radio mode refills the queue from what was playing last
+			if seed := a.playerComponent.GetCurrentTrack(); seed != nil && a.queueComponent.IsRadio() {
+				return a, a.loadRadioTracks(*seed)
+			}
+			return a, a.showInfo("End of queue")
+		}
+		return a, a.playTrack(track)
+		
+	case queue.RemovedCurrentMsg:
+		// Carry on with the entry that followed, or go idle if there is none
+		if track, ok := a.queueComponent.Next(); ok {
+			return a, a.playTrack(track)
+		}
+		a.queueComponent.ClearCurrent()
+		updatedPlayer, playerCmd := a.playerComponent.Update(player.StopMsg{})
+		a.playerComponent = updatedPlayer.(*player.PlayerComponent)
+		return a, playerCmd
+		
+	case radioTracksMsg:
+		return a, a.handleRadioTracks(msg)
+		
+	case queue.SaveQueueMsg:
+		return a, a.saveQueue(msg.Tracks)
+		
+	case queue.LoadQueueMsg:
+		return a, a.loadQueue()
+		
+	case queueSavedMsg:
+		if msg.err != nil {
+			return a, a.showError(fmt.Sprintf("Could not save queue: %v", msg.err))
+		}
+		return a, a.showInfo(fmt.Sprintf("Saved %s to %s", trackCount(msg.count), msg.path))
+		
+	case queueLoadedMsg:
+		return a, a.handleLoadedQueue(msg)
+		
+	case player.ShowRelatedMsg:
+		a.currentView = ViewSearch
+		return a, a.searchComponent.BrowseRelated(*msg.Track)
+		
+	case player.ShowArtistMsg:
+		a.currentView = ViewSearch
+		return a, a.searchComponent.BrowseArtist(msg.User)
+		
+	case player.PlaybackCompletedMsg:
+		// Keep going through the queue when the finished track came from it
+		if a.queueComponent.GetCurrentIndex() < 0 {
+			// With nothing queued, radio mode carries on from the finished track
+			if a.queueComponent.IsRadio() && a.queueComponent.Len() == 0 && msg.Track != nil {
+				return a, a.loadRadioTracks(*msg.Track)
+			}
+			return a, nil
+		}
+		if a.queueComponent.GetRepeatMode() == queue.RepeatOne && msg.Track != nil {
+			return a, a.playTrack(msg.Track)
+		}
+		return a.Update(player.NextTrackMsg{})
+		
+	case player.PreviousTrackMsg:
+		track, ok := a.queueComponent.Previous()
+		if !ok {
+			return a, a.showInfo("Start of queue")
+		}
+		return a, a.playTrack(track)
+		
+	case tea.BlurMsg:
+		return a, a.pauseOnBlur()
+		
+	case tea.FocusMsg:
+		return a, a.resumeOnFocus()
+		
+	case player.EQChangedMsg:
+		a.settings.EQ = config.EQSettings{Low: msg.Low, Mid: msg.Mid, High: msg.High}
+		return a, a.saveSettings()
+		
+	case player.NormalizationChangedMsg:
+		a.settings.Playback.Normalize = msg.Enabled
+		notice := "Loudness normalization off"
+		if msg.Enabled {
+			notice = "Loudness normalization on"
+		}
+		return a, tea.Batch(a.saveSettings(), a.showInfo(notice))
+		
+	case player.TrackHalfwayMsg:
+		// A track counts as listened to once half of it has played
+		return a, tea.Batch(a.recordPlay(msg.Track), a.cacheTrack(msg.Track))
+		
+	case idleCheckMsg:
+		return a, a.checkIdle()
+		
+	case notificationExpiredMsg:
+		// Only dismiss if no newer notification replaced this one
+		if msg.expiry.Equal(a.notificationExpiry) {
+			a.notification = ""
+		}
 		return a, nil
 		
 	default:
@@ -221,6 +720,11 @@ func (a *App) View() string {
 		return "Goodbye!\n"
 	}
 	
+	// Without room for the header and footer, only ask for a bigger terminal
+	if a.width < styles.MinViewWidth || a.height < minAppHeight {
+		return styles.RenderTooSmall(a.width)
+	}
+	
 	// Build the view
 	var view string
 	
@@ -232,22 +736,33 @@ func (a *App) View() string {
 	switch a.currentView {
 	case ViewSearch:
 		content = a.searchComponent.View()
+		if banner := a.renderErrorBanner(); banner != "" {
+			content = lipgloss.JoinVertical(lipgloss.Left, banner, content)
+		}
+		if offer := a.renderResumeOffer(); offer != "" {
+			content = lipgloss.JoinVertical(lipgloss.Left, offer, content)
+		}
 	case ViewPlayer:
 		content = a.playerComponent.View()
 	case ViewQueue:
-		content = "Queue view - Coming soon!"
+		content = a.queueComponent.View()
 	}
 	
 	// Footer
 	footer := a.renderFooter()
 	
-	// Combine all parts
-	view = lipgloss.JoinVertical(
-		lipgloss.Left,
-		header,
-		content,
-		footer,
-	)
+	// Combine all parts, with any active notification and the now-playing
+	// line just above the footer
+	parts := []string{header, content}
+	if toast := a.renderNotification(); toast != "" {
+		parts = append(parts, toast)
+	}
+	if nowPlaying := a.renderNowPlaying(); nowPlaying != "" {
+		parts = append(parts, nowPlaying)
+	}
+	parts = append(parts, footer)
+	
+	view = lipgloss.JoinVertical(lipgloss.Left, parts...)
 	
 	return view
 }
@@ -278,27 +793,641 @@ func (a *App) renderHeader() string {
 	return styles.HeaderStyle.Render(header)
 }
 
+// renderNowPlaying renders a one-line summary of the loaded track so it stays
+// visible from every view. It is empty when nothing is loaded.
+func (a *App) renderNowPlaying() string {
+	track := a.playerComponent.GetCurrentTrack()
+	if track == nil {
+		return ""
+	}
+	
+	var icon string
+	switch a.playerComponent.GetState() {
+	case player.StatePlaying:
+		icon = styles.Icon("▶", "[playing]")
+	case player.StatePaused:
+		icon = styles.Icon("⏸", "[paused]")
+	case player.StateLoading, player.StateBuffering:
+		icon = styles.Icon("⟳", "[loading]")
+	default:
+		icon = styles.Icon("⏹", "[stopped]")
+	}
+	
+	position := a.playerComponent.GetPosition()
+	duration := a.playerComponent.GetDuration()
+	var progress float64
+	if duration > 0 {
+		progress = float64(position) / float64(duration)
+	}
+	
+	line := fmt.Sprintf("%s %s - %s  %s %s / %s",
+		icon,
+		styles.TruncateText(track.Title, 40),
+		styles.TruncateText(track.Artist(), 25),
+		styles.RenderProgressBar(nowPlayingBarWidth, progress),
+		styles.FormatDurationFromTime(position),
+		styles.FormatDurationFromTime(duration),
+	)
+	
+	return styles.NowPlayingStyle.Render(line)
+}
+
 // renderFooter renders the application footer
 func (a *App) renderFooter() string {
-	helpText := "Tab: Next View • Shift+Tab: Previous View • Ctrl+C: Quit"
+	helpText := "Tab: Next View • Shift+Tab: Previous View"
+	if a.digitsSwitchViews() {
+		helpText += " • 1/2/3: Search/Player/Queue"
+	} else {
+		helpText += " • Alt+1/2/3: Search/Player/Queue"
+	}
+	helpText += " • Ctrl+C: Quit"
 	
 	// Add global audio controls (work from any view)
 	if a.playerComponent.GetCurrentTrack() != nil {
 		helpText += " • Space: Play/Pause • ←→: Seek • +/-: Volume"
 	}
+	if a.queueComponent.IsRadio() {
+		helpText += " • Radio: on"
+	}
 	
 	// Add view-specific help
 	switch a.currentView {
 	case ViewSearch:
-		helpText += " • Enter: Search • ↑↓: Navigate • Enter: Select"
+		helpText += " • Enter: Search • ↑↓/jk: Navigate • Enter: Select"
 	case ViewPlayer:
 		// Player-specific controls already shown above
 		helpText += ""
+	case ViewQueue:
+		helpText += " • ↑↓/jk: Navigate • Enter: Play"
 	}
 	
 	return styles.FooterStyle.Render(helpText)
 }
 
+// playTrack starts a track in the player
+func (a *App) playTrack(track *soundcloud.Track) tea.Cmd {
+	updatedPlayer, cmd := a.playerComponent.Update(player.PlayTrackMsg{Track: track})
+	a.playerComponent = updatedPlayer.(*player.PlayerComponent)
+	return cmd
+}
+
+// pauseOnBlur pauses playback when the terminal loses focus, if enabled
+func (a *App) pauseOnBlur() tea.Cmd {
+	if a.settings == nil || !a.settings.Playback.PauseOnFocusLoss || a.audioPlayer == nil {
+		return nil
+	}
+	
+	// Only auto-pause audible playback so a manual pause is left alone
+	state := a.audioPlayer.GetState()
+	if state != audio.StatePlaying && state != audio.StateBuffering {
+		return nil
+	}
+	
+	a.autoPaused = true
+	audioPlayer := a.audioPlayer
+	return func() tea.Msg {
+		if err := audioPlayer.Pause(); err != nil {
+			return fmt.Errorf("failed to pause: %w", err)
+		}
+		return player.ProgressUpdateMsg{
+			Position: audioPlayer.GetPosition(),
+			Duration: audioPlayer.GetDuration(),
+		}
+	}
+}
+
+// resumeOnFocus resumes playback on regaining focus, but only if it was
+// paused by pauseOnBlur
+func (a *App) resumeOnFocus() tea.Cmd {
+	if !a.autoPaused || a.audioPlayer == nil {
+		return nil
+	}
+	a.autoPaused = false
+	
+	if a.audioPlayer.GetState() != audio.StatePaused {
+		return nil
+	}
+	
+	audioPlayer := a.audioPlayer
+	return func() tea.Msg {
+		if err := audioPlayer.Resume(); err != nil {
+			return fmt.Errorf("failed to resume: %w", err)
+		}
+		return player.ProgressUpdateMsg{
+			Position: audioPlayer.GetPosition(),
+			Duration: audioPlayer.GetDuration(),
+		}
+	}
+}
+
+// loadRadioTracks fetches tracks related to seed for radio mode
+func (a *App) loadRadioTracks(seed soundcloud.Track) tea.Cmd {
+	provider := a.provider
+	return func() tea.Msg {
+		if provider == nil {
+			return radioTracksMsg{seed: seed, err: fmt.Errorf("no search provider available")}
+		}
+		tracks, err := provider.GetRelatedTracks(seed.ID)
+		return radioTracksMsg{seed: seed, tracks: tracks, err: err}
+	}
+}
+
+// saveQueue writes tracks to the queue file
+func (a *App) saveQueue(tracks []soundcloud.Track) tea.Cmd {
+	path := a.queueFile
+	return func() tea.Msg {
+		count, err := playlist.Write(path, tracks)
+		return queueSavedMsg{path: path, count: count, err: err}
+	}
+}
+
+// loadQueue reads the queue file, looking up entries that only carry a link
+func (a *App) loadQueue() tea.Cmd {
+	path := a.queueFile
+	var resolver playlist.Resolver
+	if a.soundCloudClient != nil {
+		resolver = a.soundCloudClient
+	}
+	return func() tea.Msg {
+		entries, err := playlist.Read(path)
+		if err != nil {
+			return queueLoadedMsg{path: path, err: err}
+		}
+		
+		msg := queueLoadedMsg{path: path}
+		for _, entry := range entries {
+			track, err := playlist.Resolve(entry, resolver)
+			if err != nil {
+				msg.failed++
+				continue
+			}
+			msg.tracks = append(msg.tracks, *track)
+		}
+		return msg
+	}
+}
+
+// handleLoadedQueue adds the tracks read from the queue file that aren't
+// queued yet
+func (a *App) handleLoadedQueue(msg queueLoadedMsg) tea.Cmd {
+	if errors.Is(msg.err, fs.ErrNotExist) {
+		return a.showInfo("No saved queue at " + msg.path)
+	}
+	if msg.err != nil {
+		return a.showError(fmt.Sprintf("Could not load queue: %v", msg.err))
+	}
+	
+	added := a.queueComponent.AddAll(msg.tracks)
+	message := fmt.Sprintf("Loaded %s from %s", trackCount(added), msg.path)
+	if msg.failed > 0 {
+		message += fmt.Sprintf(" (%d could not be found)", msg.failed)
+	}
+	return a.showInfo(message)
+}
+
+// trackCount formats a number of tracks, as in "1 track" or "3 tracks"
+func trackCount(n int) string {
+	if n == 1 {
+		return "1 track"
+	}
+	return fmt.Sprintf("%d tracks", n)
+}
+
+// handleRadioTracks enqueues related tracks that aren't queued or played yet
+// and plays the first. Radio stops once SoundCloud suggests nothing new, so it
+// can't cycle through the same handful of tracks forever.
+func (a *App) handleRadioTracks(msg radioTracksMsg) tea.Cmd {
+	if msg.err != nil {
+		return a.showError(fmt.Sprintf("Radio failed: %v", msg.err))
+	}
+	
+	added := 0
+	for _, track := range msg.tracks {
+		if track.ID == msg.seed.ID || a.playedIDs[track.ID] || a.queueComponent.Contains(track.ID) {
+			continue
+		}
+		a.queueComponent.Add(track)
+		added++
+	}
+	if added == 0 {
+		return a.showInfo("End of queue - no related tracks to add")
+	}
+	
+	track, ok := a.queueComponent.Next()
+	if !ok {
+		return nil
+	}
+	return tea.Batch(
+		a.showInfo(fmt.Sprintf("Radio: added %d related tracks", added)),
+		a.playTrack(track),
+	)
+}
+
+// Shutdown stops audio and flushes persisted state, for when the program
+// ended without the app quitting, e.g. on SIGINT. It waits for a teardown
+// already under way rather than repeating it.
+func (a *App) Shutdown() {
+	a.quitting = true
+	a.shutdown()()
+}
+
+// shutdown returns a command that stops audio and flushes persisted state
+// before quitting. Teardown runs at most once, however often it's requested.
+func (a *App) shutdown() tea.Cmd {
+	audioPlayer := a.audioPlayer
+	searchHistory := a.searchHistory
+	var settings *config.Settings
+	if a.settings != nil {
+		snapshot := *a.settings
+		settings = &snapshot
+	}
+	once := a.shutdownOnce
+	saveSession := a.sessionSaver()
+	
+	return func() tea.Msg {
+		once.Do(func() {
+			if audioPlayer != nil {
+				// Close can block on a fade-out; don't let a stuck speaker hold up quitting
+				closed := make(chan struct{})
+				go func() {
+					defer close(closed)
+					_ = audioPlayer.Close()
+				}()
+				
+				select {
+				case <-closed:
+				case <-time.After(shutdownTimeout):
+				}
+			}
+			
+			// Saving is best effort: quitting must not fail because of it
+			if searchHistory != nil {
+				_ = searchHistory.Save()
+			}
+			if settings != nil {
+				_ = settings.Save()
+			}
+			if saveSession != nil {
+				saveSession()
+			}
+		})
+		
+		return tea.Quit()
+	}
+}
+
+// enrichTrack fetches the full metadata of a track picked from a listing. A
+// failed lookup is dropped, leaving the listing's metadata in place.
+func (a *App) enrichTrack(track *soundcloud.Track) tea.Cmd {
+	if a.soundCloudClient == nil || track == nil || track.ID == 0 {
+		return nil
+	}
+	
+	if a.prefetcher != nil {
+		if enriched, ok := a.prefetcher.Get(track.ID); ok {
+			return func() tea.Msg {
+				return player.TrackEnrichedMsg{Track: enriched}
+			}
+		}
+	}
+	
+	client := a.soundCloudClient
+	return func() tea.Msg {
+		enriched, err := client.EnrichTrack(track)
+		if err != nil {
+			return nil
+		}
+		return player.TrackEnrichedMsg{Track: enriched}
+	}
+}
+
+// newPrefetcher returns a prefetcher looking tracks up through client, or
+// nil without SoundCloud
+func newPrefetcher(client soundcloud.ClientInterface) *prefetch.Manager {
+	if client == nil {
+		return nil
+	}
+	return prefetch.NewManager(client.EnrichTrack, prefetch.DefaultConcurrency)
+}
+
+// warmAroundSelection prefetches the full metadata of the search results
+// near the selection, dropping the previous warm-up when they changed
+func (a *App) warmAroundSelection() {
+	if a.prefetcher == nil {
+		return
+	}
+	
+	results := a.searchComponent.GetDisplayedResults()
+	if len(results) == 0 {
+		a.prefetcher.Cancel()
+		return
+	}
+	
+	selected := a.searchComponent.GetSelectedIndex()
+	start := max(0, selected-prefetchBehind)
+	end := min(len(results), selected+prefetchAhead+1)
+	a.prefetcher.Warm(results[start:end])
+}
+
+// sessionSaver returns a function that saves the playing track and position,
+// or nil when nothing worth resuming is playing
+func (a *App) sessionSaver() func() {
+	track := a.playerComponent.GetCurrentTrack()
+	if a.sessionStore == nil || track == nil || track.ID == 0 {
+		return nil
+	}
+	
+	switch a.playerComponent.GetState() {
+	case player.StatePlaying, player.StatePaused, player.StateBuffering:
+	default:
+		return nil
+	}
+	
+	store := a.sessionStore
+	trackID := track.ID
+	position := a.playerComponent.GetPosition()
+	return func() {
+		// Best effort: losing the resume point shouldn't interrupt playback
+		_ = store.SaveSession(trackID, position)
+	}
+}
+
+// playbackActive reports whether a track is playing or on its way to, which
+// keeps the app from timing out. A paused track counts as idle.
+func (a *App) playbackActive() bool {
+	switch a.playerComponent.GetState() {
+	case player.StatePlaying, player.StateBuffering, player.StateLoading:
+		return true
+	default:
+		return false
+	}
+}
+
+// scheduleIdleCheck wakes the app to check for idleness after d
+func scheduleIdleCheck(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return idleCheckMsg{}
+	})
+}
+
+// checkIdle quits once the app has gone the idle timeout without key input
+// or playback, and otherwise checks again when it next could have
+func (a *App) checkIdle() tea.Cmd {
+	if a.idleTimeout <= 0 || a.quitting {
+		return nil
+	}
+	
+	now := time.Now()
+	if a.playbackActive() {
+		a.lastActivity = now
+	}
+	
+	if remaining := a.lastActivity.Add(a.idleTimeout).Sub(now); remaining > 0 {
+		return scheduleIdleCheck(remaining)
+	}
+	
+	a.quitting = true
+	return a.shutdown()
+}
+
+// saveSessionPeriodically saves the playing position at most once every
+// session.SaveInterval
+func (a *App) saveSessionPeriodically() tea.Cmd {
+	if time.Since(a.lastSessionSave) < session.SaveInterval {
+		return nil
+	}
+	
+	saveSession := a.sessionSaver()
+	if saveSession == nil {
+		return nil
+	}
+	
+	a.lastSessionSave = time.Now()
+	return func() tea.Msg {
+		saveSession()
+		return nil
+	}
+}
+
+// offerResume looks up the track of the saved session so the search view can
+// offer to pick it up where it left off
+func (a *App) offerResume() tea.Cmd {
+	if a.sessionStore == nil || a.soundCloudClient == nil {
+		return nil
+	}
+	
+	store := a.sessionStore
+	client := a.soundCloudClient
+	return func() tea.Msg {
+		saved, err := store.LoadSession()
+		if err != nil || saved == nil || saved.Position <= 0 {
+			return nil
+		}
+		
+		track, err := client.GetTrackByID(saved.TrackID)
+		if err != nil {
+			return nil
+		}
+		
+		// A track that had all but finished is nothing to resume
+		duration := time.Duration(track.Duration) * time.Millisecond
+		if duration > 0 && saved.Position >= duration-2*time.Second {
+			return nil
+		}
+		
+		return resumeOfferMsg{track: track, position: saved.Position}
+	}
+}
+
+// handleResumeOfferKey resumes the saved session on Enter in an empty search
+// box and dismisses the offer on Esc
+func (a *App) handleResumeOfferKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if a.resumeOffer == nil {
+		return nil, false
+	}
+	
+	switch msg.Type {
+	case tea.KeyEnter:
+		if a.searchComponent.GetState() != search.StateInput || a.searchComponent.GetQuery() != "" {
+			return nil, false
+		}
+		
+		offer := a.resumeOffer
+		a.resumeOffer = nil
+		a.queueComponent.ClearCurrent()
+		updatedPlayer, cmd := a.playerComponent.Update(player.PlayTrackMsg{
+			Track:   offer.track,
+			StartAt: offer.position,
+		})
+		a.playerComponent = updatedPlayer.(*player.PlayerComponent)
+		return cmd, true
+		
+	case tea.KeyEsc:
+		a.resumeOffer = nil
+	}
+	
+	return nil, false
+}
+
+// renderResumeOffer renders the offer to resume the saved session while the
+// search box is empty
+func (a *App) renderResumeOffer() string {
+	if a.resumeOffer == nil || a.searchComponent.GetState() != search.StateInput || a.searchComponent.GetQuery() != "" {
+		return ""
+	}
+	
+	return styles.PlayingStatusStyle.Render(fmt.Sprintf("Press Enter to resume %s at %s (Esc to dismiss)",
+		styles.TruncateText(a.resumeOffer.track.Title, 50),
+		styles.FormatDurationFromTime(a.resumeOffer.position),
+	))
+}
+
+// saveSettings persists a snapshot of the settings in the background
+func (a *App) saveSettings() tea.Cmd {
+	settings := *a.settings
+	return func() tea.Msg {
+		// Best effort: failing to save preferences shouldn't interrupt playback
+		_ = settings.Save()
+		return nil
+	}
+}
+
+// recordPlay logs a listen of track to the listening history. Saving runs
+// as a command so the write never holds up playback.
+func (a *App) recordPlay(track *soundcloud.Track) tea.Cmd {
+	if a.playHistory == nil || track == nil {
+		return nil
+	}
+	
+	recorder := a.playHistory
+	played := *track
+	playedAt := time.Now()
+	return func() tea.Msg {
+		recorder.Record(played, playedAt)
+		// Best effort: failing to save the history shouldn't interrupt playback
+		_ = recorder.Save()
+		return nil
+	}
+}
+
+// cacheTrack stores track in the track cache so it can be played offline.
+// The stream is looked up again and downloaded in the background.
+func (a *App) cacheTrack(track *soundcloud.Track) tea.Cmd {
+	if a.trackCache == nil || a.streamExtractor == nil || track == nil {
+		return nil
+	}
+	
+	cache := a.trackCache
+	extractor := a.streamExtractor
+	played := *track
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+		
+		// Best effort: a track that can't be cached still played fine
+		if info, err := extractor.ExtractStreamURL(ctx, played.ID); err == nil {
+			_ = cache.Store(ctx, played, info)
+		}
+		return nil
+	}
+}
+
+// renderNotification renders the active toast notification, if any
+func (a *App) renderNotification() string {
+	if a.notification == "" {
+		return ""
+	}
+	
+	if a.notificationIsError {
+		return styles.ErrorStatusStyle.Render(styles.Icon("❌ ", "[error] ") + a.notification)
+	}
+	return styles.PlayingStatusStyle.Render(a.notification)
+}
+
+// setErrorBanner records why track failed to play for the search view
+func (a *App) setErrorBanner(track *soundcloud.Track, err error) {
+	a.errorBanner = fmt.Sprintf("Playback failed: %v", err)
+	if track != nil {
+		a.errorBanner = fmt.Sprintf("Couldn't play %q: %v", track.Title, err)
+	}
+	a.errorBannerExpiry = time.Now().Add(a.errorBannerDuration)
+}
+
+// isCurrentQueueEntry reports whether track is the queue's playing entry
+func (a *App) isCurrentQueueEntry(track *soundcloud.Track) bool {
+	current, ok := a.queueComponent.Current()
+	return ok && track != nil && current.ID == track.ID && current.StreamURL == track.StreamURL
+}
+
+// skipFailedQueueEntry moves on from a queue entry that failed to play. After
+// MaxQueueFailures failures in a row the queue stops instead, so a queue of
+// unplayable tracks (or one on repeat) doesn't keep trying forever.
+func (a *App) skipFailedQueueEntry(track *soundcloud.Track, err error) tea.Cmd {
+	a.queueFailures++
+	if a.queueFailures >= MaxQueueFailures {
+		a.queueFailures = 0
+		return a.showError(fmt.Sprintf("Stopped the queue after %d tracks in a row failed to play", MaxQueueFailures))
+	}
+	
+	return tea.Batch(
+		a.showError(fmt.Sprintf("Skipped %q: %v", track.Title, err)),
+		func() tea.Msg { return player.NextTrackMsg{} },
+	)
+}
+
+// unavailableReason returns ErrGeoBlocked or ErrPurchaseRequired when err
+// says a track can't be streamed at all, and nil for other failures
+func unavailableReason(err error) error {
+	for _, reason := range []error{audio.ErrGeoBlocked, audio.ErrPurchaseRequired} {
+		if errors.Is(err, reason) {
+			return reason
+		}
+	}
+	return nil
+}
+
+// clearErrorBanner removes the playback failure banner
+func (a *App) clearErrorBanner() {
+	a.errorBanner = ""
+	a.errorBannerExpiry = time.Time{}
+}
+
+// errorBannerActive reports whether the banner is set and not yet expired.
+// Expiry is checked when rendering, so no timer is needed.
+func (a *App) errorBannerActive() bool {
+	return a.errorBanner != "" && time.Now().Before(a.errorBannerExpiry)
+}
+
+// renderErrorBanner renders the playback failure banner while it is current
+func (a *App) renderErrorBanner() string {
+	if !a.errorBannerActive() {
+		return ""
+	}
+	return styles.ErrorStatusStyle.Render(styles.Icon("⚠ ", "[error] ") + a.errorBanner)
+}
+
+// showInfo displays an informational toast notification
+func (a *App) showInfo(message string) tea.Cmd {
+	return a.notify(message, false)
+}
+
+// showError displays an error toast notification
+func (a *App) showError(message string) tea.Cmd {
+	return a.notify(message, true)
+}
+
+// notify sets the toast notification and schedules its dismissal
+func (a *App) notify(message string, isError bool) tea.Cmd {
+	expiry := time.Now().Add(a.notificationDuration)
+	a.notification = message
+	a.notificationIsError = isError
+	a.notificationExpiry = expiry
+	
+	return tea.Tick(a.notificationDuration, func(time.Time) tea.Msg {
+		return notificationExpiredMsg{expiry: expiry}
+	})
+}
+
 // nextView switches to the next view in the cycle
 func (a *App) nextView() {
 	switch a.currentView {
@@ -323,6 +1452,32 @@ func (a *App) previousView() {
 	}
 }
 
+// viewForKey returns the view a number key jumps to
+func viewForKey(key string) (ViewType, bool) {
+	switch key {
+	case "1":
+		return ViewSearch, true
+	case "2":
+		return ViewPlayer, true
+	case "3":
+		return ViewQueue, true
+	}
+	return ViewSearch, false
+}
+
+// digitsSwitchViews reports whether plain number keys jump between views.
+// The player uses digits to seek and the search box needs them for typing,
+// so there only Alt+number switches.
+func (a *App) digitsSwitchViews() bool {
+	switch a.currentView {
+	case ViewPlayer:
+		return false
+	case ViewSearch:
+		return a.searchComponent.GetState() != search.StateInput
+	}
+	return true
+}
+
 // Getter methods for testing
 func (a *App) GetCurrentView() ViewType {
 	return a.currentView
@@ -338,4 +1493,43 @@ func (a *App) IsQuitting() bool {
 
 func (a *App) GetSize() (int, int) {
 	return a.width, a.height
+}
+
+func (a *App) GetSearchComponent() *search.SearchComponent {
+	return a.searchComponent
+}
+
+func (a *App) GetPlayerComponent() *player.PlayerComponent {
+	return a.playerComponent
+}
+
+func (a *App) GetQueueComponent() *queue.QueueComponent {
+	return a.queueComponent
+}
+
+// GetErrorBanner returns the playback failure shown in the search view, or ""
+func (a *App) GetErrorBanner() string {
+	if !a.errorBannerActive() {
+		return ""
+	}
+	return a.errorBanner
+}
+
+func (a *App) GetNotification() string {
+	return a.notification
+}
+
+// SetErrorBannerDuration changes how long playback failures stay in the search view
+func (a *App) SetErrorBannerDuration(d time.Duration) {
+	a.errorBannerDuration = d
+}
+
+// SetIdleTimeout changes how long the app may go without key input or
+// playback before quitting; 0 never quits. It takes effect from Init.
+func (a *App) SetIdleTimeout(d time.Duration) {
+	a.idleTimeout = d
+}
+
+func (a *App) SetNotificationDuration(d time.Duration) {
+	a.notificationDuration = d
 }
\ No newline at end of file
@@ -0,0 +1,114 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/config"
+	"soundcloud-tui/internal/offline"
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/ui/components/search"
+)
+
+// Backend is where the app finds tracks and how it plays them: the search
+// view lists tracks from Provider, and Extractor turns them into streams
+type Backend struct {
+	Provider  search.Provider
+	Extractor audio.StreamExtractor
+
+	// Offline marks a backend that only has tracks stored on this machine,
+	// which the search view points out
+	Offline bool
+	
+	// Cache, when set, stores the tracks listened to for the offline backend
+	Cache *offline.Cache
+}
+
+// BackendFactory creates a backend configured by the saved settings
+type BackendFactory func(settings *config.Settings) (Backend, error)
+
+// DefaultBackend names the backend the app uses unless told otherwise
+const DefaultBackend = "soundcloud"
+
+// OfflineBackend names the backend that plays only tracks in the local cache
+const OfflineBackend = "offline"
+
+var (
+	backendsMu sync.RWMutex
+	backends   = map[string]BackendFactory{
+		DefaultBackend: newSoundCloudBackend,
+		OfflineBackend: newOfflineBackend,
+	}
+)
+
+// RegisterBackend makes a backend available to OpenBackend under name,
+// replacing any registered under that name before
+func RegisterBackend(name string, factory BackendFactory) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	backends[name] = factory
+}
+
+// Backends lists the names of the registered backends in sorted order
+func Backends() []string {
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// OpenBackend creates the backend registered under name
+func OpenBackend(name string, settings *config.Settings) (Backend, error) {
+	backendsMu.RLock()
+	factory, ok := backends[name]
+	backendsMu.RUnlock()
+	if !ok {
+		return Backend{}, fmt.Errorf("unknown backend %q", name)
+	}
+	return factory(settings)
+}
+
+// newSoundCloudBackend searches SoundCloud and streams its tracks
+func newSoundCloudBackend(settings *config.Settings) (Backend, error) {
+	client, err := soundcloud.NewClient()
+	if err != nil {
+		return Backend{}, fmt.Errorf("failed to create SoundCloud client: %w", err)
+	}
+	client.SetMaxResults(settings.Search.MaxResults)
+
+	backend := Backend{
+		Provider:  client,
+		Extractor: audio.NewRealSoundCloudStreamExtractor(client),
+	}
+	
+	// Fill the track cache as tracks are played. An unreadable index is left
+	// alone rather than overwritten.
+	if index, err := offline.Load(filepath.Join(config.ConfigDir(), offline.DirName)); err == nil {
+		backend.Cache = offline.NewCache(index)
+	}
+	
+	return backend, nil
+}
+
+// newOfflineBackend searches the metadata of cached tracks and plays their
+// audio from disk, without connecting to SoundCloud
+func newOfflineBackend(settings *config.Settings) (Backend, error) {
+	index, err := offline.Load(filepath.Join(config.ConfigDir(), offline.DirName))
+	if err != nil {
+		return Backend{}, fmt.Errorf("failed to load track cache: %w", err)
+	}
+
+	return Backend{
+		Provider:  offline.NewProvider(index),
+		Extractor: offline.NewExtractor(index),
+		Offline:   true,
+	}, nil
+}
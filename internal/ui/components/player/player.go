@@ -3,13 +3,19 @@ package player
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/gopxl/beep"
 
 	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/clipboard"
+	"soundcloud-tui/internal/opener"
 	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/trackvolume"
 	"soundcloud-tui/internal/ui/styles"
 )
 
@@ -23,6 +29,7 @@ const (
 	StatePaused
 	StateCompleted
 	StateError
+	StateBuffering // Playing, but the stream buffer is running low
 )
 
 // String returns the string representation of State
@@ -40,6 +47,8 @@ func (s State) String() string {
 		return "completed"
 	case StateError:
 		return "error"
+	case StateBuffering:
+		return "buffering"
 	default:
 		return "unknown"
 	}
@@ -48,6 +57,9 @@ func (s State) String() string {
 // PlayTrackMsg represents a message to play a track
 type PlayTrackMsg struct {
 	Track *soundcloud.Track
+	
+	// StartAt is where playback starts once the stream is playing
+	StartAt time.Duration
 }
 
 // StreamInfoMsg represents stream info message
@@ -60,6 +72,10 @@ type StreamInfoMsg struct {
 type ProgressUpdateMsg struct {
 	Position time.Duration
 	Duration time.Duration
+	
+	// tick is the generation of the progress tick that sent this update,
+	// zero for updates sent after a user action
+	tick int
 }
 
 // PlaybackStartedMsg indicates that playback has successfully started
@@ -73,6 +89,133 @@ type PlaybackFailedMsg struct {
 	Error error
 }
 
+// PlaybackCompletedMsg indicates that a track played through to its end
+type PlaybackCompletedMsg struct {
+	Track *soundcloud.Track
+}
+
+// NextTrackMsg asks the app to skip to the next queue entry
+type NextTrackMsg struct{}
+
+// PreviousTrackMsg asks the app to go back to the previous queue entry
+type PreviousTrackMsg struct{}
+
+// StopMsg stops playback and leaves the player idle without a track
+type StopMsg struct{}
+
+// TrackEnrichedMsg carries the full metadata of a track fetched after it was
+// selected from a listing
+type TrackEnrichedMsg struct {
+	Track *soundcloud.Track
+}
+
+// LocalTrack returns a track that plays the local file at path, titled after
+// the file name
+func LocalTrack(path string) *soundcloud.Track {
+	name := filepath.Base(audio.LocalPath(path))
+	return &soundcloud.Track{
+		Title:     strings.TrimSuffix(name, filepath.Ext(name)),
+		StreamURL: path,
+	}
+}
+
+// FormatNowPlaying formats track for sharing in chat, as
+// "🎵 Title — Artist <url>". The artist and link are left out when unknown,
+// as for local files.
+func FormatNowPlaying(track *soundcloud.Track) string {
+	text := "🎵 " + track.Title
+	if artist := track.Artist(); artist != soundcloud.UnknownArtist {
+		text += " — " + artist
+	}
+	if track.PermalinkURL != "" {
+		text += " <" + track.PermalinkURL + ">"
+	}
+	return text
+}
+
+// ShowRelatedMsg asks the app to list tracks related to Track
+type ShowRelatedMsg struct {
+	Track *soundcloud.Track
+}
+
+// ShowArtistMsg asks the app to list the tracks of User
+type ShowArtistMsg struct {
+	User soundcloud.User
+}
+
+// EQChangedMsg reports new equalizer band gains (in dB) so they can be persisted
+type EQChangedMsg struct {
+	Low  float64
+	Mid  float64
+	High float64
+}
+
+// NormalizationChangedMsg reports that loudness normalization was turned on
+// or off so the choice can be persisted
+type NormalizationChangedMsg struct {
+	Enabled bool
+}
+
+// TrackHalfwayMsg is sent once per track when playback first reaches half its
+// length, for integrations such as scrobbling that count a track as listened
+type TrackHalfwayMsg struct {
+	Track *soundcloud.Track
+}
+
+// DownloadFailedMsg reports that the audio player gave up downloading the
+// playing stream after playback had started
+type DownloadFailedMsg struct {
+	Error error
+}
+
+// FormatChangedMsg carries the sample rate and channels of a stream the
+// audio player just started decoding
+type FormatChangedMsg struct {
+	Format beep.Format
+}
+
+// volumeChangedMsg carries the volume the audio player settled on after a
+// volume key, so the component only changes in Update
+type volumeChangedMsg struct {
+	volume float64
+}
+
+// seekCommitMsg fires once arrow-key scrubbing has been idle long enough to seek
+type seekCommitMsg struct {
+	generation int
+}
+
+// seekCommitDelay is how long the arrow keys must be idle before a scrub seeks
+const seekCommitDelay = 300 * time.Millisecond
+
+// endSeekMargin is how much of the track is left to play after End
+const endSeekMargin = 10 * time.Second
+
+// minPlayerHeight is the shortest the player panel is laid out for
+const minPlayerHeight = 8
+
+// DefaultTickInterval is how often progress refreshes during playback unless
+// configured otherwise
+const DefaultTickInterval = 250 * time.Millisecond
+
+// progressEasing is the share of the gap to the reported position that the
+// displayed position closes per progress tick
+const progressEasing = 0.5
+
+// HeartbeatInterval is how often progress is checked while nothing visibly
+// changes: while paused, or while the position isn't advancing. It still
+// catches state changes made outside the player, such as a stream ending.
+const HeartbeatInterval = time.Second
+
+// volumeBarWidth is the widest the volume slider next to the percentage gets
+const volumeBarWidth = 10
+
+// eqStep is the gain change (in dB) per +/- key press in the EQ panel
+const eqStep = 1.0
+
+// eqBandNames labels the equalizer bands in panel order
+var eqBandNames = []string{"Low", "Mid", "High"}
+
 // PlayerComponent represents the player view component
 type PlayerComponent struct {
 	// Size
@@ -88,15 +231,54 @@ type PlayerComponent struct {
 	volume          float64
 	error           error
 	prematureStopDetected bool    // Flag to track if we've already detected a premature stop
+	halfwayReported bool          // TrackHalfwayMsg was sent for the current track
+	autoResume      bool          // Restart a prematurely stopped stream without waiting for input
+	resumePosition  time.Duration // Where the next stream starts, to continue after a premature stop
+	tickInterval    time.Duration // Progress refresh interval during playback
+	tickGeneration  int           // Bumped per scheduled tick so only the latest tick chain runs
+	positionIdle    bool          // The last progress update didn't move the position
+	displayedPosition time.Duration // Smoothed position shown; only seeks move it back
+	
+	// Track that was active when a new one started loading, restored if loading fails
+	previousTrack   *soundcloud.Track
+	previousState   State
+	
+	// Pending arrow-key seek shown as a marker until it is committed
+	seekPreview     *time.Duration
+	seekGeneration  int  // Bumped per scrub step so only the last tick commits
+	seekCommitting  bool // Seek issued; the next progress update clears the preview
+	
+	// Equalizer panel
+	eqPanelOpen     bool
+	eqBand          int // Selected band index into eqBandNames
+	
+	// Stream info panel, for debugging playback
+	infoPanelOpen   bool
+	streamInfo      *audio.StreamInfo // Resolved stream of the current track
+	streamFormat    beep.Format       // Decoded format of the current track; zero until reported
+	
+	// Per-track volume memory; nil when it is off
+	volumeMemory     *trackvolume.Store
+	defaultVolume    float64 // Volume for tracks without one of their own
+	
+	// Failures the audio player reports after Play returns; nil when it
+	// can't report any
+	playerErrors    chan error
+	
+	// Formats of newly decoded streams, latest only; nil when the audio
+	// player can't report them
+	formatChanges   chan beep.Format
 	
 	// Dependencies
 	audioPlayer     audio.Player
 	streamExtractor audio.StreamExtractor
+	urlOpener       opener.Opener
+	clipboard       clipboard.Clipboard
 }
 
 // NewPlayerComponent creates a new player component
 func NewPlayerComponent(audioPlayer audio.Player, streamExtractor audio.StreamExtractor) *PlayerComponent {
-	return &PlayerComponent{
+	p := &PlayerComponent{
 		width:           80,
 		height:          20,
 		state:           StateIdle,
@@ -105,14 +287,81 @@ func NewPlayerComponent(audioPlayer audio.Player, streamExtractor audio.StreamEx
 		duration:        0,
 		volume:          1.0,
 		error:           nil,
+		tickInterval:    DefaultTickInterval,
 		audioPlayer:     audioPlayer,
 		streamExtractor: streamExtractor,
+		urlOpener:       opener.NewBrowserOpener(),
+		clipboard:       clipboard.NewSystemClipboard(),
 	}
+	
+	// Show the player's volume from the start rather than after the first update
+	if audioPlayer != nil {
+		p.volume = audioPlayer.GetVolume()
+	}
+	
+	if reporter, ok := audioPlayer.(audio.ErrorReporter); ok {
+		errs := make(chan error, 1)
+		reporter.SetErrorCallback(func(err error) {
+			// A failure still waiting to be handled already ends the track
+			select {
+			case errs <- err:
+			default:
+			}
+		})
+		p.playerErrors = errs
+	}
+	
+	if reporter, ok := audioPlayer.(audio.FormatReporter); ok {
+		formats := make(chan beep.Format, 1)
+		reporter.SetFormatChangeCallback(func(format beep.Format) {
+			// Replace a format still waiting to be handled; only the latest counts
+			for {
+				select {
+				case formats <- format:
+					return
+				default:
+				}
+				select {
+				case <-formats:
+				default:
+				}
+			}
+		})
+		p.formatChanges = formats
+	}
+	
+	return p
 }
 
 // Init initializes the player component
 func (p *PlayerComponent) Init() tea.Cmd {
-	return p.tickProgress()
+	return tea.Batch(p.tickProgress(), p.waitForPlayerError(), p.waitForFormatChange())
+}
+
+// waitForPlayerError delivers the next failure the audio player reports
+// after Play returned as a DownloadFailedMsg
+func (p *PlayerComponent) waitForPlayerError() tea.Cmd {
+	if p.playerErrors == nil {
+		return nil
+	}
+	
+	errs := p.playerErrors
+	return func() tea.Msg {
+		return DownloadFailedMsg{Error: <-errs}
+	}
+}
+
+// waitForFormatChange delivers the next format the audio player reports as
+// a FormatChangedMsg
+func (p *PlayerComponent) waitForFormatChange() tea.Cmd {
+	if p.formatChanges == nil {
+		return nil
+	}
+	
+	formats := p.formatChanges
+	return func() tea.Msg {
+		return FormatChangedMsg{Format: <-formats}
+	}
 }
 
 // Update handles messages and updates the player component
@@ -124,32 +373,88 @@ func (p *PlayerComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case PlayTrackMsg:
 		return p.handlePlayTrack(msg)
 		
+	case StopMsg:
+		return p.stop()
+		
+	case TrackEnrichedMsg:
+		// Only swap in the metadata if the track is still the one playing
+		if msg.Track != nil && p.currentTrack != nil && p.currentTrack.ID == msg.Track.ID {
+			p.currentTrack = msg.Track
+		}
+		return p, nil
+		
 	case StreamInfoMsg:
 		return p.handleStreamInfo(msg)
 		
 	case ProgressUpdateMsg:
+		// A newer tick superseded this one, e.g. after resuming from a pause
+		if msg.tick != 0 && msg.tick != p.tickGeneration {
+			return p, nil
+		}
+		// Ticks only keep running while a track is loaded for playback
+		if msg.tick != 0 && p.state != StatePlaying && p.state != StatePaused && p.state != StateBuffering {
+			return p, nil
+		}
+		// Updates after a user action, like resuming, always tick at full rate
+		p.positionIdle = msg.tick != 0 && msg.Position == p.position
 		p.position = msg.Position
+		p.updateDisplayedPosition(msg.tick != 0 && p.state != StateLoading)
 		p.duration = msg.Duration
+		// Only the seek's own update ends the preview; a tick read before the
+		// seek landed would snap the display back to the old position
+		if p.seekCommitting && msg.tick == 0 {
+			p.cancelSeekPreview()
+		}
 		
 		// If we were loading and got progress, transition to playing
 		if p.state == StateLoading {
 			p.state = StatePlaying
 			// Send playback started message
+			track := p.currentTrack
 			return p, tea.Batch(
 				p.tickProgress(),
 				func() tea.Msg {
 					return PlaybackStartedMsg{
-						Track: p.currentTrack,
+						Track: track,
 					}
 				},
 			)
 		}
 		
+		halfway := p.reportHalfway()
+		
 		// Sync state with audio player if available
 		if p.audioPlayer != nil {
+			wasCompleted := p.state == StateCompleted
+			wasStalled := p.prematureStopDetected
 			p.syncStateWithAudioPlayer()
+			p.updateBuffering()
+			
+			// Pick the stream back up where it stopped when unattended
+			if p.autoResume && !wasStalled && p.prematureStopDetected {
+				return p, tea.Batch(halfway, p.restartStream(p.position))
+			}
+			
+			// Report the end of the track once, not on every later tick
+			if !wasCompleted && p.state == StateCompleted {
+				track := p.currentTrack
+				return p, tea.Batch(
+					p.tickProgress(),
+					halfway,
+					func() tea.Msg {
+						return PlaybackCompletedMsg{Track: track}
+					},
+				)
+			}
 		}
-		return p, p.tickProgress()
+		return p, tea.Batch(p.tickProgress(), halfway)
+		
+	case seekCommitMsg:
+		return p.commitSeek(msg)
+		
+	case volumeChangedMsg:
+		p.volume = msg.volume
+		return p, nil
 		
 	case LoadingTimeoutMsg:
 		// Handle loading timeout
@@ -159,13 +464,37 @@ func (p *PlayerComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return p, nil
 		
+	case DownloadFailedMsg:
+		// Keep listening for later failures
+		listen := p.waitForPlayerError()
+		switch p.state {
+		case StatePlaying, StatePaused, StateBuffering, StateLoading:
+			p.state = StateError
+			p.error = fmt.Errorf("stream download failed: %w", msg.Error)
+			failedTrack, err := p.currentTrack, p.error
+			return p, tea.Batch(listen, func() tea.Msg {
+				return PlaybackFailedMsg{
+					Track: failedTrack,
+					Error: err,
+				}
+			})
+		}
+		// Nothing is playing that the failure could belong to
+		return p, listen
+		
+	case FormatChangedMsg:
+		p.streamFormat = msg.Format
+		// Keep listening for the next stream
+		return p, p.waitForFormatChange()
+		
 	case PlaybackErrorMsg:
 		// Handle playback errors
 		p.state = StateError
 		p.error = msg.Error
+		failedTrack := p.currentTrack
 		return p, func() tea.Msg {
 			return PlaybackFailedMsg{
-				Track: p.currentTrack,
+				Track: failedTrack,
 				Error: msg.Error,
 			}
 		}
@@ -192,6 +521,17 @@ func (p *PlayerComponent) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return p, nil
 	}
 	
+	if p.eqPanelOpen {
+		if model, cmd, handled := p.handleEQKey(msg); handled {
+			return model, cmd
+		}
+	}
+	if p.infoPanelOpen {
+		if model, cmd, handled := p.handleInfoKey(msg); handled {
+			return model, cmd
+		}
+	}
+	
 	switch msg.Type {
 	case tea.KeySpace:
 		return p.togglePlayPause()
@@ -202,62 +542,315 @@ func (p *PlayerComponent) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case tea.KeyRight:
 		return p.seekForward()
 		
+	case tea.KeyHome:
+		return p.restartTrack()
+		
+	case tea.KeyEnd:
+		return p.seekToEnd()
+		
 	case tea.KeyRunes:
 		switch string(msg.Runes) {
 		case "+", "=":
 			return p.increaseVolume()
 		case "-":
 			return p.decreaseVolume()
+		case "o":
+			return p.openInBrowser()
+		case "y":
+			return p.copyURL()
+		case "Y":
+			return p.copyNowPlaying()
+		case "e":
+			p.eqPanelOpen = true
+			p.infoPanelOpen = false
+			return p, nil
+		case "i":
+			p.infoPanelOpen = true
+			p.eqPanelOpen = false
+			return p, nil
+		case "N":
+			return p.toggleNormalization()
+		case "n":
+			return p, func() tea.Msg { return NextTrackMsg{} }
+		case "p":
+			return p, func() tea.Msg { return PreviousTrackMsg{} }
+		case "0", "1", "2", "3", "4", "5", "6", "7", "8", "9":
+			return p.seekToPercent(int(msg.Runes[0] - '0'))
+		case "r":
+			return p.restartTrack()
+		case "b":
+			return p.replayTrack()
+		case "R":
+			if p.currentTrack != nil {
+				track := p.currentTrack
+				return p, func() tea.Msg { return ShowRelatedMsg{Track: track} }
+			}
+		case "u":
+			// Local files have no artist to browse
+			if p.currentTrack != nil && (p.currentTrack.User.ID != 0 || p.currentTrack.User.Username != "") {
+				user := p.currentTrack.User
+				return p, func() tea.Msg { return ShowArtistMsg{User: user} }
+			}
 		}
 	}
 	
 	return p, nil
 }
 
+// handleEQKey handles keys while the equalizer panel is open.
+// It reports whether the key was consumed by the panel.
+func (p *PlayerComponent) handleEQKey(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		p.eqPanelOpen = false
+		return p, nil, true
+		
+	case tea.KeyUp:
+		if p.eqBand > 0 {
+			p.eqBand--
+		}
+		return p, nil, true
+		
+	case tea.KeyDown:
+		if p.eqBand < len(eqBandNames)-1 {
+			p.eqBand++
+		}
+		return p, nil, true
+		
+	case tea.KeyRunes:
+		switch string(msg.Runes) {
+		case "e":
+			p.eqPanelOpen = false
+			return p, nil, true
+		case "+", "=":
+			model, cmd := p.adjustEQ(eqStep)
+			return model, cmd, true
+		case "-":
+			model, cmd := p.adjustEQ(-eqStep)
+			return model, cmd, true
+		}
+	}
+	
+	return p, nil, false
+}
+
+// handleInfoKey handles keys while the stream info panel is open.
+// It reports whether the key was consumed by the panel.
+func (p *PlayerComponent) handleInfoKey(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		p.infoPanelOpen = false
+		return p, nil, true
+		
+	case tea.KeyRunes:
+		switch string(msg.Runes) {
+		case "i":
+			p.infoPanelOpen = false
+			return p, nil, true
+		case "c":
+			model, cmd := p.copyStreamURL()
+			return model, cmd, true
+		}
+	}
+	
+	return p, nil, false
+}
+
+// copyStreamURL copies the full URL of the current stream to the clipboard,
+// for bug reports
+func (p *PlayerComponent) copyStreamURL() (tea.Model, tea.Cmd) {
+	if p.streamInfo == nil || p.streamInfo.URL == "" {
+		return p, nil
+	}
+	return p, clipboard.CopyCmd(p.clipboard, p.streamInfo.URL)
+}
+
+// adjustEQ changes the selected band's gain by delta dB
+func (p *PlayerComponent) adjustEQ(delta float64) (tea.Model, tea.Cmd) {
+	gains := [3]float64{}
+	gains[0], gains[1], gains[2] = p.audioPlayer.GetEQ()
+	
+	gain := gains[p.eqBand] + delta
+	if gain > audio.MaxEQGain {
+		gain = audio.MaxEQGain
+	}
+	if gain < -audio.MaxEQGain {
+		gain = -audio.MaxEQGain
+	}
+	if gain == gains[p.eqBand] {
+		return p, nil
+	}
+	gains[p.eqBand] = gain
+	
+	if err := p.audioPlayer.SetEQ(gains[0], gains[1], gains[2]); err != nil {
+		return p, nil
+	}
+	
+	return p, func() tea.Msg {
+		return EQChangedMsg{Low: gains[0], Mid: gains[1], High: gains[2]}
+	}
+}
+
+// toggleNormalization turns loudness normalization on or off
+func (p *PlayerComponent) toggleNormalization() (tea.Model, tea.Cmd) {
+	enabled := !p.audioPlayer.GetNormalization()
+	p.audioPlayer.SetNormalization(enabled)
+	return p, func() tea.Msg {
+		return NormalizationChangedMsg{Enabled: enabled}
+	}
+}
+
+// openInBrowser opens the current track's SoundCloud page
+func (p *PlayerComponent) openInBrowser() (tea.Model, tea.Cmd) {
+	if p.currentTrack == nil {
+		return p, nil
+	}
+	return p, opener.OpenCmd(p.urlOpener, p.currentTrack.PermalinkURL)
+}
+
+// copyURL copies the current track's SoundCloud link to the clipboard
+func (p *PlayerComponent) copyURL() (tea.Model, tea.Cmd) {
+	if p.currentTrack == nil {
+		return p, nil
+	}
+	return p, clipboard.CopyCmd(p.clipboard, p.currentTrack.PermalinkURL)
+}
+
+// copyNowPlaying copies the current track, formatted for sharing, to the
+// clipboard
+func (p *PlayerComponent) copyNowPlaying() (tea.Model, tea.Cmd) {
+	if p.currentTrack == nil {
+		return p, nil
+	}
+	return p, clipboard.CopyCmd(p.clipboard, FormatNowPlaying(p.currentTrack))
+}
+
 // LoadingTimeoutMsg represents a loading timeout
 type LoadingTimeoutMsg struct{}
 
 // handlePlayTrack handles play track message
 func (p *PlayerComponent) handlePlayTrack(msg PlayTrackMsg) (tea.Model, tea.Cmd) {
+	// Remember what is still playing so a failed extraction doesn't lose it
+	p.previousTrack = nil
+	if p.currentTrack != nil && (p.state == StatePlaying || p.state == StatePaused || p.state == StateBuffering) {
+		p.previousTrack = p.currentTrack
+		p.previousState = p.state
+	}
+	
 	p.currentTrack = msg.Track
 	p.state = StateLoading
 	p.error = nil
 	p.prematureStopDetected = false // Reset flag for new track
+	p.halfwayReported = false
+	p.streamFormat = beep.Format{}
+	p.resumePosition = msg.StartAt
+	p.displayedPosition = msg.StartAt
+	p.cancelSeekPreview()
+	
+	return p, p.loadStream()
+}
+
+// loadStream looks up the current track's stream, with a loading timeout.
+// Local files need no stream extraction.
+func (p *PlayerComponent) loadStream() tea.Cmd {
+	if audio.IsLocalStream(p.currentTrack.StreamURL) {
+		streamInfo := &audio.StreamInfo{URL: p.currentTrack.StreamURL}
+		return tea.Batch(
+			func() tea.Msg {
+				return StreamInfoMsg{StreamInfo: streamInfo}
+			},
+			p.loadingTimeoutCmd(),
+		)
+	}
 	
 	if p.streamExtractor == nil {
 		p.state = StateError
 		p.error = fmt.Errorf("no stream extractor available")
-		return p, nil
+		return nil
 	}
 	
 	// Start loading with timeout
-	return p, tea.Batch(
-		p.extractStreamURL(msg.Track.ID),
+	return tea.Batch(
+		p.extractStreamURL(p.currentTrack.ID),
 		p.loadingTimeoutCmd(),
 	)
 }
 
+// stop halts the audio player and drops the current track
+func (p *PlayerComponent) stop() (tea.Model, tea.Cmd) {
+	p.currentTrack = nil
+	p.previousTrack = nil
+	p.state = StateIdle
+	p.position = 0
+	p.displayedPosition = 0
+	p.duration = 0
+	p.error = nil
+	p.prematureStopDetected = false
+	p.cancelSeekPreview()
+	
+	if p.audioPlayer == nil {
+		return p, nil
+	}
+	
+	return p, func() tea.Msg {
+		if err := p.audioPlayer.Stop(); err != nil {
+			return fmt.Errorf("failed to stop: %w", err)
+		}
+		return nil
+	}
+}
+
 // handleStreamInfo handles stream info message
 func (p *PlayerComponent) handleStreamInfo(msg StreamInfoMsg) (tea.Model, tea.Cmd) {
+	// An extractor returning neither a stream nor an error left nothing to play
+	if msg.Error == nil && msg.StreamInfo == nil {
+		msg.Error = fmt.Errorf("no stream found")
+	}
+	
 	if msg.Error != nil {
-		p.state = StateError
-		p.error = msg.Error
-		// Send playback failed message
-		return p, func() tea.Msg {
+		failedTrack := p.currentTrack
+		failedCmd := func() tea.Msg {
 			return PlaybackFailedMsg{
-				Track: p.currentTrack,
+				Track: failedTrack,
 				Error: msg.Error,
 			}
 		}
+		
+		// The previous stream was never stopped, so keep showing it
+		if p.previousTrack != nil {
+			p.currentTrack = p.previousTrack
+			p.state = p.previousState
+			p.previousTrack = nil
+			return p, tea.Batch(failedCmd, p.tickProgress())
+		}
+		
+		p.state = StateError
+		p.error = msg.Error
+		p.streamInfo = nil
+		// Send playback failed message
+		return p, failedCmd
 	}
+	p.previousTrack = nil
+	p.streamInfo = msg.StreamInfo
 	
-	// Store expected duration from SoundCloud metadata
-	if msg.StreamInfo != nil && msg.StreamInfo.Duration > 0 {
+	// Store expected duration from SoundCloud metadata; the audio player reports
+	// it as the total until the progressively decoded length catches up
+	p.expectedDuration = 0
+	if msg.StreamInfo.Duration > 0 {
 		p.expectedDuration = time.Duration(msg.StreamInfo.Duration) * time.Millisecond
 	}
+	if p.audioPlayer != nil {
+		p.audioPlayer.SetExpectedDuration(p.expectedDuration)
+		p.audioPlayer.SetExpectedFormat(msg.StreamInfo.Format)
+	}
+	
+	// playStream applies the volume to the new stream
+	p.restoreVolume()
 	
 	// Stay in loading state until playback actually starts
-	return p, p.playStream(msg.StreamInfo.URL)
+	resumeAt := p.resumePosition
+	p.resumePosition = 0
+	return p, p.playStream(msg.StreamInfo.URL, resumeAt)
 }
 
 // togglePlayPause toggles between play and pause
@@ -267,7 +860,7 @@ func (p *PlayerComponent) togglePlayPause() (tea.Model, tea.Cmd) {
 	}
 	
 	switch p.audioPlayer.GetState() {
-	case audio.StatePlaying:
+	case audio.StatePlaying, audio.StateBuffering:
 		return p, func() tea.Msg {
 			err := p.audioPlayer.Pause()
 			if err != nil {
@@ -297,22 +890,10 @@ func (p *PlayerComponent) togglePlayPause() (tea.Model, tea.Cmd) {
 			// Only restart from beginning if we're near the end or if duration is unknown
 			if p.position >= expectedDuration-2*time.Second || expectedDuration == 0 {
 				// Track completed - replay from beginning using normal flow with timeout
-				p.state = StateLoading
-				p.error = nil
-				p.prematureStopDetected = false
-				return p, tea.Batch(
-					p.extractStreamURL(p.currentTrack.ID),
-					p.loadingTimeoutCmd(),
-				)
+				return p, p.restartStream(0)
 			} else {
-				// Premature stop - restart using normal flow (simpler and more reliable)
-				p.state = StateLoading
-				p.error = nil
-				p.prematureStopDetected = false
-				return p, tea.Batch(
-					p.extractStreamURL(p.currentTrack.ID),
-					p.loadingTimeoutCmd(),
-				)
+				// Premature stop - restart using normal flow and continue where it stopped
+				return p, p.restartStream(p.position)
 			}
 		}
 		return p, nil
@@ -321,19 +902,92 @@ func (p *PlayerComponent) togglePlayPause() (tea.Model, tea.Cmd) {
 	}
 }
 
-// seekBackward seeks backward by 10 seconds
+// reportHalfway returns a command sending TrackHalfwayMsg the first time the
+// current track plays past half its length, or nil
+func (p *PlayerComponent) reportHalfway() tea.Cmd {
+	if p.halfwayReported || p.currentTrack == nil {
+		return nil
+	}
+	if p.state != StatePlaying && p.state != StateBuffering {
+		return nil
+	}
+	
+	duration := p.expectedDuration
+	if duration <= 0 {
+		duration = p.duration
+	}
+	if duration <= 0 || p.position < duration/2 {
+		return nil
+	}
+	
+	p.halfwayReported = true
+	track := p.currentTrack
+	return func() tea.Msg {
+		return TrackHalfwayMsg{Track: track}
+	}
+}
+
+// restartStream loads the current track's stream again and starts it at
+// resumeAt once it plays
+func (p *PlayerComponent) restartStream(resumeAt time.Duration) tea.Cmd {
+	p.state = StateLoading
+	p.error = nil
+	p.prematureStopDetected = false
+	p.resumePosition = resumeAt
+	return p.loadStream()
+}
+
+// seekBackward moves the seek preview back by 10 seconds
 func (p *PlayerComponent) seekBackward() (tea.Model, tea.Cmd) {
+	return p.scrub(-10 * time.Second)
+}
+
+// seekForward moves the seek preview forward by 10 seconds
+func (p *PlayerComponent) seekForward() (tea.Model, tea.Cmd) {
+	return p.scrub(10 * time.Second)
+}
+
+// scrub moves the seek preview by delta. Repeated presses accumulate and only
+// the position reached once the keys go idle is actually seeked to.
+func (p *PlayerComponent) scrub(delta time.Duration) (tea.Model, tea.Cmd) {
 	if p.audioPlayer == nil {
 		return p, nil
 	}
 	
-	newPos := p.position - 10*time.Second
+	newPos := p.position
+	if p.seekPreview != nil {
+		newPos = *p.seekPreview
+	}
+	newPos += delta
+	if newPos > p.duration {
+		newPos = p.duration
+	}
 	if newPos < 0 {
 		newPos = 0
 	}
 	
+	p.seekPreview = &newPos
+	p.seekCommitting = false
+	p.seekGeneration++
+	generation := p.seekGeneration
+	
+	return p, tea.Tick(seekCommitDelay, func(time.Time) tea.Msg {
+		return seekCommitMsg{generation: generation}
+	})
+}
+
+// commitSeek performs the seek for the preview once scrubbing went idle
+func (p *PlayerComponent) commitSeek(msg seekCommitMsg) (tea.Model, tea.Cmd) {
+	// A newer key press superseded this tick, or the preview was dropped
+	if msg.generation != p.seekGeneration || p.seekPreview == nil || p.audioPlayer == nil {
+		return p, nil
+	}
+	
+	target := *p.seekPreview
+	p.seekCommitting = true
+	
 	return p, func() tea.Msg {
-		err := p.audioPlayer.Seek(newPos)
+		err := p.audioPlayer.Seek(target)
 		if err != nil {
 			return fmt.Errorf("failed to seek: %w", err)
 		}
@@ -344,18 +998,79 @@ func (p *PlayerComponent) seekBackward() (tea.Model, tea.Cmd) {
 	}
 }
 
-// seekForward seeks forward by 10 seconds
-func (p *PlayerComponent) seekForward() (tea.Model, tea.Cmd) {
-	if p.audioPlayer == nil {
+// updateDisplayedPosition eases the displayed position toward the reported
+// one during playback without ever moving it back, as the wall-clock tracker
+// and the decoder can disagree slightly. Other updates, like the one after a
+// seek or the first of a new track, are shown as reported.
+func (p *PlayerComponent) updateDisplayedPosition(smooth bool) {
+	if !smooth {
+		p.displayedPosition = p.position
+		return
+	}
+	if p.position <= p.displayedPosition {
+		return
+	}
+	p.displayedPosition += time.Duration(float64(p.position-p.displayedPosition) * progressEasing)
+}
+
+// cancelSeekPreview drops any pending scrub, including its commit tick
+func (p *PlayerComponent) cancelSeekPreview() {
+	p.seekPreview = nil
+	p.seekCommitting = false
+	p.seekGeneration++
+}
+
+// seekToPercent jumps to digit*10% of the track
+func (p *PlayerComponent) seekToPercent(digit int) (tea.Model, tea.Cmd) {
+	duration, ok := p.jumpableDuration()
+	if !ok {
+		return p, nil
+	}
+	return p, p.jumpTo(duration*time.Duration(digit)/10, duration)
+}
+
+// seekToEnd jumps to endSeekMargin before the end of the track, or to its
+// start when the track is shorter than that
+func (p *PlayerComponent) seekToEnd() (tea.Model, tea.Cmd) {
+	duration, ok := p.jumpableDuration()
+	if !ok {
 		return p, nil
 	}
+	return p, p.jumpTo(duration-endSeekMargin, duration)
+}
+
+// jumpableDuration returns the duration of the current track, and false when
+// there is no loaded track of known length to jump within
+func (p *PlayerComponent) jumpableDuration() (time.Duration, bool) {
+	if p.audioPlayer == nil || p.currentTrack == nil {
+		return 0, false
+	}
+	if p.state != StatePlaying && p.state != StatePaused && p.state != StateBuffering {
+		return 0, false
+	}
 	
-	newPos := p.position + 10*time.Second
-	if newPos > p.duration {
-		newPos = p.duration
+	duration := p.audioPlayer.GetDuration()
+	if duration <= 0 {
+		duration = p.duration
+	}
+	if duration <= 0 {
+		return 0, false // Unknown duration, nothing sensible to jump to
+	}
+	return duration, true
+}
+
+// jumpTo seeks straight to newPos, clamped to the track, dropping any
+// pending scrub
+func (p *PlayerComponent) jumpTo(newPos, duration time.Duration) tea.Cmd {
+	p.cancelSeekPreview()
+	if newPos < 0 {
+		newPos = 0
+	}
+	if newPos > duration {
+		newPos = duration
 	}
 	
-	return p, func() tea.Msg {
+	return func() tea.Msg {
 		err := p.audioPlayer.Seek(newPos)
 		if err != nil {
 			return fmt.Errorf("failed to seek: %w", err)
@@ -367,29 +1082,66 @@ func (p *PlayerComponent) seekForward() (tea.Model, tea.Cmd) {
 	}
 }
 
-// increaseVolume increases volume by 10%
-func (p *PlayerComponent) increaseVolume() (tea.Model, tea.Cmd) {
-	if p.audioPlayer == nil {
-		// Even without audio player, update local volume for UI feedback
-		p.volume = p.volume + 0.1
-		if p.volume > 1.0 {
-			p.volume = 1.0
+// restartTrack seeks back to the start of the current track, keeping the
+// playing or paused state and the already extracted stream
+func (p *PlayerComponent) restartTrack() (tea.Model, tea.Cmd) {
+	if p.audioPlayer == nil || p.currentTrack == nil {
+		return p, nil
+	}
+	if p.state != StatePlaying && p.state != StatePaused && p.state != StateBuffering {
+		return p, nil
+	}
+	
+	// Show the jump right away rather than on the next progress tick
+	p.position = 0
+	p.displayedPosition = 0
+	p.cancelSeekPreview()
+	
+	return p, func() tea.Msg {
+		err := p.audioPlayer.Seek(0)
+		if err != nil {
+			return fmt.Errorf("failed to restart track: %w", err)
 		}
+		return ProgressUpdateMsg{
+			Position: p.audioPlayer.GetPosition(),
+			Duration: p.audioPlayer.GetDuration(),
+		}
+	}
+}
+
+// replayTrack plays the current track again from the start. A loaded stream
+// is seeked back and resumed if paused. Once the audio player has stopped or
+// the track has finished there is no stream left to seek in, so it is
+// loaded again and played.
+func (p *PlayerComponent) replayTrack() (tea.Model, tea.Cmd) {
+	if p.audioPlayer == nil || p.currentTrack == nil {
 		return p, nil
 	}
 	
-	newVolume := p.volume + 0.1
-	if newVolume > 1.0 {
-		newVolume = 1.0
+	loaded := p.state == StatePlaying || p.state == StatePaused || p.state == StateBuffering
+	stopped := p.state == StateCompleted || (loaded && p.audioPlayer.GetState() == audio.StateStopped)
+	if !loaded && !stopped {
+		return p, nil
+	}
+	
+	// Show the jump right away rather than on the next progress tick
+	p.position = 0
+	p.displayedPosition = 0
+	p.cancelSeekPreview()
+	
+	if stopped {
+		return p, p.restartStream(0)
 	}
 	
 	return p, func() tea.Msg {
-		err := p.audioPlayer.SetVolume(newVolume)
-		if err != nil {
-			return fmt.Errorf("failed to set volume: %w", err)
+		if err := p.audioPlayer.Seek(0); err != nil {
+			return fmt.Errorf("failed to restart track: %w", err)
+		}
+		if p.audioPlayer.GetState() == audio.StatePaused {
+			if err := p.audioPlayer.Resume(); err != nil {
+				return fmt.Errorf("failed to resume: %w", err)
+			}
 		}
-		// Update local volume tracking
-		p.volume = p.audioPlayer.GetVolume()
 		return ProgressUpdateMsg{
 			Position: p.audioPlayer.GetPosition(),
 			Duration: p.audioPlayer.GetDuration(),
@@ -397,36 +1149,73 @@ func (p *PlayerComponent) increaseVolume() (tea.Model, tea.Cmd) {
 	}
 }
 
+// increaseVolume increases volume by 10%
+func (p *PlayerComponent) increaseVolume() (tea.Model, tea.Cmd) {
+	return p.setVolume(p.volume + 0.1)
+}
+
 // decreaseVolume decreases volume by 10%
 func (p *PlayerComponent) decreaseVolume() (tea.Model, tea.Cmd) {
+	return p.setVolume(p.volume - 0.1)
+}
+
+// setVolume applies volume, clamped to 0-1, and reports the level the audio
+// player settled on in a volumeChangedMsg
+func (p *PlayerComponent) setVolume(volume float64) (tea.Model, tea.Cmd) {
+	volume = min(max(volume, 0), 1)
 	if p.audioPlayer == nil {
 		// Even without audio player, update local volume for UI feedback
-		p.volume = p.volume - 0.1
-		if p.volume < 0.0 {
-			p.volume = 0.0
-		}
+		p.volume = volume
 		return p, nil
 	}
 	
-	newVolume := p.volume - 0.1
-	if newVolume < 0.0 {
-		newVolume = 0.0
-	}
-	
+	audioPlayer := p.audioPlayer
+	remember := p.rememberVolume(volume)
 	return p, func() tea.Msg {
-		err := p.audioPlayer.SetVolume(newVolume)
+		err := audioPlayer.SetVolume(volume)
 		if err != nil {
 			return fmt.Errorf("failed to set volume: %w", err)
 		}
-		// Update local volume tracking
-		p.volume = p.audioPlayer.GetVolume()
-		return ProgressUpdateMsg{
-			Position: p.audioPlayer.GetPosition(),
-			Duration: p.audioPlayer.GetDuration(),
-		}
+		remember()
+		return volumeChangedMsg{volume: audioPlayer.GetVolume()}
 	}
 }
 
+// rememberVolume returns a function that records volume for the current
+// track once it has been applied. With no track loaded the change sets the
+// default volume instead; with volume memory off it does nothing.
+func (p *PlayerComponent) rememberVolume(volume float64) func() {
+	if p.volumeMemory == nil {
+		return func() {}
+	}
+	if p.currentTrack == nil {
+		p.defaultVolume = volume
+		return func() {}
+	}
+	
+	store := p.volumeMemory
+	trackID, artistID := p.currentTrack.ID, p.currentTrack.User.ID
+	return func() {
+		store.Remember(trackID, artistID, volume)
+		// Persisting is best-effort and must not fail the volume change
+		_ = store.Save()
+	}
+}
+
+// restoreVolume picks the volume the current track starts at: the one
+// remembered for it or its artist, or the default for tracks without one
+func (p *PlayerComponent) restoreVolume() {
+	if p.volumeMemory == nil || p.currentTrack == nil {
+		return
+	}
+	
+	volume, ok := p.volumeMemory.Lookup(p.currentTrack.ID, p.currentTrack.User.ID)
+	if !ok {
+		volume = p.defaultVolume
+	}
+	p.volume = volume
+}
+
 // extractStreamURL extracts the stream URL for a track
 func (p *PlayerComponent) extractStreamURL(trackID int64) tea.Cmd {
 	return func() tea.Msg {
@@ -448,7 +1237,8 @@ type PlaybackErrorMsg struct {
 }
 
 // playStream starts playing a stream
-func (p *PlayerComponent) playStream(streamURL string) tea.Cmd {
+func (p *PlayerComponent) playStream(streamURL string, resumeAt time.Duration) tea.Cmd {
+	volume := p.volume
 	return func() tea.Msg {
 		// Use shorter timeout to prevent hanging the TUI
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -461,6 +1251,15 @@ func (p *PlayerComponent) playStream(streamURL string) tea.Cmd {
 			}
 		}
 		
+		// Play builds a new audio pipeline, so carry the user's volume over
+		// to it, e.g. when a prematurely stopped stream is restarted
+		_ = p.audioPlayer.SetVolume(volume)
+		
+		if resumeAt > 0 {
+			// Play from the start if the stream can't seek that far yet
+			_ = p.audioPlayer.Seek(resumeAt)
+		}
+		
 		return ProgressUpdateMsg{
 			Position: p.audioPlayer.GetPosition(),
 			Duration: p.audioPlayer.GetDuration(),
@@ -468,20 +1267,54 @@ func (p *PlayerComponent) playStream(streamURL string) tea.Cmd {
 	}
 }
 
-// tickProgress returns a command that sends progress updates
+// TickInterval returns how long until the next progress update in the
+// current state: the heartbeat while paused or while the position stands
+// still, the configured interval otherwise
+func (p *PlayerComponent) TickInterval() time.Duration {
+	idle := p.state == StatePaused || p.positionIdle
+	if idle && p.tickInterval < HeartbeatInterval {
+		return HeartbeatInterval
+	}
+	return p.tickInterval
+}
+
+// tickProgress returns a command that sends progress updates. Scheduling a
+// tick supersedes any tick still pending, so only one tick chain runs.
 func (p *PlayerComponent) tickProgress() tea.Cmd {
-	// Use shorter interval for smoother progress updates
-	return tea.Tick(250*time.Millisecond, func(t time.Time) tea.Msg {
-		if p.audioPlayer != nil && (p.state == StatePlaying || p.state == StatePaused) {
-			return ProgressUpdateMsg{
-				Position: p.audioPlayer.GetPosition(),
-				Duration: p.audioPlayer.GetDuration(),
-			}
-		}
+	p.tickGeneration++
+	generation := p.tickGeneration
+	
+	audioPlayer := p.audioPlayer
+	if audioPlayer == nil {
 		return nil
+	}
+	
+	// Update drops the tick, ending the chain, once nothing is playing
+	return tea.Tick(p.TickInterval(), func(t time.Time) tea.Msg {
+		return ProgressUpdateMsg{
+			Position: audioPlayer.GetPosition(),
+			Duration: audioPlayer.GetDuration(),
+			tick:     generation,
+		}
 	})
 }
 
+// updateBuffering switches between playing and buffering as the stream
+// buffer drains and refills, or the audio player reports a stall. Buffering
+// only ends once the buffer is well above the low mark, so brief dips around
+// it don't make the display flicker.
+func (p *PlayerComponent) updateBuffering() {
+	available, total, completed := p.audioPlayer.BufferHealth()
+	stalled := p.audioPlayer.GetState() == audio.StateBuffering
+	
+	switch {
+	case p.state == StatePlaying && (stalled || audio.BufferLow(available, total, completed)):
+		p.state = StateBuffering
+	case p.state == StateBuffering && !stalled && audio.BufferRecovered(available, total, completed):
+		p.state = StatePlaying
+	}
+}
+
 // syncStateWithAudioPlayer synchronizes the UI state with the audio player state
 func (p *PlayerComponent) syncStateWithAudioPlayer() {
 	if p.audioPlayer == nil {
@@ -491,16 +1324,21 @@ func (p *PlayerComponent) syncStateWithAudioPlayer() {
 	audioState := p.audioPlayer.GetState()
 	switch audioState {
 	case audio.StatePlaying:
-		if p.state != StatePlaying && p.state != StateLoading {
+		if p.state != StatePlaying && p.state != StateLoading && p.state != StateBuffering {
 			p.state = StatePlaying
 			p.prematureStopDetected = false // Reset flag when playback starts
 		}
+	case audio.StateBuffering:
+		// The player stalled waiting for data
+		if p.state == StatePlaying || p.state == StatePaused {
+			p.state = StateBuffering
+		}
 	case audio.StatePaused:
-		if p.state == StatePlaying {
+		if p.state == StatePlaying || p.state == StateBuffering {
 			p.state = StatePaused
 		}
 	case audio.StateStopped:
-		if p.state == StatePlaying || p.state == StatePaused {
+		if p.state == StatePlaying || p.state == StatePaused || p.state == StateBuffering {
 			// Only mark as completed if we're near the end of the track
 			// Otherwise it might be a temporary stop due to buffering/network issues
 			if p.currentTrack != nil {
@@ -531,11 +1369,13 @@ func (p *PlayerComponent) syncStateWithAudioPlayer() {
 func (p *PlayerComponent) handleError(err error) (tea.Model, tea.Cmd) {
 	p.state = StateError
 	p.error = err
+	p.cancelSeekPreview()
 	// Send playback failed message if we have a current track
 	if p.currentTrack != nil {
+		failedTrack := p.currentTrack
 		return p, func() tea.Msg {
 			return PlaybackFailedMsg{
-				Track: p.currentTrack,
+				Track: failedTrack,
 				Error: err,
 			}
 		}
@@ -545,12 +1385,23 @@ func (p *PlayerComponent) handleError(err error) (tea.Model, tea.Cmd) {
 
 // View renders the player component
 func (p *PlayerComponent) View() string {
+	if p.width < styles.MinViewWidth || p.height < minPlayerHeight {
+		return styles.RenderTooSmall(p.width)
+	}
+	
+	if p.eqPanelOpen && p.audioPlayer != nil {
+		return p.renderEQView()
+	}
+	if p.infoPanelOpen && p.audioPlayer != nil {
+		return p.renderInfoView()
+	}
+	
 	switch p.state {
 	case StateIdle:
 		return p.renderIdleView()
 	case StateLoading:
 		return p.renderLoadingView()
-	case StatePlaying, StatePaused:
+	case StatePlaying, StatePaused, StateBuffering:
 		return p.renderPlayingView()
 	case StateCompleted:
 		return p.renderCompletedView()
@@ -565,7 +1416,7 @@ func (p *PlayerComponent) View() string {
 func (p *PlayerComponent) renderIdleView() string {
 	content := lipgloss.JoinVertical(
 		lipgloss.Center,
-		styles.StatusStyle.Render("🎵 No track loaded"),
+		styles.StatusStyle.Render(styles.Icon("🎵 No track loaded", "[idle] No track loaded")),
 		"",
 		styles.HelpStyle.Render("Select a track from the search to start playing"),
 	)
@@ -586,7 +1437,7 @@ func (p *PlayerComponent) renderLoadingView() string {
 		styles.TrackTitleStyle.Render(p.currentTrack.Title),
 		styles.TrackArtistStyle.Render(p.currentTrack.Artist()),
 		"",
-		styles.LoadingStatusStyle.Render("🔄 Loading..."),
+		styles.LoadingStatusStyle.Render(styles.Icon("🔄 Loading...", "[loading]")),
 	)
 	
 	return styles.PlayerStyle.Width(p.width-4).Height(p.height-4).Render(
@@ -606,20 +1457,25 @@ func (p *PlayerComponent) renderPlayingView() string {
 		p.currentTrack.Artist(),
 		p.width-8, // Account for player panel padding
 	)
+	if details := p.renderTrackDetails(); details != "" {
+		metadata = lipgloss.JoinVertical(lipgloss.Left, metadata, details)
+	}
 	
 	// Status
 	var status string
-	if p.audioPlayer != nil {
+	if p.state == StateBuffering {
+		status = styles.BufferingStatusStyle.Render(styles.Icon("⟳ Buffering…", "[buffering]"))
+	} else if p.audioPlayer != nil {
 		switch p.audioPlayer.GetState() {
 		case audio.StatePlaying:
-			status = styles.PlayingStatusStyle.Render("▶ Playing")
+			status = styles.PlayingStatusStyle.Render(styles.Icon("▶ Playing", "[playing]"))
 		case audio.StatePaused:
-			status = styles.PausedStatusStyle.Render("⏸ Paused")
+			status = styles.PausedStatusStyle.Render(styles.Icon("⏸ Paused", "[paused]"))
 		default:
-			status = styles.StatusStyle.Render("⏹ Stopped")
+			status = styles.StatusStyle.Render(styles.Icon("⏹ Stopped", "[stopped]"))
 		}
 	} else {
-		status = styles.StatusStyle.Render("⏹ Stopped")
+		status = styles.StatusStyle.Render(styles.Icon("⏹ Stopped", "[stopped]"))
 	}
 	
 	// Progress bar
@@ -633,32 +1489,54 @@ func (p *PlayerComponent) renderPlayingView() string {
 	}
 	
 	if displayDuration > 0 {
-		progress := float64(p.position) / float64(displayDuration)
-		progressBar = styles.RenderProgressBar(p.width-12, progress)
+		progress := float64(p.displayedPosition) / float64(displayDuration)
 		
-		posStr := styles.FormatDurationFromTime(p.position)
+		posStr := styles.FormatDurationFromTime(p.displayedPosition)
 		durStr := styles.FormatDurationFromTime(displayDuration)
 		timeInfo = fmt.Sprintf("%s / %s", posStr, durStr)
+		
+		if p.seekPreview != nil {
+			target := float64(*p.seekPreview) / float64(displayDuration)
+			progressBar = styles.RenderSeekPreviewBar(p.width-12, progress, target)
+			timeInfo += "  " + styles.Icon("→ ", "-> ") + styles.FormatDurationFromTime(*p.seekPreview)
+		} else {
+			progressBar = styles.RenderProgressBar(p.width-12, progress)
+		}
 	} else {
 		progressBar = styles.RenderProgressBar(p.width-12, 0)
 		timeInfo = styles.FormatDurationFromTime(0) + " / " + styles.FormatDurationFromTime(0)
 	}
 	
 	// Volume info with appropriate icon
-	volumePercent := int(p.volume * 100)
-	var volumeIcon string
-	switch {
-	case p.volume == 0:
-		volumeIcon = "🔇" // Muted
-	case p.volume < 0.5:
-		volumeIcon = "🔉" // Low volume
-	default:
-		volumeIcon = "🔊" // High volume
+	percent := fmt.Sprintf("%d%%", int(p.volume*100))
+	if p.audioPlayer != nil && p.audioPlayer.GetNormalization() {
+		percent += " • normalized"
+	}
+	volumeInfo := volumeIcon(p.volume) + " " + percent
+	
+	// The slider shrinks to fit beside the percentage, or drops out entirely
+	sliderWidth := min(volumeBarWidth, p.width-12-lipgloss.Width(volumeInfo)-2)
+	if slider := styles.RenderProgressBar(sliderWidth, p.volume); slider != "" {
+		volumeInfo = volumeIcon(p.volume) + " " + slider + " " + percent
 	}
-	volumeInfo := fmt.Sprintf("%s %d%%", volumeIcon, volumePercent)
+	
+	// Output level meters
+	var left, right float64
+	if p.audioPlayer != nil {
+		left, right = p.audioPlayer.GetLevels()
+	}
+	meterWidth := (p.width - 12) / 2
+	if meterWidth > 30 {
+		meterWidth = 30
+	}
+	meters := lipgloss.JoinVertical(
+		lipgloss.Left,
+		styles.RenderLevelMeter("L", meterWidth, left),
+		styles.RenderLevelMeter("R", meterWidth, right),
+	)
 	
 	// Controls help
-	controls := styles.HelpStyle.Render("Space: Play/Pause • ←→: Seek • +/-: Volume")
+	controls := styles.HelpStyle.Render("Space: Play/Pause • ←→: Seek • 0-9: Jump • r/Home: Restart • b: Replay • End: Near end • R: Related • u: Artist • n/p: Next/Prev • +/-: Volume • e: EQ • N: Normalize • i: Stream info • o: Open in browser • y/Y: Copy link/now playing")
 	
 	// Combine everything
 	content := lipgloss.JoinVertical(
@@ -671,6 +1549,7 @@ func (p *PlayerComponent) renderPlayingView() string {
 		styles.StatusStyle.Render(timeInfo),
 		"",
 		styles.StatusStyle.Render(volumeInfo),
+		meters,
 		"",
 		controls,
 	)
@@ -678,6 +1557,72 @@ func (p *PlayerComponent) renderPlayingView() string {
 	return styles.PlayerStyle.Width(p.width-4).Render(content)
 }
 
+// renderTrackDetails renders the stream quality, genre, counts and the first
+// line of the description, whichever the track has
+func (p *PlayerComponent) renderTrackDetails() string {
+	var parts []string
+	if badge := p.qualityBadge(); badge != "" {
+		parts = append(parts, "["+badge+"]")
+	}
+	if format := formatLabel(p.streamFormat); format != "" {
+		parts = append(parts, format)
+	}
+	if p.currentTrack.Genre != "" {
+		parts = append(parts, p.currentTrack.Genre)
+	}
+	if p.currentTrack.PlaybackCount > 0 {
+		parts = append(parts, styles.Icon("▶ ", "")+styles.FormatCount(p.currentTrack.PlaybackCount)+" plays")
+	}
+	if p.currentTrack.LikesCount > 0 {
+		parts = append(parts, styles.Icon("♥ ", "")+styles.FormatCount(p.currentTrack.LikesCount)+" likes")
+	}
+	if p.currentTrack.CommentCount > 0 {
+		parts = append(parts, styles.Icon("💬 ", "")+styles.FormatCount(p.currentTrack.CommentCount)+" comments")
+	}
+	
+	var lines []string
+	if len(parts) > 0 {
+		lines = append(lines, styles.StatusStyle.Render(strings.Join(parts, " • ")))
+	}
+	if description := strings.TrimSpace(p.currentTrack.Description); description != "" {
+		firstLine := strings.TrimSpace(strings.SplitN(description, "\n", 2)[0])
+		lines = append(lines, styles.HelpStyle.Render(styles.TruncateText(firstLine, p.width-12)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// qualityBadge labels the codec and bitrate of the stream being played, e.g.
+// "MP3 128", falling back to its format when it has no transcoding preset
+func (p *PlayerComponent) qualityBadge() string {
+	if p.streamInfo == nil {
+		return ""
+	}
+	if label := audio.PresetLabel(p.streamInfo.Preset); label != "" {
+		return label
+	}
+	return strings.ToUpper(p.streamInfo.Format)
+}
+
+// formatLabel describes a decoded stream format, e.g. "44.1 kHz stereo", or
+// returns "" while it is unknown
+func formatLabel(format beep.Format) string {
+	if format.SampleRate == 0 {
+		return ""
+	}
+	
+	rate := fmt.Sprintf("%g kHz", float64(format.SampleRate)/1000)
+	switch format.NumChannels {
+	case 0:
+		return rate
+	case 1:
+		return rate + " mono"
+	case 2:
+		return rate + " stereo"
+	default:
+		return fmt.Sprintf("%s %d channels", rate, format.NumChannels)
+	}
+}
+
 // renderCompletedView renders the completed view
 func (p *PlayerComponent) renderCompletedView() string {
 	if p.currentTrack == nil {
@@ -692,7 +1637,7 @@ func (p *PlayerComponent) renderCompletedView() string {
 	)
 	
 	// Status
-	status := styles.StatusStyle.Render("✅ Track Completed")
+	status := styles.StatusStyle.Render(styles.Icon("✅ Track Completed", "[completed]"))
 	
 	// Progress bar (show as full)
 	var progressBar string
@@ -715,17 +1660,7 @@ func (p *PlayerComponent) renderCompletedView() string {
 	}
 	
 	// Volume info
-	volumePercent := int(p.volume * 100)
-	var volumeIcon string
-	switch {
-	case p.volume == 0:
-		volumeIcon = "🔇" // Muted
-	case p.volume < 0.5:
-		volumeIcon = "🔉" // Low volume
-	default:
-		volumeIcon = "🔊" // High volume
-	}
-	volumeInfo := fmt.Sprintf("%s %d%%", volumeIcon, volumePercent)
+	volumeInfo := fmt.Sprintf("%s %d%%", volumeIcon(p.volume), int(p.volume*100))
 	
 	// Controls help
 	controls := styles.HelpStyle.Render("Space: Replay • Search for another track")
@@ -757,13 +1692,18 @@ func (p *PlayerComponent) renderErrorView() string {
 		trackInfo = "Unknown track"
 	}
 	
+	errorText := "Unknown error"
+	if p.error != nil {
+		errorText = p.error.Error()
+	}
+	
 	content := lipgloss.JoinVertical(
 		lipgloss.Center,
-		styles.ErrorStatusStyle.Render("❌ Playback Error"),
+		styles.ErrorStatusStyle.Render(styles.Icon("❌ Playback Error", "[error] Playback Error")),
 		"",
 		styles.StatusStyle.Render(trackInfo),
 		"",
-		styles.ErrorStatusStyle.Render(p.error.Error()),
+		styles.ErrorStatusStyle.Render(errorText),
 		"",
 		styles.HelpStyle.Render("Try selecting another track"),
 	)
@@ -773,6 +1713,117 @@ func (p *PlayerComponent) renderErrorView() string {
 	)
 }
 
+// renderEQView renders the equalizer panel
+func (p *PlayerComponent) renderEQView() string {
+	gains := [3]float64{}
+	gains[0], gains[1], gains[2] = p.audioPlayer.GetEQ()
+	
+	title := styles.TrackTitleStyle.Render("Equalizer")
+	if gains == [3]float64{} {
+		title += " " + styles.HelpStyle.Render("(flat, bypassed)")
+	}
+	
+	rows := []string{title, ""}
+	for i, name := range eqBandNames {
+		marker := "  "
+		if i == p.eqBand {
+			marker = styles.Icon("▶ ", "> ")
+		}
+		bar := styles.RenderProgressBar(24, (gains[i]+audio.MaxEQGain)/(2*audio.MaxEQGain))
+		row := fmt.Sprintf("%s%-5s %s %+3.0f dB", marker, name, bar, gains[i])
+		if i == p.eqBand {
+			row = styles.ControlActiveStyle.Render(row)
+		} else {
+			row = styles.StatusStyle.Render(row)
+		}
+		rows = append(rows, row)
+	}
+	
+	rows = append(rows, "", styles.HelpStyle.Render("↑↓: Band • +/-: Adjust • e/Esc: Close"))
+	
+	content := lipgloss.JoinVertical(lipgloss.Left, rows...)
+	
+	return styles.PlayerStyle.Width(p.width-4).Height(p.height-4).Render(
+		lipgloss.Place(p.width-8, p.height-8, lipgloss.Center, lipgloss.Center, content),
+	)
+}
+
+// renderInfoView renders the stream info panel: what was resolved for the
+// current track and how the player is handling it
+func (p *PlayerComponent) renderInfoView() string {
+	rows := []string{styles.TrackTitleStyle.Render("Stream Info"), ""}
+	
+	if p.streamInfo == nil {
+		rows = append(rows, styles.StatusStyle.Render("No stream loaded"))
+	} else {
+		info := p.streamInfo
+		field := func(name, value string) {
+			if value == "" {
+				value = "unknown"
+			}
+			rows = append(rows, styles.StatusStyle.Render(fmt.Sprintf("%-12s %s", name+":", value)))
+		}
+		
+		field("URL", styles.TruncateText(info.URL, max(p.width-30, 10)))
+		field("Format", info.Format)
+		field("Quality", info.Quality)
+		if info.Bitrate > 0 {
+			field("Bitrate", fmt.Sprintf("%d kbps", info.Bitrate))
+		} else {
+			field("Bitrate", "")
+		}
+		rate := int(p.streamFormat.SampleRate)
+		if rate == 0 {
+			rate = p.audioPlayer.GetSampleRate()
+		}
+		if rate > 0 {
+			field("Sample rate", fmt.Sprintf("%d Hz", rate))
+		} else {
+			field("Sample rate", "")
+		}
+		if p.streamFormat.NumChannels > 0 {
+			field("Channels", fmt.Sprint(p.streamFormat.NumChannels))
+		} else {
+			field("Channels", "")
+		}
+		field("Buffer", p.bufferStats())
+	}
+	
+	rows = append(rows, "", styles.HelpStyle.Render("c: Copy stream URL • i/Esc: Close"))
+	
+	content := lipgloss.JoinVertical(lipgloss.Left, rows...)
+	
+	return styles.PlayerStyle.Width(p.width-4).Height(p.height-4).Render(
+		lipgloss.Place(p.width-8, p.height-8, lipgloss.Center, lipgloss.Center, content),
+	)
+}
+
+// bufferStats describes how much of the stream is buffered ahead of playback
+func (p *PlayerComponent) bufferStats() string {
+	available, total, completed := p.audioPlayer.BufferHealth()
+	if total == 0 {
+		return "not buffered"
+	}
+	
+	stats := fmt.Sprintf("%d KB of %d KB ahead", available/1024, total/1024)
+	if completed {
+		stats += ", download complete"
+	}
+	return stats
+}
+
+// volumeIcon returns the icon (or plain label) matching the volume level
+func volumeIcon(volume float64) string {
+	switch {
+	case volume == 0:
+		return styles.Icon("🔇", "[muted]")
+	case volume < 0.5:
+		return styles.Icon("🔉", "[vol]") // Low volume
+	default:
+		return styles.Icon("🔊", "[vol]") // High volume
+	}
+}
+
 // formatDuration formats a duration to MM:SS format
 func formatDuration(d time.Duration) string {
 	minutes := int(d.Minutes())
@@ -808,14 +1859,66 @@ func (p *PlayerComponent) GetPosition() time.Duration {
 	return p.position
 }
 
+// GetDisplayedPosition returns the smoothed position the progress bar shows
+func (p *PlayerComponent) GetDisplayedPosition() time.Duration {
+	return p.displayedPosition
+}
+
 func (p *PlayerComponent) GetDuration() time.Duration {
 	return p.duration
 }
 
+// GetSeekPreview returns the pending scrub target, or nil when not scrubbing
+func (p *PlayerComponent) GetSeekPreview() *time.Duration {
+	return p.seekPreview
+}
+
 func (p *PlayerComponent) GetError() error {
 	return p.error
 }
 
+// SetAutoResume sets whether a stream that stops before the end of the track
+// is restarted automatically instead of waiting for space
+func (p *PlayerComponent) SetAutoResume(enabled bool) {
+	p.autoResume = enabled
+}
+
+// SetVolumeMemory remembers the volume set for each track in store and
+// restores it when the track plays again; nil turns volume memory off
+func (p *PlayerComponent) SetVolumeMemory(store *trackvolume.Store) {
+	p.volumeMemory = store
+	p.defaultVolume = p.volume
+}
+
+// SetTickInterval sets how often progress refreshes during playback.
+// Non-positive intervals restore DefaultTickInterval.
+func (p *PlayerComponent) SetTickInterval(interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultTickInterval
+	}
+	p.tickInterval = interval
+}
+
+// SetOpener replaces the opener used for the "open in browser" action
+func (p *PlayerComponent) SetOpener(o opener.Opener) {
+	p.urlOpener = o
+}
+
+// SetClipboard replaces the clipboard used for the "copy link" action
+func (p *PlayerComponent) SetClipboard(c clipboard.Clipboard) {
+	p.clipboard = c
+}
+
+// IsEQPanelOpen reports whether the equalizer panel is shown
+func (p *PlayerComponent) IsEQPanelOpen() bool {
+	return p.eqPanelOpen
+}
+
+// IsInfoPanelOpen reports whether the stream info panel is shown
+func (p *PlayerComponent) IsInfoPanelOpen() bool {
+	return p.infoPanelOpen
+}
+
 func (p *PlayerComponent) SetSize(width, height int) {
 	p.width = width
 	p.height = height
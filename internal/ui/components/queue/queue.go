@@ -0,0 +1,487 @@
+package queue
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/ui/components/player"
+	"soundcloud-tui/internal/ui/styles"
+)
+
+// RepeatMode controls what happens at the ends of the queue
+type RepeatMode int
+
+const (
+	RepeatOff RepeatMode = iota
+	RepeatAll
+	RepeatOne
+)
+
+// String returns the string representation of RepeatMode
+func (r RepeatMode) String() string {
+	switch r {
+	case RepeatOff:
+		return "off"
+	case RepeatAll:
+		return "all"
+	case RepeatOne:
+		return "one"
+	default:
+		return "unknown"
+	}
+}
+
+// RemovedCurrentMsg reports that the playing entry was removed from the queue
+type RemovedCurrentMsg struct{}
+
+// SaveQueueMsg asks for the queued tracks to be saved to the queue file
+type SaveQueueMsg struct {
+	Tracks []soundcloud.Track
+}
+
+// LoadQueueMsg asks for the tracks in the queue file to be added to the queue
+type LoadQueueMsg struct{}
+
+// QueueComponent represents the play queue view component
+type QueueComponent struct {
+	// Size
+	width  int
+	height int
+
+	// State
+	tracks        []soundcloud.Track
+	currentIndex  int // Index of the playing entry, -1 when none
+	selectedIndex int
+	repeatMode    RepeatMode
+	radio         bool // Refill with related tracks when the queue runs dry
+	confirmClear  bool // 'c' was pressed once and waits for confirmation
+}
+
+// NewQueueComponent creates a new, empty queue component
+func NewQueueComponent() *QueueComponent {
+	return &QueueComponent{
+		width:         80,
+		height:        20,
+		tracks:        []soundcloud.Track{},
+		currentIndex:  -1,
+		selectedIndex: 0,
+		repeatMode:    RepeatOff,
+	}
+}
+
+// Init initializes the queue component
+func (q *QueueComponent) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages and updates the queue component
+func (q *QueueComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return q.handleKeyMsg(msg)
+
+	case tea.WindowSizeMsg:
+		q.width = msg.Width
+		q.height = msg.Height
+	}
+
+	return q, nil
+}
+
+// handleKeyMsg handles key messages in the queue view
+func (q *QueueComponent) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Any key other than a second 'c' cancels a pending clear
+	if q.confirmClear && msg.String() != "c" {
+		q.confirmClear = false
+	}
+
+	switch msg.Type {
+	case tea.KeyUp:
+		q.moveSelection(-1)
+
+	case tea.KeyDown:
+		q.moveSelection(1)
+
+	case tea.KeyShiftUp:
+		q.moveSelected(-1)
+
+	case tea.KeyShiftDown:
+		q.moveSelected(1)
+
+	case tea.KeyEnter:
+		if track, ok := q.Play(q.selectedIndex); ok {
+			return q, playCmd(track)
+		}
+
+	case tea.KeyRunes:
+		switch string(msg.Runes) {
+		case "k":
+			q.moveSelection(-1)
+		case "j":
+			q.moveSelection(1)
+		case ".":
+			q.selectCurrent()
+		case "r":
+			q.CycleRepeatMode()
+		case "R":
+			q.ToggleRadio()
+		case "x":
+			return q, q.removeSelected()
+		case "s":
+			return q, q.saveCmd()
+		case "l":
+			return q, func() tea.Msg {
+				return LoadQueueMsg{}
+			}
+		case "c":
+			if q.confirmClear {
+				q.Clear()
+			} else if len(q.tracks) > 0 {
+				q.confirmClear = true
+			}
+		}
+	}
+
+	return q, nil
+}
+
+// moveSelection moves the highlighted entry by delta, staying within bounds
+func (q *QueueComponent) moveSelection(delta int) {
+	q.selectedIndex += delta
+	if q.selectedIndex >= len(q.tracks) {
+		q.selectedIndex = len(q.tracks) - 1
+	}
+	if q.selectedIndex < 0 {
+		q.selectedIndex = 0
+	}
+}
+
+// selectCurrent moves the highlight back to the entry playing, if any
+func (q *QueueComponent) selectCurrent() {
+	if q.currentIndex >= 0 && q.currentIndex < len(q.tracks) {
+		q.selectedIndex = q.currentIndex
+	}
+}
+
+// moveSelected moves the highlighted entry by delta, keeping it highlighted
+func (q *QueueComponent) moveSelected(delta int) {
+	if q.Move(q.selectedIndex, q.selectedIndex+delta) {
+		q.selectedIndex += delta
+	}
+}
+
+// removeSelected removes the highlighted entry, reporting when it was the
+// one playing
+func (q *QueueComponent) removeSelected() tea.Cmd {
+	wasCurrent := q.selectedIndex == q.currentIndex
+	if !q.Remove(q.selectedIndex) || !wasCurrent {
+		return nil
+	}
+
+	return func() tea.Msg {
+		return RemovedCurrentMsg{}
+	}
+}
+
+// saveCmd asks for the queue to be saved, unless it is empty
+func (q *QueueComponent) saveCmd() tea.Cmd {
+	if len(q.tracks) == 0 {
+		return nil
+	}
+
+	tracks := append([]soundcloud.Track(nil), q.tracks...)
+	return func() tea.Msg {
+		return SaveQueueMsg{Tracks: tracks}
+	}
+}
+
+// playCmd asks the player to start a track
+func playCmd(track *soundcloud.Track) tea.Cmd {
+	return func() tea.Msg {
+		return player.PlayTrackMsg{Track: track}
+	}
+}
+
+// Add appends a track to the end of the queue
+func (q *QueueComponent) Add(track soundcloud.Track) {
+	q.tracks = append(q.tracks, track)
+}
+
+// AddAll appends the tracks that aren't queued yet, in order, and returns how
+// many were added. Local files have no ID and are always added.
+func (q *QueueComponent) AddAll(tracks []soundcloud.Track) int {
+	added := 0
+	for _, track := range tracks {
+		if track.ID != 0 && q.Contains(track.ID) {
+			continue
+		}
+		q.Add(track)
+		added++
+	}
+	return added
+}
+
+// Play makes the entry at index current and returns its track
+func (q *QueueComponent) Play(index int) (*soundcloud.Track, bool) {
+	if index < 0 || index >= len(q.tracks) {
+		return nil, false
+	}
+
+	q.currentIndex = index
+	q.selectedIndex = index
+	return q.trackAt(index), true
+}
+
+// Next advances to the next entry and returns it. At the end of the queue it
+// wraps under RepeatAll and otherwise reports false without moving.
+func (q *QueueComponent) Next() (*soundcloud.Track, bool) {
+	if len(q.tracks) == 0 {
+		return nil, false
+	}
+
+	next := q.currentIndex + 1
+	if next >= len(q.tracks) {
+		if q.repeatMode != RepeatAll {
+			return nil, false
+		}
+		next = 0
+	}
+
+	return q.Play(next)
+}
+
+// Previous moves back to the previous entry and returns it. At the start of
+// the queue it wraps under RepeatAll and otherwise reports false without moving.
+func (q *QueueComponent) Previous() (*soundcloud.Track, bool) {
+	if len(q.tracks) == 0 || q.currentIndex < 0 {
+		return nil, false
+	}
+
+	previous := q.currentIndex - 1
+	if previous < 0 {
+		if q.repeatMode != RepeatAll {
+			return nil, false
+		}
+		previous = len(q.tracks) - 1
+	}
+
+	return q.Play(previous)
+}
+
+// Clear removes every entry from the queue
+func (q *QueueComponent) Clear() {
+	q.tracks = []soundcloud.Track{}
+	q.currentIndex = -1
+	q.selectedIndex = 0
+	q.confirmClear = false
+}
+
+// Remove deletes the entry at index. Removing the playing entry leaves the
+// entry before it current, so Next continues with the one that followed.
+func (q *QueueComponent) Remove(index int) bool {
+	if index < 0 || index >= len(q.tracks) {
+		return false
+	}
+
+	q.tracks = append(q.tracks[:index], q.tracks[index+1:]...)
+
+	if index <= q.currentIndex {
+		q.currentIndex--
+	}
+	if q.selectedIndex >= len(q.tracks) {
+		q.selectedIndex = len(q.tracks) - 1
+	}
+	if q.selectedIndex < 0 {
+		q.selectedIndex = 0
+	}
+	return true
+}
+
+// Move moves the entry at from to index to, shifting the entries between
+// them. The playing entry stays current wherever it ends up.
+func (q *QueueComponent) Move(from, to int) bool {
+	if from < 0 || from >= len(q.tracks) || to < 0 || to >= len(q.tracks) || from == to {
+		return false
+	}
+
+	track := q.tracks[from]
+	q.tracks = append(q.tracks[:from], q.tracks[from+1:]...)
+	q.tracks = append(q.tracks[:to], append([]soundcloud.Track{track}, q.tracks[to:]...)...)
+
+	switch {
+	case q.currentIndex == from:
+		q.currentIndex = to
+	case from < q.currentIndex && to >= q.currentIndex:
+		q.currentIndex--
+	case from > q.currentIndex && to <= q.currentIndex:
+		q.currentIndex++
+	}
+	return true
+}
+
+// Current returns the track of the playing entry, if any
+func (q *QueueComponent) Current() (*soundcloud.Track, bool) {
+	if q.currentIndex < 0 || q.currentIndex >= len(q.tracks) {
+		return nil, false
+	}
+	return q.trackAt(q.currentIndex), true
+}
+
+// ClearCurrent marks that nothing from the queue is playing
+func (q *QueueComponent) ClearCurrent() {
+	q.currentIndex = -1
+}
+
+// CycleRepeatMode switches to the next repeat mode (off → all → one → off)
+func (q *QueueComponent) CycleRepeatMode() {
+	q.repeatMode = (q.repeatMode + 1) % 3
+}
+
+// ToggleRadio switches radio mode on or off
+func (q *QueueComponent) ToggleRadio() {
+	q.radio = !q.radio
+}
+
+// Contains reports whether a track with trackID is queued
+func (q *QueueComponent) Contains(trackID int64) bool {
+	for _, track := range q.tracks {
+		if track.ID == trackID {
+			return true
+		}
+	}
+	return false
+}
+
+// trackAt returns a pointer to a copy of the track at index
+func (q *QueueComponent) trackAt(index int) *soundcloud.Track {
+	track := q.tracks[index]
+	return &track
+}
+
+// View renders the queue component
+func (q *QueueComponent) View() string {
+	radio := "off"
+	if q.radio {
+		radio = "on"
+	}
+	help := styles.HelpStyle.Render("↑↓/jk: Navigate • .: Now playing • Enter: Play • Shift+↑↓: Move • x: Remove • c: Clear • s/l: Save/Load • r: Repeat (" + q.repeatMode.String() + ") • R: Radio (" + radio + ")")
+	if q.confirmClear {
+		help = styles.HelpStyle.Render("Press c again to clear the queue, any other key to cancel")
+	}
+
+	if len(q.tracks) == 0 {
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			styles.SearchResultsStyle.Render(
+				styles.StatusStyle.Render("Queue is empty - press 'a' on a search result to add it, or 'l' to load the saved queue"),
+			),
+			help,
+		)
+	}
+
+	// Header
+	header := fmt.Sprintf("Queue (%d tracks):", len(q.tracks))
+
+	// Visible window keeps the selection roughly centered
+	visibleStart := 0
+	visibleEnd := len(q.tracks)
+	maxVisible := q.height - 6 // Reserve space for header and help
+	if maxVisible < 1 {
+		maxVisible = 1
+	}
+
+	if len(q.tracks) > maxVisible {
+		if q.selectedIndex >= maxVisible/2 {
+			visibleStart = q.selectedIndex - maxVisible/2
+			visibleEnd = visibleStart + maxVisible
+			if visibleEnd > len(q.tracks) {
+				visibleEnd = len(q.tracks)
+				visibleStart = visibleEnd - maxVisible
+			}
+		} else {
+			visibleEnd = maxVisible
+		}
+	}
+
+	var items []string
+	for i := visibleStart; i < visibleEnd; i++ {
+		track := q.tracks[i]
+
+		marker := "  "
+		if i == q.currentIndex {
+			marker = styles.Icon("♪ ", "* ")
+		}
+
+		item := fmt.Sprintf("%s%2d. %-50s %s (%s)", marker, i+1, styles.TruncateText(track.Title, 50), track.Artist(), track.DurationString())
+		if i == q.selectedIndex {
+			items = append(items, styles.SelectedListItemStyle.Render(item))
+		} else {
+			items = append(items, styles.ListItemStyle.Render(item))
+		}
+	}
+
+	// Scroll indicator
+	var scrollIndicator string
+	if len(q.tracks) > maxVisible {
+		scrollIndicator = fmt.Sprintf(" [%d-%d of %d]", visibleStart+1, visibleEnd, len(q.tracks))
+	}
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		styles.TrackTitleStyle.Render(header+scrollIndicator),
+		"",
+		lipgloss.JoinVertical(lipgloss.Left, items...),
+	)
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		styles.SearchResultsStyle.Render(content),
+		help,
+	)
+}
+
+// Getter methods for testing
+
+func (q *QueueComponent) GetTracks() []soundcloud.Track {
+	return q.tracks
+}
+
+func (q *QueueComponent) Len() int {
+	return len(q.tracks)
+}
+
+func (q *QueueComponent) GetCurrentIndex() int {
+	return q.currentIndex
+}
+
+func (q *QueueComponent) GetSelectedIndex() int {
+	return q.selectedIndex
+}
+
+func (q *QueueComponent) IsConfirmingClear() bool {
+	return q.confirmClear
+}
+
+func (q *QueueComponent) GetRepeatMode() RepeatMode {
+	return q.repeatMode
+}
+
+func (q *QueueComponent) SetRepeatMode(mode RepeatMode) {
+	q.repeatMode = mode
+}
+
+func (q *QueueComponent) IsRadio() bool {
+	return q.radio
+}
+
+func (q *QueueComponent) SetRadio(enabled bool) {
+	q.radio = enabled
+}
+
+func (q *QueueComponent) SetSize(width, height int) {
+	q.width = width
+	q.height = height
+}
@@ -1,13 +1,19 @@
 package search
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/history"
+	"soundcloud-tui/internal/opener"
 	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/ui/components/player"
 	"soundcloud-tui/internal/ui/styles"
 )
 
@@ -40,10 +46,85 @@ func (s State) String() string {
 	}
 }
 
+// SortMode controls the order results are listed in
+type SortMode int
+
+const (
+	SortRelevance SortMode = iota // The order the API returned
+	SortDuration                  // Shortest first
+	SortNewest                    // Most recently uploaded first
+)
+
+// String returns the string representation of SortMode
+func (m SortMode) String() string {
+	switch m {
+	case SortRelevance:
+		return "relevance"
+	case SortDuration:
+		return "duration"
+	case SortNewest:
+		return "newest"
+	default:
+		return "unknown"
+	}
+}
+
+// Bounds of the title column in compact result rows, used unless configured
+// otherwise
+const (
+	DefaultMinTitleWidth = 20
+	DefaultMaxTitleWidth = 60
+)
+
+// Compact result row layout: title, artist, then the duration
+const (
+	resultRowChrome     = 8                // Results border and padding, row padding and the selection marker
+	durationColumnWidth = len(" (999:59)") // Room for the duration of a long mix
+	minArtistWidth      = 8
+	maxArtistWidth      = 30
+)
+
+// minSearchHeight is the shortest the search view is laid out for
+const minSearchHeight = 3
+
 // SearchResultsMsg represents search results message
 type SearchResultsMsg struct {
 	Results []soundcloud.Track
 	Error   error
+	
+	// Total is how many matches exist in all, which may exceed the results
+	// shown; zero when unknown
+	Total int
+}
+
+// AddToQueueMsg asks the app to append a track to the play queue
+type AddToQueueMsg struct {
+	Track soundcloud.Track
+}
+
+// AddTracksToQueueMsg asks the app to append several tracks to the play
+// queue, skipping those already queued
+type AddTracksToQueueMsg struct {
+	Tracks []soundcloud.Track
+}
+
+// ArtistTracksPageMsg carries a further page of an artist's tracks, starting
+// at Offset
+type ArtistTracksPageMsg struct {
+	UserID int64
+	Offset int
+	Tracks []soundcloud.Track
+	Error  error
+}
+
+// Provider supplies the tracks the search view lists. The SoundCloud client
+// is the default; other backends implement it to reuse the view. A search
+// without matches may return an empty result or soundcloud.ErrNoResults.
+type Provider interface {
+	SearchWithTotal(query string) ([]soundcloud.Track, int, error)
+	GetArtistTracks(user soundcloud.User) ([]soundcloud.Track, error)
+	GetUserTracks(userID int64, limit, offset int) ([]soundcloud.Track, error)
+	GetRelatedTracks(trackID int64) ([]soundcloud.Track, error)
 }
 
 // SearchComponent represents the search view component
@@ -56,16 +137,50 @@ type SearchComponent struct {
 	state         State
 	query         string
 	results       []soundcloud.Track
+	total         int // All matches for the query, 0 when unknown
 	selectedIndex int
 	selectedTrack *soundcloud.Track
 	error         error
 	
+	// Search history navigation (-1 when not browsing history)
+	historyIndex int
+	
+	// Two-line result rows with artist, duration and counts under the title
+	detailed bool
+	
+	// Bounds of the title column in compact rows
+	minTitleWidth int
+	maxTitleWidth int
+	
+	// Display order of the results; results itself stays in API order
+	sortMode SortMode
+	
+	// Searches only cover tracks cached on this machine
+	offline bool
+	
+	// Browsing an artist's or related tracks replaces the results; what was
+	// shown before is kept for Esc
+	artist             *soundcloud.User
+	relatedTo          *soundcloud.Track
+	savedResults       []soundcloud.Track
+	savedTotal         int
+	savedSelectedIndex int
+	savedState         State
+	
+	// Further pages of an artist's tracks load once the selection reaches the
+	// last one loaded
+	moreArtistTracks bool
+	loadingMore      bool
+	loadMoreError    error
+	
 	// Dependencies
-	client soundcloud.ClientInterface
+	provider  Provider
+	history   *history.Store
+	urlOpener opener.Opener
 }
 
-// NewSearchComponent creates a new search component
-func NewSearchComponent(client soundcloud.ClientInterface) *SearchComponent {
+// NewSearchComponent creates a new search component listing tracks from provider
+func NewSearchComponent(provider Provider) *SearchComponent {
 	return &SearchComponent{
 		width:         80,
 		height:        20,
@@ -75,7 +190,11 @@ func NewSearchComponent(client soundcloud.ClientInterface) *SearchComponent {
 		selectedIndex: 0,
 		selectedTrack: nil,
 		error:         nil,
-		client:        client,
+		historyIndex:  -1,
+		minTitleWidth: DefaultMinTitleWidth,
+		maxTitleWidth: DefaultMaxTitleWidth,
+		provider:      provider,
+		urlOpener:     opener.NewBrowserOpener(),
 	}
 }
 
@@ -97,10 +216,14 @@ func (s *SearchComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Ignore input while searching
 			return s, nil
 		case StateError:
-			// Allow escape to go back to input
+			// Allow escape to go back to input, or to what a browse lookup left
 			if msg.Type == tea.KeyEsc {
-				s.state = StateInput
 				s.error = nil
+				if s.browsing() {
+					s.restoreResults()
+				} else {
+					s.state = StateInput
+				}
 			}
 			return s, nil
 		}
@@ -108,6 +231,10 @@ func (s *SearchComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case SearchResultsMsg:
 		return s.handleSearchResults(msg)
 		
+	case ArtistTracksPageMsg:
+		s.handleArtistTracksPage(msg)
+		return s, nil
+		
 	case tea.WindowSizeMsg:
 		s.width = msg.Width
 		s.height = msg.Height
@@ -120,13 +247,50 @@ func (s *SearchComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (s *SearchComponent) handleInputState(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.Type {
 	case tea.KeyEnter:
+		// A local audio file path plays directly instead of searching
+		if query := strings.TrimSpace(s.query); audio.IsLocalStream(query) {
+			s.selectedTrack = player.LocalTrack(query)
+			s.state = StateTrackSelected
+			return s, nil
+		}
 		if strings.TrimSpace(s.query) != "" {
 			s.state = StateSearching
+			s.historyIndex = -1
+			s.clearBrowse()
+			if s.history != nil {
+				s.history.Add(s.query)
+			}
 			return s, s.performSearch()
 		}
 		return s, nil
 		
+	case tea.KeyUp:
+		// Browse older queries, only from an empty input or while already browsing
+		if s.history == nil || (s.query != "" && s.historyIndex < 0) {
+			return s, nil
+		}
+		recent := s.history.Recent()
+		if s.historyIndex+1 < len(recent) {
+			s.historyIndex++
+			s.query = recent[s.historyIndex]
+		}
+		return s, nil
+		
+	case tea.KeyDown:
+		// Browse back towards newer queries, ending at an empty input
+		if s.history == nil || s.historyIndex < 0 {
+			return s, nil
+		}
+		s.historyIndex--
+		if s.historyIndex < 0 {
+			s.query = ""
+		} else {
+			s.query = s.history.Recent()[s.historyIndex]
+		}
+		return s, nil
+		
 	case tea.KeyBackspace:
+		s.historyIndex = -1
 		if len(s.query) > 0 {
 			s.query = s.query[:len(s.query)-1]
 		}
@@ -134,6 +298,7 @@ func (s *SearchComponent) handleInputState(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 		
 	case tea.KeyEsc:
 		s.query = ""
+		s.historyIndex = -1
 		s.results = []soundcloud.Track{}
 		s.selectedIndex = 0
 		s.selectedTrack = nil
@@ -141,6 +306,7 @@ func (s *SearchComponent) handleInputState(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 		return s, nil
 		
 	case tea.KeyRunes:
+		s.historyIndex = -1
 		s.query += string(msg.Runes)
 		return s, nil
 	}
@@ -150,6 +316,9 @@ func (s *SearchComponent) handleInputState(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 
 // handleResultsState handles key messages in results state
 func (s *SearchComponent) handleResultsState(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// selectedIndex points into the results as displayed
+	results := s.sortedResults()
+	
 	switch msg.Type {
 	case tea.KeyUp:
 		if s.selectedIndex > 0 {
@@ -158,69 +327,318 @@ func (s *SearchComponent) handleResultsState(msg tea.KeyMsg) (tea.Model, tea.Cmd
 		return s, nil
 		
 	case tea.KeyDown:
-		if s.selectedIndex < len(s.results)-1 {
+		if s.selectedIndex < len(results)-1 {
 			s.selectedIndex++
 		}
-		return s, nil
+		return s, s.loadMoreAtEnd()
 		
 	case tea.KeyEnter:
-		if s.selectedIndex < len(s.results) {
-			s.selectedTrack = &s.results[s.selectedIndex]
+		if s.selectedIndex < len(results) {
+			s.selectedTrack = &results[s.selectedIndex]
 			s.state = StateTrackSelected // Show loading feedback
 			return s, nil
 		}
 		return s, nil
 		
 	case tea.KeyEsc:
+		if s.browsing() {
+			s.restoreResults()
+			return s, nil
+		}
 		s.state = StateInput
 		s.selectedIndex = 0
 		s.selectedTrack = nil
 		s.results = []soundcloud.Track{}
 		return s, nil
+
+	case tea.KeyRunes:
+		// Vim-style navigation (only in results, letters still type in input state)
+		switch string(msg.Runes) {
+		case "k":
+			if s.selectedIndex > 0 {
+				s.selectedIndex--
+			}
+		case "j":
+			if s.selectedIndex < len(results)-1 {
+				s.selectedIndex++
+			}
+			return s, s.loadMoreAtEnd()
+		case "g":
+			s.selectedIndex = 0
+		case "G":
+			if len(results) > 0 {
+				s.selectedIndex = len(results) - 1
+			}
+			return s, s.loadMoreAtEnd()
+		case "o":
+			// Open the highlighted track's SoundCloud page
+			if s.selectedIndex < len(results) {
+				return s, opener.OpenCmd(s.urlOpener, results[s.selectedIndex].PermalinkURL)
+			}
+		case "a":
+			// Enqueue the highlighted track
+			if s.selectedIndex < len(results) {
+				track := results[s.selectedIndex]
+				return s, func() tea.Msg {
+					return AddToQueueMsg{Track: track}
+				}
+			}
+		case "A":
+			// Enqueue every result, in the order shown
+			if len(results) > 0 {
+				tracks := append([]soundcloud.Track(nil), results...)
+				return s, func() tea.Msg {
+					return AddTracksToQueueMsg{Tracks: tracks}
+				}
+			}
+		case "v":
+			// Switch between compact and detailed rows
+			s.detailed = !s.detailed
+		case "S":
+			s.cycleSortMode()
+		case "u":
+			// Browse more tracks from the highlighted track's artist
+			if s.selectedIndex < len(results) {
+				return s, s.BrowseArtist(results[s.selectedIndex].User)
+			}
+		}
+		return s, nil
 	}
-	
+
 	return s, nil
 }
 
-// handleSearchResults handles search results message
+// handleSearchResults handles search results message. A failed search shows
+// the error view, even when the message carries results; a search that found
+// nothing shows an empty result list.
 func (s *SearchComponent) handleSearchResults(msg SearchResultsMsg) (tea.Model, tea.Cmd) {
 	if msg.Error != nil {
 		s.state = StateError
 		s.error = msg.Error
 		s.results = []soundcloud.Track{}
+		s.total = 0
+		s.selectedIndex = 0
 	} else {
 		s.state = StateResults
 		s.results = msg.Results
+		s.total = msg.Total
 		s.selectedIndex = 0
 		s.error = nil
 	}
 	
+	// A full first page of an artist's tracks suggests there are more
+	s.moreArtistTracks = msg.Error == nil && s.artist != nil && s.artist.ID != 0 && len(msg.Results) >= soundcloud.UserTracksPageSize
+	s.loadingMore = false
+	s.loadMoreError = nil
+	
 	return s, nil
 }
 
+// loadMoreAtEnd fetches the next page of an artist's tracks once the
+// selection reaches the last track loaded
+func (s *SearchComponent) loadMoreAtEnd() tea.Cmd {
+	if !s.moreArtistTracks || s.loadingMore || s.provider == nil || s.selectedIndex < len(s.results)-1 {
+		return nil
+	}
+	
+	s.loadingMore = true
+	s.loadMoreError = nil
+	provider := s.provider
+	userID := s.artist.ID
+	offset := len(s.results)
+	return func() tea.Msg {
+		tracks, err := provider.GetUserTracks(userID, soundcloud.UserTracksPageSize, offset)
+		return ArtistTracksPageMsg{
+			UserID: userID,
+			Offset: offset,
+			Tracks: tracks,
+			Error:  err,
+		}
+	}
+}
+
+// handleArtistTracksPage appends a further page of the artist's tracks.
+// Pages for another artist or an earlier list are dropped. After a failure
+// reaching the end again retries.
+func (s *SearchComponent) handleArtistTracksPage(msg ArtistTracksPageMsg) {
+	if s.artist == nil || s.artist.ID != msg.UserID || msg.Offset != len(s.results) {
+		return
+	}
+	
+	s.loadingMore = false
+	if msg.Error != nil {
+		s.loadMoreError = msg.Error
+		return
+	}
+	
+	s.results = append(s.results, msg.Tracks...)
+	s.moreArtistTracks = len(msg.Tracks) >= soundcloud.UserTracksPageSize
+}
+
+// cycleSortMode switches to the next sort order, keeping the highlighted
+// track highlighted
+func (s *SearchComponent) cycleSortMode() {
+	results := s.sortedResults()
+	var selectedID int64
+	if s.selectedIndex < len(results) {
+		selectedID = results[s.selectedIndex].ID
+	}
+	
+	s.sortMode = (s.sortMode + 1) % 3
+	
+	s.selectedIndex = 0
+	for i, track := range s.sortedResults() {
+		if track.ID == selectedID {
+			s.selectedIndex = i
+			break
+		}
+	}
+}
+
+// sortedResults returns the results in the current sort order without
+// reordering the results slice itself
+func (s *SearchComponent) sortedResults() []soundcloud.Track {
+	if s.sortMode == SortRelevance {
+		return s.results
+	}
+	
+	sorted := make([]soundcloud.Track, len(s.results))
+	copy(sorted, s.results)
+	switch s.sortMode {
+	case SortDuration:
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].Duration < sorted[j].Duration
+		})
+	case SortNewest:
+		// Tracks without an upload date sort last
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].CreatedAt.After(sorted[j].CreatedAt)
+		})
+	}
+	return sorted
+}
+
+// BrowseArtist replaces the results with tracks by user. More of them load
+// as the selection reaches the end of the list.
+func (s *SearchComponent) BrowseArtist(user soundcloud.User) tea.Cmd {
+	s.saveResults()
+	s.artist = &user
+	s.relatedTo = nil
+	s.state = StateSearching
+	
+	provider := s.provider
+	return func() tea.Msg {
+		if provider == nil {
+			return SearchResultsMsg{Error: fmt.Errorf("no search provider available")}
+		}
+		results, err := provider.GetArtistTracks(user)
+		return SearchResultsMsg{
+			Results: results,
+			Error:   err,
+		}
+	}
+}
+
+// BrowseRelated replaces the results with tracks related to track
+func (s *SearchComponent) BrowseRelated(track soundcloud.Track) tea.Cmd {
+	s.saveResults()
+	s.relatedTo = &track
+	s.artist = nil
+	s.state = StateSearching
+	
+	provider := s.provider
+	return func() tea.Msg {
+		if provider == nil {
+			return SearchResultsMsg{Error: fmt.Errorf("no search provider available")}
+		}
+		results, err := provider.GetRelatedTracks(track.ID)
+		return SearchResultsMsg{
+			Results: results,
+			Error:   err,
+		}
+	}
+}
+
+// browsing reports whether the results are an artist's or related tracks
+func (s *SearchComponent) browsing() bool {
+	return s.artist != nil || s.relatedTo != nil
+}
+
+// saveResults remembers what is shown so Esc can return to it. Only the view
+// from before browsing started is kept, not an earlier browse list.
+func (s *SearchComponent) saveResults() {
+	if s.browsing() {
+		return
+	}
+	
+	s.savedResults = s.results
+	s.savedTotal = s.total
+	s.savedSelectedIndex = s.selectedIndex
+	s.savedState = StateInput
+	if s.state == StateResults || s.state == StateTrackSelected {
+		s.savedState = StateResults
+	}
+}
+
+// restoreResults leaves browsing and shows what was there before
+func (s *SearchComponent) restoreResults() {
+	s.results = s.savedResults
+	s.total = s.savedTotal
+	s.selectedIndex = s.savedSelectedIndex
+	s.state = s.savedState
+	s.clearBrowse()
+}
+
+// clearBrowse forgets any artist or related browsing state
+func (s *SearchComponent) clearBrowse() {
+	s.artist = nil
+	s.relatedTo = nil
+	s.savedResults = nil
+	s.savedTotal = 0
+	s.savedSelectedIndex = 0
+	s.savedState = StateInput
+	s.moreArtistTracks = false
+	s.loadingMore = false
+	s.loadMoreError = nil
+}
+
 // performSearch performs the actual search
 func (s *SearchComponent) performSearch() tea.Cmd {
-	if s.client == nil {
+	if s.provider == nil {
 		return func() tea.Msg {
 			return SearchResultsMsg{
 				Results: nil,
-				Error:   fmt.Errorf("no SoundCloud client available"),
+				Error:   fmt.Errorf("no search provider available"),
 			}
 		}
 	}
 	
 	query := s.query
+	searchHistory := s.history
 	return func() tea.Msg {
-		results, err := s.client.Search(query)
+		if searchHistory != nil {
+			// Persisting history is best-effort and must not block the search
+			_ = searchHistory.Save()
+		}
+		
+		results, total, err := s.provider.SearchWithTotal(query)
+		if errors.Is(err, soundcloud.ErrNoResults) {
+			err = nil // Shown as an empty result list rather than an error
+		}
 		return SearchResultsMsg{
 			Results: results,
 			Error:   err,
+			Total:   total,
 		}
 	}
 }
 
 // View renders the search component
 func (s *SearchComponent) View() string {
+	if s.width < styles.MinViewWidth || s.height < minSearchHeight {
+		return styles.RenderTooSmall(s.width)
+	}
+	
 	switch s.state {
 	case StateInput:
 		return s.renderInputView()
@@ -241,6 +659,9 @@ func (s *SearchComponent) View() string {
 func (s *SearchComponent) renderInputView() string {
 	// Search box
 	prompt := "Search SoundCloud:"
+	if s.offline {
+		prompt = "Search cached tracks (offline):"
+	}
 	input := s.query + "█" // Cursor
 	
 	searchBox := styles.SearchBoxStyle.Render(
@@ -252,7 +673,11 @@ func (s *SearchComponent) renderInputView() string {
 	)
 	
 	// Help text
-	help := styles.HelpStyle.Render("Type to search, Enter to execute, Esc to clear")
+	helpText := "Type to search, Enter to execute, Esc to clear"
+	if s.history != nil && len(s.history.Recent()) > 0 {
+		helpText += ", ↑↓ for history"
+	}
+	help := styles.HelpStyle.Render(helpText)
 	
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -263,11 +688,18 @@ func (s *SearchComponent) renderInputView() string {
 
 // renderSearchingView renders the searching view
 func (s *SearchComponent) renderSearchingView() string {
+	label := "Searching: " + s.query
+	if s.artist != nil {
+		label = "Loading tracks by " + s.artist.Username
+	} else if s.relatedTo != nil {
+		label = "Loading tracks related to " + s.relatedTo.Title
+	}
+	
 	searchBox := styles.SearchBoxStyle.Render(
 		lipgloss.JoinVertical(
 			lipgloss.Left,
-			"Searching: "+s.query,
-			styles.LoadingStatusStyle.Render("🔍 Searching..."),
+			label,
+			styles.LoadingStatusStyle.Render(styles.Icon("🔍 Searching...", "Searching...")),
 		),
 	)
 	
@@ -277,26 +709,72 @@ func (s *SearchComponent) renderSearchingView() string {
 // renderResultsView renders the results view
 func (s *SearchComponent) renderResultsView() string {
 	if len(s.results) == 0 {
+		if s.artist != nil {
+			return styles.SearchResultsStyle.Render(
+				styles.StatusStyle.Render(s.artist.Username + " has no public tracks"),
+			)
+		}
+		if s.relatedTo != nil {
+			return styles.SearchResultsStyle.Render(
+				styles.StatusStyle.Render("No related tracks found for: " + s.relatedTo.Title),
+			)
+		}
 		return styles.SearchResultsStyle.Render(
 			styles.StatusStyle.Render("No results found for: " + s.query),
 		)
 	}
 	
+	results := s.sortedResults()
+	
 	// Header
-	header := fmt.Sprintf("Search Results (%d found):", len(s.results))
+	found := fmt.Sprintf("%d found", len(results))
+	if s.total > len(results) {
+		found = fmt.Sprintf("showing %d of %s", len(results), styles.FormatThousands(int64(s.total)))
+	}
+	switch {
+	case s.loadingMore:
+		found += ", loading more"
+	case s.loadMoreError != nil:
+		found += ", couldn't load more"
+	case s.moreArtistTracks:
+		found += ", more below"
+	}
+	if s.sortMode != SortRelevance {
+		found += ", " + s.sortMode.String() + " first"
+	}
+	if s.offline {
+		found += ", offline"
+	}
+	header := fmt.Sprintf("Search Results (%s):", found)
+	backHelp := "Esc: Back to search"
+	if s.artist != nil {
+		header = fmt.Sprintf("Tracks by %s (%s):", s.artist.Username, found)
+	} else if s.relatedTo != nil {
+		header = fmt.Sprintf("Related to %s (%s):", s.relatedTo.Title, found)
+	}
+	if s.browsing() {
+		backHelp = "Esc: Back"
+	}
 	
 	// Results list
 	var resultItems []string
 	visibleStart := 0
-	visibleEnd := len(s.results)
-	maxVisible := s.height - 8 // Reserve space for header, input, and help
+	visibleEnd := len(results)
+	rowHeight := 1
+	if s.detailed {
+		rowHeight = 2
+	}
+	maxVisible := (s.height - 8) / rowHeight // Reserve space for header, input, and help
+	if maxVisible < 1 {
+		maxVisible = 1
+	}
 	
-	if len(s.results) > maxVisible {
+	if len(results) > maxVisible {
 		if s.selectedIndex >= maxVisible/2 {
 			visibleStart = s.selectedIndex - maxVisible/2
 			visibleEnd = visibleStart + maxVisible
-			if visibleEnd > len(s.results) {
-				visibleEnd = len(s.results)
+			if visibleEnd > len(results) {
+				visibleEnd = len(results)
 				visibleStart = visibleEnd - maxVisible
 			}
 		} else {
@@ -304,25 +782,39 @@ func (s *SearchComponent) renderResultsView() string {
 		}
 	}
 	
+	titleWidth, artistWidth := s.resultColumns()
 	for i := visibleStart; i < visibleEnd; i++ {
-		track := s.results[i]
-		item := fmt.Sprintf("%-50s %s (%s)",
-			styles.TruncateText(track.Title, 50),
-			track.Artist(),
-			track.DurationString(),
-		)
+		track := results[i]
+		
+		if s.detailed {
+			title := styles.TruncateText(track.Title, s.width-resultRowChrome)
+			details := "  " + trackDetails(track)
+			if i == s.selectedIndex {
+				resultItems = append(resultItems, styles.SelectedListItemStyle.Render(styles.Icon("▶ ", "> ")+title+"\n"+details))
+			} else {
+				resultItems = append(resultItems, styles.ListItemStyle.Render("  "+styles.HighlightMatch(title, s.query)+"\n"+details))
+			}
+			continue
+		}
+		
+		// Pad the plain title first so highlighting escapes don't skew the column
+		title := fmt.Sprintf("%-*s", titleWidth, styles.TruncateText(track.Title, titleWidth))
+		artist := styles.TruncateText(track.Artist(), artistWidth)
 		
 		if i == s.selectedIndex {
-			resultItems = append(resultItems, styles.SelectedListItemStyle.Render("▶ "+item))
+			// No highlight on the selected row: nested styles would reset its background
+			item := fmt.Sprintf("%s %s (%s)", title, artist, track.DurationString())
+			resultItems = append(resultItems, styles.SelectedListItemStyle.Render(styles.Icon("▶ ", "> ")+item))
 		} else {
+			item := fmt.Sprintf("%s %s (%s)", styles.HighlightMatch(title, s.query), artist, track.DurationString())
 			resultItems = append(resultItems, styles.ListItemStyle.Render("  "+item))
 		}
 	}
 	
 	// Scroll indicator
 	var scrollIndicator string
-	if len(s.results) > maxVisible {
-		scrollIndicator = fmt.Sprintf(" [%d-%d of %d]", visibleStart+1, visibleEnd, len(s.results))
+	if len(results) > maxVisible {
+		scrollIndicator = fmt.Sprintf(" [%d-%d of %d]", visibleStart+1, visibleEnd, len(results))
 	}
 	
 	content := lipgloss.JoinVertical(
@@ -332,7 +824,11 @@ func (s *SearchComponent) renderResultsView() string {
 		lipgloss.JoinVertical(lipgloss.Left, resultItems...),
 	)
 	
-	help := styles.HelpStyle.Render("↑↓: Navigate • Enter: Select • Esc: Back to search")
+	layoutHelp := "v: Detailed"
+	if s.detailed {
+		layoutHelp = "v: Compact"
+	}
+	help := styles.HelpStyle.Render("↑↓/jk: Navigate • g/G: Top/Bottom • Enter: Select • a/A: Add one/all to queue • o: Open in browser • u: More from artist • S: Sort • " + layoutHelp + " • " + backHelp)
 	
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -341,16 +837,61 @@ func (s *SearchComponent) renderResultsView() string {
 	)
 }
 
+// resultColumns splits the width of a compact result row between the title
+// and the artist. The title takes three fifths within its configured bounds;
+// on narrow terminals the minimum gives way so rows never overflow.
+func (s *SearchComponent) resultColumns() (titleWidth, artistWidth int) {
+	available := s.width - resultRowChrome - 1 - durationColumnWidth
+	
+	titleWidth = available * 3 / 5
+	if titleWidth < s.minTitleWidth {
+		titleWidth = s.minTitleWidth
+	}
+	if titleWidth > s.maxTitleWidth {
+		titleWidth = s.maxTitleWidth
+	}
+	if titleWidth > available-minArtistWidth {
+		titleWidth = available - minArtistWidth
+	}
+	if titleWidth < 4 {
+		titleWidth = 4 // Room for at least one character and the ellipsis
+	}
+	
+	artistWidth = available - titleWidth
+	if artistWidth > maxArtistWidth {
+		artistWidth = maxArtistWidth
+	}
+	if artistWidth < 4 {
+		artistWidth = 4
+	}
+	return titleWidth, artistWidth
+}
+
+// trackDetails is the second line of a detailed result row
+func trackDetails(track soundcloud.Track) string {
+	details := track.Artist() + " • " + track.DurationString()
+	if track.PlaybackCount > 0 {
+		details += " • " + styles.Icon("▶ ", "") + styles.FormatCount(track.PlaybackCount) + " plays"
+	}
+	if track.LikesCount > 0 {
+		details += " • " + styles.Icon("♥ ", "") + styles.FormatCount(track.LikesCount) + " likes"
+	}
+	return details
+}
+
 // renderErrorView renders the error view
 func (s *SearchComponent) renderErrorView() string {
-	errorBox := styles.SearchBoxStyle.Render(
-		lipgloss.JoinVertical(
-			lipgloss.Left,
-			styles.ErrorStatusStyle.Render("❌ Search Error"),
-			"",
-			styles.ErrorStatusStyle.Render(s.error.Error()),
-		),
-	)
+	lines := []string{
+		styles.ErrorStatusStyle.Render(styles.Icon("❌ Search Error", "[error] Search Error")),
+		"",
+	}
+	if !s.browsing() && strings.TrimSpace(s.query) != "" {
+		// Tell a failed search apart from one that found nothing
+		lines = append(lines, styles.StatusStyle.Render("Search failed for: "+s.query))
+	}
+	lines = append(lines, styles.ErrorStatusStyle.Render(s.error.Error()))
+	
+	errorBox := styles.SearchBoxStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
 	
 	help := styles.HelpStyle.Render("Esc: Back to search")
 	
@@ -372,10 +913,10 @@ func (s *SearchComponent) renderTrackSelectedView() string {
 			lipgloss.Left,
 			styles.TrackTitleStyle.Render("Loading Track..."),
 			"",
-			styles.LoadingStatusStyle.Render("🎵 "+s.selectedTrack.Title),
+			styles.LoadingStatusStyle.Render(styles.Icon("🎵 ", "")+s.selectedTrack.Title),
 			styles.TrackArtistStyle.Render("by "+s.selectedTrack.Artist()),
 			"",
-			styles.LoadingStatusStyle.Render("⏳ Fetching stream URL..."),
+			styles.LoadingStatusStyle.Render(styles.Icon("⏳ Fetching stream URL...", "Fetching stream URL...")),
 		),
 	)
 	
@@ -391,6 +932,12 @@ func (s *SearchComponent) GetResults() []soundcloud.Track {
 	return s.results
 }
 
+// GetDisplayedResults returns the results in the order shown, which the
+// selected index points into
+func (s *SearchComponent) GetDisplayedResults() []soundcloud.Track {
+	return s.sortedResults()
+}
+
 func (s *SearchComponent) IsSearching() bool {
 	return s.state == StateSearching
 }
@@ -411,10 +958,67 @@ func (s *SearchComponent) GetError() error {
 	return s.error
 }
 
+// IsDetailed reports whether results use the two-line detailed layout
+func (s *SearchComponent) IsDetailed() bool {
+	return s.detailed
+}
+
+// GetSortMode returns the order results are listed in
+func (s *SearchComponent) GetSortMode() SortMode {
+	return s.sortMode
+}
+
+// GetArtist returns the artist whose tracks are shown, or nil for plain search results
+func (s *SearchComponent) GetArtist() *soundcloud.User {
+	return s.artist
+}
+
+// GetRelatedTo returns the track whose related tracks are shown, or nil
+func (s *SearchComponent) GetRelatedTo() *soundcloud.Track {
+	return s.relatedTo
+}
+
 func (s *SearchComponent) ClearSelection() {
 	s.selectedTrack = nil
 }
 
+// SetHistory attaches a search history store used for Up/Down recall
+func (s *SearchComponent) SetHistory(h *history.Store) {
+	s.history = h
+	s.historyIndex = -1
+}
+
+// SetOffline marks searches as covering only the tracks cached on this
+// machine, which the search box and results header point out
+func (s *SearchComponent) SetOffline(offline bool) {
+	s.offline = offline
+}
+
+// IsOffline reports whether searches cover only cached tracks
+func (s *SearchComponent) IsOffline() bool {
+	return s.offline
+}
+
+// SetOpener replaces the opener used for the "open in browser" action
+func (s *SearchComponent) SetOpener(o opener.Opener) {
+	s.urlOpener = o
+}
+
+// SetTitleWidthRange bounds the title column of compact result rows. Values
+// of zero or less keep the defaults.
+func (s *SearchComponent) SetTitleWidthRange(minWidth, maxWidth int) {
+	s.minTitleWidth, s.maxTitleWidth = DefaultMinTitleWidth, DefaultMaxTitleWidth
+	if minWidth > 0 {
+		s.minTitleWidth = minWidth
+	}
+	if maxWidth > 0 {
+		s.maxTitleWidth = maxWidth
+	}
+	if s.maxTitleWidth < s.minTitleWidth {
+		s.maxTitleWidth = s.minTitleWidth
+	}
+}
+
 func (s *SearchComponent) SetSize(width, height int) {
 	s.width = width
 	s.height = height
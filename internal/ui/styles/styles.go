@@ -2,10 +2,12 @@ package styles
 
 import (
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 var (
@@ -106,6 +108,10 @@ var (
 			Foreground(MutedColor).
 			MarginBottom(1)
 	
+	NowPlayingStyle = lipgloss.NewStyle().
+			Foreground(TextColor).
+			Padding(0, 1)
+	
 	ProgressBarStyle = lipgloss.NewStyle().
 			Foreground(AccentColor).
 			Background(SecondaryColor)
@@ -148,6 +154,10 @@ var (
 				Foreground(AccentColor).
 				Bold(true)
 	
+	BufferingStatusStyle = StatusStyle.Copy().
+				Foreground(PrimaryColor).
+				Bold(true)
+	
 	// Search styles
 	SearchBoxStyle = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
@@ -161,6 +171,11 @@ var (
 				Padding(1).
 				Height(15) // Reserve space for results
 	
+	// Highlight style for matched search terms
+	HighlightStyle = lipgloss.NewStyle().
+			Foreground(AccentColor).
+			Bold(true)
+	
 	// Help styles
 	HelpStyle = lipgloss.NewStyle().
 			Foreground(MutedColor).
@@ -168,6 +183,42 @@ var (
 			MarginTop(1)
 )
 
+// Plain (no-color) mode
+
+var (
+	plainMode    bool
+	savedProfile termenv.Profile
+)
+
+// SetNoColor switches all styles to plain ASCII rendering and replaces
+// emoji icons with text labels. Used for NO_COLOR, screen readers and pipes.
+func SetNoColor(enabled bool) {
+	if enabled == plainMode {
+		return
+	}
+	
+	if enabled {
+		savedProfile = lipgloss.ColorProfile()
+		lipgloss.SetColorProfile(termenv.Ascii)
+	} else {
+		lipgloss.SetColorProfile(savedProfile)
+	}
+	plainMode = enabled
+}
+
+// NoColor reports whether plain rendering is enabled
+func NoColor() bool {
+	return plainMode
+}
+
+// Icon returns the emoji icon, or the text label when plain rendering is enabled
+func Icon(emoji, label string) string {
+	if plainMode {
+		return label
+	}
+	return emoji
+}
+
 // Helper functions for dynamic styling
 
 // RenderProgressBar renders a progress bar with the given percentage
@@ -189,20 +240,124 @@ func RenderProgressBar(width int, progress float64) string {
 		fillWidth = width
 	}
 	
+	// Colors are the only thing distinguishing filled from empty blocks,
+	// so fall back to ASCII characters in plain mode
+	if plainMode {
+		return strings.Repeat("#", fillWidth) + strings.Repeat("-", width-fillWidth)
+	}
+	
+	return renderBarCells(fillWidth, width-fillWidth)
+}
+
+// renderBarCells draws filled then empty progress bar blocks
+func renderBarCells(filledCells, emptyCells int) string {
 	// Use Unicode block characters for smoother progress bar
 	filled := lipgloss.NewStyle().
 		Background(PrimaryColor).
 		Foreground(PrimaryColor).
-		Render(strings.Repeat("█", fillWidth))
+		Render(strings.Repeat("█", filledCells))
 	
 	empty := lipgloss.NewStyle().
 		Background(SecondaryColor).
 		Foreground(SecondaryColor).
-		Render(strings.Repeat("█", width-fillWidth))
+		Render(strings.Repeat("█", emptyCells))
 	
 	return lipgloss.JoinHorizontal(lipgloss.Left, filled, empty)
 }
 
+// RenderSeekPreviewBar renders a progress bar with a marker at target, the
+// position a pending seek will jump to. Both values are fractions of the track.
+func RenderSeekPreviewBar(width int, progress, target float64) string {
+	if width <= 0 {
+		return ""
+	}
+	
+	fillWidth := int(float64(width) * clampFraction(progress))
+	markerPos := int(float64(width) * clampFraction(target))
+	if markerPos >= width {
+		markerPos = width - 1
+	}
+	
+	if plainMode {
+		bar := []rune(strings.Repeat("#", fillWidth) + strings.Repeat("-", width-fillWidth))
+		bar[markerPos] = '|'
+		return string(bar)
+	}
+	
+	// Split the bar around the marker cell
+	leftFilled := fillWidth
+	if leftFilled > markerPos {
+		leftFilled = markerPos
+	}
+	rightFilled := fillWidth - markerPos - 1
+	if rightFilled < 0 {
+		rightFilled = 0
+	}
+	rightWidth := width - markerPos - 1
+	
+	marker := lipgloss.NewStyle().
+		Foreground(AccentColor).
+		Background(SecondaryColor).
+		Bold(true).
+		Render("┃")
+	
+	return lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		renderBarCells(leftFilled, markerPos-leftFilled),
+		marker,
+		renderBarCells(rightFilled, rightWidth-rightFilled),
+	)
+}
+
+// clampFraction limits v to the range 0-1
+func clampFraction(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
+
+// meterRangeDB is the dynamic range shown by a level meter
+const meterRangeDB = 48.0
+
+// RenderLevelMeter renders a labelled VU meter bar for a linear amplitude
+// level (0-1), drawn on a decibel scale so quiet passages still register
+func RenderLevelMeter(label string, width int, level float64) string {
+	if width <= 0 {
+		return label
+	}
+	
+	fill := 0.0
+	if level > 0 {
+		fill = 1 + 20*math.Log10(level)/meterRangeDB
+	}
+	if fill < 0 {
+		fill = 0
+	}
+	if fill > 1 {
+		fill = 1
+	}
+	
+	fillWidth := int(float64(width) * fill)
+	
+	if plainMode {
+		return label + " " + strings.Repeat("#", fillWidth) + strings.Repeat(".", width-fillWidth)
+	}
+	
+	filled := lipgloss.NewStyle().
+		Foreground(PrimaryColor).
+		Render(strings.Repeat("▮", fillWidth))
+	
+	empty := lipgloss.NewStyle().
+		Foreground(SecondaryColor).
+		Render(strings.Repeat("▯", width-fillWidth))
+	
+	return label + " " + filled + empty
+}
+
 // FormatDuration formats a duration in milliseconds to MM:SS format
 func FormatDuration(durationMs int64) string {
 	if durationMs <= 0 {
@@ -253,6 +408,60 @@ func formatTime(minutes, seconds int) string {
 		))
 }
 
+// FormatCount abbreviates large counts, e.g. 1234 as 1.2K and 5600000 as 5.6M
+func FormatCount(n int64) string {
+	switch {
+	case n >= 1000000:
+		return trimDecimal(float64(n)/1000000) + "M"
+	case n >= 1000:
+		return trimDecimal(float64(n)/1000) + "K"
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
+
+// FormatThousands formats n with thousands separators, e.g. 1203 as 1,203
+func FormatThousands(n int64) string {
+	if n < 0 {
+		return "-" + FormatThousands(-n)
+	}
+	
+	digits := fmt.Sprintf("%d", n)
+	var b strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(digit)
+	}
+	return b.String()
+}
+
+// trimDecimal formats v with one decimal, dropping a trailing .0
+func trimDecimal(v float64) string {
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", v), ".0")
+}
+
+// MinViewWidth is the narrowest a view is laid out for; narrower views show
+// TooSmallMessage instead of garbled columns
+const MinViewWidth = 24
+
+// TooSmallMessage replaces a view the terminal is too small for
+const TooSmallMessage = "Terminal too small — resize"
+
+// RenderTooSmall renders TooSmallMessage cut to width, keeping at least one
+// character so the screen never goes blank
+func RenderTooSmall(width int) string {
+	message := []rune(TooSmallMessage)
+	if width < 1 {
+		width = 1
+	}
+	if len(message) > width {
+		message = message[:width]
+	}
+	return StatusStyle.Render(string(message))
+}
+
 // TruncateText truncates text to fit within the specified width
 func TruncateText(text string, width int) string {
 	if len(text) <= width {
@@ -266,6 +475,58 @@ func TruncateText(text string, width int) string {
 	return text[:width-3] + "..."
 }
 
+// HighlightMatch wraps every case-insensitive occurrence of the query's
+// whitespace-separated tokens in the highlight style. The visible text is
+// unchanged, so callers should truncate and measure before highlighting.
+func HighlightMatch(text, query string) string {
+	tokens := strings.Fields(strings.ToLower(query))
+	if text == "" || len(tokens) == 0 {
+		return text
+	}
+	
+	// Lowercasing can change byte lengths for some runes; skip highlighting
+	// rather than risk splitting a multi-byte character
+	lower := strings.ToLower(text)
+	if len(lower) != len(text) {
+		return text
+	}
+	
+	// Mark matched byte ranges (tokens may overlap)
+	matched := make([]bool, len(text))
+	for _, token := range tokens {
+		offset := 0
+		for {
+			idx := strings.Index(lower[offset:], token)
+			if idx < 0 {
+				break
+			}
+			start := offset + idx
+			for i := start; i < start+len(token); i++ {
+				matched[i] = true
+			}
+			offset = start + len(token)
+		}
+	}
+	
+	// Compose runs of matched and unmatched text
+	var b strings.Builder
+	runStart := 0
+	for i := 1; i <= len(text); i++ {
+		if i < len(text) && matched[i] == matched[runStart] {
+			continue
+		}
+		segment := text[runStart:i]
+		if matched[runStart] {
+			b.WriteString(HighlightStyle.Render(segment))
+		} else {
+			b.WriteString(segment)
+		}
+		runStart = i
+	}
+	
+	return b.String()
+}
+
 // RenderTrackTitle renders a track title with appropriate styling and truncation
 func RenderTrackTitle(title string, maxWidth int) string {
 	if title == "" {
@@ -278,7 +539,7 @@ func RenderTrackTitle(title string, maxWidth int) string {
 
 // RenderArtistName renders an artist name with appropriate styling and truncation
 func RenderArtistName(artistName string, maxWidth int) string {
-	if artistName == "" {
+	if strings.TrimSpace(artistName) == "" {
 		artistName = "Unknown Artist"
 	}
 	
@@ -0,0 +1,48 @@
+package version
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+)
+
+// Version and Commit identify the build. Release builds set them with
+// -ldflags "-X soundcloud-tui/internal/version.Version=<version>
+// -X soundcloud-tui/internal/version.Commit=<commit>".
+var (
+	Version = "dev"
+	Commit  = ""
+)
+
+// UserAgent identifies this build in HTTP requests
+func UserAgent() string {
+	return "sctui/" + Version
+}
+
+// String describes the build as "sctui <version> (commit <commit>, <go version>)".
+// Without a commit set at build time, the one Go recorded from the checkout
+// is used, if any.
+func String() string {
+	commit := Commit
+	if commit == "" {
+		commit = vcsRevision()
+	}
+	if commit == "" {
+		commit = "unknown"
+	}
+	return fmt.Sprintf("sctui %s (commit %s, %s)", Version, commit, runtime.Version())
+}
+
+// vcsRevision returns the commit Go embedded when building from a checkout
+func vcsRevision() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" {
+			return setting.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+package audio_test
+
+import (
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+)
+
+// bufferedWAV returns a reader over a downloaded WAV stream of 1024 silent
+// samples
+func bufferedWAV() *audio.BufferReader {
+	const dataSize = 1024 * 4
+	header := wavHeader()
+	binary.LittleEndian.PutUint32(header[4:], 36+dataSize)
+	binary.LittleEndian.PutUint32(header[40:], dataSize)
+	data := append(header, make([]byte, dataSize)...)
+	return audio.NewBufferReader(audio.NewCompletedStreamBuffer(data))
+}
+
+func TestBufferReader_WAVDecodesAfterFailedMP3Attempt(t *testing.T) {
+	reader := bufferedWAV()
+
+	_, _, err := audio.Decode(audio.FormatMP3, reader)
+	require.Error(t, err, "WAV data is not MP3")
+
+	reader.Reset()
+	streamer, _, err := audio.Decode(audio.FormatWAV, reader)
+	require.NoError(t, err)
+	defer streamer.Close()
+
+	assert.Equal(t, 1024, countSamples(streamer))
+}
+
+func TestBufferReader_ResetReopensClosedReader(t *testing.T) {
+	reader := bufferedWAV()
+
+	_, err := reader.Seek(100, io.SeekStart)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+
+	_, err = reader.Read(make([]byte, 4))
+	assert.ErrorIs(t, err, audio.ErrReaderClosed)
+
+	reader.Reset()
+	header := make([]byte, 4)
+	_, err = io.ReadFull(reader, header)
+	require.NoError(t, err)
+	assert.Equal(t, "RIFF", string(header), "reading starts over from the first byte")
+}
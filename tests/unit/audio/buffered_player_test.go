@@ -2,6 +2,7 @@ package audio_test
 
 import (
 	"context"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -130,17 +131,17 @@ func TestBufferedStreamPlayer_SeekOperations(t *testing.T) {
 func TestBufferedStreamPlayer_CallbacksAndCleanup(t *testing.T) {
 	player := audio.NewBufferedStreamPlayer()
 	
-	stateChangeCalled := false
-	errorCalled := false
+	var playingReported atomic.Bool
 	
 	// Set callbacks
 	player.SetStateChangeCallback(func(state audio.PlayerState) {
-		stateChangeCalled = true
+		if state == audio.StatePlaying {
+			playingReported.Store(true)
+		}
 	})
 	
-	player.SetErrorCallback(func(err error) {
-		errorCalled = true
-	})
+	// Play reports its own failures; the callback is for downloads that fail later
+	player.SetErrorCallback(func(err error) {})
 	
 	// Try to play invalid URL to trigger error callback
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
@@ -151,6 +152,7 @@ func TestBufferedStreamPlayer_CallbacksAndCleanup(t *testing.T) {
 	
 	// Give callbacks time to execute
 	time.Sleep(100 * time.Millisecond)
+	assert.False(t, playingReported.Load(), "a failed play should never report playing")
 	
 	// Close should not panic
 	assert.NoError(t, player.Close())
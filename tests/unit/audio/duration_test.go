@@ -0,0 +1,47 @@
+package audio_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"soundcloud-tui/internal/audio"
+)
+
+func TestEffectiveDuration_StableDuringProgressiveBuffering(t *testing.T) {
+	expected := 3 * time.Minute
+
+	// The decoded length grows as more of the stream is buffered
+	for _, decoded := range []time.Duration{0, 5 * time.Second, 30 * time.Second, 90 * time.Second, expected - time.Millisecond} {
+		assert.Equal(t, expected, audio.EffectiveDuration(decoded, expected),
+			"total should stay at the metadata duration while buffering (decoded %s)", decoded)
+	}
+
+	// Once the decoded length exceeds the metadata it becomes authoritative
+	assert.Equal(t, expected+time.Second, audio.EffectiveDuration(expected+time.Second, expected))
+}
+
+func TestEffectiveDuration_WithoutMetadata(t *testing.T) {
+	assert.Equal(t, 42*time.Second, audio.EffectiveDuration(42*time.Second, 0))
+	assert.Equal(t, time.Duration(0), audio.EffectiveDuration(0, 0))
+}
+
+func TestPlayers_SetExpectedDuration(t *testing.T) {
+	players := map[string]audio.Player{
+		"beep":     audio.NewBeepPlayer(),
+		"buffered": audio.NewBufferedStreamPlayer(),
+	}
+
+	for name, player := range players {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, time.Duration(0), player.GetDuration())
+
+			player.SetExpectedDuration(200 * time.Second)
+			assert.Equal(t, 200*time.Second, player.GetDuration(), "metadata duration is known before decoding starts")
+
+			player.SetExpectedDuration(0)
+			assert.Equal(t, time.Duration(0), player.GetDuration())
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package audio_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gopxl/beep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+)
+
+const testSampleRate = beep.SampleRate(44100)
+
+// sineStreamer produces a DC-free stereo sine wave
+func sineStreamer(frequency float64) beep.Streamer {
+	i := 0
+	return beep.StreamerFunc(func(samples [][2]float64) (int, bool) {
+		for j := range samples {
+			v := 0.5 * math.Sin(2*math.Pi*frequency*float64(i)/float64(testSampleRate))
+			samples[j] = [2]float64{v, v}
+			i++
+		}
+		return len(samples), true
+	})
+}
+
+// peakAfterSettling streams a second of audio and returns the peak of the last half
+func peakAfterSettling(s beep.Streamer) float64 {
+	samples := make([][2]float64, int(testSampleRate))
+	n, _ := s.Stream(samples)
+
+	peak := 0.0
+	for _, sample := range samples[n/2 : n] {
+		peak = math.Max(peak, math.Abs(sample[0]))
+	}
+	return peak
+}
+
+func TestEqualizer_ZeroGainPassesSignalUnchanged(t *testing.T) {
+	reference := make([][2]float64, 4096)
+	sineStreamer(440).Stream(reference)
+
+	eq := audio.NewEqualizer(sineStreamer(440), testSampleRate)
+	eq.SetGains(0, 0, 0)
+	require.True(t, eq.Bypassed())
+
+	output := make([][2]float64, 4096)
+	n, ok := eq.Stream(output)
+
+	assert.True(t, ok)
+	assert.Equal(t, len(output), n)
+	assert.Equal(t, reference, output)
+}
+
+func TestEqualizer_ReturningToFlatBypassesAgain(t *testing.T) {
+	eq := audio.NewEqualizer(sineStreamer(440), testSampleRate)
+
+	eq.SetGains(6, 0, 0)
+	assert.False(t, eq.Bypassed())
+
+	eq.SetGains(0, 0, 0)
+	assert.True(t, eq.Bypassed())
+}
+
+func TestEqualizer_LowBoostAffectsBassOnly(t *testing.T) {
+	bass := audio.NewEqualizer(sineStreamer(60), testSampleRate)
+	bass.SetGains(12, 0, 0)
+
+	treble := audio.NewEqualizer(sineStreamer(10000), testSampleRate)
+	treble.SetGains(12, 0, 0)
+
+	// A +12 dB shelf roughly quadruples amplitude well below the corner
+	assert.Greater(t, peakAfterSettling(bass), 1.5)
+	assert.InDelta(t, 0.5, peakAfterSettling(treble), 0.05)
+}
+
+func TestEqualizer_HighCutAttenuatesTreble(t *testing.T) {
+	eq := audio.NewEqualizer(sineStreamer(10000), testSampleRate)
+	eq.SetGains(0, 0, -12)
+
+	assert.Less(t, peakAfterSettling(eq), 0.2)
+}
+
+func TestValidateEQGains(t *testing.T) {
+	assert.NoError(t, audio.ValidateEQGains(0, 0, 0))
+	assert.NoError(t, audio.ValidateEQGains(-audio.MaxEQGain, 3, audio.MaxEQGain))
+	assert.Error(t, audio.ValidateEQGains(audio.MaxEQGain+1, 0, 0))
+	assert.Error(t, audio.ValidateEQGains(0, math.NaN(), 0))
+}
+
+func TestPlayers_SetEQ(t *testing.T) {
+	players := map[string]audio.Player{
+		"beep":     audio.NewBeepPlayer(),
+		"buffered": audio.NewBufferedStreamPlayer(),
+	}
+
+	for name, player := range players {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, player.SetEQ(3, -2, 1))
+			low, mid, high := player.GetEQ()
+			assert.Equal(t, []float64{3, -2, 1}, []float64{low, mid, high})
+
+			assert.Error(t, player.SetEQ(20, 0, 0))
+			low, _, _ = player.GetEQ()
+			assert.Equal(t, 3.0, low, "invalid gains should not be applied")
+		})
+	}
+}
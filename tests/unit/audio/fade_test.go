@@ -0,0 +1,112 @@
+package audio_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gopxl/beep/effects"
+	"github.com/gopxl/beep/speaker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+)
+
+func gainOf(ctrl *effects.Volume) float64 {
+	speaker.Lock()
+	defer speaker.Unlock()
+	return audio.EffectiveGain(ctrl)
+}
+
+// sampleFade records the effective gain while a fade runs
+func sampleFade(ctrl *effects.Volume, volume float64, duration time.Duration) []float64 {
+	done := make(chan struct{})
+	go func() {
+		audio.FadeVolume(context.Background(), ctrl, volume, duration)
+		close(done)
+	}()
+
+	var gains []float64
+	for {
+		select {
+		case <-done:
+			return append(gains, gainOf(ctrl))
+		case <-time.After(2 * time.Millisecond):
+			gains = append(gains, gainOf(ctrl))
+		}
+	}
+}
+
+func TestFadeVolume_FadeInRampsGradually(t *testing.T) {
+	ctrl := &effects.Volume{Base: 2, Volume: 0, Silent: true}
+
+	gains := sampleFade(ctrl, 1.0, 100*time.Millisecond)
+
+	intermediate := map[float64]bool{}
+	for i, gain := range gains {
+		if gain > 0 && gain < 1 {
+			intermediate[gain] = true
+		}
+		if i > 0 {
+			assert.GreaterOrEqual(t, gain, gains[i-1], "fade-in should never get quieter")
+		}
+	}
+	assert.GreaterOrEqual(t, len(intermediate), 3, "volume should ramp through several levels instead of jumping")
+	assert.InDelta(t, 1.0, gains[len(gains)-1], 0.0001)
+	assert.False(t, ctrl.Silent)
+}
+
+func TestFadeVolume_FadeOutEndsSilent(t *testing.T) {
+	ctrl := &effects.Volume{Base: 2, Volume: 0, Silent: false}
+
+	gains := sampleFade(ctrl, 0, 100*time.Millisecond)
+
+	for i := 1; i < len(gains); i++ {
+		assert.LessOrEqual(t, gains[i], gains[i-1], "fade-out should never get louder")
+	}
+	assert.True(t, ctrl.Silent)
+}
+
+func TestFadeVolume_RestoresChosenVolume(t *testing.T) {
+	ctrl := &effects.Volume{Base: 2, Volume: 0, Silent: true}
+
+	audio.FadeVolume(context.Background(), ctrl, 0.6, 50*time.Millisecond)
+
+	// The fade must land on the same setting SetVolume(0.6) would apply
+	reference := &effects.Volume{Base: 2}
+	audio.FadeVolume(context.Background(), reference, 0.6, 0)
+	assert.Equal(t, reference.Volume, ctrl.Volume)
+	assert.False(t, ctrl.Silent)
+}
+
+func TestFadeVolume_ZeroDurationAppliesImmediately(t *testing.T) {
+	ctrl := &effects.Volume{Base: 2, Volume: 0, Silent: true}
+
+	start := time.Now()
+	audio.FadeVolume(context.Background(), ctrl, 1.0, 0)
+
+	assert.Less(t, time.Since(start), 10*time.Millisecond)
+	assert.Equal(t, 1.0, gainOf(ctrl))
+}
+
+func TestFadeVolume_CancelStopsRamp(t *testing.T) {
+	ctrl := &effects.Volume{Base: 2, Volume: 0, Silent: true}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	audio.FadeVolume(ctx, ctrl, 1.0, time.Second)
+
+	assert.Less(t, gainOf(ctrl), 1.0, "a cancelled fade should not reach the target")
+}
+
+func TestPlayers_SetFadeDuration(t *testing.T) {
+	beepPlayer := audio.NewBeepPlayer()
+	beepPlayer.SetFadeDuration(0)
+	beepPlayer.SetFadeDuration(audio.DefaultFadeDuration)
+	require.Equal(t, 1.0, beepPlayer.GetVolume())
+
+	bufferedPlayer := audio.NewBufferedStreamPlayer()
+	bufferedPlayer.SetFadeDuration(0)
+	require.Equal(t, 1.0, bufferedPlayer.GetVolume())
+}
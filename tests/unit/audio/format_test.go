@@ -0,0 +1,118 @@
+package audio_test
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gopxl/beep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+)
+
+func TestDecode_OggVorbisFixture(t *testing.T) {
+	file, err := os.Open("testdata/short_vorbis.ogg")
+	require.NoError(t, err)
+
+	streamer, format, err := audio.Decode(audio.FormatOgg, file)
+	require.NoError(t, err)
+	defer streamer.Close()
+
+	assert.Equal(t, beep.SampleRate(44100), format.SampleRate)
+	assert.Equal(t, 22050, streamer.Len())
+
+	// Decode every sample to make sure the stream is actually readable
+	samples := make([][2]float64, 512)
+	total := 0
+	for {
+		n, ok := streamer.Stream(samples)
+		total += n
+		if !ok {
+			break
+		}
+	}
+	assert.NoError(t, streamer.Err())
+	assert.Equal(t, 22050, total)
+}
+
+func TestDecode_OpusIsReportedAsUnsupported(t *testing.T) {
+	_, _, err := audio.Decode(audio.FormatOpus, io.NopCloser(strings.NewReader("OggS")))
+
+	assert.ErrorIs(t, err, audio.ErrOpusUnsupported)
+}
+
+// countSamples decodes the whole stream and returns how many samples it held
+func countSamples(streamer beep.Streamer) int {
+	samples := make([][2]float64, 512)
+	total := 0
+	for {
+		n, ok := streamer.Stream(samples)
+		total += n
+		if !ok {
+			return total
+		}
+	}
+}
+
+func TestDecodeWithFallback_MislabeledStream(t *testing.T) {
+	file, err := os.Open("testdata/short_vorbis.ogg")
+	require.NoError(t, err)
+
+	// A stream served as MP3 that is really Ogg Vorbis still decodes
+	streamer, format, err := audio.DecodeWithFallback(audio.FormatMP3, io.NopCloser(file))
+	require.NoError(t, err)
+	defer streamer.Close()
+
+	assert.Equal(t, beep.SampleRate(44100), format.SampleRate)
+	assert.Equal(t, 22050, countSamples(streamer))
+}
+
+func TestDecodeWithFallback_UndeclaredFormat(t *testing.T) {
+	file, err := os.Open("testdata/short_vorbis.ogg")
+	require.NoError(t, err)
+
+	streamer, _, err := audio.DecodeWithFallback("", io.NopCloser(file))
+	require.NoError(t, err)
+	defer streamer.Close()
+
+	assert.Equal(t, 22050, countSamples(streamer))
+}
+
+func TestDecodeWithFallback_OpusData(t *testing.T) {
+	header := "OggS" + strings.Repeat("\x00", 24) + "OpusHead"
+
+	_, _, err := audio.DecodeWithFallback(audio.FormatOgg, io.NopCloser(strings.NewReader(header)))
+
+	assert.ErrorIs(t, err, audio.ErrOpusUnsupported, "opus data should be reported rather than guessed at")
+}
+
+func TestDecodeWithFallback_UnknownData(t *testing.T) {
+	_, _, err := audio.DecodeWithFallback("", io.NopCloser(strings.NewReader("<html>not audio</html>")))
+
+	assert.Error(t, err)
+}
+
+func TestFormatFromMimeType(t *testing.T) {
+	tests := []struct {
+		mimeType string
+		expected string
+	}{
+		{"audio/mpeg", audio.FormatMP3},
+		{"audio/ogg", audio.FormatOgg},
+		{"audio/ogg; codecs=\"opus\"", audio.FormatOpus},
+		{"audio/ogg; codecs=\"vorbis\"", audio.FormatOgg},
+		{"audio/wav", audio.FormatWAV},
+		{"application/vnd.apple.mpegurl", audio.FormatHLS},
+		{"text/html", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mimeType, func(t *testing.T) {
+			assert.Equal(t, tt.expected, audio.FormatFromMimeType(tt.mimeType))
+		})
+	}
+}
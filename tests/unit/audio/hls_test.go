@@ -0,0 +1,430 @@
+package audio_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gopxl/beep"
+	"github.com/gopxl/beep/wav"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+)
+
+// mockMediaPlaylist has four segments: 10s, 10s, 9.5s and 4s long
+const mockMediaPlaylist = `#EXTM3U
+#EXT-X-VERSION:3
+#EXT-X-TARGETDURATION:10
+#EXT-X-MEDIA-SEQUENCE:0
+#EXTINF:10.0,
+segments/0.mp3?sig=a
+#EXTINF:10.0,
+segments/1.mp3?sig=b
+#EXTINF:9.5,
+https://cdn.example.com/media/2.mp3
+#EXTINF:4,
+segments/3.mp3
+#EXT-X-ENDLIST
+`
+
+func parseMockPlaylist(t *testing.T) *audio.HLSPlaylist {
+	t.Helper()
+	base, err := url.Parse("https://playback.example.com/tracks/1/playlist.m3u8")
+	require.NoError(t, err)
+
+	playlist, err := audio.ParseHLSPlaylist(strings.NewReader(mockMediaPlaylist), base)
+	require.NoError(t, err)
+	return playlist
+}
+
+func TestParseHLSPlaylist(t *testing.T) {
+	playlist := parseMockPlaylist(t)
+
+	require.Len(t, playlist.Segments, 4)
+	assert.Equal(t, audio.HLSSegment{
+		URL:      "https://playback.example.com/tracks/1/segments/1.mp3?sig=b",
+		Start:    10 * time.Second,
+		Duration: 10 * time.Second,
+	}, playlist.Segments[1])
+	assert.Equal(t, "https://cdn.example.com/media/2.mp3", playlist.Segments[2].URL)
+	assert.Equal(t, 29500*time.Millisecond, playlist.Segments[3].Start)
+	assert.Equal(t, 33500*time.Millisecond, playlist.Duration())
+}
+
+func TestHLSPlaylist_SegmentAt(t *testing.T) {
+	playlist := parseMockPlaylist(t)
+
+	tests := []struct {
+		name     string
+		position time.Duration
+		segment  int
+		offset   time.Duration
+	}{
+		{"start", 0, 0, 0},
+		{"inside first segment", 4 * time.Second, 0, 4 * time.Second},
+		{"segment boundary", 10 * time.Second, 1, 0},
+		{"just before a boundary", 20*time.Second - time.Millisecond, 1, 10*time.Second - time.Millisecond},
+		{"fractional segment", 25 * time.Second, 2, 5 * time.Second},
+		{"last segment", 30 * time.Second, 3, 500 * time.Millisecond},
+		{"end of track", 33500 * time.Millisecond, 3, 4 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			segment, offset, err := playlist.SegmentAt(tt.position)
+			require.NoError(t, err)
+			assert.Equal(t, tt.segment, segment)
+			assert.Equal(t, tt.offset, offset)
+			// Seeking to the segment start and on by the offset reaches the target
+			assert.Equal(t, tt.position, playlist.Segments[segment].Start+offset)
+		})
+	}
+
+	_, _, err := playlist.SegmentAt(-time.Second)
+	assert.ErrorContains(t, err, "cannot be negative")
+	_, _, err = playlist.SegmentAt(34 * time.Second)
+	assert.ErrorContains(t, err, "exceeds duration")
+}
+
+func TestParseHLSPlaylist_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		playlist string
+		expected string
+	}{
+		{"empty", "#EXTM3U\n#EXT-X-ENDLIST\n", "no segments"},
+		{"master playlist", "#EXTM3U\n#EXT-X-STREAM-INF:BANDWIDTH=128000\nlow.m3u8\n", "master playlists"},
+		{"segment without duration", "#EXTM3U\nsegments/0.mp3\n", "has no duration"},
+		{"bad duration", "#EXTM3U\n#EXTINF:soon,\nsegments/0.mp3\n", "invalid segment duration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := audio.ParseHLSPlaylist(strings.NewReader(tt.playlist), nil)
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
+
+// hlsServer serves a playlist of one-second 8kHz WAV segments, each a
+// constant level so samples show which segment they came from, and records
+// the paths requested
+type hlsServer struct {
+	*httptest.Server
+	levels []float64
+
+	mu       sync.Mutex
+	requests []string
+	missing  map[int]bool          // Segments answered with a 404
+	held     map[int]chan struct{} // Segments answered once released
+}
+
+func newHLSServer(t *testing.T, levels ...float64) *hlsServer {
+	t.Helper()
+	format := beep.Format{SampleRate: 8000, NumChannels: 1, Precision: 2}
+
+	segments := make([][]byte, len(levels))
+	var playlist strings.Builder
+	playlist.WriteString("#EXTM3U\n#EXT-X-TARGETDURATION:1\n")
+	for i, level := range levels {
+		// wav.Encode needs to seek back to fill in the header
+		path := filepath.Join(t.TempDir(), fmt.Sprintf("%d.wav", i))
+		file, err := os.Create(path)
+		require.NoError(t, err)
+		require.NoError(t, wav.Encode(file, constantLevel(level, format.SampleRate.N(time.Second)), format))
+		require.NoError(t, file.Close())
+		segments[i], err = os.ReadFile(path)
+		require.NoError(t, err)
+		fmt.Fprintf(&playlist, "#EXTINF:1.0,\nsegments/%d.wav\n", i)
+	}
+	playlist.WriteString("#EXT-X-ENDLIST\n")
+
+	s := &hlsServer{levels: levels, missing: map[int]bool{}, held: map[int]chan struct{}{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r.URL.Path)
+		s.mu.Unlock()
+
+		if r.URL.Path == "/tracks/1/playlist.m3u8" {
+			w.Header().Set("Content-Type", "application/vnd.apple.mpegurl")
+			w.Write([]byte(playlist.String()))
+			return
+		}
+		var i int
+		if _, err := fmt.Sscanf(r.URL.Path, "/tracks/1/segments/%d.wav", &i); err != nil || i >= len(segments) || s.isMissing(i) {
+			http.NotFound(w, r)
+			return
+		}
+		if release := s.release(i); release != nil {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+				return
+			}
+		}
+		w.Header().Set("Content-Type", "audio/wav")
+		w.Write(segments[i])
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *hlsServer) playlistURL() string {
+	return s.URL + "/tracks/1/playlist.m3u8"
+}
+
+func (s *hlsServer) isMissing(i int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.missing[i]
+}
+
+// hold keeps segment i from being answered until the returned func is called
+func (s *hlsServer) hold(i int) func() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	release := make(chan struct{})
+	s.held[i] = release
+	return func() { close(release) }
+}
+
+func (s *hlsServer) release(i int) chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.held[i]
+}
+
+func (s *hlsServer) requested() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+func (s *hlsServer) timesRequested(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := 0
+	for _, requested := range s.requests {
+		if requested == path {
+			count++
+		}
+	}
+	return count
+}
+
+// constantLevel streams n samples at level
+func constantLevel(level float64, n int) beep.Streamer {
+	return beep.Take(n, beep.StreamerFunc(func(samples [][2]float64) (int, bool) {
+		for i := range samples {
+			samples[i] = [2]float64{level, level}
+		}
+		return len(samples), true
+	}))
+}
+
+// drain streams s to the end, returning every sample. The silence played
+// while a segment downloads is left out; the test segments are never silent.
+func drain(s beep.Streamer) [][2]float64 {
+	var all [][2]float64
+	buf := make([][2]float64, 1000)
+	for {
+		n, ok := s.Stream(buf)
+		waiting := false
+		for _, sample := range buf[:n] {
+			if sample == [2]float64{} {
+				waiting = true
+				continue
+			}
+			all = append(all, sample)
+		}
+		if !ok {
+			return all
+		}
+		if waiting {
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
+
+func TestHLSStream_PlaysSegmentsInOrder(t *testing.T) {
+	server := newHLSServer(t, 0.1, 0.2, 0.3)
+
+	stream, format, err := audio.NewHLSStream(context.Background(), server.playlistURL())
+	require.NoError(t, err)
+	defer stream.Close()
+
+	assert.Equal(t, beep.SampleRate(8000), format.SampleRate)
+	assert.Equal(t, 24000, stream.Len())
+
+	samples := drain(stream)
+	require.Len(t, samples, 24000)
+	// beep's WAV decoder reads 16-bit samples back at half scale
+	for i, level := range server.levels {
+		assert.InDelta(t, level/2, samples[i*8000][0], 0.001, "first sample of segment %d", i)
+		assert.InDelta(t, level/2, samples[i*8000+7999][0], 0.001, "last sample of segment %d", i)
+	}
+	assert.Equal(t, stream.Len(), stream.Position())
+	assert.NoError(t, stream.Err())
+
+	assert.Equal(t, []string{
+		"/tracks/1/playlist.m3u8",
+		"/tracks/1/segments/0.wav",
+		"/tracks/1/segments/1.wav",
+		"/tracks/1/segments/2.wav",
+	}, server.requested())
+}
+
+func TestHLSStream_PlaysSilenceWhileSegmentDownloads(t *testing.T) {
+	server := newHLSServer(t, 0.1, 0.2)
+	release := server.hold(1)
+
+	stream, _, err := audio.NewHLSStream(context.Background(), server.playlistURL())
+	require.NoError(t, err)
+	defer stream.Close()
+
+	samples := make([][2]float64, 8000)
+	n, ok := stream.Stream(samples)
+	require.True(t, ok)
+	require.Equal(t, 8000, n)
+
+	// The second segment is still downloading, which mustn't hold up the speaker
+	done := make(chan struct{})
+	go func() {
+		n, ok = stream.Stream(samples)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Stream waited for the segment download")
+	}
+	assert.True(t, ok)
+	assert.Equal(t, 8000, n)
+	assert.Equal(t, [2]float64{}, samples[0])
+	assert.Equal(t, [2]float64{}, samples[7999])
+	assert.Equal(t, 8000, stream.Position(), "the position should hold while waiting")
+
+	release()
+	rest := drain(stream)
+	require.Len(t, rest, 8000)
+	assert.InDelta(t, 0.2/2, rest[0][0], 0.001)
+	assert.Equal(t, stream.Len(), stream.Position())
+}
+
+func TestHLSStream_MissingSegmentEndsPlayback(t *testing.T) {
+	server := newHLSServer(t, 0.1, 0.2, 0.3)
+	server.missing[1] = true
+
+	stream, _, err := audio.NewHLSStream(context.Background(), server.playlistURL())
+	require.NoError(t, err)
+	defer stream.Close()
+
+	samples := drain(stream)
+	assert.Len(t, samples, 8000, "only the first segment should play")
+	assert.ErrorContains(t, stream.Err(), "segment 1")
+}
+
+func TestHLSStream_InvalidPlaylist(t *testing.T) {
+	server := newHLSServer(t, 0.1)
+
+	_, _, err := audio.NewHLSStream(context.Background(), server.URL+"/tracks/2/playlist.m3u8")
+	assert.ErrorContains(t, err, "failed to fetch HLS playlist")
+}
+
+func TestBufferedStreamPlayer_PlayAndSeekHLS(t *testing.T) {
+	server := newHLSServer(t, 0.1, 0.2, 0.3, 0.4)
+	player := audio.NewBufferedStreamPlayer()
+	defer player.Close()
+	player.SetExpectedFormat(audio.FormatHLS)
+
+	playOrSkip(t, player, server.playlistURL())
+	assert.Equal(t, audio.StatePlaying, player.GetState())
+	assert.Equal(t, 4*time.Second, player.GetDuration())
+
+	// The player stays responsive while the target segment downloads
+	release := server.hold(2)
+	seeked := make(chan error, 1)
+	go func() {
+		seeked <- player.Seek(2500 * time.Millisecond)
+	}()
+	require.Eventually(t, func() bool {
+		return server.timesRequested("/tracks/1/segments/2.wav") > 0
+	}, time.Second, time.Millisecond)
+
+	state := make(chan audio.PlayerState, 1)
+	go func() {
+		state <- player.GetState()
+	}()
+	select {
+	case s := <-state:
+		assert.Equal(t, audio.StatePlaying, s)
+	case <-time.After(time.Second):
+		t.Fatal("GetState waited for the segment download")
+	}
+
+	release()
+	require.NoError(t, <-seeked)
+	assert.InDelta(t, float64(2500*time.Millisecond), float64(player.GetPosition()), float64(200*time.Millisecond))
+}
+
+func TestHLSStream_SeekFetchesTargetSegment(t *testing.T) {
+	server := newHLSServer(t, 0.1, 0.2, 0.3, 0.4)
+
+	stream, _, err := audio.NewHLSStream(context.Background(), server.playlistURL())
+	require.NoError(t, err)
+	defer stream.Close()
+	assert.NotContains(t, server.requested(), "/tracks/1/segments/2.wav")
+
+	// 2.5s is halfway into the third segment
+	require.NoError(t, stream.Seek(20000))
+	assert.Contains(t, server.requested(), "/tracks/1/segments/2.wav")
+	assert.Equal(t, 20000, stream.Position())
+
+	samples := make([][2]float64, 100)
+	n, ok := stream.Stream(samples)
+	require.True(t, ok)
+	require.Equal(t, 100, n)
+	assert.InDelta(t, 0.3/2, samples[0][0], 0.001)
+	assert.Equal(t, 20100, stream.Position())
+
+	// Seeking within the playing segment needs no fetch
+	require.NoError(t, stream.Seek(16000))
+	assert.Equal(t, 16000, stream.Position())
+
+	// Playback carries on through the following segments to the end
+	rest := drain(stream)
+	require.Len(t, rest, 16000)
+	assert.InDelta(t, 0.3/2, rest[0][0], 0.001)
+	assert.InDelta(t, 0.4/2, rest[15999][0], 0.001)
+	assert.Equal(t, 1, server.timesRequested("/tracks/1/segments/2.wav"))
+	assert.Equal(t, 1, server.timesRequested("/tracks/1/segments/3.wav"))
+	assert.NoError(t, stream.Err())
+}
+
+func TestHLSStream_SeekOutOfRange(t *testing.T) {
+	server := newHLSServer(t, 0.1, 0.2)
+
+	stream, _, err := audio.NewHLSStream(context.Background(), server.playlistURL())
+	require.NoError(t, err)
+	defer stream.Close()
+
+	assert.Error(t, stream.Seek(-1))
+	assert.Error(t, stream.Seek(16001))
+
+	// Seeking back after the end starts playback again
+	drain(stream)
+	require.NoError(t, stream.Seek(0))
+	assert.Len(t, drain(stream), 16000)
+}
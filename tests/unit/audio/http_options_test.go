@@ -0,0 +1,106 @@
+package audio_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/version"
+)
+
+// recordingTransport captures outgoing requests without touching the network
+type recordingTransport struct {
+	requests chan *http.Request
+}
+
+func newRecordingTransport() *recordingTransport {
+	return &recordingTransport{requests: make(chan *http.Request, 16)}
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.requests <- req:
+	default:
+	}
+	return nil, errors.New("network disabled in tests")
+}
+
+func (t *recordingTransport) next(tb testing.TB) *http.Request {
+	tb.Helper()
+	select {
+	case req := <-t.requests:
+		return req
+	case <-time.After(2 * time.Second):
+		tb.Fatal("no request was sent")
+		return nil
+	}
+}
+
+func TestDefaultHTTPOptions(t *testing.T) {
+	options := audio.DefaultHTTPOptions()
+
+	assert.Equal(t, 30*time.Second, options.ConnectTimeout)
+	assert.Equal(t, time.Duration(0), options.StreamTimeout, "streaming body reads should not be capped by default")
+	assert.Equal(t, audio.DefaultUserAgent, options.UserAgent)
+	assert.Equal(t, "sctui/"+version.Version, audio.DefaultUserAgent)
+	assert.Equal(t, 10, options.MaxIdleConns)
+	assert.Equal(t, 30*time.Second, options.IdleConnTimeout)
+	assert.Equal(t, audio.DefaultPositionInterval, options.PositionInterval)
+}
+
+func TestBeepPlayer_SetsUserAgentOnStreamRequest(t *testing.T) {
+	transport := newRecordingTransport()
+	player := audio.NewBeepPlayer(
+		audio.WithTransport(transport),
+		audio.WithUserAgent("sctui-test/1.0"),
+	)
+	defer player.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	err := player.Play(ctx, "https://example.com/stream.mp3")
+	require.Error(t, err)
+
+	req := transport.next(t)
+	assert.Equal(t, "sctui-test/1.0", req.Header.Get("User-Agent"))
+	assert.Equal(t, "https://example.com/stream.mp3", req.URL.String())
+}
+
+func TestBufferedStreamPlayer_SetsUserAgentOnStreamRequest(t *testing.T) {
+	transport := newRecordingTransport()
+	player := audio.NewBufferedStreamPlayer(
+		audio.WithTransport(transport),
+		audio.WithUserAgent("sctui-test/1.0"),
+	)
+	defer player.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	err := player.Play(ctx, "https://example.com/stream.mp3")
+	require.Error(t, err)
+
+	req := transport.next(t)
+	assert.Equal(t, "sctui-test/1.0", req.Header.Get("User-Agent"))
+}
+
+func TestBufferedStreamPlayer_DefaultUserAgent(t *testing.T) {
+	transport := newRecordingTransport()
+	player := audio.NewBufferedStreamPlayer(audio.WithTransport(transport))
+	defer player.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	_ = player.Play(ctx, "https://example.com/stream.mp3")
+
+	req := transport.next(t)
+	assert.Equal(t, audio.DefaultUserAgent, req.Header.Get("User-Agent"))
+}
@@ -0,0 +1,82 @@
+package audio_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gopxl/beep"
+	"github.com/stretchr/testify/assert"
+
+	"soundcloud-tui/internal/audio"
+)
+
+func TestLevelMeter_PassesSamplesThroughUnchanged(t *testing.T) {
+	reference := make([][2]float64, 4096)
+	sineStreamer(440).Stream(reference)
+
+	meter := audio.NewLevelMeter(sineStreamer(440))
+	output := make([][2]float64, 4096)
+	n, ok := meter.Stream(output)
+
+	assert.True(t, ok)
+	assert.Equal(t, len(output), n)
+	assert.Equal(t, reference, output)
+}
+
+func TestLevelMeter_MeasuresRMSPerChannel(t *testing.T) {
+	meter := audio.NewLevelMeter(sineStreamer(440))
+	meter.Stream(make([][2]float64, int(testSampleRate)))
+
+	left, right := meter.Levels()
+
+	// A sine of amplitude 0.5 has an RMS of 0.5/√2
+	assert.InDelta(t, 0.5/math.Sqrt2, left, 0.01)
+	assert.InDelta(t, 0.5/math.Sqrt2, right, 0.01)
+}
+
+func TestLevelMeter_DecaysOnSilence(t *testing.T) {
+	loud := true
+	meter := audio.NewLevelMeter(beep.StreamerFunc(func(samples [][2]float64) (int, bool) {
+		for i := range samples {
+			if loud {
+				samples[i] = [2]float64{1, 0.5}
+			} else {
+				samples[i] = [2]float64{}
+			}
+		}
+		return len(samples), true
+	}))
+	meter.Stream(make([][2]float64, 512))
+	left, right := meter.Levels()
+	assert.InDelta(t, 1.0, left, 1e-9)
+	assert.InDelta(t, 0.5, right, 1e-9)
+
+	// The level falls off gradually rather than dropping straight to zero
+	loud = false
+	meter.Stream(make([][2]float64, 512))
+	decayed, _ := meter.Levels()
+	assert.Greater(t, decayed, 0.0)
+	assert.Less(t, decayed, left)
+
+	for i := 0; i < 100; i++ {
+		meter.Stream(make([][2]float64, 512))
+	}
+	left, right = meter.Levels()
+	assert.InDelta(t, 0, left, 1e-3)
+	assert.InDelta(t, 0, right, 1e-3)
+}
+
+func TestPlayers_ReportZeroLevelsWhenIdle(t *testing.T) {
+	players := map[string]audio.Player{
+		"beep":     audio.NewBeepPlayer(),
+		"buffered": audio.NewBufferedStreamPlayer(),
+	}
+
+	for name, player := range players {
+		t.Run(name, func(t *testing.T) {
+			left, right := player.GetLevels()
+			assert.Zero(t, left)
+			assert.Zero(t, right)
+		})
+	}
+}
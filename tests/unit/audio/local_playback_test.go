@@ -0,0 +1,51 @@
+package audio_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+)
+
+// shortToneURL is a file:// URL for the quarter second 22.05kHz mono WAV
+// fixture
+func shortToneURL(t *testing.T) string {
+	t.Helper()
+	path, err := filepath.Abs("testdata/short_tone.wav")
+	require.NoError(t, err)
+	return "file://" + path
+}
+
+func TestBeepPlayer_PlaysLocalWAVFixture(t *testing.T) {
+	player := audio.NewBeepPlayer()
+	defer player.Close()
+
+	err := player.Play(context.Background(), shortToneURL(t))
+	if err != nil && strings.Contains(err.Error(), "failed to initialize speaker") {
+		t.Skipf("no audio device: %v", err)
+	}
+
+	require.NoError(t, err)
+	assert.Equal(t, audio.StatePlaying, player.GetState())
+	// 5512 frames at 22.05kHz, a frame short of a quarter second
+	assert.InDelta(t, float64(250*time.Millisecond), float64(player.GetDuration()), float64(time.Second/22050))
+	assert.Equal(t, 22050, player.GetSampleRate())
+}
+
+func TestBeepPlayer_MissingLocalFile(t *testing.T) {
+	player := audio.NewBeepPlayer()
+	defer player.Close()
+
+	start := time.Now()
+	err := player.Play(context.Background(), "file://"+filepath.Join(t.TempDir(), "missing.wav"))
+
+	assert.ErrorContains(t, err, "failed to open local file")
+	assert.Less(t, time.Since(start), time.Second, "waiting won't make a missing file appear, so it isn't retried")
+	assert.Equal(t, audio.StateStopped, player.GetState())
+}
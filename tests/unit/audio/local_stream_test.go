@@ -0,0 +1,34 @@
+package audio_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"soundcloud-tui/internal/audio"
+)
+
+func TestIsLocalStream(t *testing.T) {
+	tests := []struct {
+		streamURL string
+		local     bool
+	}{
+		{"file:///home/me/Music/song.mp3", true},
+		{"/home/me/Music/song.mp3", true},
+		{"song.WAV", true},
+		{"./mixes/set.ogg", true},
+		{"https://cf-media.sndcdn.com/stream.mp3", false},
+		{"https://soundcloud.com/artist/track", false},
+		{"lofi hip hop", false},
+		{"/home/me/notes.txt", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.local, audio.IsLocalStream(tt.streamURL), tt.streamURL)
+	}
+}
+
+func TestLocalPath(t *testing.T) {
+	assert.Equal(t, "/home/me/My Music/song.mp3", audio.LocalPath("file:///home/me/My%20Music/song.mp3"))
+	assert.Equal(t, "./song.mp3", audio.LocalPath("./song.mp3"))
+}
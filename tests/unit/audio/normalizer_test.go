@@ -0,0 +1,111 @@
+package audio_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/gopxl/beep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+)
+
+const normalizerRate = beep.SampleRate(44100)
+
+// sine streams an endless 440 Hz tone with the given peak amplitude
+func sine(amplitude float64) beep.Streamer {
+	position := 0
+	return beep.StreamerFunc(func(samples [][2]float64) (int, bool) {
+		for i := range samples {
+			value := amplitude * math.Sin(2*math.Pi*440*float64(position)/float64(normalizerRate))
+			samples[i] = [2]float64{value, value}
+			position++
+		}
+		return len(samples), true
+	})
+}
+
+// streamFor streams d worth of samples in speaker-sized blocks, returning the
+// RMS level in dBFS of the last second and the highest peak overall
+func streamFor(t *testing.T, streamer beep.Streamer, d time.Duration) (levelDB, peak float64) {
+	total := normalizerRate.N(d)
+	lastSecond := total - normalizerRate.N(time.Second)
+	block := make([][2]float64, normalizerRate.N(time.Second/10))
+
+	var sum float64
+	for streamed := 0; streamed < total; {
+		n, ok := streamer.Stream(block[:min(len(block), total-streamed)])
+		require.True(t, ok)
+		for i, sample := range block[:n] {
+			peak = math.Max(peak, math.Max(math.Abs(sample[0]), math.Abs(sample[1])))
+			if streamed+i >= lastSecond {
+				sum += sample[0] * sample[0]
+			}
+		}
+		streamed += n
+	}
+
+	return 10 * math.Log10(sum/float64(normalizerRate.N(time.Second))), peak
+}
+
+// sineLevel is the RMS level in dBFS of a sine with the given peak amplitude
+func sineLevel(amplitude float64) float64 {
+	return 20 * math.Log10(amplitude/math.Sqrt2)
+}
+
+func TestNormalizer_AttenuatesLoudSignalTowardsTarget(t *testing.T) {
+	normalizer := audio.NewNormalizer(sine(0.9), normalizerRate)
+	normalizer.SetEnabled(true)
+
+	level, _ := streamFor(t, normalizer, 10*time.Second)
+
+	assert.Less(t, level, sineLevel(0.9)-10, "a loud signal should be turned down")
+	assert.InDelta(t, audio.NormalizationTarget, level, 1.5)
+	assert.Less(t, normalizer.Gain(), 0.0)
+}
+
+func TestNormalizer_BoostsQuietSignalWithinLimit(t *testing.T) {
+	// 20 dB below the target needs more boost than normalization allows
+	amplitude := math.Sqrt2 * math.Pow(10, (audio.NormalizationTarget-20)/20)
+	normalizer := audio.NewNormalizer(sine(amplitude), normalizerRate)
+	normalizer.SetEnabled(true)
+
+	level, _ := streamFor(t, normalizer, 10*time.Second)
+
+	assert.Greater(t, level, sineLevel(amplitude)+5, "a quiet signal should be turned up")
+	assert.Less(t, level, audio.NormalizationTarget-5, "the boost should be limited")
+}
+
+func TestNormalizer_NeverClips(t *testing.T) {
+	// A quiet passage raises the gain before a full-scale one arrives
+	quiet := beep.Take(normalizerRate.N(5*time.Second), sine(0.02))
+	normalizer := audio.NewNormalizer(beep.Seq(quiet, sine(1.0)), normalizerRate)
+	normalizer.SetEnabled(true)
+
+	_, peak := streamFor(t, normalizer, 8*time.Second)
+
+	assert.LessOrEqual(t, peak, 1.0)
+}
+
+func TestNormalizer_DisabledPassesThrough(t *testing.T) {
+	normalizer := audio.NewNormalizer(sine(0.9), normalizerRate)
+
+	level, peak := streamFor(t, normalizer, 2*time.Second)
+
+	assert.InDelta(t, sineLevel(0.9), level, 0.01)
+	assert.InDelta(t, 0.9, peak, 0.001)
+	assert.Equal(t, 0.0, normalizer.Gain())
+}
+
+func TestNormalizer_DisablingRestoresLevel(t *testing.T) {
+	normalizer := audio.NewNormalizer(sine(0.9), normalizerRate)
+	normalizer.SetEnabled(true)
+	streamFor(t, normalizer, 5*time.Second)
+
+	normalizer.SetEnabled(false)
+	level, _ := streamFor(t, normalizer, time.Second)
+
+	assert.InDelta(t, sineLevel(0.9), level, 0.01)
+}
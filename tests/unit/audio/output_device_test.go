@@ -0,0 +1,59 @@
+package audio_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+)
+
+// fakePactl puts a pactl on PATH that lists two sinks
+func fakePactl(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS != "linux" {
+		t.Skip("output devices are only selectable on Linux")
+	}
+
+	dir := t.TempDir()
+	script := "#!/bin/sh\nprintf '0\\talsa_output.pci.analog-stereo\\tPipeWire\\ts32le 2ch 48000Hz\\tRUNNING\\n1\\tbluez_output.headphones\\tPipeWire\\ts16le 2ch 48000Hz\\tSUSPENDED\\n'\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pactl"), []byte(script), 0o755))
+	t.Setenv("PATH", dir)
+}
+
+func TestOutputDevices_ListAndSelect(t *testing.T) {
+	fakePactl(t)
+	t.Setenv("PULSE_SINK", "")
+
+	player := audio.NewBufferedStreamPlayer()
+	defer player.Close()
+	var selector audio.OutputDeviceSelector = player
+	devices, err := selector.ListOutputDevices()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alsa_output.pci.analog-stereo", "bluez_output.headphones"}, devices)
+
+	err = selector.SetOutputDevice("bluez_output.headphones")
+	if errors.Is(err, audio.ErrSpeakerStarted) {
+		t.Skip("an earlier test already started the speaker")
+	}
+	require.NoError(t, err)
+	assert.Equal(t, "bluez_output.headphones", os.Getenv("PULSE_SINK"))
+
+	assert.ErrorContains(t, selector.SetOutputDevice("hdmi"), `unknown output device "hdmi"`)
+}
+
+func TestOutputDevices_UnsupportedWithoutBackend(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	player := audio.NewBeepPlayer()
+	defer player.Close()
+	var selector audio.OutputDeviceSelector = player
+	_, err := selector.ListOutputDevices()
+
+	assert.ErrorIs(t, err, audio.ErrOutputDeviceUnsupported)
+}
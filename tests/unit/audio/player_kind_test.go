@@ -0,0 +1,39 @@
+package audio_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+)
+
+func TestParsePlayerKind(t *testing.T) {
+	for _, name := range []string{"beep", "buffered"} {
+		kind, err := audio.ParsePlayerKind(name)
+		require.NoError(t, err)
+		assert.Equal(t, audio.PlayerKind(name), kind)
+	}
+
+	_, err := audio.ParsePlayerKind("vlc")
+	assert.ErrorContains(t, err, `unknown player "vlc"`)
+}
+
+func TestNewPlayer_SelectsImplementation(t *testing.T) {
+	beep := audio.NewPlayer(audio.PlayerBeep)
+	defer beep.Close()
+	assert.IsType(t, &audio.BeepPlayer{}, beep)
+
+	buffered := audio.NewPlayer(audio.PlayerBuffered)
+	defer buffered.Close()
+	assert.IsType(t, &audio.BufferedStreamPlayer{}, buffered)
+}
+
+func TestNewPlayer_DefaultsToBuffered(t *testing.T) {
+	assert.Equal(t, audio.PlayerBuffered, audio.DefaultPlayerKind)
+
+	player := audio.NewPlayer("")
+	defer player.Close()
+	assert.IsType(t, &audio.BufferedStreamPlayer{}, player)
+}
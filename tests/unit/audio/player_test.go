@@ -2,15 +2,52 @@ package audio_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/gopxl/beep"
+	"github.com/gopxl/beep/wav"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	
 	"soundcloud-tui/internal/audio"
 )
 
+// silentWAV serves a minute of 8kHz mono silence and returns its URL
+func silentWAV(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "silence.wav")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+	
+	format := beep.Format{SampleRate: 8000, NumChannels: 1, Precision: 2}
+	require.NoError(t, wav.Encode(file, beep.Silence(format.SampleRate.N(time.Minute)), format))
+	
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/wav")
+		http.ServeFile(w, r, path)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL + "/silence.wav"
+}
+
+// playOrSkip starts streamURL on player, skipping the test when there's no
+// audio device to play on
+func playOrSkip(t *testing.T, player audio.Player, streamURL string) {
+	t.Helper()
+	err := player.Play(context.Background(), streamURL)
+	if err != nil && strings.Contains(err.Error(), "failed to initialize speaker") {
+		t.Skipf("no audio device: %v", err)
+	}
+	require.NoError(t, err)
+}
+
 func TestBeepPlayer_NewPlayer(t *testing.T) {
 	player := audio.NewBeepPlayer()
 	
@@ -29,7 +66,7 @@ func TestBeepPlayer_Play(t *testing.T) {
 	}{
 		{
 			name:      "valid stream URL starts playback",
-			streamURL: "https://cf-media.sndcdn.com/test.mp3",
+			streamURL: silentWAV(t),
 			wantErr:   false,
 		},
 		{
@@ -42,17 +79,16 @@ func TestBeepPlayer_Play(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			player := audio.NewBeepPlayer()
-			ctx := context.Background()
-			
-			err := player.Play(ctx, tt.streamURL)
+			defer player.Close()
 			
 			if tt.wantErr {
+				err := player.Play(context.Background(), tt.streamURL)
 				assert.Error(t, err)
 				assert.Equal(t, audio.StateStopped, player.GetState())
 				return
 			}
 			
-			require.NoError(t, err)
+			playOrSkip(t, player, tt.streamURL)
 			assert.Equal(t, audio.StatePlaying, player.GetState())
 			assert.Greater(t, player.GetDuration(), time.Duration(0))
 		})
@@ -74,7 +110,7 @@ func TestBeepPlayer_PlayWithContextCancellation(t *testing.T) {
 
 func TestBeepPlayer_Pause(t *testing.T) {
 	player := audio.NewBeepPlayer()
-	ctx := context.Background()
+	defer player.Close()
 	
 	// Cannot pause when stopped
 	err := player.Pause()
@@ -82,8 +118,7 @@ func TestBeepPlayer_Pause(t *testing.T) {
 	assert.Contains(t, err.Error(), "cannot pause: player is stopped")
 	
 	// Start playing first
-	err = player.Play(ctx, "https://example.com/test.mp3")
-	require.NoError(t, err)
+	playOrSkip(t, player, silentWAV(t))
 	assert.Equal(t, audio.StatePlaying, player.GetState())
 	
 	// Now pause should work
@@ -99,14 +134,13 @@ func TestBeepPlayer_Pause(t *testing.T) {
 
 func TestBeepPlayer_Stop(t *testing.T) {
 	player := audio.NewBeepPlayer()
-	ctx := context.Background()
+	defer player.Close()
 	
 	// Start playing
-	err := player.Play(ctx, "https://example.com/test.mp3")
-	require.NoError(t, err)
+	playOrSkip(t, player, silentWAV(t))
 	
 	// Simulate some playback position
-	err = player.Seek(30 * time.Second)
+	err := player.Seek(30 * time.Second)
 	require.NoError(t, err)
 	assert.Equal(t, 30*time.Second, player.GetPosition())
 	
@@ -139,11 +173,10 @@ func TestBeepPlayer_Volume(t *testing.T) {
 
 func TestBeepPlayer_Seek(t *testing.T) {
 	player := audio.NewBeepPlayer()
-	ctx := context.Background()
+	defer player.Close()
 	
 	// Start playing to set duration
-	err := player.Play(ctx, "https://example.com/test.mp3")
-	require.NoError(t, err)
+	playOrSkip(t, player, silentWAV(t))
 	
 	duration := player.GetDuration()
 	require.Greater(t, duration, time.Duration(0))
@@ -162,7 +195,7 @@ func TestBeepPlayer_Seek(t *testing.T) {
 	}
 	
 	// Test invalid seek positions
-	err = player.Seek(-1 * time.Second)
+	err := player.Seek(-1 * time.Second)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "position cannot be negative")
 	
@@ -173,23 +206,24 @@ func TestBeepPlayer_Seek(t *testing.T) {
 
 func TestBeepPlayer_StateTransitions(t *testing.T) {
 	player := audio.NewBeepPlayer()
+	defer player.Close()
 	ctx := context.Background()
+	streamURL := silentWAV(t)
 	
 	// Initial state
 	assert.Equal(t, audio.StateStopped, player.GetState())
 	
 	// Stopped -> Playing
-	err := player.Play(ctx, "https://example.com/test.mp3")
-	require.NoError(t, err)
+	playOrSkip(t, player, streamURL)
 	assert.Equal(t, audio.StatePlaying, player.GetState())
 	
 	// Playing -> Paused
-	err = player.Pause()
+	err := player.Pause()
 	require.NoError(t, err)
 	assert.Equal(t, audio.StatePaused, player.GetState())
 	
 	// Paused -> Playing (resume)
-	err = player.Play(ctx, "https://example.com/test.mp3")
+	err = player.Play(ctx, streamURL)
 	require.NoError(t, err)
 	assert.Equal(t, audio.StatePlaying, player.GetState())
 	
@@ -199,7 +233,7 @@ func TestBeepPlayer_StateTransitions(t *testing.T) {
 	assert.Equal(t, audio.StateStopped, player.GetState())
 	
 	// Paused -> Stopped
-	err = player.Play(ctx, "https://example.com/test.mp3")
+	err = player.Play(ctx, streamURL)
 	require.NoError(t, err)
 	err = player.Pause()
 	require.NoError(t, err)
@@ -210,15 +244,13 @@ func TestBeepPlayer_StateTransitions(t *testing.T) {
 
 func TestBeepPlayer_Close(t *testing.T) {
 	player := audio.NewBeepPlayer()
-	ctx := context.Background()
 	
 	// Start playing
-	err := player.Play(ctx, "https://example.com/test.mp3")
-	require.NoError(t, err)
+	playOrSkip(t, player, silentWAV(t))
 	assert.Equal(t, audio.StatePlaying, player.GetState())
 	
 	// Close should stop playback
-	err = player.Close()
+	err := player.Close()
 	require.NoError(t, err)
 	assert.Equal(t, audio.StateStopped, player.GetState())
 	assert.Equal(t, time.Duration(0), player.GetPosition())
@@ -232,6 +264,7 @@ func TestPlayerState_String(t *testing.T) {
 		{audio.StateStopped, "stopped"},
 		{audio.StatePlaying, "playing"},
 		{audio.StatePaused, "paused"},
+		{audio.StateBuffering, "buffering"},
 		{audio.PlayerState(999), "unknown"}, // Invalid state
 	}
 	
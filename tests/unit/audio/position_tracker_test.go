@@ -0,0 +1,132 @@
+package audio_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"soundcloud-tui/internal/audio"
+)
+
+// fakeClock is a clock that only moves when told to
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+// startedTracker returns a tracker started on a fake clock
+func startedTracker() (*audio.PositionTracker, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	tracker := audio.NewPositionTracker(clock)
+	tracker.Start(44100)
+	return tracker, clock
+}
+
+// positionAfter advances clock by d and returns the tracked position
+func positionAfter(tracker *audio.PositionTracker, clock *fakeClock, d time.Duration) time.Duration {
+	clock.Advance(d)
+	tracker.Update()
+	return tracker.GetPosition()
+}
+
+func TestPositionTracker_AdvancesWithClock(t *testing.T) {
+	tracker, clock := startedTracker()
+
+	assert.Equal(t, 3*time.Second, positionAfter(tracker, clock, 3*time.Second))
+	assert.Equal(t, 4500*time.Millisecond, positionAfter(tracker, clock, 1500*time.Millisecond))
+}
+
+func TestPositionTracker_PauseResumeCycles(t *testing.T) {
+	tracker, clock := startedTracker()
+
+	clock.Advance(10 * time.Second)
+	tracker.Pause()
+	assert.Equal(t, 10*time.Second, positionAfter(tracker, clock, 5*time.Second), "paused position stays put")
+	tracker.Resume()
+
+	assert.Equal(t, 12*time.Second, positionAfter(tracker, clock, 2*time.Second))
+
+	tracker.Pause()
+	clock.Advance(time.Minute)
+	tracker.Resume()
+	clock.Advance(3 * time.Second)
+	tracker.Pause()
+	clock.Advance(7 * time.Second)
+	tracker.Resume()
+
+	assert.Equal(t, 16*time.Second, positionAfter(tracker, clock, time.Second))
+}
+
+func TestPositionTracker_RepeatedPauseKeepsFirst(t *testing.T) {
+	tracker, clock := startedTracker()
+
+	clock.Advance(4 * time.Second)
+	tracker.Pause()
+	clock.Advance(2 * time.Second)
+	tracker.Pause()
+	clock.Advance(2 * time.Second)
+	tracker.Resume()
+
+	assert.Equal(t, 5*time.Second, positionAfter(tracker, clock, time.Second))
+}
+
+func TestPositionTracker_ResumeWithoutPauseIsIgnored(t *testing.T) {
+	tracker, clock := startedTracker()
+
+	clock.Advance(2 * time.Second)
+	tracker.Resume()
+
+	assert.Equal(t, 3*time.Second, positionAfter(tracker, clock, time.Second))
+}
+
+func TestPositionTracker_SetPositionContinuesFromTarget(t *testing.T) {
+	tracker, clock := startedTracker()
+	clock.Advance(5 * time.Second)
+	tracker.Pause()
+	clock.Advance(5 * time.Second)
+	tracker.Resume()
+
+	tracker.SetPosition(90 * time.Second)
+	assert.Equal(t, 90*time.Second, tracker.GetPosition())
+	assert.Equal(t, 92*time.Second, positionAfter(tracker, clock, 2*time.Second))
+}
+
+func TestPositionTracker_SetPositionWhilePausedStaysPaused(t *testing.T) {
+	tracker, clock := startedTracker()
+	clock.Advance(5 * time.Second)
+	tracker.Pause()
+
+	tracker.SetPosition(30 * time.Second)
+	assert.Equal(t, 30*time.Second, positionAfter(tracker, clock, 10*time.Second))
+
+	tracker.Resume()
+	assert.Equal(t, 31*time.Second, positionAfter(tracker, clock, time.Second))
+}
+
+func TestPositionTracker_StartResetsState(t *testing.T) {
+	tracker, clock := startedTracker()
+	clock.Advance(20 * time.Second)
+	tracker.SetPosition(time.Minute)
+	tracker.Pause()
+
+	tracker.Start(44100)
+
+	assert.Equal(t, 2*time.Second, positionAfter(tracker, clock, 2*time.Second))
+}
+
+func TestPositionTracker_StoppedTrackerKeepsLastPosition(t *testing.T) {
+	tracker, clock := startedTracker()
+	positionAfter(tracker, clock, 8*time.Second)
+
+	tracker.Stop()
+
+	assert.Equal(t, 8*time.Second, positionAfter(tracker, clock, 5*time.Second))
+}
@@ -0,0 +1,192 @@
+package audio_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+)
+
+// wavHeader returns a 44.1kHz 16-bit stereo PCM header for a long data chunk
+func wavHeader() []byte {
+	const dataSize = 100 * 1024 * 1024
+	var header bytes.Buffer
+	header.WriteString("RIFF")
+	binary.Write(&header, binary.LittleEndian, uint32(36+dataSize))
+	header.WriteString("WAVEfmt ")
+	binary.Write(&header, binary.LittleEndian, uint32(16))      // fmt chunk size
+	binary.Write(&header, binary.LittleEndian, uint16(1))       // PCM
+	binary.Write(&header, binary.LittleEndian, uint16(2))       // channels
+	binary.Write(&header, binary.LittleEndian, uint32(44100))   // sample rate
+	binary.Write(&header, binary.LittleEndian, uint32(44100*4)) // byte rate
+	binary.Write(&header, binary.LittleEndian, uint16(4))       // block align
+	binary.Write(&header, binary.LittleEndian, uint16(16))      // bits per sample
+	header.WriteString("data")
+	binary.Write(&header, binary.LittleEndian, uint32(dataSize))
+	return header.Bytes()
+}
+
+// trickleBody serves a WAV header followed by chunks of silence at a fixed
+// pace, stopping after limit bytes of audio until the request is cancelled
+type trickleBody struct {
+	ctx      context.Context
+	header   []byte
+	chunk    int
+	interval time.Duration
+	limit    int
+	sent     int
+}
+
+func (b *trickleBody) Read(p []byte) (int, error) {
+	if len(b.header) > 0 {
+		n := copy(p, b.header)
+		b.header = b.header[n:]
+		return n, nil
+	}
+
+	if b.sent >= b.limit {
+		<-b.ctx.Done()
+		return 0, b.ctx.Err()
+	}
+
+	select {
+	case <-b.ctx.Done():
+		return 0, b.ctx.Err()
+	case <-time.After(b.interval):
+	}
+
+	n := b.chunk
+	if n > len(p) {
+		n = len(p)
+	}
+	if n > b.limit-b.sent {
+		n = b.limit - b.sent
+	}
+	clear(p[:n])
+	b.sent += n
+	return n, nil
+}
+
+func (b *trickleBody) Close() error {
+	return nil
+}
+
+// trickleTransport answers every request with a trickleBody, served as
+// contentType (audio/wav by default)
+type trickleTransport struct {
+	chunk       int
+	interval    time.Duration
+	limit       int
+	contentType string
+}
+
+func (t *trickleTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	contentType := t.contentType
+	if contentType == "" {
+		contentType = "audio/wav"
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{contentType}},
+		Body: &trickleBody{
+			ctx:      req.Context(),
+			header:   wavHeader(),
+			chunk:    t.chunk,
+			interval: t.interval,
+			limit:    t.limit,
+		},
+		Request: req,
+	}, nil
+}
+
+var _ io.ReadCloser = (*trickleBody)(nil)
+
+// playTimed starts playback and reports how long Play took to return
+func playTimed(t *testing.T, player *audio.BufferedStreamPlayer) (time.Duration, error) {
+	t.Helper()
+	start := time.Now()
+	err := player.Play(context.Background(), "https://example.com/stream.wav")
+	return time.Since(start), err
+}
+
+func TestBufferedStreamPlayer_SlowStreamStartsWithMinimumBuffer(t *testing.T) {
+	// 16KB every 100ms would need over 6s to fill the default 1MB preload
+	player := audio.NewBufferedStreamPlayer(
+		audio.WithTransport(&trickleTransport{chunk: 16 * 1024, interval: 100 * time.Millisecond, limit: 10 * 1024 * 1024}),
+		audio.WithPreloadTimeout(10*time.Second),
+	)
+	defer player.Close()
+
+	elapsed, err := playTimed(t, player)
+
+	if err != nil {
+		// Without an audio device the speaker can't start, but preloading must have succeeded
+		assert.NotContains(t, err.Error(), "preload")
+	}
+	assert.Less(t, elapsed, 3*time.Second, "playback should start once the minimum buffer is in")
+}
+
+func TestBufferedStreamPlayer_ReachingPreloadSizeStartsImmediately(t *testing.T) {
+	player := audio.NewBufferedStreamPlayer(
+		audio.WithTransport(&trickleTransport{chunk: 32 * 1024, interval: time.Millisecond, limit: 256 * 1024}),
+		audio.WithPreloadSize(128*1024),
+	)
+	defer player.Close()
+
+	elapsed, err := playTimed(t, player)
+
+	if err != nil {
+		assert.NotContains(t, err.Error(), "preload")
+	}
+	assert.Less(t, elapsed, 900*time.Millisecond, "a filled preload shouldn't wait for the slow-stream fallback")
+}
+
+func TestBufferedStreamPlayer_HonorsPreloadTimeout(t *testing.T) {
+	// Only a trickle arrives, never enough to start
+	player := audio.NewBufferedStreamPlayer(
+		audio.WithTransport(&trickleTransport{chunk: 1024, interval: 10 * time.Millisecond, limit: 4 * 1024}),
+		audio.WithPreloadTimeout(300*time.Millisecond),
+	)
+	defer player.Close()
+
+	elapsed, err := playTimed(t, player)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "preload timeout")
+	assert.Less(t, elapsed, 2*time.Second)
+	assert.Equal(t, audio.StateStopped, player.GetState())
+}
+
+func TestDefaultHTTPOptions_Preload(t *testing.T) {
+	options := audio.DefaultHTTPOptions()
+
+	assert.Equal(t, int64(audio.DefaultPreloadSize), options.PreloadSize)
+	assert.Equal(t, audio.DefaultPreloadTimeout, options.PreloadTimeout)
+}
+
+func TestBufferedStreamPlayer_ExpectedFormatSkipsOtherDecoders(t *testing.T) {
+	// The server claims MP3, but the track metadata knows the stream is WAV.
+	// An MP3 attempt would scan the whole buffer and then wait seconds for
+	// data that never comes.
+	player := audio.NewBufferedStreamPlayer(
+		audio.WithTransport(&trickleTransport{chunk: 32 * 1024, interval: time.Millisecond, limit: 256 * 1024, contentType: "audio/mpeg"}),
+		audio.WithPreloadSize(128*1024),
+	)
+	defer player.Close()
+	player.SetExpectedFormat(audio.FormatWAV)
+
+	elapsed, err := playTimed(t, player)
+
+	if err != nil {
+		assert.NotContains(t, err.Error(), "audio stream", "the WAV data should decode")
+	}
+	assert.Less(t, elapsed, 2*time.Second, "the WAV decoder should be tried without an MP3 attempt first")
+}
@@ -0,0 +1,154 @@
+package audio_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gopxl/beep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+)
+
+const (
+	rangedFrames    = 1 << 20 // 4MB of PCM
+	rangedFirstPart = 64 * 1024
+)
+
+// indexedWAV returns a WAV stream whose frames encode their own index: the
+// left channel holds the low 15 bits, the right channel the rest
+func indexedWAV() []byte {
+	header := wavHeader()
+	binary.LittleEndian.PutUint32(header[4:], 36+rangedFrames*4)
+	binary.LittleEndian.PutUint32(header[40:], rangedFrames*4)
+
+	content := bytes.NewBuffer(header)
+	for frame := 0; frame < rangedFrames; frame++ {
+		binary.Write(content, binary.LittleEndian, int16(frame&0x7fff))
+		binary.Write(content, binary.LittleEndian, int16(frame>>15))
+	}
+	return content.Bytes()
+}
+
+// frameIndex decodes the index indexedWAV stored in a sample
+func frameIndex(sample [2]float64) int {
+	low := int(math.Round(sample[0] * (1<<16 - 1)))
+	high := int(math.Round(sample[1] * (1<<16 - 1)))
+	return high<<15 | low
+}
+
+// streamFull fills samples, streaming as often as the decoder needs to
+func streamFull(t *testing.T, streamer beep.Streamer, samples [][2]float64) {
+	for filled := 0; filled < len(samples); {
+		n, ok := streamer.Stream(samples[filled:])
+		require.True(t, ok, "stream ended after %d samples", filled)
+		filled += n
+	}
+}
+
+// rangeServer serves content, stalling after the first part of a plain
+// request so only a Range request can reach later bytes. With ignoreRange it
+// answers Range requests with the whole content, as some servers do.
+type rangeServer struct {
+	content     []byte
+	ignoreRange bool
+
+	mu     sync.Mutex
+	ranges []string
+}
+
+func (s *rangeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "audio/wav")
+
+	requested := r.Header.Get("Range")
+	if requested == "" {
+		w.Write(s.content[:rangedFirstPart])
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+		return
+	}
+
+	s.mu.Lock()
+	s.ranges = append(s.ranges, requested)
+	s.mu.Unlock()
+
+	if s.ignoreRange {
+		w.Write(s.content)
+		return
+	}
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(s.content))
+}
+
+func (s *rangeServer) requestedRanges() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.ranges...)
+}
+
+func TestStreamBuffer_SeekPastDownloadFetchesRange(t *testing.T) {
+	for _, ignoreRange := range []bool{false, true} {
+		t.Run(fmt.Sprintf("ignoreRange=%v", ignoreRange), func(t *testing.T) {
+			server := &rangeServer{content: indexedWAV(), ignoreRange: ignoreRange}
+			httpServer := httptest.NewServer(server)
+			defer httpServer.Close()
+
+			buffer := audio.OpenStreamBuffer(context.Background(), httpServer.URL, 256*1024)
+			defer buffer.Close()
+
+			streamer, _, err := audio.Decode(audio.FormatWAV, audio.NewBufferReader(buffer))
+			require.NoError(t, err)
+
+			const target = 700000
+			require.NoError(t, streamer.Seek(target))
+
+			samples := make([][2]float64, 32*1024)
+			streamFull(t, streamer, samples)
+			for i, sample := range samples {
+				require.Equal(t, target+i, frameIndex(sample), "sample %d", i)
+			}
+			assert.Equal(t, target+len(samples), streamer.Position())
+			assert.Equal(t, []string{fmt.Sprintf("bytes=%d-", 44+target*4)}, server.requestedRanges())
+		})
+	}
+}
+
+func TestStreamBuffer_SeekBackBeforeWindowRefetches(t *testing.T) {
+	server := &rangeServer{content: indexedWAV()}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	buffer := audio.OpenStreamBuffer(context.Background(), httpServer.URL, 256*1024)
+	defer buffer.Close()
+
+	streamer, _, err := audio.Decode(audio.FormatWAV, audio.NewBufferReader(buffer))
+	require.NoError(t, err)
+
+	require.NoError(t, streamer.Seek(900000))
+	require.NoError(t, streamer.Seek(1000))
+
+	samples := make([][2]float64, 16)
+	streamFull(t, streamer, samples)
+	assert.Equal(t, 1000, frameIndex(samples[0]))
+
+	// The download for the first seek may be replaced before it is requested
+	ranges := server.requestedRanges()
+	require.NotEmpty(t, ranges)
+	assert.Equal(t, "bytes=4044-", ranges[len(ranges)-1])
+}
+
+func TestStreamBuffer_SeekPastFinishedStreamFails(t *testing.T) {
+	reader := bufferedWAV()
+
+	_, err := reader.Seek(1<<20, 0)
+
+	assert.Error(t, err)
+}
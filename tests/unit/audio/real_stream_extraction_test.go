@@ -48,9 +48,9 @@ func (m *MockRealSoundCloudAPI) GetTrackInfoWithOptions(options soundcloudapi.Ge
 func TestRealStreamExtraction_ValidTrackWithProgressiveFormat(t *testing.T) {
 	// Create a track with progressive transcoding
 	mockTrack := soundcloudapi.Track{
-		ID:         123456789,
-		Title:      "Test Track",
-		DurationMS: 180000, // 3 minutes
+		ID:           123456789,
+		Title:        "Test Track",
+		DurationMS:   180000, // 3 minutes
 		PermalinkURL: "https://soundcloud.com/artist/test-track",
 		Media: soundcloudapi.Media{
 			Transcodings: []soundcloudapi.Transcoding{
@@ -105,9 +105,9 @@ func TestRealStreamExtraction_ValidTrackWithProgressiveFormat(t *testing.T) {
 
 func TestRealStreamExtraction_FallbackToHLSWhenNoProgressive(t *testing.T) {
 	mockTrack := soundcloudapi.Track{
-		ID:         987654321,
-		Title:      "HLS Only Track",
-		DurationMS: 240000,
+		ID:           987654321,
+		Title:        "HLS Only Track",
+		DurationMS:   240000,
 		PermalinkURL: "https://soundcloud.com/artist/hls-track",
 		Media: soundcloudapi.Media{
 			Transcodings: []soundcloudapi.Transcoding{
@@ -149,9 +149,9 @@ func TestRealStreamExtraction_FallbackToHLSWhenNoProgressive(t *testing.T) {
 
 func TestRealStreamExtraction_PreferProgressiveOverHLS(t *testing.T) {
 	mockTrack := soundcloudapi.Track{
-		ID:         555666777,
-		Title:      "Multi-Format Track",
-		DurationMS: 200000,
+		ID:           555666777,
+		Title:        "Multi-Format Track",
+		DurationMS:   200000,
 		PermalinkURL: "https://soundcloud.com/artist/multi-format",
 		Media: soundcloudapi.Media{
 			Transcodings: []soundcloudapi.Transcoding{
@@ -231,7 +231,7 @@ func TestRealStreamExtraction_HandleAPIErrors(t *testing.T) {
 						return nil, tt.apiError
 					}
 					return []soundcloudapi.Track{{
-						ID: tt.trackID,
+						ID:           tt.trackID,
 						PermalinkURL: "https://soundcloud.com/test/track",
 						Media: soundcloudapi.Media{
 							Transcodings: []soundcloudapi.Transcoding{
@@ -264,7 +264,7 @@ func TestRealStreamExtraction_HandleAPIErrors(t *testing.T) {
 
 func TestRealStreamExtraction_HandleExpiredURLs(t *testing.T) {
 	mockTrack := soundcloudapi.Track{
-		ID: 111222333,
+		ID:           111222333,
 		PermalinkURL: "https://soundcloud.com/test/expired",
 		Media: soundcloudapi.Media{
 			Transcodings: []soundcloudapi.Transcoding{
@@ -316,7 +316,7 @@ func TestRealStreamExtraction_ContextCancellation(t *testing.T) {
 	}
 
 	extractor := audio.NewRealSoundCloudStreamExtractor(mockAPI)
-	
+
 	// Create context that cancels immediately
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
@@ -330,7 +330,7 @@ func TestRealStreamExtraction_ContextCancellation(t *testing.T) {
 
 func TestRealStreamExtraction_NoTranscodingsAvailable(t *testing.T) {
 	mockTrack := soundcloudapi.Track{
-		ID: 999888777,
+		ID:           999888777,
 		PermalinkURL: "https://soundcloud.com/test/no-transcodings",
 		Media: soundcloudapi.Media{
 			Transcodings: []soundcloudapi.Transcoding{}, // Empty transcodings
@@ -399,7 +399,7 @@ func TestRealStreamExtraction_URLValidation(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			isValid, err := extractor.ValidateStreamURL(ctx, tt.url)
-			
+
 			require.NoError(t, err, "Validation should not return error for URL format checking")
 			assert.Equal(t, tt.shouldValid, isValid, tt.description)
 		})
@@ -408,7 +408,7 @@ func TestRealStreamExtraction_URLValidation(t *testing.T) {
 
 func TestRealStreamExtraction_QualitySelection(t *testing.T) {
 	mockTrack := soundcloudapi.Track{
-		ID: 123456789,
+		ID:           123456789,
 		PermalinkURL: "https://soundcloud.com/test/quality",
 		Media: soundcloudapi.Media{
 			Transcodings: []soundcloudapi.Transcoding{
@@ -456,9 +456,60 @@ func TestRealStreamExtraction_QualitySelection(t *testing.T) {
 	require.NoError(t, err)
 	assert.Contains(t, qualities, "progressive")
 	assert.Contains(t, qualities, "hls")
-	
+
 	// Progressive should be preferred
 	streamInfo, err := extractor.ExtractStreamURL(ctx, 123456789)
 	require.NoError(t, err)
 	assert.Equal(t, "progressive", streamInfo.Quality)
-}
\ No newline at end of file
+}
+
+func TestRealStreamExtraction_PreferredQualityHLS(t *testing.T) {
+	mockTrack := soundcloudapi.Track{
+		ID:           555666777,
+		DurationMS:   200000,
+		PermalinkURL: "https://soundcloud.com/artist/multi-format",
+		Media: soundcloudapi.Media{
+			Transcodings: []soundcloudapi.Transcoding{
+				{Format: soundcloudapi.TranscodingFormat{Protocol: "progressive", MimeType: "audio/mpeg"}},
+				{Format: soundcloudapi.TranscodingFormat{Protocol: "hls", MimeType: "audio/mpeg"}},
+			},
+		},
+	}
+
+	mockAPI := &MockRealSoundCloudAPI{
+		GetTrackInfoFunc: func(options soundcloudapi.GetTrackInfoOptions) ([]soundcloudapi.Track, error) {
+			return []soundcloudapi.Track{mockTrack}, nil
+		},
+		GetDownloadURLFunc: func(trackURL string, format string) (string, error) {
+			return "https://cf-hls-media.sndcdn.com/playlist.m3u8?format=" + format, nil
+		},
+	}
+
+	extractor := audio.NewRealSoundCloudStreamExtractor(mockAPI)
+	require.NoError(t, extractor.SetPreferredQuality(audio.QualityHLS))
+
+	streamInfo, err := extractor.ExtractStreamURL(context.Background(), 555666777)
+	require.NoError(t, err)
+	assert.Equal(t, audio.QualityHLS, streamInfo.Quality)
+	assert.Equal(t, audio.FormatHLS, streamInfo.Format)
+
+	// A track without HLS still plays progressively
+	mockTrack.Media.Transcodings = mockTrack.Media.Transcodings[:1]
+	streamInfo, err = extractor.ExtractStreamURL(context.Background(), 555666777)
+	require.NoError(t, err)
+	assert.Equal(t, audio.QualityProgressive, streamInfo.Quality)
+}
+
+func TestParseQuality(t *testing.T) {
+	for _, name := range []string{"progressive", "hls"} {
+		quality, err := audio.ParseQuality(name)
+		require.NoError(t, err)
+		assert.Equal(t, name, quality)
+	}
+
+	_, err := audio.ParseQuality("flac")
+	assert.ErrorContains(t, err, `unknown quality "flac"`)
+
+	extractor := audio.NewRealSoundCloudStreamExtractor(&MockRealSoundCloudAPI{})
+	assert.Error(t, extractor.SetPreferredQuality("flac"))
+}
@@ -0,0 +1,34 @@
+package audio_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gopxl/beep"
+	"github.com/stretchr/testify/assert"
+
+	"soundcloud-tui/internal/audio"
+)
+
+// oneSecond returns a second of silence at rate
+func oneSecond(rate beep.SampleRate) beep.Streamer {
+	return beep.Take(rate.N(time.Second), beep.Silence(-1))
+}
+
+func TestMatchSampleRate_TracksAtDifferentRates(t *testing.T) {
+	speakerRate := beep.SampleRate(44100)
+
+	// The first track initialized the speaker and plays as is
+	first := audio.MatchSampleRate(oneSecond(44100), 44100, speakerRate)
+	assert.Equal(t, 44100, countSamples(first))
+
+	// A second of a 48kHz track still lasts a second on the 44.1kHz speaker
+	second := audio.MatchSampleRate(oneSecond(48000), 48000, speakerRate)
+	assert.InDelta(t, 44100, countSamples(second), 10)
+}
+
+func TestMatchSampleRate_UnknownRatePassesThrough(t *testing.T) {
+	streamer := oneSecond(48000)
+
+	assert.Same(t, streamer, audio.MatchSampleRate(streamer, 48000, 0))
+}
@@ -0,0 +1,76 @@
+package audio_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+)
+
+// stallingServer sends the start of a stream and then stalls. It reports when
+// the request is being downloaded and when the client gave up on it.
+func stallingServer(t *testing.T) (url string, started, released <-chan struct{}) {
+	downloading := make(chan struct{})
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/wav")
+		w.Write(wavHeader())
+		w.(http.Flusher).Flush()
+		close(downloading)
+		<-r.Context().Done()
+		close(done)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, downloading, done
+}
+
+// awaitClosed fails the test unless ch is closed within a few seconds
+func awaitClosed(t *testing.T, ch <-chan struct{}, failure string) {
+	t.Helper()
+	select {
+	case <-ch:
+	case <-time.After(2 * time.Second):
+		t.Fatal(failure)
+	}
+}
+
+func TestStreamBuffer_DownloadStopsWhenContextIsCancelled(t *testing.T) {
+	url, started, released := stallingServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	buffer := audio.OpenStreamBuffer(ctx, url, 64*1024)
+	defer buffer.Close()
+	awaitClosed(t, started, "download should start")
+
+	cancel()
+
+	awaitClosed(t, released, "download kept its request open after the context was cancelled")
+}
+
+func TestStreamBuffer_CloseStopsDownload(t *testing.T) {
+	url, started, released := stallingServer(t)
+
+	buffer := audio.OpenStreamBuffer(context.Background(), url, 64*1024)
+	awaitClosed(t, started, "download should start")
+
+	buffer.Close()
+
+	awaitClosed(t, released, "download kept its request open after Close")
+}
+
+func TestBufferedStreamPlayer_PlayAfterCloseFails(t *testing.T) {
+	url, _, _ := stallingServer(t)
+	player := audio.NewBufferedStreamPlayer()
+	require.NoError(t, player.Close())
+
+	err := player.Play(context.Background(), url)
+
+	assert.ErrorIs(t, err, audio.ErrPlayerClosed)
+	assert.Equal(t, audio.StateStopped, player.GetState())
+}
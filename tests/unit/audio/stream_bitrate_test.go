@@ -0,0 +1,171 @@
+package audio_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	soundcloudapi "github.com/zackradisic/soundcloud-api"
+
+	"soundcloud-tui/internal/audio"
+)
+
+func TestRealStreamExtraction_BitrateFromPreset(t *testing.T) {
+	tests := []struct {
+		preset   string
+		mimeType string
+		expected int
+	}{
+		{"mp3_1_0", "audio/mpeg", 128},
+		{"mp3_0_0", "audio/mpeg", 128},
+		{"opus_0_0", `audio/ogg; codecs="opus"`, 64},
+		{"aac_160k", "audio/mp4", 160},
+		{"aac_1_0", "audio/mp4", 0},
+		{"", "audio/mpeg", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.preset, func(t *testing.T) {
+			mockAPI := &MockRealSoundCloudAPI{
+				GetTrackInfoFunc: func(options soundcloudapi.GetTrackInfoOptions) ([]soundcloudapi.Track, error) {
+					return []soundcloudapi.Track{{
+						ID:           1,
+						PermalinkURL: "https://soundcloud.com/artist/track",
+						Media: soundcloudapi.Media{Transcodings: []soundcloudapi.Transcoding{{
+							Preset: tt.preset,
+							Format: soundcloudapi.TranscodingFormat{Protocol: "progressive", MimeType: tt.mimeType},
+						}}},
+					}}, nil
+				},
+				GetDownloadURLFunc: func(trackURL string, format string) (string, error) {
+					return "https://cf-media.sndcdn.com/track.mp3", nil
+				},
+			}
+
+			streamInfo, err := audio.NewRealSoundCloudStreamExtractor(mockAPI).ExtractStreamURL(context.Background(), 1)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, streamInfo.Bitrate)
+		})
+	}
+}
+
+func TestPresetLabel(t *testing.T) {
+	tests := []struct {
+		preset   string
+		expected string
+	}{
+		{"mp3_1_0", "MP3 128"},
+		{"mp3_0_1", "MP3 128"},
+		{"opus_0_0", "Opus 64"},
+		{"aac_160k", "AAC 160"},
+		{"aac_1_0", "AAC"},
+		{"flac_0_0", "FLAC"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.preset, func(t *testing.T) {
+			assert.Equal(t, tt.expected, audio.PresetLabel(tt.preset))
+		})
+	}
+}
+
+func TestRealStreamExtraction_CarriesPreset(t *testing.T) {
+	mockAPI := &MockRealSoundCloudAPI{
+		GetTrackInfoFunc: func(options soundcloudapi.GetTrackInfoOptions) ([]soundcloudapi.Track, error) {
+			return []soundcloudapi.Track{{
+				ID:           1,
+				PermalinkURL: "https://soundcloud.com/artist/track",
+				Media: soundcloudapi.Media{Transcodings: []soundcloudapi.Transcoding{{
+					Preset: "opus_0_0",
+					Format: soundcloudapi.TranscodingFormat{Protocol: "hls", MimeType: `audio/ogg; codecs="opus"`},
+				}}},
+			}}, nil
+		},
+		GetDownloadURLFunc: func(trackURL string, format string) (string, error) {
+			return "https://cf-hls-opus-media.sndcdn.com/playlist.m3u8", nil
+		},
+	}
+
+	streamInfo, err := audio.NewRealSoundCloudStreamExtractor(mockAPI).ExtractStreamURL(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, "opus_0_0", streamInfo.Preset)
+}
+
+// resolvingAPI is a mock API that can also resolve transcodings, recording
+// which ones were resolved
+type resolvingAPI struct {
+	*MockRealSoundCloudAPI
+	resolved []string
+}
+
+func (r *resolvingAPI) GetMediaURL(transcodingURL string) (string, error) {
+	r.resolved = append(r.resolved, transcodingURL)
+	return "https://cf-media.sndcdn.com/resolved.mp3", nil
+}
+
+// trackWithTranscodings returns a mock API serving a track with transcodings
+func trackWithTranscodings(transcodings ...soundcloudapi.Transcoding) *MockRealSoundCloudAPI {
+	return &MockRealSoundCloudAPI{
+		GetTrackInfoFunc: func(options soundcloudapi.GetTrackInfoOptions) ([]soundcloudapi.Track, error) {
+			return []soundcloudapi.Track{{
+				ID:           1,
+				PermalinkURL: "https://soundcloud.com/artist/track",
+				Media:        soundcloudapi.Media{Transcodings: transcodings},
+			}}, nil
+		},
+		GetDownloadURLFunc: func(trackURL string, format string) (string, error) {
+			return "https://cf-media.sndcdn.com/first.mp3", nil
+		},
+	}
+}
+
+func progressiveMP3(preset string) soundcloudapi.Transcoding {
+	return soundcloudapi.Transcoding{
+		URL:    "https://api-v2.soundcloud.com/media/soundcloud:tracks:1/" + preset + "/stream/progressive",
+		Preset: preset,
+		Format: soundcloudapi.TranscodingFormat{Protocol: "progressive", MimeType: "audio/mpeg"},
+	}
+}
+
+func TestRealStreamExtraction_PicksBestProgressivePreset(t *testing.T) {
+	older, newer := progressiveMP3("mp3_0_0"), progressiveMP3("mp3_1_0")
+	api := &resolvingAPI{MockRealSoundCloudAPI: trackWithTranscodings(older, newer)}
+
+	streamInfo, err := audio.NewRealSoundCloudStreamExtractor(api).ExtractStreamURL(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, "mp3_1_0", streamInfo.Preset)
+	assert.Equal(t, []string{newer.URL}, api.resolved)
+	assert.Equal(t, "https://cf-media.sndcdn.com/resolved.mp3", streamInfo.URL)
+	assert.Equal(t, audio.FormatMP3, streamInfo.Format)
+}
+
+func TestRealStreamExtraction_KeepsCodecOfFirstTranscoding(t *testing.T) {
+	mp3 := progressiveMP3("mp3_0_0")
+	aac := soundcloudapi.Transcoding{
+		URL:    "https://api-v2.soundcloud.com/media/soundcloud:tracks:1/aac/stream/progressive",
+		Preset: "aac_160k",
+		Format: soundcloudapi.TranscodingFormat{Protocol: "progressive", MimeType: "audio/mp4"},
+	}
+	api := &resolvingAPI{MockRealSoundCloudAPI: trackWithTranscodings(mp3, aac)}
+
+	streamInfo, err := audio.NewRealSoundCloudStreamExtractor(api).ExtractStreamURL(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, "mp3_0_0", streamInfo.Preset, "a higher bitrate in another codec shouldn't win")
+	assert.Equal(t, []string{mp3.URL}, api.resolved)
+}
+
+func TestRealStreamExtraction_WithoutResolverUsesFirstTranscoding(t *testing.T) {
+	api := trackWithTranscodings(progressiveMP3("mp3_0_0"), progressiveMP3("mp3_1_0"))
+
+	streamInfo, err := audio.NewRealSoundCloudStreamExtractor(api).ExtractStreamURL(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, "mp3_0_0", streamInfo.Preset, "GetDownloadURL streams the first transcoding")
+	assert.Equal(t, "https://cf-media.sndcdn.com/first.mp3", streamInfo.URL)
+}
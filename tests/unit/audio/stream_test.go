@@ -2,6 +2,8 @@ package audio_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -15,7 +17,7 @@ func TestSoundCloudStreamExtractor_ExtractStreamURL(t *testing.T) {
 	tests := []struct {
 		name        string
 		trackID     int64
-		clientID    string
+		api         audio.SoundCloudAPI
 		wantErr     bool
 		wantFormat  string
 		wantQuality string
@@ -23,28 +25,28 @@ func TestSoundCloudStreamExtractor_ExtractStreamURL(t *testing.T) {
 		{
 			name:        "valid track ID returns stream info",
 			trackID:     123456789,
-			clientID:    "test-client-id",
+			api:         &MockSoundCloudAPI{},
 			wantErr:     false,
 			wantFormat:  "mp3",
 			wantQuality: "sq",
 		},
 		{
-			name:     "invalid track ID returns error",
-			trackID:  -1,
-			clientID: "test-client-id",
-			wantErr:  true,
+			name:    "invalid track ID returns error",
+			trackID: -1,
+			api:     &MockSoundCloudAPI{},
+			wantErr: true,
 		},
 		{
-			name:     "empty client ID returns error",
-			trackID:  123456789,
-			clientID: "",
-			wantErr:  true,
+			name:    "uninitialized API client returns error",
+			trackID: 123456789,
+			api:     nil,
+			wantErr: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			extractor := audio.NewSoundCloudStreamExtractor(tt.clientID)
+			extractor := audio.NewSoundCloudStreamExtractorWithAPI(tt.api)
 			
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 			defer cancel()
@@ -76,28 +78,25 @@ func TestSoundCloudStreamExtractor_GetAvailableQualities(t *testing.T) {
 	tests := []struct {
 		name           string
 		trackID        int64
-		clientID       string
 		wantQualities  []string
 		wantErr        bool
 	}{
 		{
 			name:          "valid track returns available qualities",
 			trackID:       123456789,
-			clientID:      "test-client-id",
 			wantQualities: []string{"sq", "hq"},
 			wantErr:       false,
 		},
 		{
-			name:     "invalid track ID returns error",
-			trackID:  -1,
-			clientID: "test-client-id",
-			wantErr:  true,
+			name:    "invalid track ID returns error",
+			trackID: -1,
+			wantErr: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			extractor := audio.NewSoundCloudStreamExtractor(tt.clientID)
+			extractor := audio.NewSoundCloudStreamExtractorWithAPI(&MockSoundCloudAPI{})
 			
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 			defer cancel()
@@ -118,38 +117,43 @@ func TestSoundCloudStreamExtractor_GetAvailableQualities(t *testing.T) {
 }
 
 func TestSoundCloudStreamExtractor_ValidateStreamURL(t *testing.T) {
+	// Serves the test stream, and 404 for anything else
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/test.mp3" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "audio/mpeg")
+	}))
+	defer server.Close()
+	
 	tests := []struct {
 		name      string
 		streamURL string
-		clientID  string
 		wantValid bool
 		wantErr   bool
 	}{
 		{
 			name:      "valid stream URL returns true",
-			streamURL: "https://cf-media.sndcdn.com/test.mp3",
-			clientID:  "test-client-id",
+			streamURL: server.URL + "/test.mp3",
 			wantValid: true,
 			wantErr:   false,
 		},
 		{
 			name:      "invalid stream URL returns false",
-			streamURL: "https://invalid-url.com/test.mp3",
-			clientID:  "test-client-id",
+			streamURL: server.URL + "/missing.mp3",
 			wantValid: false,
 			wantErr:   false,
 		},
 		{
 			name:      "empty URL returns error",
 			streamURL: "",
-			clientID:  "test-client-id",
 			wantValid: false,
 			wantErr:   true,
 		},
 		{
 			name:      "malformed URL returns error",
 			streamURL: "not-a-url",
-			clientID:  "test-client-id",
 			wantValid: false,
 			wantErr:   true,
 		},
@@ -157,7 +161,7 @@ func TestSoundCloudStreamExtractor_ValidateStreamURL(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			extractor := audio.NewSoundCloudStreamExtractor(tt.clientID)
+			extractor := audio.NewSoundCloudStreamExtractorWithAPI(&MockSoundCloudAPI{})
 			
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 			defer cancel()
@@ -0,0 +1,101 @@
+package audio_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	soundcloudapi "github.com/zackradisic/soundcloud-api"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/soundcloud/retry"
+)
+
+// refusingAPI serves track, refusing its download URL with status
+func refusingAPI(track soundcloudapi.Track, status int) *MockRealSoundCloudAPI {
+	return &MockRealSoundCloudAPI{
+		GetTrackInfoFunc: func(options soundcloudapi.GetTrackInfoOptions) ([]soundcloudapi.Track, error) {
+			return []soundcloudapi.Track{track}, nil
+		},
+		GetDownloadURLFunc: func(trackURL string, format string) (string, error) {
+			return "", &soundcloudapi.FailedRequestError{Status: status}
+		},
+	}
+}
+
+func restrictedTrack(monetization string, snipped bool) soundcloudapi.Track {
+	transcoding := progressiveMP3("mp3_0_0")
+	transcoding.Snipped = snipped
+	return soundcloudapi.Track{
+		ID:                1,
+		PermalinkURL:      "https://soundcloud.com/artist/track",
+		MonetizationModel: monetization,
+		Media:             soundcloudapi.Media{Transcodings: []soundcloudapi.Transcoding{transcoding}},
+	}
+}
+
+func TestRealStreamExtraction_UnavailableTracks(t *testing.T) {
+	tests := []struct {
+		name     string
+		track    soundcloudapi.Track
+		status   int
+		expected error
+	}{
+		{"forbidden in region", restrictedTrack("AD_SUPPORTED", false), http.StatusForbidden, audio.ErrGeoBlocked},
+		{"unavailable for legal reasons", restrictedTrack("", false), http.StatusUnavailableForLegalReasons, audio.ErrGeoBlocked},
+		{"payment required", restrictedTrack("", false), http.StatusPaymentRequired, audio.ErrPurchaseRequired},
+		{"forbidden Go+ track", restrictedTrack("SUB_HIGH_TIER", false), http.StatusForbidden, audio.ErrPurchaseRequired},
+		{"forbidden preview-only track", restrictedTrack("", true), http.StatusForbidden, audio.ErrPurchaseRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extractor := audio.NewRealSoundCloudStreamExtractor(refusingAPI(tt.track, tt.status))
+
+			streamInfo, err := extractor.ExtractStreamURL(context.Background(), 1)
+
+			assert.Nil(t, streamInfo)
+			require.ErrorIs(t, err, tt.expected)
+			assert.Equal(t, tt.status, retry.Status(err), "the API error should stay reachable")
+		})
+	}
+}
+
+func TestRealStreamExtraction_OtherFailuresAreNotUnavailable(t *testing.T) {
+	extractor := audio.NewRealSoundCloudStreamExtractor(refusingAPI(restrictedTrack("", false), http.StatusNotFound))
+
+	_, err := extractor.ExtractStreamURL(context.Background(), 1)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get download URL")
+	assert.NotErrorIs(t, err, audio.ErrGeoBlocked)
+	assert.NotErrorIs(t, err, audio.ErrPurchaseRequired)
+}
+
+// refusingResolver refuses every transcoding with err
+type refusingResolver struct {
+	*MockRealSoundCloudAPI
+	err error
+}
+
+func (r *refusingResolver) GetMediaURL(transcodingURL string) (string, error) {
+	return "", r.err
+}
+
+func TestRealStreamExtraction_UnavailableThroughResolver(t *testing.T) {
+	api := &refusingResolver{
+		MockRealSoundCloudAPI: trackWithTranscodings(progressiveMP3("mp3_0_0")),
+		err: fmt.Errorf("failed to get media URL: %w", &retry.StatusError{
+			StatusCode: http.StatusForbidden,
+			Err:        errors.New("request failed with status 403 Forbidden"),
+		}),
+	}
+
+	_, err := audio.NewRealSoundCloudStreamExtractor(api).ExtractStreamURL(context.Background(), 1)
+
+	assert.ErrorIs(t, err, audio.ErrGeoBlocked)
+}
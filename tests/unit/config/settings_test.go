@@ -0,0 +1,66 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/config"
+)
+
+func TestLoadSettings_MissingFileUsesDefaults(t *testing.T) {
+	settings, err := config.LoadSettings(filepath.Join(t.TempDir(), "missing.json"))
+
+	require.NoError(t, err)
+	assert.Equal(t, config.EQSettings{}, settings.EQ)
+}
+
+func TestSettings_SaveAndLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", config.SettingsFileName)
+
+	settings, err := config.LoadSettings(path)
+	require.NoError(t, err)
+	settings.EQ = config.EQSettings{Low: 4, Mid: -2, High: 1}
+	require.NoError(t, settings.Save())
+
+	loaded, err := config.LoadSettings(path)
+	require.NoError(t, err)
+	assert.Equal(t, config.EQSettings{Low: 4, Mid: -2, High: 1}, loaded.EQ)
+}
+
+func TestLoadSettings_CorruptFileFallsBackToDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), config.SettingsFileName)
+	require.NoError(t, os.WriteFile(path, []byte(`{"eq": {"low": 3`), 0o644))
+
+	settings, err := config.LoadSettings(path)
+
+	assert.Error(t, err)
+	require.NotNil(t, settings)
+	assert.Equal(t, config.EQSettings{}, settings.EQ)
+}
+
+func TestStreamingSettings_Conversions(t *testing.T) {
+	assert.Equal(t, int64(0), config.StreamingSettings{}.PreloadSize(), "unset preload should use the player default")
+	assert.Equal(t, time.Duration(0), config.StreamingSettings{}.PreloadTimeout())
+
+	streaming := config.StreamingSettings{PreloadKB: 512, PreloadTimeoutSeconds: 30}
+	assert.Equal(t, int64(512*1024), streaming.PreloadSize())
+	assert.Equal(t, 30*time.Second, streaming.PreloadTimeout())
+}
+
+func TestSettings_IntervalConversions(t *testing.T) {
+	assert.Equal(t, time.Duration(0), config.StreamingSettings{}.PositionInterval(), "unset interval should use the player default")
+	assert.Equal(t, time.Duration(0), config.PlaybackSettings{}.ProgressInterval(), "unset interval should use the player default")
+
+	assert.Equal(t, 500*time.Millisecond, config.StreamingSettings{PositionIntervalMS: 500}.PositionInterval())
+	assert.Equal(t, time.Second, config.PlaybackSettings{ProgressIntervalMS: 1000}.ProgressInterval())
+}
+
+func TestSettings_IdleTimeout(t *testing.T) {
+	assert.Equal(t, time.Duration(0), config.PlaybackSettings{}.IdleTimeout(), "the idle timeout is off by default")
+	assert.Equal(t, 30*time.Minute, config.PlaybackSettings{IdleTimeoutMinutes: 30}.IdleTimeout())
+}
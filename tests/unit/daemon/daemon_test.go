@@ -0,0 +1,174 @@
+package daemon_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/daemon"
+	"soundcloud-tui/internal/testutil"
+)
+
+func newDispatcher() (*daemon.Dispatcher, *testutil.MockAudioPlayer) {
+	mockPlayer := testutil.NewMockAudioPlayer()
+	return daemon.NewDispatcher(&testutil.MockSoundCloudClient{}, mockPlayer, &testutil.MockStreamExtractor{}), mockPlayer
+}
+
+func TestDispatcher_PlayResolvesTrackAndStartsStream(t *testing.T) {
+	dispatcher, mockPlayer := newDispatcher()
+
+	response := dispatcher.Dispatch("play https://soundcloud.com/artist/track")
+
+	require.True(t, response.OK, response.Error)
+	play, ok := mockPlayer.LastCall("Play")
+	require.True(t, ok)
+	assert.Equal(t, "https://example.com/stream.mp3", play.Args[0])
+	assert.Equal(t, 240*time.Second, mockPlayer.ExpectedDuration)
+	assert.Equal(t, "mp3", mockPlayer.ExpectedFormat)
+
+	assert.Equal(t, "playing", response.Status.State)
+	assert.Equal(t, "Test Track", response.Status.Title)
+	assert.Equal(t, "Test Artist", response.Status.Artist)
+	assert.Equal(t, "https://soundcloud.com/artist/track", response.Status.URL)
+}
+
+func TestDispatcher_PlayLocalFile(t *testing.T) {
+	dispatcher, mockPlayer := newDispatcher()
+
+	response := dispatcher.Dispatch("play /music/Some Song.mp3")
+
+	require.True(t, response.OK, response.Error)
+	play, _ := mockPlayer.LastCall("Play")
+	assert.Equal(t, "/music/Some Song.mp3", play.Args[0])
+	assert.Equal(t, "Some Song", response.Status.Title)
+	assert.Empty(t, response.Status.Artist)
+}
+
+func TestDispatcher_PlaybackControls(t *testing.T) {
+	dispatcher, mockPlayer := newDispatcher()
+	require.True(t, dispatcher.Dispatch("play https://soundcloud.com/artist/track").OK)
+
+	response := dispatcher.Dispatch("pause")
+	require.True(t, response.OK, response.Error)
+	assert.Equal(t, "paused", response.Status.State)
+
+	response = dispatcher.Dispatch("  RESUME  ")
+	require.True(t, response.OK, response.Error)
+	assert.Equal(t, "playing", response.Status.State)
+
+	response = dispatcher.Dispatch("volume 35")
+	require.True(t, response.OK, response.Error)
+	assert.InDelta(t, 0.35, mockPlayer.Volume, 0.001)
+	assert.Equal(t, 35, response.Status.Volume)
+
+	response = dispatcher.Dispatch("stop")
+	require.True(t, response.OK, response.Error)
+	assert.Equal(t, "stopped", response.Status.State)
+	assert.Empty(t, response.Status.Title, "a stopped player has no track")
+}
+
+func TestDispatcher_Status(t *testing.T) {
+	dispatcher, mockPlayer := newDispatcher()
+	mockPlayer.State = audio.StatePlaying
+	mockPlayer.Position = 1500 * time.Millisecond
+	mockPlayer.Duration = time.Minute
+
+	response := dispatcher.Dispatch("status")
+
+	require.True(t, response.OK)
+	assert.Equal(t, &daemon.Status{State: "playing", PositionMS: 1500, DurationMS: 60000, Volume: 100}, response.Status)
+}
+
+func TestDispatcher_RejectsBadRequests(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected string
+	}{
+		{"", "empty command"},
+		{"skip", `unknown command "skip"`},
+		{"play", "play needs a track URL or file path"},
+		{"volume", "volume must be a number from 0 to 100"},
+		{"volume loud", "volume must be a number from 0 to 100"},
+		{"volume 101", "volume must be a number from 0 to 100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			dispatcher, _ := newDispatcher()
+			response := dispatcher.Dispatch(tt.line)
+			assert.False(t, response.OK)
+			assert.Contains(t, response.Error, tt.expected)
+			assert.Nil(t, response.Status)
+		})
+	}
+}
+
+func TestDispatcher_ReportsExtractionFailure(t *testing.T) {
+	mockPlayer := testutil.NewMockAudioPlayer()
+	extractor := &testutil.MockStreamExtractor{
+		ExtractFunc: func(ctx context.Context, trackID int64) (*audio.StreamInfo, error) {
+			return nil, errors.New("geo-blocked")
+		},
+	}
+	dispatcher := daemon.NewDispatcher(&testutil.MockSoundCloudClient{}, mockPlayer, extractor)
+
+	response := dispatcher.Dispatch("play https://soundcloud.com/artist/track")
+
+	assert.False(t, response.OK)
+	assert.Contains(t, response.Error, "geo-blocked")
+	assert.Zero(t, mockPlayer.CallCount("Play"))
+}
+
+func TestSocket_SendAndServe(t *testing.T) {
+	// Socket paths are limited to about a hundred bytes, too short for t.TempDir
+	dir, err := os.MkdirTemp("", "sctui")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	socketPath := filepath.Join(dir, daemon.SocketName)
+
+	listener, err := daemon.Listen(socketPath)
+	require.NoError(t, err)
+	dispatcher, _ := newDispatcher()
+	served := make(chan error, 1)
+	go func() { served <- daemon.Serve(listener, dispatcher) }()
+
+	response, err := daemon.Send(socketPath, "volume 20")
+	require.NoError(t, err)
+	assert.True(t, response.OK)
+	assert.Equal(t, 20, response.Status.Volume)
+
+	response, err = daemon.Send(socketPath, "rewind")
+	require.NoError(t, err)
+	assert.False(t, response.OK)
+
+	_, err = daemon.Listen(socketPath)
+	assert.ErrorContains(t, err, "already listening")
+
+	listener.Close()
+	assert.NoError(t, <-served)
+}
+
+func TestListen_ReplacesStaleSocket(t *testing.T) {
+	dir, err := os.MkdirTemp("", "sctui")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	socketPath := filepath.Join(dir, daemon.SocketName)
+	require.NoError(t, os.WriteFile(socketPath, nil, 0o600))
+
+	listener, err := daemon.Listen(socketPath)
+	require.NoError(t, err)
+	listener.Close()
+}
+
+func TestSend_WithoutDaemon(t *testing.T) {
+	_, err := daemon.Send(filepath.Join(t.TempDir(), "missing.sock"), "status")
+
+	assert.ErrorContains(t, err, "failed to connect to daemon")
+}
@@ -0,0 +1,99 @@
+package history_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/history"
+)
+
+func TestStore_AddAndRecent(t *testing.T) {
+	store := history.NewStore("", 10)
+	
+	store.Add("lofi")
+	store.Add("jazz")
+	store.Add("ambient")
+	
+	// Most recent first
+	assert.Equal(t, []string{"ambient", "jazz", "lofi"}, store.Recent())
+}
+
+func TestStore_Dedup(t *testing.T) {
+	store := history.NewStore("", 10)
+	
+	store.Add("lofi")
+	store.Add("jazz")
+	store.Add("lofi")
+	store.Add("  LoFi  ")
+	
+	// Re-submitting a query moves it to the front instead of duplicating it
+	assert.Equal(t, []string{"LoFi", "jazz"}, store.Recent())
+}
+
+func TestStore_IgnoresBlankQueries(t *testing.T) {
+	store := history.NewStore("", 10)
+	
+	store.Add("")
+	store.Add("   ")
+	
+	assert.Empty(t, store.Recent())
+}
+
+func TestStore_Cap(t *testing.T) {
+	store := history.NewStore("", 3)
+	
+	for i := 1; i <= 5; i++ {
+		store.Add(fmt.Sprintf("query %d", i))
+	}
+	
+	// Only the newest entries survive
+	assert.Equal(t, []string{"query 5", "query 4", "query 3"}, store.Recent())
+}
+
+func TestStore_RecentReturnsCopy(t *testing.T) {
+	store := history.NewStore("", 10)
+	store.Add("lofi")
+	
+	recent := store.Recent()
+	recent[0] = "mutated"
+	
+	assert.Equal(t, []string{"lofi"}, store.Recent())
+}
+
+func TestStore_PersistenceRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", history.SearchFileName)
+	
+	store := history.NewStore(path, 10)
+	store.Add("lofi")
+	store.Add("jazz")
+	require.NoError(t, store.Save())
+	
+	loaded, err := history.Load(path, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"jazz", "lofi"}, loaded.Recent())
+}
+
+func TestStore_LoadAppliesCap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), history.SearchFileName)
+	
+	store := history.NewStore(path, 10)
+	for i := 1; i <= 5; i++ {
+		store.Add(fmt.Sprintf("query %d", i))
+	}
+	require.NoError(t, store.Save())
+	
+	loaded, err := history.Load(path, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"query 5", "query 4"}, loaded.Recent())
+}
+
+func TestStore_LoadMissingFile(t *testing.T) {
+	loaded, err := history.Load(filepath.Join(t.TempDir(), "missing.json"), 10)
+	
+	require.NoError(t, err)
+	assert.Empty(t, loaded.Recent())
+}
@@ -0,0 +1,119 @@
+package history_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/history"
+	"soundcloud-tui/internal/soundcloud"
+)
+
+var (
+	firstLight = soundcloud.Track{ID: 1, Title: "First Light", PermalinkURL: "https://soundcloud.com/dawn/first-light", User: soundcloud.User{Username: "dawn"}}
+	nightDrive = soundcloud.Track{ID: 2, Title: "Night Drive", User: soundcloud.User{Username: "dusk"}}
+	localDemo  = soundcloud.Track{Title: "demo", StreamURL: "/music/demo.mp3"}
+)
+
+// at returns a time the given number of minutes into the day
+func at(minutes int) time.Time {
+	return time.Date(2026, 10, 16, 0, minutes, 0, 0, time.UTC)
+}
+
+func titles(plays []history.Play) []string {
+	var result []string
+	for _, play := range plays {
+		result = append(result, play.Title)
+	}
+	return result
+}
+
+func TestRecorder_RecordAndRecent(t *testing.T) {
+	recorder := history.NewRecorder("", 10)
+
+	recorder.Record(firstLight, at(1))
+	recorder.Record(nightDrive, at(2))
+	recorder.Record(firstLight, at(3))
+	recorder.Record(soundcloud.Track{Title: "Nowhere"}, at(4))
+
+	assert.Equal(t, 3, recorder.Len(), "a track without an ID or location isn't recorded")
+	recent := recorder.Recent(0)
+	assert.Equal(t, []string{"First Light", "Night Drive", "First Light"}, titles(recent))
+	assert.Equal(t, history.Play{
+		TrackID:  1,
+		Title:    "First Light",
+		Artist:   "dawn",
+		URL:      "https://soundcloud.com/dawn/first-light",
+		PlayedAt: at(3),
+	}, recent[0])
+	assert.Equal(t, []string{"First Light", "Night Drive"}, titles(recorder.Recent(2)))
+}
+
+func TestRecorder_DropsOldestPlaysBeyondCap(t *testing.T) {
+	recorder := history.NewRecorder("", 3)
+
+	for i := 0; i < 5; i++ {
+		recorder.Record(soundcloud.Track{ID: int64(i + 1), Title: string(rune('A' + i))}, at(i))
+	}
+
+	assert.Equal(t, []string{"E", "D", "C"}, titles(recorder.Recent(0)))
+}
+
+func TestRecorder_TopTracks(t *testing.T) {
+	recorder := history.NewRecorder("", 0)
+	recorder.Record(nightDrive, at(1))
+	recorder.Record(firstLight, at(2))
+	recorder.Record(localDemo, at(3))
+	recorder.Record(nightDrive, at(4))
+	recorder.Record(localDemo, at(5))
+	recorder.Record(firstLight, at(6))
+	recorder.Record(nightDrive, at(7))
+
+	top := recorder.TopTracks(0)
+
+	require.Len(t, top, 3)
+	assert.Equal(t, "Night Drive", top[0].Title)
+	assert.Equal(t, 3, top[0].Count)
+	assert.Equal(t, at(7), top[0].PlayedAt, "the latest play is kept")
+	// Two plays each: the most recently played comes first
+	assert.Equal(t, "First Light", top[1].Title)
+	assert.Equal(t, 2, top[1].Count)
+	assert.Equal(t, "demo", top[2].Title, "local files are counted by their path")
+	assert.Equal(t, 2, top[2].Count)
+
+	assert.Len(t, recorder.TopTracks(1), 1)
+}
+
+func TestRecorder_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", history.PlaysFileName)
+	recorder := history.NewRecorder(path, 10)
+	recorder.Record(firstLight, at(1))
+	recorder.Record(localDemo, at(2))
+	require.NoError(t, recorder.Save())
+
+	loaded, err := history.LoadRecorder(path, 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, recorder.Recent(0), loaded.Recent(0))
+	assert.Equal(t, "/music/demo.mp3", loaded.Recent(1)[0].URL)
+
+	// A smaller cap keeps the most recent plays
+	capped, err := history.LoadRecorder(path, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"demo"}, titles(capped.Recent(0)))
+}
+
+func TestLoadRecorder_MissingAndCorruptFile(t *testing.T) {
+	recorder, err := history.LoadRecorder(filepath.Join(t.TempDir(), history.PlaysFileName), 0)
+	require.NoError(t, err)
+	assert.Zero(t, recorder.Len())
+
+	path := filepath.Join(t.TempDir(), history.PlaysFileName)
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0o644))
+	_, err = history.LoadRecorder(path, 0)
+	assert.ErrorContains(t, err, "failed to parse play history")
+}
@@ -0,0 +1,237 @@
+package offline_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/offline"
+	"soundcloud-tui/internal/soundcloud"
+)
+
+var (
+	dawn = soundcloud.User{ID: 7, Username: "dawn", FirstName: "Dawn", LastName: "Raid"}
+	dusk = soundcloud.User{ID: 8, Username: "dusk"}
+)
+
+// cachedIndex returns an index of three tracks, the last added first
+func cachedIndex(dir string) *offline.Index {
+	index := offline.NewIndex(dir)
+	index.Add(soundcloud.Track{ID: 1, Title: "First Light", Genre: "Ambient", Duration: 185000, User: dawn}, "1.mp3")
+	index.Add(soundcloud.Track{ID: 2, Title: "Night Drive", Genre: "Synthwave", User: dusk}, "2.ogg")
+	index.Add(soundcloud.Track{ID: 3, Title: "Morning Drive", Genre: "House", User: dawn}, "/music/3.wav")
+	return index
+}
+
+func ids(tracks []soundcloud.Track) []int64 {
+	var result []int64
+	for _, track := range tracks {
+		result = append(result, track.ID)
+	}
+	return result
+}
+
+func TestIndex_Search(t *testing.T) {
+	index := cachedIndex("")
+
+	tests := []struct {
+		query    string
+		expected []int64
+	}{
+		{"drive", []int64{3, 2}},
+		{"DRIVE night", []int64{2}},
+		{"dawn", []int64{3, 1}},
+		{"raid", []int64{3, 1}},
+		{"ambient", []int64{1}},
+		{"", []int64{3, 2, 1}},
+		{"jazz", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ids(index.Search(tt.query)))
+		})
+	}
+}
+
+func TestIndex_Lookup(t *testing.T) {
+	dir := t.TempDir()
+	index := cachedIndex(dir)
+
+	track, path, ok := index.Lookup(1)
+	require.True(t, ok)
+	assert.Equal(t, "First Light", track.Title)
+	assert.Equal(t, filepath.Join(dir, "1.mp3"), path, "relative paths are inside the cache directory")
+
+	_, path, ok = index.Lookup(3)
+	require.True(t, ok)
+	assert.Equal(t, "/music/3.wav", path)
+
+	_, _, ok = index.Lookup(4)
+	assert.False(t, ok)
+}
+
+func TestIndex_AddReplacesTrack(t *testing.T) {
+	index := cachedIndex("")
+
+	index.Add(soundcloud.Track{ID: 1, Title: "First Light (Remaster)", User: dawn}, "1-remaster.mp3")
+	index.Add(soundcloud.Track{Title: "No ID"}, "x.mp3")
+	index.Add(soundcloud.Track{ID: 9, Title: "No file"}, "")
+
+	assert.Equal(t, 3, index.Len())
+	assert.Equal(t, []int64{1, 3, 2}, ids(index.Search("")), "a re-cached track is the most recent")
+	track, path, _ := index.Lookup(1)
+	assert.Equal(t, "First Light (Remaster)", track.Title)
+	assert.Equal(t, "1-remaster.mp3", path)
+}
+
+func TestIndex_SaveAndLoad(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), offline.DirName)
+	require.NoError(t, cachedIndex(dir).Save())
+
+	loaded, err := offline.Load(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, []int64{3, 2, 1}, ids(loaded.Search("")))
+	track, path, ok := loaded.Lookup(2)
+	require.True(t, ok)
+	assert.Equal(t, dusk, track.User)
+	assert.Equal(t, filepath.Join(dir, "2.ogg"), path)
+}
+
+func TestLoad_MissingAndCorruptIndex(t *testing.T) {
+	index, err := offline.Load(t.TempDir())
+	require.NoError(t, err)
+	assert.Zero(t, index.Len())
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, offline.IndexFileName), []byte("{not json"), 0o644))
+	_, err = offline.Load(dir)
+	assert.ErrorContains(t, err, "failed to parse track cache index")
+}
+
+func TestProvider(t *testing.T) {
+	provider := offline.NewProvider(cachedIndex(""))
+
+	tracks, total, err := provider.SearchWithTotal("drive")
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3, 2}, ids(tracks))
+	assert.Equal(t, 2, total)
+
+	_, _, err = provider.SearchWithTotal("jazz")
+	assert.ErrorIs(t, err, soundcloud.ErrNoResults)
+
+	tracks, err = provider.GetArtistTracks(dawn)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3, 1}, ids(tracks))
+
+	tracks, err = provider.GetUserTracks(dawn.ID, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, ids(tracks))
+	tracks, err = provider.GetUserTracks(dawn.ID, 1, 5)
+	require.NoError(t, err)
+	assert.Empty(t, tracks)
+
+	tracks, err = provider.GetRelatedTracks(1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3}, ids(tracks), "the artist's other cached tracks")
+}
+
+func TestExtractor_StreamsCachedFile(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "My Cache")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "1.mp3"), []byte("ID3"), 0o644))
+	extractor := offline.NewExtractor(cachedIndex(dir))
+
+	info, err := extractor.ExtractStreamURL(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.True(t, audio.IsLocalStream(info.URL))
+	assert.Equal(t, filepath.Join(dir, "1.mp3"), audio.LocalPath(info.URL))
+	assert.Equal(t, audio.FormatMP3, info.Format)
+	assert.Equal(t, int64(185000), info.Duration)
+
+	valid, err := extractor.ValidateStreamURL(context.Background(), info.URL)
+	require.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestExtractor_UncachedTracks(t *testing.T) {
+	extractor := offline.NewExtractor(cachedIndex(t.TempDir()))
+
+	_, err := extractor.ExtractStreamURL(context.Background(), 4)
+	assert.ErrorIs(t, err, offline.ErrNotCached)
+
+	// Listed in the index, but the file is gone
+	_, err = extractor.ExtractStreamURL(context.Background(), 2)
+	assert.ErrorIs(t, err, offline.ErrNotCached)
+}
+
+// streamServer serves "audio" at /stream.mp3 and counts the downloads
+func streamServer(t *testing.T, downloads *int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/stream.mp3" {
+			http.NotFound(w, r)
+			return
+		}
+		*downloads++
+		w.Write([]byte("ID3 audio"))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCache_StoresPlayedStream(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), offline.DirName)
+	downloads := 0
+	server := streamServer(t, &downloads)
+	cache := offline.NewCache(offline.NewIndex(dir))
+	track := soundcloud.Track{ID: 9, Title: "Harbour Lights", Duration: 1000, User: dusk}
+	info := &audio.StreamInfo{URL: server.URL + "/stream.mp3", Format: audio.FormatMP3}
+
+	require.NoError(t, cache.Store(context.Background(), track, info))
+	data, err := os.ReadFile(filepath.Join(dir, "9.mp3"))
+	require.NoError(t, err)
+	assert.Equal(t, "ID3 audio", string(data))
+
+	// The saved index lets the offline backend find and play it
+	index, err := offline.Load(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{9}, ids(index.Search("harbour")))
+	streamed, err := offline.NewExtractor(index).ExtractStreamURL(context.Background(), 9)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "9.mp3"), audio.LocalPath(streamed.URL))
+
+	// A cached track isn't downloaded again
+	require.NoError(t, cache.Store(context.Background(), track, info))
+	assert.Equal(t, 1, downloads)
+}
+
+func TestCache_SkipsUncacheableStreams(t *testing.T) {
+	dir := t.TempDir()
+	downloads := 0
+	server := streamServer(t, &downloads)
+	index := offline.NewIndex(dir)
+	cache := offline.NewCache(index)
+	track := soundcloud.Track{ID: 9, Title: "Harbour Lights"}
+
+	err := cache.Store(context.Background(), track, &audio.StreamInfo{URL: server.URL + "/playlist.m3u8", Format: audio.FormatHLS})
+	assert.ErrorIs(t, err, offline.ErrNotCacheable)
+
+	err = cache.Store(context.Background(), track, &audio.StreamInfo{URL: server.URL + "/gone.mp3", Format: audio.FormatMP3})
+	assert.ErrorContains(t, err, "HTTP 404")
+
+	_, _, ok := index.Lookup(9)
+	assert.False(t, ok, "failed downloads shouldn't be indexed")
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "no partial files should be left behind")
+}
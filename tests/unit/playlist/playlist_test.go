@@ -0,0 +1,198 @@
+package playlist_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/playlist"
+	"soundcloud-tui/internal/soundcloud"
+)
+
+func queuedTracks() []soundcloud.Track {
+	return []soundcloud.Track{
+		{
+			ID:           1,
+			Title:        "First Light",
+			Duration:     185000,
+			PermalinkURL: "https://soundcloud.com/dawn/first-light",
+			Genre:        "Ambient",
+			CreatedAt:    time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+			User:         soundcloud.User{ID: 7, Username: "dawn"},
+		},
+		{
+			ID:           2,
+			Title:        "Night Drive",
+			Duration:     242500,
+			PermalinkURL: "https://soundcloud.com/dusk/night-drive",
+			User:         soundcloud.User{ID: 8, Username: "dusk"},
+		},
+		{
+			Title:     "demo",
+			StreamURL: "/music/demo.mp3",
+		},
+	}
+}
+
+// fakeResolver looks tracks up by link
+type fakeResolver map[string]soundcloud.Track
+
+func (r fakeResolver) GetTrackInfo(url string) (*soundcloud.Track, error) {
+	track, ok := r[url]
+	if !ok {
+		return nil, errors.New("track not found")
+	}
+	return &track, nil
+}
+
+func TestWriteRead_JSONRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "queue.json")
+
+	written, err := playlist.Write(path, queuedTracks())
+	require.NoError(t, err)
+	assert.Equal(t, 3, written)
+
+	tracks, err := playlist.Read(path)
+	require.NoError(t, err)
+	assert.Equal(t, queuedTracks(), tracks)
+}
+
+func TestWrite_M3U(t *testing.T) {
+	path := filepath.Join(t.TempDir(), playlist.QueueFileName)
+
+	written, err := playlist.Write(path, queuedTracks())
+	require.NoError(t, err)
+	assert.Equal(t, 3, written)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `#EXTM3U
+#EXTINF:185,dawn - First Light
+https://soundcloud.com/dawn/first-light
+#EXTINF:242,dusk - Night Drive
+https://soundcloud.com/dusk/night-drive
+#EXTINF:-1,demo
+/music/demo.mp3
+`, string(data))
+}
+
+func TestWrite_M3USkipsTracksWithoutLocation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), playlist.QueueFileName)
+
+	written, err := playlist.Write(path, []soundcloud.Track{{ID: 3, Title: "Nowhere"}})
+
+	require.NoError(t, err)
+	assert.Zero(t, written)
+}
+
+func TestWriteRead_M3URoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), playlist.QueueFileName)
+	_, err := playlist.Write(path, queuedTracks())
+	require.NoError(t, err)
+
+	entries, err := playlist.Read(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, "https://soundcloud.com/dawn/first-light", entries[0].PermalinkURL)
+	assert.Equal(t, "First Light", entries[0].Title)
+	assert.Equal(t, "dawn", entries[0].User.Username)
+	assert.Equal(t, int64(185000), entries[0].Duration)
+	assert.Equal(t, "/music/demo.mp3", entries[2].StreamURL)
+	assert.Equal(t, "demo", entries[2].Title)
+
+	// Links are looked up again to get playable tracks
+	original := queuedTracks()
+	resolver := fakeResolver{
+		original[0].PermalinkURL: original[0],
+		original[1].PermalinkURL: original[1],
+	}
+	var tracks []soundcloud.Track
+	for _, entry := range entries {
+		track, err := playlist.Resolve(entry, resolver)
+		require.NoError(t, err)
+		tracks = append(tracks, *track)
+	}
+	assert.Equal(t, original, tracks)
+}
+
+func TestRead_PlainURLList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "urls.txt")
+	require.NoError(t, os.WriteFile(path, []byte(`# Favourites
+https://soundcloud.com/dawn/first-light
+
+  https://soundcloud.com/dusk/night-drive
+`), 0o644))
+
+	entries, err := playlist.Read(path)
+
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "https://soundcloud.com/dawn/first-light", entries[0].PermalinkURL)
+	assert.Empty(t, entries[0].Title)
+	assert.Equal(t, "https://soundcloud.com/dusk/night-drive", entries[1].PermalinkURL)
+}
+
+func TestRead_Missing(t *testing.T) {
+	_, err := playlist.Read(filepath.Join(t.TempDir(), playlist.QueueFileName))
+
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestRead_CorruptJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.json")
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0o644))
+
+	_, err := playlist.Read(path)
+
+	assert.ErrorContains(t, err, "failed to parse playlist")
+}
+
+func TestWrite_UnwritablePath(t *testing.T) {
+	// A regular file where the directory should be
+	blocker := filepath.Join(t.TempDir(), "blocker")
+	require.NoError(t, os.WriteFile(blocker, nil, 0o644))
+
+	_, err := playlist.Write(filepath.Join(blocker, playlist.QueueFileName), queuedTracks())
+
+	assert.ErrorContains(t, err, "failed to create playlist directory")
+}
+
+func TestResolve(t *testing.T) {
+	resolver := fakeResolver{"https://soundcloud.com/dawn/first-light": queuedTracks()[0]}
+
+	t.Run("full track is kept", func(t *testing.T) {
+		track, err := playlist.Resolve(queuedTracks()[1], resolver)
+		require.NoError(t, err)
+		assert.Equal(t, queuedTracks()[1], *track)
+	})
+
+	t.Run("link is normalized and looked up", func(t *testing.T) {
+		entry := soundcloud.Track{PermalinkURL: "https://m.soundcloud.com/dawn/first-light?si=abc"}
+		track, err := playlist.Resolve(entry, resolver)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), track.ID)
+	})
+
+	t.Run("unknown link fails", func(t *testing.T) {
+		entry := soundcloud.Track{PermalinkURL: "https://soundcloud.com/dusk/night-drive"}
+		_, err := playlist.Resolve(entry, resolver)
+		assert.Error(t, err)
+	})
+
+	t.Run("link without resolver fails", func(t *testing.T) {
+		entry := soundcloud.Track{PermalinkURL: "https://soundcloud.com/dawn/first-light"}
+		_, err := playlist.Resolve(entry, nil)
+		assert.ErrorContains(t, err, "without SoundCloud")
+	})
+
+	t.Run("local file needs no lookup", func(t *testing.T) {
+		track, err := playlist.Resolve(queuedTracks()[2], nil)
+		require.NoError(t, err)
+		assert.Equal(t, "/music/demo.mp3", track.StreamURL)
+	})
+}
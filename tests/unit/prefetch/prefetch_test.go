@@ -0,0 +1,167 @@
+package prefetch_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/prefetch"
+	"soundcloud-tui/internal/soundcloud"
+)
+
+func listing(ids ...int64) []soundcloud.Track {
+	var tracks []soundcloud.Track
+	for _, id := range ids {
+		tracks = append(tracks, soundcloud.Track{ID: id, Title: "Listed"})
+	}
+	return tracks
+}
+
+// recordingFetcher enriches tracks, recording which it was asked for
+type recordingFetcher struct {
+	mu      sync.Mutex
+	fetched []int64
+	release chan struct{} // When set, each fetch waits for a value
+	started chan int64    // When set, receives each ID as its fetch starts
+}
+
+func (f *recordingFetcher) fetch(track *soundcloud.Track) (*soundcloud.Track, error) {
+	f.mu.Lock()
+	f.fetched = append(f.fetched, track.ID)
+	f.mu.Unlock()
+
+	if f.started != nil {
+		f.started <- track.ID
+	}
+	if f.release != nil {
+		<-f.release
+	}
+
+	full := *track
+	full.ArtworkURL = "https://i1.sndcdn.com/artworks-full.jpg"
+	return &full, nil
+}
+
+func (f *recordingFetcher) fetchedIDs() []int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]int64(nil), f.fetched...)
+}
+
+func TestManager_WarmCachesTracks(t *testing.T) {
+	fetcher := &recordingFetcher{}
+	manager := prefetch.NewManager(fetcher.fetch, 2)
+
+	manager.Warm(listing(1, 2, 3, 2, 0))
+	manager.Wait()
+
+	assert.ElementsMatch(t, []int64{1, 2, 3}, fetcher.fetchedIDs(), "duplicates and tracks without an ID are skipped")
+	track, ok := manager.Get(2)
+	require.True(t, ok)
+	assert.Equal(t, "https://i1.sndcdn.com/artworks-full.jpg", track.ArtworkURL)
+	_, ok = manager.Get(4)
+	assert.False(t, ok)
+
+	// Cached tracks aren't fetched again
+	manager.Warm(listing(1, 2, 3, 4))
+	manager.Wait()
+	assert.ElementsMatch(t, []int64{1, 2, 3, 4}, fetcher.fetchedIDs())
+}
+
+func TestManager_RespectsConcurrencyLimit(t *testing.T) {
+	var running, peak atomic.Int32
+	fetch := func(track *soundcloud.Track) (*soundcloud.Track, error) {
+		now := running.Add(1)
+		for {
+			old := peak.Load()
+			if now <= old || peak.CompareAndSwap(old, now) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		running.Add(-1)
+		return track, nil
+	}
+	manager := prefetch.NewManager(fetch, 3)
+
+	manager.Warm(listing(1, 2, 3, 4, 5, 6, 7, 8, 9, 10))
+	manager.Wait()
+
+	assert.Equal(t, int32(3), peak.Load())
+	for id := int64(1); id <= 10; id++ {
+		_, ok := manager.Get(id)
+		assert.True(t, ok, "track %d should be cached", id)
+	}
+}
+
+func TestManager_NewWarmDropsQueuedTracks(t *testing.T) {
+	fetcher := &recordingFetcher{release: make(chan struct{}), started: make(chan int64, 10)}
+	manager := prefetch.NewManager(fetcher.fetch, 1)
+
+	manager.Warm(listing(1, 2, 3))
+	first := <-fetcher.started // One fetch holds the only slot
+
+	manager.Warm(listing(7, 8)) // The query changed
+	close(fetcher.release)
+	manager.Wait()
+
+	assert.ElementsMatch(t, []int64{first, 7, 8}, fetcher.fetchedIDs(), "the replaced warm-up's queued tracks are dropped")
+	_, ok := manager.Get(first)
+	assert.True(t, ok, "a fetch under way still finishes and is cached")
+}
+
+func TestManager_Cancel(t *testing.T) {
+	fetcher := &recordingFetcher{release: make(chan struct{}), started: make(chan int64, 10)}
+	manager := prefetch.NewManager(fetcher.fetch, 1)
+
+	manager.Warm(listing(1, 2, 3))
+	<-fetcher.started
+	manager.Cancel()
+	close(fetcher.release)
+	manager.Wait()
+
+	assert.Len(t, fetcher.fetchedIDs(), 1)
+}
+
+func TestManager_FailedFetchesAreNotCached(t *testing.T) {
+	calls := 0
+	fetch := func(track *soundcloud.Track) (*soundcloud.Track, error) {
+		calls++
+		return nil, errors.New("lookup failed")
+	}
+	manager := prefetch.NewManager(fetch, 1)
+
+	manager.Warm(listing(1))
+	manager.Wait()
+	_, ok := manager.Get(1)
+	assert.False(t, ok)
+
+	manager.Warm(listing(1))
+	manager.Wait()
+	assert.Equal(t, 2, calls, "a failed track is tried again on the next warm-up")
+}
+
+func TestManager_CacheIsCapped(t *testing.T) {
+	fetcher := &recordingFetcher{}
+	manager := prefetch.NewManager(fetcher.fetch, 4)
+
+	var ids []int64
+	for id := int64(1); id <= prefetch.MaxCachedTracks+5; id++ {
+		ids = append(ids, id)
+	}
+	// One at a time so the oldest are known
+	for _, id := range ids {
+		manager.Warm(listing(id))
+		manager.Wait()
+	}
+
+	_, ok := manager.Get(1)
+	assert.False(t, ok, "the oldest tracks are dropped")
+	_, ok = manager.Get(ids[len(ids)-1])
+	assert.True(t, ok)
+}
@@ -0,0 +1,52 @@
+package session_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/session"
+)
+
+func TestStore_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", session.FileName)
+	store := session.NewStore(path)
+
+	require.NoError(t, store.SaveSession(42, 95*time.Second))
+
+	saved, err := session.NewStore(path).LoadSession()
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.Equal(t, int64(42), saved.TrackID)
+	assert.Equal(t, 95*time.Second, saved.Position)
+}
+
+func TestStore_LoadWithoutSession(t *testing.T) {
+	store := session.NewStore(filepath.Join(t.TempDir(), session.FileName))
+
+	saved, err := store.LoadSession()
+	require.NoError(t, err)
+	assert.Nil(t, saved)
+}
+
+func TestStore_LoadCorruptSession(t *testing.T) {
+	path := filepath.Join(t.TempDir(), session.FileName)
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0o644))
+
+	_, err := session.NewStore(path).LoadSession()
+	assert.Error(t, err)
+}
+
+func TestStore_EmptyPathIsNoOp(t *testing.T) {
+	store := session.NewStore("")
+
+	require.NoError(t, store.SaveSession(42, time.Minute))
+
+	saved, err := store.LoadSession()
+	require.NoError(t, err)
+	assert.Nil(t, saved)
+}
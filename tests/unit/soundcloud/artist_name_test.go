@@ -0,0 +1,35 @@
+package soundcloud_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"soundcloud-tui/internal/soundcloud"
+)
+
+func TestUser_FullName(t *testing.T) {
+	tests := []struct {
+		name     string
+		user     soundcloud.User
+		expected string
+	}{
+		{"all names", soundcloud.User{Username: "dj", FirstName: "Ada", LastName: "Lovelace"}, "Ada Lovelace"},
+		{"first name only", soundcloud.User{Username: "dj", FirstName: "Ada"}, "Ada"},
+		{"last name only", soundcloud.User{Username: "dj", LastName: "Lovelace"}, "Lovelace"},
+		{"username only", soundcloud.User{Username: "dj"}, "dj"},
+		{"names without username", soundcloud.User{FirstName: "Ada", LastName: "Lovelace"}, "Ada Lovelace"},
+		{"first name without username", soundcloud.User{FirstName: "Ada"}, "Ada"},
+		{"last name without username", soundcloud.User{LastName: "Lovelace"}, "Lovelace"},
+		{"blank names fall back to username", soundcloud.User{Username: "dj", FirstName: " ", LastName: " "}, "dj"},
+		{"nothing", soundcloud.User{}, soundcloud.UnknownArtist},
+		{"only whitespace", soundcloud.User{Username: " ", FirstName: "\t"}, soundcloud.UnknownArtist},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.user.FullName())
+			assert.Equal(t, tt.expected, soundcloud.Track{User: tt.user}.Artist())
+		})
+	}
+}
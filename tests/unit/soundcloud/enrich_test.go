@@ -0,0 +1,64 @@
+package soundcloud_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	soundcloudapi "github.com/zackradisic/soundcloud-api"
+
+	"soundcloud-tui/internal/soundcloud"
+)
+
+func TestClientEnrichTrack(t *testing.T) {
+	api := &fakeAPI{
+		tracks: []soundcloudapi.Track{{
+			ID:            42,
+			Title:         "Full Title",
+			Description:   "The whole description",
+			ArtworkURL:    "https://i1.sndcdn.com/artworks-large.jpg",
+			Genre:         "Ambient",
+			PlaybackCount: 5000,
+			CommentCount:  12,
+		}},
+	}
+	client := testClient(t, api)
+	partial := &soundcloud.Track{
+		ID:           42,
+		Title:        "Full Title",
+		Description:  "The whole…",
+		PermalinkURL: "https://soundcloud.com/artist/full-title",
+		User:         soundcloud.User{Username: "artist"},
+	}
+
+	track, err := client.EnrichTrack(partial)
+
+	require.NoError(t, err)
+	assert.Equal(t, "The whole description", track.Description)
+	assert.Equal(t, "https://i1.sndcdn.com/artworks-large.jpg", track.ArtworkURL)
+	assert.Equal(t, "Ambient", track.Genre)
+	assert.Equal(t, int64(5000), track.PlaybackCount)
+	assert.Equal(t, int64(12), track.CommentCount)
+
+	// Fields the lookup left empty keep the search metadata
+	assert.Equal(t, "https://soundcloud.com/artist/full-title", track.PermalinkURL)
+	assert.Equal(t, "artist", track.User.Username)
+	assert.Equal(t, "The whole…", partial.Description, "the original track should be left alone")
+}
+
+func TestClientEnrichTrack_NotFound(t *testing.T) {
+	client := testClient(t, &fakeAPI{})
+
+	track, err := client.EnrichTrack(&soundcloud.Track{ID: 42})
+
+	assert.Nil(t, track)
+	assert.ErrorContains(t, err, "failed to enrich track")
+}
+
+func TestClientEnrichTrack_WithoutID(t *testing.T) {
+	client := testClient(t, &fakeAPI{})
+
+	_, err := client.EnrichTrack(&soundcloud.Track{Title: "Local file"})
+
+	assert.Error(t, err)
+}
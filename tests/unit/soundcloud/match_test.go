@@ -0,0 +1,60 @@
+package soundcloud_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/soundcloud"
+)
+
+func TestNormalizePermalink(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"https://soundcloud.com/artist/track", "soundcloud.com/artist/track"},
+		{"http://www.soundcloud.com/artist/track/", "soundcloud.com/artist/track"},
+		{"https://m.soundcloud.com/Artist/Track?si=abc#t=1:00", "soundcloud.com/artist/track"},
+		{"  https://soundcloud.com/artist/track  ", "soundcloud.com/artist/track"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, soundcloud.NormalizePermalink(tt.input))
+		})
+	}
+}
+
+func multiResult() []soundcloud.Track {
+	return []soundcloud.Track{
+		{ID: 1, Title: "Remix", PermalinkURL: "https://soundcloud.com/artist/track-remix"},
+		{ID: 2, Title: "Original", PermalinkURL: "https://soundcloud.com/artist/track"},
+		{ID: 3, Title: "Live", PermalinkURL: "https://soundcloud.com/artist/track-live"},
+	}
+}
+
+func TestMatchTracks_PrefersPermalinkMatch(t *testing.T) {
+	matches := soundcloud.MatchTracks("https://www.soundcloud.com/artist/track?in=playlist", multiResult())
+
+	require.Len(t, matches, 1)
+	assert.Equal(t, int64(2), matches[0].ID)
+}
+
+func TestMatchTracks_URLFallsBackToFirst(t *testing.T) {
+	matches := soundcloud.MatchTracks("https://soundcloud.com/someone/else", multiResult())
+
+	require.Len(t, matches, 1)
+	assert.Equal(t, int64(1), matches[0].ID)
+}
+
+func TestMatchTracks_SearchInputKeepsAll(t *testing.T) {
+	matches := soundcloud.MatchTracks("artist track", multiResult())
+
+	assert.Len(t, matches, 3)
+}
+
+func TestMatchTracks_Empty(t *testing.T) {
+	assert.Empty(t, soundcloud.MatchTracks("https://soundcloud.com/artist/track", nil))
+}
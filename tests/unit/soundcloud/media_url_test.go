@@ -0,0 +1,41 @@
+package soundcloud_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const transcodingURL = "https://api-v2.soundcloud.com/media/soundcloud:tracks:42/abc/stream/progressive"
+
+func TestClientGetMediaURL(t *testing.T) {
+	client, requested := clientServing(t, http.StatusOK, `{"url": "https://cf-media.sndcdn.com/track.128.mp3"}`)
+
+	mediaURL, err := client.GetMediaURL(transcodingURL)
+
+	require.NoError(t, err)
+	assert.Equal(t, "https://cf-media.sndcdn.com/track.128.mp3", mediaURL)
+
+	require.Len(t, *requested, 1)
+	endpoint := (*requested)[0]
+	assert.Equal(t, "/media/soundcloud:tracks:42/abc/stream/progressive", endpoint.Path)
+	assert.Equal(t, "abc", endpoint.Query().Get("client_id"))
+}
+
+func TestClientGetMediaURL_Empty(t *testing.T) {
+	client, _ := clientServing(t, http.StatusOK, `{}`)
+
+	_, err := client.GetMediaURL(transcodingURL)
+
+	assert.ErrorContains(t, err, "no media URL")
+}
+
+func TestClientGetMediaURL_Error(t *testing.T) {
+	client, _ := clientServing(t, http.StatusNotFound, `{}`)
+
+	_, err := client.GetMediaURL(transcodingURL)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,41 @@
+package soundcloud_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/soundcloud"
+)
+
+func TestParseRelatedTracks(t *testing.T) {
+	data := []byte(`{
+		"collection": [
+			{"kind": "track", "id": 11, "title": "Near", "duration": 180000,
+			 "permalink_url": "https://soundcloud.com/a/near", "user": {"id": 3, "username": "a"}},
+			{"kind": "playlist", "id": 12, "title": "Not a track"},
+			{"kind": "track", "id": 13, "title": "Far", "user": {"id": 4, "username": "b"}}
+		]
+	}`)
+
+	tracks, err := soundcloud.ParseRelatedTracks(data)
+	require.NoError(t, err)
+	require.Len(t, tracks, 2)
+	assert.Equal(t, int64(11), tracks[0].ID)
+	assert.Equal(t, "Near", tracks[0].Title)
+	assert.Equal(t, "https://soundcloud.com/a/near", tracks[0].PermalinkURL)
+	assert.Equal(t, "a", tracks[0].User.Username)
+	assert.Equal(t, int64(13), tracks[1].ID)
+}
+
+func TestParseRelatedTracks_Empty(t *testing.T) {
+	tracks, err := soundcloud.ParseRelatedTracks([]byte(`{"collection": []}`))
+	require.NoError(t, err)
+	assert.Empty(t, tracks)
+}
+
+func TestParseRelatedTracks_Invalid(t *testing.T) {
+	_, err := soundcloud.ParseRelatedTracks([]byte(`not json`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,142 @@
+package soundcloud_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	soundcloudapi "github.com/zackradisic/soundcloud-api"
+
+	"soundcloud-tui/internal/soundcloud/retry"
+)
+
+// fastPolicy retries quickly so tests don't sleep for real backoff periods
+func fastPolicy() retry.Policy {
+	return retry.Policy{
+		MaxAttempts:    4,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Second,
+	}
+}
+
+// flakyAPI fails with the given errors in turn, then succeeds
+type flakyAPI struct {
+	failures []error
+	calls    int
+}
+
+func (f *flakyAPI) call() error {
+	f.calls++
+	if f.calls <= len(f.failures) {
+		return f.failures[f.calls-1]
+	}
+	return nil
+}
+
+func TestDo_RetriesRateLimitUntilSuccess(t *testing.T) {
+	api := &flakyAPI{failures: []error{
+		&soundcloudapi.FailedRequestError{Status: http.StatusTooManyRequests},
+		&soundcloudapi.FailedRequestError{Status: http.StatusTooManyRequests},
+	}}
+
+	err := retry.Do(context.Background(), fastPolicy(), api.call)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, api.calls)
+}
+
+func TestDo_RetriesServerErrors(t *testing.T) {
+	api := &flakyAPI{failures: []error{
+		&soundcloudapi.FailedRequestError{Status: http.StatusBadGateway},
+	}}
+
+	err := retry.Do(context.Background(), fastPolicy(), api.call)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, api.calls)
+}
+
+func TestDo_DoesNotRetryClientErrors(t *testing.T) {
+	api := &flakyAPI{failures: []error{
+		&soundcloudapi.FailedRequestError{Status: http.StatusNotFound},
+	}}
+
+	err := retry.Do(context.Background(), fastPolicy(), api.call)
+
+	var apiErr *soundcloudapi.FailedRequestError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.Status)
+	assert.Equal(t, 1, api.calls)
+}
+
+func TestDo_GivesUpAfterMaxAttempts(t *testing.T) {
+	rateLimited := &soundcloudapi.FailedRequestError{Status: http.StatusTooManyRequests}
+	api := &flakyAPI{failures: []error{rateLimited, rateLimited, rateLimited, rateLimited, rateLimited}}
+
+	err := retry.Do(context.Background(), fastPolicy(), api.call)
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, rateLimited)
+	assert.Contains(t, err.Error(), "giving up after 4 attempts")
+	assert.Equal(t, 4, api.calls)
+}
+
+func TestDo_HonorsRetryAfter(t *testing.T) {
+	api := &flakyAPI{failures: []error{
+		&retry.StatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: 50 * time.Millisecond},
+	}}
+
+	start := time.Now()
+	err := retry.Do(context.Background(), fastPolicy(), api.call)
+
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestDo_StopsWhenContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	api := &flakyAPI{failures: []error{
+		&retry.StatusError{StatusCode: http.StatusServiceUnavailable, RetryAfter: time.Hour},
+	}}
+
+	err := retry.Do(ctx, fastPolicy(), api.call)
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, api.calls)
+}
+
+func TestDo_PassesThroughOtherErrors(t *testing.T) {
+	boom := errors.New("network unreachable")
+
+	calls := 0
+	err := retry.Do(context.Background(), fastPolicy(), func() error {
+		calls++
+		return boom
+	})
+
+	assert.Equal(t, boom, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 3*time.Second, retry.ParseRetryAfter("3", now))
+	assert.Equal(t, 30*time.Second, retry.ParseRetryAfter("Mon, 01 Jan 2024 12:00:30 GMT", now))
+	assert.Equal(t, time.Duration(0), retry.ParseRetryAfter("", now))
+	assert.Equal(t, time.Duration(0), retry.ParseRetryAfter("soon", now))
+	assert.Equal(t, time.Duration(0), retry.ParseRetryAfter("-5", now))
+}
+
+func TestStatus(t *testing.T) {
+	assert.Equal(t, http.StatusForbidden, retry.Status(fmt.Errorf("wrapped: %w", &retry.StatusError{StatusCode: http.StatusForbidden})))
+	assert.Equal(t, http.StatusNotFound, retry.Status(&soundcloudapi.FailedRequestError{Status: http.StatusNotFound}))
+	assert.Zero(t, retry.Status(errors.New("connection reset")))
+	assert.Zero(t, retry.Status(nil))
+}
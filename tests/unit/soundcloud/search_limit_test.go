@@ -0,0 +1,57 @@
+package soundcloud_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/soundcloud"
+)
+
+// trackResults returns n search results with IDs 1..n
+func trackResults(n int) []map[string]interface{} {
+	results := make([]map[string]interface{}, n)
+	for i := range results {
+		results[i] = trackResult(int64(i+1), "Track")
+	}
+	return results
+}
+
+func TestClientSearch_CapsResults(t *testing.T) {
+	api := &fakeAPI{results: trackResults(80), total: 1203}
+	client := testClient(t, api)
+
+	tracks, total, err := client.SearchWithTotal("lofi")
+
+	require.NoError(t, err)
+	assert.Len(t, tracks, soundcloud.DefaultMaxResults, "more results than asked for should be dropped")
+	assert.Equal(t, 1203, total)
+	require.Len(t, api.searchOptions, 1)
+	assert.Equal(t, soundcloud.DefaultMaxResults, api.searchOptions[0].Limit)
+}
+
+func TestClientSearch_ConfiguredMaxResults(t *testing.T) {
+	api := &fakeAPI{results: trackResults(30), total: 30}
+	client := testClient(t, api)
+	client.SetMaxResults(20)
+
+	tracks, total, err := client.SearchWithTotal("lofi")
+
+	require.NoError(t, err)
+	assert.Len(t, tracks, 20)
+	assert.Equal(t, 30, total)
+	assert.Equal(t, 20, api.searchOptions[0].Limit)
+	assert.Equal(t, int64(1), tracks[0].ID, "the first results should be kept")
+}
+
+func TestClientSearch_TotalFallsBackToResultCount(t *testing.T) {
+	// Without total_results in the response the total is what came back
+	client := testClient(t, &fakeAPI{results: trackResults(3)})
+
+	tracks, total, err := client.SearchWithTotal("lofi")
+
+	require.NoError(t, err)
+	assert.Len(t, tracks, 3)
+	assert.Equal(t, 3, total)
+}
@@ -0,0 +1,143 @@
+package soundcloud_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	soundcloudapi "github.com/zackradisic/soundcloud-api"
+
+	"soundcloud-tui/internal/soundcloud"
+)
+
+// fakeAPI answers searches with the given errors in turn, then with results
+// and total. Searches and track lookups record the requested options.
+type fakeAPI struct {
+	clientID      string
+	failures      []error
+	results       []map[string]interface{}
+	total         int
+	calls         int
+	searchOptions []soundcloudapi.SearchOptions
+
+	tracks       []soundcloudapi.Track
+	trackOptions []soundcloudapi.GetTrackInfoOptions
+}
+
+func (f *fakeAPI) ClientID() string {
+	return f.clientID
+}
+
+func (f *fakeAPI) GetTrackInfo(options soundcloudapi.GetTrackInfoOptions) ([]soundcloudapi.Track, error) {
+	f.trackOptions = append(f.trackOptions, options)
+	return f.tracks, nil
+}
+
+func (f *fakeAPI) Search(options soundcloudapi.SearchOptions) (*soundcloudapi.PaginatedQuery, error) {
+	f.calls++
+	f.searchOptions = append(f.searchOptions, options)
+	if f.calls <= len(f.failures) {
+		return nil, f.failures[f.calls-1]
+	}
+	return &soundcloudapi.PaginatedQuery{Collection: f.results, TotalResults: f.total}, nil
+}
+
+func (f *fakeAPI) GetDownloadURL(url string, streamType string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func trackResult(id int64, title string) map[string]interface{} {
+	return map[string]interface{}{"kind": "track", "id": id, "title": title}
+}
+
+// testClient creates a client over apis, handing out the next one each time
+// the client asks for a fresh API
+func testClient(t *testing.T, apis ...*fakeAPI) *soundcloud.Client {
+	t.Helper()
+	created := 0
+	client, err := soundcloud.NewClientWithAPI(func() (soundcloud.API, error) {
+		if created >= len(apis) {
+			return nil, errors.New("no more APIs")
+		}
+		created++
+		return apis[created-1], nil
+	})
+	require.NoError(t, err)
+	client.SetRetryPolicy(fastPolicy())
+	return client
+}
+
+func TestClientSearch_RetriesTransientFailure(t *testing.T) {
+	api := &fakeAPI{
+		failures: []error{&soundcloudapi.FailedRequestError{Status: http.StatusBadGateway}},
+		results:  []map[string]interface{}{trackResult(1, "Found")},
+	}
+	client := testClient(t, api)
+
+	tracks, err := client.Search("lofi")
+
+	require.NoError(t, err)
+	require.Len(t, tracks, 1)
+	assert.Equal(t, "Found", tracks[0].Title)
+	assert.Equal(t, 2, api.calls)
+}
+
+func TestClientSearch_ReportsUnavailableAfterRetries(t *testing.T) {
+	unavailable := &soundcloudapi.FailedRequestError{Status: http.StatusServiceUnavailable}
+	api := &fakeAPI{failures: []error{unavailable, unavailable, unavailable, unavailable}}
+	client := testClient(t, api)
+
+	_, err := client.Search("lofi")
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, soundcloud.ErrSearchUnavailable)
+	assert.NotErrorIs(t, err, soundcloud.ErrNoResults)
+	assert.Equal(t, 4, api.calls)
+}
+
+func TestClientSearch_ReportsNoResults(t *testing.T) {
+	client := testClient(t, &fakeAPI{})
+
+	tracks, err := client.Search("nothing matches this")
+
+	assert.Empty(t, tracks)
+	assert.ErrorIs(t, err, soundcloud.ErrNoResults)
+	assert.NotErrorIs(t, err, soundcloud.ErrSearchUnavailable)
+}
+
+func TestClientSearch_RefreshesRejectedClientID(t *testing.T) {
+	stale := &fakeAPI{
+		clientID: "stale",
+		failures: []error{&soundcloudapi.FailedRequestError{Status: http.StatusUnauthorized}},
+	}
+	fresh := &fakeAPI{
+		clientID: "fresh",
+		results:  []map[string]interface{}{trackResult(1, "Found")},
+	}
+	client := testClient(t, stale, fresh)
+
+	tracks, err := client.Search("lofi")
+
+	require.NoError(t, err)
+	require.Len(t, tracks, 1)
+	assert.Equal(t, 1, stale.calls, "auth failures should not be retried with the same client ID")
+	assert.Equal(t, 1, fresh.calls)
+}
+
+func TestClientSearch_RefreshesClientIDOnlyOnce(t *testing.T) {
+	forbidden := &soundcloudapi.FailedRequestError{Status: http.StatusForbidden}
+	client := testClient(t,
+		&fakeAPI{failures: []error{forbidden}},
+		&fakeAPI{failures: []error{forbidden}},
+		&fakeAPI{results: []map[string]interface{}{trackResult(1, "Unreachable")}},
+	)
+
+	_, err := client.Search("lofi")
+
+	require.Error(t, err)
+	var apiErr *soundcloudapi.FailedRequestError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusForbidden, apiErr.Status)
+}
@@ -0,0 +1,44 @@
+package soundcloud_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	soundcloudapi "github.com/zackradisic/soundcloud-api"
+)
+
+func TestClientGetTrackByID(t *testing.T) {
+	api := &fakeAPI{
+		tracks: []soundcloudapi.Track{{
+			ID:           42,
+			Title:        "By ID",
+			DurationMS:   185000,
+			PermalinkURL: "https://soundcloud.com/artist/by-id",
+			User:         soundcloudapi.User{ID: 7, Username: "artist"},
+		}},
+	}
+	client := testClient(t, api)
+
+	track, err := client.GetTrackByID(42)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), track.ID)
+	assert.Equal(t, "By ID", track.Title)
+	assert.Equal(t, int64(185000), track.Duration)
+	assert.Equal(t, "https://soundcloud.com/artist/by-id", track.PermalinkURL)
+	assert.Equal(t, "artist", track.User.Username)
+
+	require.Len(t, api.trackOptions, 1)
+	assert.Equal(t, []int64{42}, api.trackOptions[0].ID)
+	assert.Empty(t, api.trackOptions[0].URL)
+}
+
+func TestClientGetTrackByID_NotFound(t *testing.T) {
+	client := testClient(t, &fakeAPI{tracks: []soundcloudapi.Track{{ID: 1, Title: "Other"}}})
+
+	track, err := client.GetTrackByID(42)
+
+	assert.Nil(t, track)
+	assert.ErrorContains(t, err, "no track found with ID 42")
+}
@@ -0,0 +1,105 @@
+package soundcloud_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	soundcloudapi "github.com/zackradisic/soundcloud-api"
+
+	"soundcloud-tui/internal/soundcloud"
+)
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"canonical", "https://soundcloud.com/artist/track", "https://soundcloud.com/artist/track"},
+		{"http upgraded", "http://soundcloud.com/artist/track", "https://soundcloud.com/artist/track"},
+		{"no scheme", "soundcloud.com/artist/track", "https://soundcloud.com/artist/track"},
+		{"www", "https://www.soundcloud.com/artist/track", "https://soundcloud.com/artist/track"},
+		{"mobile", "https://m.soundcloud.com/artist/track", "https://soundcloud.com/artist/track"},
+		{"mobile over http", "http://m.soundcloud.com/artist/track", "https://soundcloud.com/artist/track"},
+		{"upper case host", "https://SoundCloud.com/artist/track", "https://soundcloud.com/artist/track"},
+		{"surrounding whitespace", "  https://soundcloud.com/artist/track\n", "https://soundcloud.com/artist/track"},
+		{"trailing slash", "https://soundcloud.com/artist/track/", "https://soundcloud.com/artist/track"},
+		{"share tracking", "https://soundcloud.com/artist/track?si=0123456789abcdef&utm_source=clipboard&utm_medium=text&utm_campaign=social_sharing", "https://soundcloud.com/artist/track"},
+		{"playlist context", "https://soundcloud.com/artist/track?in=artist/sets/album", "https://soundcloud.com/artist/track"},
+		{"mobile with query", "https://m.soundcloud.com/artist/track?ref=clipboard&p=i&c=1", "https://soundcloud.com/artist/track"},
+		{"timestamp fragment", "https://soundcloud.com/artist/track#t=1:23", "https://soundcloud.com/artist/track"},
+		{"secret link", "https://soundcloud.com/artist/track/s-AbC123?si=xyz", "https://soundcloud.com/artist/track/s-AbC123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalized, err := soundcloud.NormalizeURL(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, normalized)
+		})
+	}
+}
+
+func TestNormalizeURL_RejectsNonTrackLinks(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"empty", ""},
+		{"blank", "   "},
+		{"search text", "some song name"},
+		{"other host", "https://example.com/artist/track"},
+		{"lookalike host", "https://soundcloud.com.example.com/artist/track"},
+		{"short link", "https://on.soundcloud.com/AbCdEf"},
+		{"other scheme", "ftp://soundcloud.com/artist/track"},
+		{"home page", "https://soundcloud.com"},
+		{"profile", "https://soundcloud.com/artist"},
+		{"profile with slash", "https://soundcloud.com/artist/"},
+		{"playlist", "https://soundcloud.com/artist/sets/album"},
+		{"mobile playlist", "https://m.soundcloud.com/artist/sets/album?si=abc"},
+		{"playlist index", "https://soundcloud.com/artist/sets"},
+		{"likes", "https://soundcloud.com/artist/likes"},
+		{"tracks tab", "https://soundcloud.com/artist/tracks"},
+		{"reposts", "https://soundcloud.com/artist/reposts"},
+		{"search page", "https://soundcloud.com/search/sounds?q=song"},
+		{"discover", "https://soundcloud.com/discover/sets/charts-top"},
+		{"user page", "https://soundcloud.com/you/library"},
+		{"too deep", "https://soundcloud.com/artist/track/comments"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := soundcloud.NormalizeURL(tt.input)
+			assert.ErrorIs(t, err, soundcloud.ErrInvalidTrackURL)
+		})
+	}
+}
+
+func TestNormalizeURL_ExplainsPlaylistRejection(t *testing.T) {
+	_, err := soundcloud.NormalizeURL("https://soundcloud.com/artist/sets/album")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "playlist")
+}
+
+func TestClientGetTrackInfo_NormalizesURL(t *testing.T) {
+	api := &fakeAPI{tracks: []soundcloudapi.Track{{ID: 1, Title: "Track"}}}
+	client := testClient(t, api)
+
+	_, err := client.GetTrackInfo("https://m.soundcloud.com/artist/track?si=abc&utm_source=clipboard")
+
+	require.NoError(t, err)
+	require.NotEmpty(t, api.trackOptions)
+	assert.Equal(t, "https://soundcloud.com/artist/track", api.trackOptions[0].URL)
+}
+
+func TestClientGetTrackInfo_RejectsPlaylistWithoutCallingAPI(t *testing.T) {
+	api := &fakeAPI{}
+	client := testClient(t, api)
+
+	_, err := client.GetTrackInfo("https://soundcloud.com/artist/sets/album")
+
+	assert.ErrorIs(t, err, soundcloud.ErrInvalidTrackURL)
+	assert.Empty(t, api.trackOptions)
+}
@@ -0,0 +1,91 @@
+package soundcloud_test
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/soundcloud"
+)
+
+// roundTripFunc answers HTTP requests without a network
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// clientServing returns a client whose api-v2 requests get status and body,
+// recording the requested URLs
+func clientServing(t *testing.T, status int, body string) (*soundcloud.Client, *[]*url.URL) {
+	t.Helper()
+	var requested []*url.URL
+	client := testClient(t, &fakeAPI{clientID: "abc"})
+	client.SetHTTPClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requested = append(requested, req.URL)
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     http.Header{},
+			Request:    req,
+		}, nil
+	})})
+	return client, &requested
+}
+
+func TestClientGetUserTracks(t *testing.T) {
+	client, requested := clientServing(t, http.StatusOK, `{
+		"collection": [
+			{"kind": "track", "id": 31, "title": "Newest", "user": {"id": 7, "username": "dj-seven"}},
+			{"kind": "track", "id": 30, "title": "Older", "user": {"id": 7, "username": "dj-seven"}}
+		]
+	}`)
+
+	tracks, err := client.GetUserTracks(7, 20, 40)
+
+	require.NoError(t, err)
+	require.Len(t, tracks, 2)
+	assert.Equal(t, "Newest", tracks[0].Title)
+	assert.Equal(t, "dj-seven", tracks[1].User.Username)
+
+	require.Len(t, *requested, 1)
+	endpoint := (*requested)[0]
+	assert.Equal(t, "/users/7/tracks", endpoint.Path)
+	assert.Equal(t, "20", endpoint.Query().Get("limit"))
+	assert.Equal(t, "40", endpoint.Query().Get("offset"))
+	assert.Equal(t, "abc", endpoint.Query().Get("client_id"))
+}
+
+func TestClientGetUserTracks_NoPublicTracks(t *testing.T) {
+	client, _ := clientServing(t, http.StatusOK, `{"collection": []}`)
+
+	tracks, err := client.GetUserTracks(7, 20, 0)
+
+	require.NoError(t, err)
+	assert.Empty(t, tracks)
+}
+
+func TestClientGetUserTracks_Error(t *testing.T) {
+	client, _ := clientServing(t, http.StatusNotFound, `{}`)
+
+	_, err := client.GetUserTracks(7, 20, 0)
+
+	assert.Error(t, err)
+}
+
+func TestClientGetArtistTracks_UsesUserTracks(t *testing.T) {
+	client, requested := clientServing(t, http.StatusOK, `{"collection": [{"kind": "track", "id": 1, "title": "Only"}]}`)
+
+	tracks, err := client.GetArtistTracks(soundcloud.User{ID: 7, Username: "dj-seven"})
+
+	require.NoError(t, err)
+	assert.Len(t, tracks, 1)
+	require.Len(t, *requested, 1)
+	assert.Equal(t, "/users/7/tracks", (*requested)[0].Path)
+	assert.Equal(t, "50", (*requested)[0].Query().Get("limit"))
+}
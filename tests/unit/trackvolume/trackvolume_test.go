@@ -0,0 +1,105 @@
+package trackvolume_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/trackvolume"
+)
+
+func TestStore_LookupTrackThenArtist(t *testing.T) {
+	store := trackvolume.NewStore("", 10)
+	store.Remember(1, 10, 0.4)
+	store.Remember(2, 10, 0.6)
+
+	volume, ok := store.Lookup(1, 10)
+	require.True(t, ok)
+	assert.Equal(t, 0.4, volume, "the track's own volume wins over its artist's")
+
+	volume, ok = store.Lookup(3, 10)
+	require.True(t, ok)
+	assert.Equal(t, 0.6, volume, "the artist's most recent volume is used")
+
+	_, ok = store.Lookup(4, 20)
+	assert.False(t, ok)
+	_, ok = store.Lookup(4, 0)
+	assert.False(t, ok, "an unknown artist never matches")
+}
+
+func TestStore_RememberReplacesAndClamps(t *testing.T) {
+	store := trackvolume.NewStore("", 10)
+	store.Remember(1, 0, 0.4)
+	store.Remember(1, 0, 1.5)
+	store.Remember(0, 0, 0.3) // Tracks without an ID can't be remembered
+
+	volume, ok := store.Lookup(1, 0)
+	require.True(t, ok)
+	assert.Equal(t, 1.0, volume)
+	assert.Equal(t, 1, store.Len())
+}
+
+func TestStore_Cap(t *testing.T) {
+	store := trackvolume.NewStore("", 3)
+	for id := int64(1); id <= 5; id++ {
+		store.Remember(id, 0, 0.5)
+	}
+	store.Remember(3, 0, 0.2) // Adjusting a track again keeps it
+
+	assert.Equal(t, 3, store.Len())
+	_, ok := store.Lookup(2, 0)
+	assert.False(t, ok, "the least recently adjusted tracks are dropped")
+	_, ok = store.Lookup(3, 0)
+	assert.True(t, ok)
+}
+
+func TestStore_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", trackvolume.FileName)
+	store := trackvolume.NewStore(path, 10)
+	store.Remember(1, 10, 0.4)
+	store.Remember(2, 20, 0.7)
+	require.NoError(t, store.Save())
+
+	loaded, err := trackvolume.Load(path, 10)
+	require.NoError(t, err)
+
+	volume, ok := loaded.Lookup(1, 10)
+	require.True(t, ok)
+	assert.Equal(t, 0.4, volume)
+	volume, ok = loaded.Lookup(2, 20)
+	require.True(t, ok)
+	assert.Equal(t, 0.7, volume)
+}
+
+func TestLoad_CapsSavedEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), trackvolume.FileName)
+	store := trackvolume.NewStore(path, 10)
+	for id := int64(1); id <= 5; id++ {
+		store.Remember(id, 0, 0.5)
+	}
+	require.NoError(t, store.Save())
+
+	loaded, err := trackvolume.Load(path, 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, loaded.Len())
+	_, ok := loaded.Lookup(5, 0)
+	assert.True(t, ok, "the most recent entries survive")
+}
+
+func TestLoad_MissingAndCorrupt(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := trackvolume.Load(filepath.Join(dir, "missing.json"), 10)
+	require.NoError(t, err)
+	assert.Zero(t, store.Len())
+
+	corrupt := filepath.Join(dir, "corrupt.json")
+	require.NoError(t, os.WriteFile(corrupt, []byte("{not json"), 0o644))
+	store, err = trackvolume.Load(corrupt, 10)
+	assert.ErrorContains(t, err, "failed to parse track volumes")
+	assert.NotNil(t, store, "a usable empty store is still returned")
+}
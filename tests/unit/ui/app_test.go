@@ -2,14 +2,79 @@ package ui_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
 	"soundcloud-tui/internal/ui/app"
+	"soundcloud-tui/internal/ui/components/player"
+	"soundcloud-tui/internal/ui/components/search"
 )
 
+// createTestApp builds an App around mocks, with HOME pointed at a temp dir so
+// settings and history stay out of the real config. Nil mocks get defaults.
+func createTestApp(t *testing.T, client *testutil.MockSoundCloudClient, audioPlayer *testutil.MockAudioPlayer) *app.App {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	if client == nil {
+		client = &testutil.MockSoundCloudClient{}
+	}
+	if audioPlayer == nil {
+		audioPlayer = testutil.NewMockAudioPlayer()
+	}
+	return app.NewAppWithDependencies(client, audioPlayer, &testutil.MockStreamExtractor{})
+}
+
+// quickMsgs runs cmd, including batched commands, and returns the messages
+// produced within a short wait. Timers such as progress ticks are left behind.
+func quickMsgs(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+
+	select {
+	case msg := <-done:
+		batch, ok := msg.(tea.BatchMsg)
+		if !ok {
+			if msg == nil {
+				return nil
+			}
+			return []tea.Msg{msg}
+		}
+		var msgs []tea.Msg
+		for _, sub := range batch {
+			msgs = append(msgs, quickMsgs(sub)...)
+		}
+		return msgs
+	case <-time.After(50 * time.Millisecond):
+		return nil
+	}
+}
+
+// settle feeds the messages cmd produces back into the app, as the Bubble
+// Tea runtime would, until only timers are left
+func settle(application *app.App, cmd tea.Cmd) {
+	pending := []tea.Cmd{cmd}
+	for rounds := 0; len(pending) > 0 && rounds < 20; rounds++ {
+		var next []tea.Cmd
+		for _, c := range pending {
+			for _, msg := range quickMsgs(c) {
+				_, followUp := application.Update(msg)
+				next = append(next, followUp)
+			}
+		}
+		pending = next
+	}
+}
+
 func TestApp_NewApp(t *testing.T) {
 	application := app.NewApp()
 
@@ -189,3 +254,50 @@ func TestApp_ErrorHandling(t *testing.T) {
 	// Should not crash and return the app
 }
 
+func TestApp_SearchToPlayerFlow(t *testing.T) {
+	client := &testutil.MockSoundCloudClient{
+		SearchFunc: func(query string) ([]soundcloud.Track, error) {
+			return []soundcloud.Track{
+				{ID: 7, Title: "Lofi Beat", User: soundcloud.User{Username: "beats"}},
+				{ID: 8, Title: "Lofi Rain", User: soundcloud.User{Username: "beats"}},
+			}, nil
+		},
+	}
+	mockPlayer := &testutil.MockAudioPlayer{Duration: 3 * time.Minute}
+	application := createTestApp(t, client, mockPlayer)
+
+	application.Update(runeKey("lofi"))
+	_, cmd := application.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	settle(application, cmd)
+
+	require.Contains(t, application.View(), "Lofi Rain")
+
+	application.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd = application.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	settle(application, cmd)
+
+	assert.Equal(t, app.ViewPlayer, application.GetCurrentView())
+	require.NotNil(t, application.GetPlayerComponent().GetCurrentTrack())
+	assert.Equal(t, int64(8), application.GetPlayerComponent().GetCurrentTrack().ID)
+	assert.Equal(t, player.StatePlaying, application.GetPlayerComponent().GetState())
+	assert.Equal(t, audio.StatePlaying, mockPlayer.GetState())
+}
+
+func TestApp_StreamInfoOnlyReachesPlayer(t *testing.T) {
+	application := createTestApp(t, nil, nil)
+	application.Update(search.SearchResultsMsg{Results: []soundcloud.Track{
+		{ID: 1, Title: "First"},
+		{ID: 2, Title: "Second"},
+	}})
+	application.Update(tea.KeyMsg{Type: tea.KeyDown})
+	searchComponent := application.GetSearchComponent()
+	before := searchComponent.View()
+
+	application.Update(player.StreamInfoMsg{StreamInfo: &audio.StreamInfo{URL: "https://example.com/stream.mp3"}})
+	application.Update(player.ProgressUpdateMsg{Position: time.Second, Duration: time.Minute})
+
+	assert.Equal(t, search.StateResults, searchComponent.GetState())
+	assert.Equal(t, 1, searchComponent.GetSelectedIndex())
+	assert.Len(t, searchComponent.GetResults(), 2)
+	assert.Equal(t, before, searchComponent.View())
+}
@@ -0,0 +1,235 @@
+package ui_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/app"
+	"soundcloud-tui/internal/ui/components/player"
+	"soundcloud-tui/internal/ui/components/search"
+)
+
+func TestSearchComponent_BrowseArtist(t *testing.T) {
+	artist := soundcloud.User{ID: 7, Username: "dj-seven"}
+	var requested soundcloud.User
+	client := &testutil.MockSoundCloudClient{
+		ArtistTracksFunc: func(user soundcloud.User) ([]soundcloud.Track, error) {
+			requested = user
+			return []soundcloud.Track{
+				{ID: 10, Title: "Seven One", User: artist},
+				{ID: 11, Title: "Seven Two", User: artist},
+				{ID: 12, Title: "Seven Three", User: artist},
+			}, nil
+		},
+	}
+	component := search.NewSearchComponent(client)
+	component.SetSize(120, 40)
+	original := []soundcloud.Track{
+		{ID: 1, Title: "First", User: soundcloud.User{ID: 1, Username: "someone"}},
+		{ID: 2, Title: "Second", User: artist},
+	}
+	component.Update(search.SearchResultsMsg{Results: original})
+	component.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+
+	_, cmd := component.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	require.NotNil(t, cmd)
+	assert.Equal(t, search.StateSearching, component.GetState())
+
+	component.Update(cmd())
+	assert.Equal(t, artist, requested)
+	assert.Equal(t, search.StateResults, component.GetState())
+	require.Len(t, component.GetResults(), 3)
+	require.NotNil(t, component.GetArtist())
+	assert.Equal(t, "dj-seven", component.GetArtist().Username)
+	assert.Equal(t, 0, component.GetSelectedIndex())
+	assert.Contains(t, component.View(), "Tracks by dj-seven")
+
+	// Esc goes back to the original results and selection, not the input
+	component.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, search.StateResults, component.GetState())
+	assert.Equal(t, original, component.GetResults())
+	assert.Equal(t, 1, component.GetSelectedIndex())
+	assert.Nil(t, component.GetArtist())
+
+	// A second Esc behaves as before
+	component.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, search.StateInput, component.GetState())
+}
+
+func TestSearchComponent_BrowseArtistError(t *testing.T) {
+	client := &testutil.MockSoundCloudClient{
+		ArtistTracksFunc: func(user soundcloud.User) ([]soundcloud.Track, error) {
+			return nil, errors.New("boom")
+		},
+	}
+	component := search.NewSearchComponent(client)
+	original := []soundcloud.Track{{ID: 1, Title: "First", User: soundcloud.User{ID: 3, Username: "three"}}}
+	component.Update(search.SearchResultsMsg{Results: original})
+
+	_, cmd := component.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	require.NotNil(t, cmd)
+	component.Update(cmd())
+	assert.Equal(t, search.StateError, component.GetState())
+
+	component.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, search.StateResults, component.GetState())
+	assert.Equal(t, original, component.GetResults())
+}
+
+// artistPage returns count tracks by artist, numbered from first
+func artistPage(artist soundcloud.User, first, count int) []soundcloud.Track {
+	tracks := make([]soundcloud.Track, count)
+	for i := range tracks {
+		tracks[i] = soundcloud.Track{ID: int64(first + i), Title: "Track", User: artist}
+	}
+	return tracks
+}
+
+func TestSearchComponent_BrowseArtistLoadsMorePages(t *testing.T) {
+	artist := soundcloud.User{ID: 7, Username: "dj-seven"}
+	var offsets []int
+	client := &testutil.MockSoundCloudClient{
+		ArtistTracksFunc: func(user soundcloud.User) ([]soundcloud.Track, error) {
+			return artistPage(artist, 0, soundcloud.UserTracksPageSize), nil
+		},
+		UserTracksFunc: func(userID int64, limit, offset int) ([]soundcloud.Track, error) {
+			assert.Equal(t, artist.ID, userID)
+			assert.Equal(t, soundcloud.UserTracksPageSize, limit)
+			offsets = append(offsets, offset)
+			return artistPage(artist, offset, 3), nil
+		},
+	}
+	component := search.NewSearchComponent(client)
+	component.SetSize(120, 40)
+
+	component.Update(component.BrowseArtist(artist)())
+	require.Len(t, component.GetResults(), soundcloud.UserTracksPageSize)
+	assert.Contains(t, component.View(), "more below")
+
+	// Moving within the list doesn't load anything
+	_, cmd := component.Update(runeKey("j"))
+	assert.Nil(t, cmd)
+
+	// Reaching the end loads the next page
+	_, cmd = component.Update(runeKey("G"))
+	require.NotNil(t, cmd)
+	assert.Contains(t, component.View(), "loading more")
+	component.Update(cmd())
+	assert.Equal(t, []int{soundcloud.UserTracksPageSize}, offsets)
+	require.Len(t, component.GetResults(), soundcloud.UserTracksPageSize+3)
+	assert.Equal(t, int64(soundcloud.UserTracksPageSize+2), component.GetResults()[soundcloud.UserTracksPageSize+2].ID)
+
+	// A short page was the last one
+	_, cmd = component.Update(runeKey("G"))
+	assert.Nil(t, cmd)
+	assert.NotContains(t, component.View(), "more below")
+}
+
+func TestSearchComponent_BrowseArtistRetriesFailedPage(t *testing.T) {
+	artist := soundcloud.User{ID: 7, Username: "dj-seven"}
+	fail := true
+	client := &testutil.MockSoundCloudClient{
+		ArtistTracksFunc: func(user soundcloud.User) ([]soundcloud.Track, error) {
+			return artistPage(artist, 0, soundcloud.UserTracksPageSize), nil
+		},
+		UserTracksFunc: func(userID int64, limit, offset int) ([]soundcloud.Track, error) {
+			if fail {
+				return nil, errors.New("boom")
+			}
+			return artistPage(artist, offset, 1), nil
+		},
+	}
+	component := search.NewSearchComponent(client)
+	component.SetSize(120, 40)
+	component.Update(component.BrowseArtist(artist)())
+
+	_, cmd := component.Update(runeKey("G"))
+	require.NotNil(t, cmd)
+	component.Update(cmd())
+	assert.Equal(t, search.StateResults, component.GetState())
+	assert.Len(t, component.GetResults(), soundcloud.UserTracksPageSize)
+	assert.Contains(t, component.View(), "couldn't load more")
+
+	fail = false
+	_, cmd = component.Update(tea.KeyMsg{Type: tea.KeyDown})
+	require.NotNil(t, cmd)
+	component.Update(cmd())
+	assert.Len(t, component.GetResults(), soundcloud.UserTracksPageSize+1)
+}
+
+func TestSearchComponent_StaleArtistPageIsDropped(t *testing.T) {
+	artist := soundcloud.User{ID: 7, Username: "dj-seven"}
+	client := &testutil.MockSoundCloudClient{
+		ArtistTracksFunc: func(user soundcloud.User) ([]soundcloud.Track, error) {
+			return artistPage(user, 0, soundcloud.UserTracksPageSize), nil
+		},
+	}
+	component := search.NewSearchComponent(client)
+	component.Update(component.BrowseArtist(artist)())
+
+	component.Update(search.ArtistTracksPageMsg{UserID: 8, Offset: soundcloud.UserTracksPageSize, Tracks: artistPage(artist, 100, 1)})
+	component.Update(search.ArtistTracksPageMsg{UserID: 7, Offset: 3, Tracks: artistPage(artist, 100, 1)})
+
+	assert.Len(t, component.GetResults(), soundcloud.UserTracksPageSize)
+}
+
+func TestSearchComponent_ArtistWithoutPublicTracks(t *testing.T) {
+	component := search.NewSearchComponent(&testutil.MockSoundCloudClient{})
+	component.SetSize(120, 40)
+
+	component.Update(component.BrowseArtist(soundcloud.User{ID: 9, Username: "quiet"})())
+
+	assert.Equal(t, search.StateResults, component.GetState())
+	assert.Empty(t, component.GetResults())
+	assert.Contains(t, component.View(), "quiet has no public tracks")
+}
+
+func TestPlayerComponent_ArtistKeyEmitsMessage(t *testing.T) {
+	artist := soundcloud.User{ID: 7, Username: "dj-seven"}
+	component := player.NewPlayerComponent(testutil.NewMockAudioPlayer(), &testutil.MockStreamExtractor{})
+	component.SetCurrentTrack(&soundcloud.Track{ID: 1, Title: "Seven One", User: artist})
+
+	_, cmd := component.Update(runeKey("u"))
+	require.NotNil(t, cmd)
+
+	msg, ok := cmd().(player.ShowArtistMsg)
+	require.True(t, ok)
+	assert.Equal(t, artist, msg.User)
+}
+
+func TestPlayerComponent_ArtistKeyIgnoredWithoutArtist(t *testing.T) {
+	component := player.NewPlayerComponent(testutil.NewMockAudioPlayer(), &testutil.MockStreamExtractor{})
+
+	_, cmd := component.Update(runeKey("u"))
+	assert.Nil(t, cmd)
+
+	component.SetCurrentTrack(player.LocalTrack("/music/song.mp3"))
+	_, cmd = component.Update(runeKey("u"))
+	assert.Nil(t, cmd)
+}
+
+func TestApp_ShowArtistBrowsesInSearchView(t *testing.T) {
+	artist := soundcloud.User{ID: 7, Username: "dj-seven"}
+	client := &testutil.MockSoundCloudClient{
+		ArtistTracksFunc: func(user soundcloud.User) ([]soundcloud.Track, error) {
+			return artistPage(user, 1, 2), nil
+		},
+	}
+	application := createTestApp(t, client, nil)
+	application.SetCurrentView(app.ViewPlayer)
+
+	_, cmd := application.Update(player.ShowArtistMsg{User: artist})
+	require.NotNil(t, cmd)
+	application.Update(cmd())
+
+	assert.Equal(t, app.ViewSearch, application.GetCurrentView())
+	require.NotNil(t, application.GetSearchComponent().GetArtist())
+	assert.Equal(t, artist, *application.GetSearchComponent().GetArtist())
+	assert.Len(t, application.GetSearchComponent().GetResults(), 2)
+}
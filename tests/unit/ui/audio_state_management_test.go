@@ -9,6 +9,7 @@ import (
 
 	"soundcloud-tui/internal/audio"
 	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
 	"soundcloud-tui/internal/ui/components/player"
 )
 
@@ -34,7 +35,7 @@ func TestAudioStateManagement_StateTransitions(t *testing.T) {
 			initialPlayerState: audio.StatePlaying,
 			initialUIState:    player.StatePlaying,
 			action:            "pause",
-			expectedUIState:   player.StatePlaying, // State doesn't change until audio confirms
+			expectedUIState:   player.StatePaused,
 			expectedAudioCall: "pause",
 		},
 		{
@@ -57,10 +58,10 @@ func TestAudioStateManagement_StateTransitions(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockPlayer := &MockAudioPlayer{
-				state: tt.initialPlayerState,
+			mockPlayer := &testutil.MockAudioPlayer{
+				State: tt.initialPlayerState,
 			}
-			mockExtractor := &MockStreamExtractor{}
+			mockExtractor := &testutil.MockStreamExtractor{}
 
 			playerComponent := player.NewPlayerComponent(mockPlayer, mockExtractor)
 			playerComponent.SetState(tt.initialUIState)
@@ -81,6 +82,11 @@ func TestAudioStateManagement_StateTransitions(t *testing.T) {
 			case "pause", "resume":
 				spaceMsg := tea.KeyMsg{Type: tea.KeySpace}
 				updatedComponent, cmd = playerComponent.Update(spaceMsg)
+				// The UI state doesn't change until the audio player confirms
+				assert.Equal(t, tt.initialUIState, playerComponent.GetState())
+				if cmd != nil {
+					updatedComponent, _ = playerComponent.Update(cmd())
+				}
 			case "stop":
 				// For testing purposes, simulate stop by setting to idle
 				playerComponent.SetState(player.StateIdle)
@@ -98,11 +104,11 @@ func TestAudioStateManagement_StateTransitions(t *testing.T) {
 }
 
 func TestAudioStateManagement_StateSynchronization(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{
-		state:    audio.StateStopped,
-		position: 0,
-		duration: 180000,
-		volume:   0.8,
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:    audio.StateStopped,
+		Position: 0,
+		Duration: 180000,
+		Volume:   0.8,
 	}
 
 	playerComponent := player.NewPlayerComponent(mockPlayer, nil)
@@ -125,12 +131,12 @@ func TestAudioStateManagement_StateSynchronization(t *testing.T) {
 	}
 
 	for _, state := range states {
-		mockPlayer.state = state.audioState
+		mockPlayer.State = state.audioState
 		
 		// Simulate state detection through progress updates
 		progressMsg := player.ProgressUpdateMsg{
-			Position: time.Duration(mockPlayer.position) * time.Millisecond,
-			Duration: time.Duration(mockPlayer.duration) * time.Millisecond,
+			Position: time.Duration(mockPlayer.Position) * time.Millisecond,
+			Duration: time.Duration(mockPlayer.Duration) * time.Millisecond,
 		}
 
 		// Update component state based on audio player state
@@ -151,11 +157,11 @@ func TestAudioStateManagement_StateSynchronization(t *testing.T) {
 }
 
 func TestAudioStateManagement_ProgressUpdatesDuringPlayback(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{
-		state:    audio.StatePlaying,
-		position: 0,
-		duration: 180000, // 3 minutes
-		volume:   1.0,
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:    audio.StatePlaying,
+		Position: 0,
+		Duration: 180000, // 3 minutes
+		Volume:   1.0,
 	}
 
 	playerComponent := player.NewPlayerComponent(mockPlayer, nil)
@@ -171,11 +177,11 @@ func TestAudioStateManagement_ProgressUpdatesDuringPlayback(t *testing.T) {
 	progressPoints := []int64{0, 30000, 60000, 90000, 120000, 150000, 180000}
 
 	for i, pos := range progressPoints {
-		mockPlayer.position = time.Duration(pos) * time.Millisecond
+		mockPlayer.Position = time.Duration(pos) * time.Millisecond
 		
 		progressMsg := player.ProgressUpdateMsg{
 			Position: time.Duration(pos) * time.Millisecond,
-			Duration: time.Duration(mockPlayer.duration) * time.Millisecond,
+			Duration: time.Duration(mockPlayer.Duration) * time.Millisecond,
 		}
 
 		updatedComponent, cmd := playerComponent.Update(progressMsg)
@@ -229,10 +235,10 @@ func TestAudioStateManagement_ErrorStateHandling(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockPlayer := &MockAudioPlayer{
-				state: audio.StateStopped,
+			mockPlayer := &testutil.MockAudioPlayer{
+				State: audio.StateStopped,
 			}
-			mockExtractor := &MockStreamExtractor{}
+			mockExtractor := &testutil.MockStreamExtractor{}
 
 			playerComponent := player.NewPlayerComponent(mockPlayer, mockExtractor)
 			playerComponent.SetState(tt.initialState)
@@ -273,10 +279,10 @@ func TestAudioStateManagement_ErrorStateHandling(t *testing.T) {
 }
 
 func TestAudioStateManagement_StateRecoveryAfterError(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{
-		state: audio.StateStopped,
+	mockPlayer := &testutil.MockAudioPlayer{
+		State: audio.StateStopped,
 	}
-	mockExtractor := &MockStreamExtractor{}
+	mockExtractor := &testutil.MockStreamExtractor{}
 
 	playerComponent := player.NewPlayerComponent(mockPlayer, mockExtractor)
 	
@@ -312,9 +318,9 @@ func TestAudioStateManagement_StateRecoveryAfterError(t *testing.T) {
 }
 
 func TestAudioStateManagement_VolumeStateConsistency(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{
-		state:  audio.StatePlaying,
-		volume: 0.5,
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:  audio.StatePlaying,
+		Volume: 0.5,
 	}
 
 	playerComponent := player.NewPlayerComponent(mockPlayer, nil)
@@ -331,7 +337,7 @@ func TestAudioStateManagement_VolumeStateConsistency(t *testing.T) {
 
 	for _, newVolume := range volumeChanges {
 		// Simulate volume change
-		mockPlayer.volume = newVolume
+		mockPlayer.Volume = newVolume
 		
 		// Update through progress message (which would normally happen)
 		progressMsg := player.ProgressUpdateMsg{
@@ -354,11 +360,11 @@ func TestAudioStateManagement_VolumeStateConsistency(t *testing.T) {
 }
 
 func TestAudioStateManagement_SeekOperations(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{
-		state:    audio.StatePlaying,
-		position: 60000, // 1 minute
-		duration: 180000, // 3 minutes
-		volume:   0.8,
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:    audio.StatePlaying,
+		Position: 60000, // 1 minute
+		Duration: 180000, // 3 minutes
+		Volume:   0.8,
 	}
 
 	playerComponent := player.NewPlayerComponent(mockPlayer, nil)
@@ -409,11 +415,11 @@ func TestAudioStateManagement_SeekOperations(t *testing.T) {
 }
 
 func TestAudioStateManagement_ConcurrentStateUpdates(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{
-		state:    audio.StatePlaying,
-		position: 30000,
-		duration: 180000,
-		volume:   0.7,
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:    audio.StatePlaying,
+		Position: 30000,
+		Duration: 180000,
+		Volume:   0.7,
 	}
 
 	playerComponent := player.NewPlayerComponent(mockPlayer, nil)
@@ -480,13 +486,13 @@ func TestAudioStateManagement_StateTransitionWithoutAudioPlayer(t *testing.T) {
 }
 
 func TestAudioStateManagement_TrackChangesDuringPlayback(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{
-		state:    audio.StatePlaying,
-		position: 60000,
-		duration: 180000,
-		volume:   0.8,
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:    audio.StatePlaying,
+		Position: 60000,
+		Duration: 180000,
+		Volume:   0.8,
 	}
-	mockExtractor := &MockStreamExtractor{}
+	mockExtractor := &testutil.MockStreamExtractor{}
 
 	playerComponent := player.NewPlayerComponent(mockPlayer, mockExtractor)
 	
@@ -0,0 +1,95 @@
+package ui_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/components/player"
+)
+
+// stalledComponent returns a component whose audio player stopped a minute
+// into a three-minute track
+func stalledComponent(autoResume bool) (*player.PlayerComponent, *testutil.MockAudioPlayer) {
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePlaying, Duration: 3 * time.Minute}
+	component := player.NewPlayerComponent(mockPlayer, &testutil.MockStreamExtractor{})
+	component.SetAutoResume(autoResume)
+	component.SetCurrentTrack(&soundcloud.Track{ID: 1, Title: "Long Mix", Duration: 180000})
+	component.SetState(player.StatePlaying)
+	component.Update(player.ProgressUpdateMsg{Position: time.Minute, Duration: 3 * time.Minute})
+
+	mockPlayer.State = audio.StateStopped
+	return component, mockPlayer
+}
+
+func TestPlayerComponent_AutoResumeRestartsStalledStream(t *testing.T) {
+	component, mockPlayer := stalledComponent(true)
+
+	_, cmd := component.Update(player.ProgressUpdateMsg{Position: time.Minute, Duration: 3 * time.Minute})
+	require.NotNil(t, cmd)
+	assert.Equal(t, player.StateLoading, component.GetState())
+
+	var streamInfo tea.Msg
+	for _, msg := range quickMsgs(cmd) {
+		if _, ok := msg.(player.StreamInfoMsg); ok {
+			streamInfo = msg
+		}
+	}
+	require.NotNil(t, streamInfo, "the stream should be extracted again")
+
+	_, cmd = component.Update(streamInfo)
+	require.NotNil(t, cmd)
+	cmd()
+
+	assert.Equal(t, 1, mockPlayer.CallCount("Play"))
+	seek, ok := mockPlayer.LastCall("Seek")
+	require.True(t, ok, "playback should continue from where it stopped")
+	assert.Equal(t, []interface{}{time.Minute}, seek.Args)
+}
+
+func TestPlayerComponent_StalledStreamWaitsWithoutAutoResume(t *testing.T) {
+	component, mockPlayer := stalledComponent(false)
+
+	_, cmd := component.Update(player.ProgressUpdateMsg{Position: time.Minute, Duration: 3 * time.Minute})
+
+	assert.Empty(t, quickMsgs(cmd))
+	assert.Equal(t, player.StatePlaying, component.GetState())
+	assert.Zero(t, mockPlayer.CallCount("Play"))
+}
+
+func TestPlayerComponent_RestartAfterPrematureStopKeepsVolume(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePlaying, Volume: 0.3, Duration: 3 * time.Minute}
+	component := player.NewPlayerComponent(mockPlayer, &testutil.MockStreamExtractor{})
+	component.SetCurrentTrack(&soundcloud.Track{ID: 1, Title: "Long Mix", Duration: 180000})
+	component.SetState(player.StatePlaying)
+	component.Update(player.ProgressUpdateMsg{Position: time.Minute, Duration: 3 * time.Minute})
+	require.InDelta(t, 0.3, component.GetVolume(), 0.001)
+
+	// The stream stopped early; the rebuilt pipeline starts at full volume
+	mockPlayer.State = audio.StateStopped
+	mockPlayer.Volume = 1.0
+
+	_, cmd := component.Update(tea.KeyMsg{Type: tea.KeySpace})
+	var streamInfo tea.Msg
+	for _, msg := range quickMsgs(cmd) {
+		if _, ok := msg.(player.StreamInfoMsg); ok {
+			streamInfo = msg
+		}
+	}
+	require.NotNil(t, streamInfo, "the stream should be restarted")
+
+	_, cmd = component.Update(streamInfo)
+	require.NotNil(t, cmd)
+	update := cmd()
+
+	assert.Equal(t, 1, mockPlayer.CallCount("Play"))
+	assert.InDelta(t, 0.3, mockPlayer.GetVolume(), 0.001)
+	component.Update(update)
+	assert.InDelta(t, 0.3, component.GetVolume(), 0.001, "the volume shown should not jump back")
+}
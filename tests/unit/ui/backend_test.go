@@ -0,0 +1,188 @@
+package ui_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/config"
+	"soundcloud-tui/internal/offline"
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/app"
+	"soundcloud-tui/internal/ui/components/player"
+	"soundcloud-tui/internal/ui/components/search"
+)
+
+// libraryProvider is a search provider that isn't SoundCloud, like a local
+// music library
+type libraryProvider struct {
+	tracks  []soundcloud.Track
+	related []soundcloud.Track
+}
+
+func (l *libraryProvider) SearchWithTotal(query string) ([]soundcloud.Track, int, error) {
+	return l.tracks, len(l.tracks), nil
+}
+
+func (l *libraryProvider) GetArtistTracks(user soundcloud.User) ([]soundcloud.Track, error) {
+	return nil, nil
+}
+
+func (l *libraryProvider) GetUserTracks(userID int64, limit, offset int) ([]soundcloud.Track, error) {
+	return nil, nil
+}
+
+func (l *libraryProvider) GetRelatedTracks(trackID int64) ([]soundcloud.Track, error) {
+	return l.related, nil
+}
+
+func TestApp_SearchesAndStreamsThroughBackend(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	provider := &libraryProvider{tracks: []soundcloud.Track{{ID: 42, Title: "From the library"}}}
+	var mu sync.Mutex
+	var extracted []int64
+	extractor := &testutil.MockStreamExtractor{
+		ExtractFunc: func(ctx context.Context, trackID int64) (*audio.StreamInfo, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			extracted = append(extracted, trackID)
+			return &audio.StreamInfo{URL: "file:///library/42.mp3", Format: "mp3"}, nil
+		},
+	}
+	application := app.NewAppWithBackend(app.Backend{Provider: provider, Extractor: extractor}, testutil.NewMockAudioPlayer())
+
+	for _, r := range "library" {
+		application.Update(runeKey(string(r)))
+	}
+	_, cmd := application.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	for _, msg := range quickMsgs(cmd) {
+		if results, ok := msg.(search.SearchResultsMsg); ok {
+			application.Update(results)
+		}
+	}
+	require.Len(t, application.GetSearchComponent().GetResults(), 1)
+	assert.Equal(t, "From the library", application.GetSearchComponent().GetResults()[0].Title)
+
+	application.Update(search.AddToQueueMsg{Track: provider.tracks[0]})
+	_, cmd = application.Update(player.NextTrackMsg{})
+	quickMsgs(cmd)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []int64{42}, extracted)
+}
+
+func TestApp_RadioUsesBackendProvider(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	provider := &libraryProvider{related: []soundcloud.Track{{ID: 7, Title: "Similar"}}}
+	application := app.NewAppWithBackend(app.Backend{Provider: provider, Extractor: &testutil.MockStreamExtractor{}}, testutil.NewMockAudioPlayer())
+	application.GetQueueComponent().SetRadio(true)
+	application.Update(search.AddToQueueMsg{Track: soundcloud.Track{ID: 1, Title: "Seed"}})
+	application.Update(player.NextTrackMsg{})
+
+	_, cmd := application.Update(player.NextTrackMsg{})
+	for _, msg := range quickMsgs(cmd) {
+		application.Update(msg)
+	}
+
+	assert.True(t, application.GetQueueComponent().Contains(7))
+}
+
+func TestBackendRegistry(t *testing.T) {
+	assert.Contains(t, app.Backends(), app.DefaultBackend)
+	assert.Contains(t, app.Backends(), app.OfflineBackend)
+
+	library := app.Backend{Provider: &libraryProvider{}, Extractor: &testutil.MockStreamExtractor{}}
+	app.RegisterBackend("library", func(settings *config.Settings) (app.Backend, error) {
+		return library, nil
+	})
+	app.RegisterBackend("broken", func(settings *config.Settings) (app.Backend, error) {
+		return app.Backend{}, errors.New("offline")
+	})
+
+	assert.Subset(t, app.Backends(), []string{"broken", "library", app.DefaultBackend})
+
+	backend, err := app.OpenBackend("library", config.DefaultSettings())
+	require.NoError(t, err)
+	assert.Equal(t, library, backend)
+
+	_, err = app.OpenBackend("broken", config.DefaultSettings())
+	assert.EqualError(t, err, "offline")
+
+	_, err = app.OpenBackend("bandcamp", config.DefaultSettings())
+	assert.ErrorContains(t, err, `unknown backend "bandcamp"`)
+}
+
+func TestApp_OfflineBackendPlaysCachedTracks(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := filepath.Join(config.ConfigDir(), offline.DirName)
+	index := offline.NewIndex(dir)
+	index.Add(soundcloud.Track{ID: 5, Title: "Cached Drive"}, "5.mp3")
+	require.NoError(t, index.Save())
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "5.mp3"), []byte("ID3"), 0o644))
+
+	backend, err := app.OpenBackend(app.OfflineBackend, config.DefaultSettings())
+	require.NoError(t, err)
+	mockPlayer := testutil.NewMockAudioPlayer()
+	application := app.NewAppWithBackend(backend, mockPlayer)
+	application.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	assert.Contains(t, stripANSI(application.View()), "Search cached tracks (offline):")
+
+	for _, r := range "drive" {
+		application.Update(runeKey(string(r)))
+	}
+	_, cmd := application.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	settle(application, cmd)
+	require.Len(t, application.GetSearchComponent().GetResults(), 1)
+	assert.Contains(t, stripANSI(application.View()), "Search Results (1 found, offline):")
+
+	_, cmd = application.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	settle(application, cmd)
+
+	play, ok := mockPlayer.LastCall("Play")
+	require.True(t, ok)
+	assert.Equal(t, "file://"+filepath.Join(dir, "5.mp3"), play.Args[0])
+}
+
+func TestApp_CachesTracksListenedTo(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ID3 audio"))
+	}))
+	defer server.Close()
+
+	dir := filepath.Join(config.ConfigDir(), offline.DirName)
+	extractor := &testutil.MockStreamExtractor{
+		ExtractFunc: func(ctx context.Context, trackID int64) (*audio.StreamInfo, error) {
+			return &audio.StreamInfo{URL: server.URL + "/stream.mp3", Format: audio.FormatMP3}, nil
+		},
+	}
+	backend := app.Backend{
+		Provider:  &libraryProvider{},
+		Extractor: extractor,
+		Cache:     offline.NewCache(offline.NewIndex(dir)),
+	}
+	application := app.NewAppWithBackend(backend, testutil.NewMockAudioPlayer())
+
+	_, cmd := application.Update(player.TrackHalfwayMsg{Track: &soundcloud.Track{ID: 5, Title: "Cached Drive"}})
+	settle(application, cmd)
+
+	// Offline, the track can be found and played from the cache
+	index, err := offline.Load(dir)
+	require.NoError(t, err)
+	track, path, ok := index.Lookup(5)
+	require.True(t, ok)
+	assert.Equal(t, "Cached Drive", track.Title)
+	assert.FileExists(t, path)
+}
@@ -0,0 +1,112 @@
+package ui_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/components/player"
+)
+
+func TestPlayerComponent_ShowsBufferingWhenBufferRunsLow(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:           audio.StatePlaying,
+		Duration:        3 * time.Minute,
+		BufferAvailable: 1024,
+		BufferTotal:     4 * 1024 * 1024,
+	}
+	component := playingComponent(mockPlayer)
+
+	component.Update(player.ProgressUpdateMsg{Position: 30 * time.Second, Duration: 3 * time.Minute})
+
+	assert.Equal(t, player.StateBuffering, component.GetState())
+	assert.Contains(t, component.View(), "Buffering")
+	assert.NotContains(t, component.View(), "Loading")
+
+	// Once enough data has arrived playback is shown again
+	mockPlayer.BufferAvailable = 2 * 1024 * 1024
+	component.Update(player.ProgressUpdateMsg{Position: 31 * time.Second, Duration: 3 * time.Minute})
+
+	assert.Equal(t, player.StatePlaying, component.GetState())
+	assert.NotContains(t, component.View(), "Buffering")
+}
+
+func TestPlayerComponent_CompletedDownloadIsNotBuffering(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:           audio.StatePlaying,
+		Duration:        3 * time.Minute,
+		BufferAvailable: 1024,
+		BufferTotal:     4 * 1024 * 1024,
+		BufferCompleted: true,
+	}
+	component := playingComponent(mockPlayer)
+
+	component.Update(player.ProgressUpdateMsg{Position: 179 * time.Second, Duration: 3 * time.Minute})
+
+	assert.Equal(t, player.StatePlaying, component.GetState())
+}
+
+func TestPlayerComponent_PausingWhileBuffering(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:       audio.StatePlaying,
+		Duration:    3 * time.Minute,
+		BufferTotal: 4 * 1024 * 1024,
+	}
+	component := playingComponent(mockPlayer)
+	component.Update(player.ProgressUpdateMsg{Position: 30 * time.Second, Duration: 3 * time.Minute})
+	assert.Equal(t, player.StateBuffering, component.GetState())
+
+	mockPlayer.State = audio.StatePaused
+	component.Update(player.ProgressUpdateMsg{Position: 30 * time.Second, Duration: 3 * time.Minute})
+
+	assert.Equal(t, player.StatePaused, component.GetState())
+}
+
+func TestPlayerComponent_MapsAudioBufferingState(t *testing.T) {
+	// The buffer itself looks fine, but the player reports a stall
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:           audio.StatePlaying,
+		Duration:        3 * time.Minute,
+		BufferAvailable: 2 * 1024 * 1024,
+		BufferTotal:     4 * 1024 * 1024,
+	}
+	component := playingComponent(mockPlayer)
+
+	mockPlayer.State = audio.StateBuffering
+	component.Update(player.ProgressUpdateMsg{Position: 30 * time.Second, Duration: 3 * time.Minute})
+	assert.Equal(t, player.StateBuffering, component.GetState())
+
+	mockPlayer.State = audio.StatePlaying
+	component.Update(player.ProgressUpdateMsg{Position: 31 * time.Second, Duration: 3 * time.Minute})
+	assert.Equal(t, player.StatePlaying, component.GetState())
+}
+
+func TestPlayerComponent_BufferingHysteresis(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:           audio.StatePlaying,
+		Duration:        3 * time.Minute,
+		BufferAvailable: audio.LowBufferBytes - 1,
+		BufferTotal:     4 * 1024 * 1024,
+	}
+	component := playingComponent(mockPlayer)
+
+	component.Update(player.ProgressUpdateMsg{Position: 30 * time.Second, Duration: 3 * time.Minute})
+	assert.Equal(t, player.StateBuffering, component.GetState())
+
+	// Climbing just past the low mark isn't enough to leave buffering
+	mockPlayer.BufferAvailable = audio.LowBufferBytes + 1
+	component.Update(player.ProgressUpdateMsg{Position: 30 * time.Second, Duration: 3 * time.Minute})
+	assert.Equal(t, player.StateBuffering, component.GetState())
+
+	mockPlayer.BufferAvailable = audio.RecoveredBufferBytes
+	component.Update(player.ProgressUpdateMsg{Position: 31 * time.Second, Duration: 3 * time.Minute})
+	assert.Equal(t, player.StatePlaying, component.GetState())
+
+	// Between the marks, playback that is already going carries on
+	mockPlayer.BufferAvailable = audio.LowBufferBytes + 1
+	component.Update(player.ProgressUpdateMsg{Position: 32 * time.Second, Duration: 3 * time.Minute})
+	assert.Equal(t, player.StatePlaying, component.GetState())
+}
@@ -0,0 +1,117 @@
+package ui_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/clipboard"
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/components/player"
+)
+
+// stubClipboard records copied text instead of touching the system clipboard
+type stubClipboard struct {
+	copied []string
+	err    error
+}
+
+func (s *stubClipboard) Copy(text string) error {
+	s.copied = append(s.copied, text)
+	return s.err
+}
+
+func TestPlayerComponent_CopyLink(t *testing.T) {
+	stub := &stubClipboard{}
+	component := player.NewPlayerComponent(testutil.NewMockAudioPlayer(), &testutil.MockStreamExtractor{})
+	component.SetClipboard(stub)
+	component.SetCurrentTrack(&soundcloud.Track{ID: 1, Title: "Shared", PermalinkURL: "https://soundcloud.com/artist/shared"})
+
+	_, cmd := component.Update(runeKey("y"))
+	require.NotNil(t, cmd)
+
+	msg := cmd()
+	copied, ok := msg.(clipboard.CopiedMsg)
+	require.True(t, ok)
+	assert.Equal(t, "https://soundcloud.com/artist/shared", copied.Text)
+	assert.NoError(t, copied.Error)
+	assert.Equal(t, []string{"https://soundcloud.com/artist/shared"}, stub.copied)
+}
+
+func TestPlayerComponent_CopyLinkWithoutTrack(t *testing.T) {
+	stub := &stubClipboard{}
+	component := player.NewPlayerComponent(testutil.NewMockAudioPlayer(), &testutil.MockStreamExtractor{})
+	component.SetClipboard(stub)
+
+	_, cmd := component.Update(runeKey("y"))
+
+	assert.Nil(t, cmd)
+	assert.Empty(t, stub.copied)
+}
+
+func TestApp_CopyResultShowsToast(t *testing.T) {
+	application := createTestApp(t, nil, nil)
+
+	application.Update(clipboard.CopiedMsg{Text: "https://soundcloud.com/artist/shared"})
+	assert.Equal(t, "Copied!", application.GetNotification())
+
+	// Without a clipboard the link itself is shown instead
+	application.Update(clipboard.CopiedMsg{
+		Text:  "https://soundcloud.com/artist/shared",
+		Error: errors.New("no clipboard available"),
+	})
+	assert.Contains(t, application.GetNotification(), "https://soundcloud.com/artist/shared")
+}
+
+func TestFormatNowPlaying(t *testing.T) {
+	tests := []struct {
+		name     string
+		track    soundcloud.Track
+		expected string
+	}{
+		{
+			"soundcloud track",
+			soundcloud.Track{Title: "Shared", User: soundcloud.User{Username: "artist"}, PermalinkURL: "https://soundcloud.com/artist/shared"},
+			"🎵 Shared — artist <https://soundcloud.com/artist/shared>",
+		},
+		{
+			"no link",
+			soundcloud.Track{Title: "Shared", User: soundcloud.User{Username: "artist"}},
+			"🎵 Shared — artist",
+		},
+		{
+			"local file",
+			*player.LocalTrack("/music/Demo.mp3"),
+			"🎵 Demo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, player.FormatNowPlaying(&tt.track))
+		})
+	}
+}
+
+func TestPlayerComponent_CopyNowPlaying(t *testing.T) {
+	stub := &stubClipboard{}
+	component := player.NewPlayerComponent(testutil.NewMockAudioPlayer(), &testutil.MockStreamExtractor{})
+	component.SetClipboard(stub)
+	component.SetCurrentTrack(&soundcloud.Track{
+		ID:           1,
+		Title:        "Shared",
+		User:         soundcloud.User{Username: "artist"},
+		PermalinkURL: "https://soundcloud.com/artist/shared",
+	})
+
+	_, cmd := component.Update(runeKey("Y"))
+	require.NotNil(t, cmd)
+
+	copied, ok := cmd().(clipboard.CopiedMsg)
+	require.True(t, ok)
+	assert.NoError(t, copied.Error)
+	assert.Equal(t, []string{"🎵 Shared — artist <https://soundcloud.com/artist/shared>"}, stub.copied)
+}
@@ -0,0 +1,96 @@
+package ui_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/app"
+	"soundcloud-tui/internal/ui/components/player"
+)
+
+// errExhausted is what the buffered player reports once a download runs out
+// of retries
+var errExhausted = errors.New("failed to download stream after 5 attempts")
+
+// reportingPlayer is a mock player that can fail after Play has returned
+type reportingPlayer struct {
+	*testutil.MockAudioPlayer
+	onError func(error)
+}
+
+func (p *reportingPlayer) SetErrorCallback(callback func(error)) {
+	p.onError = callback
+}
+
+// downloadFailure returns the DownloadFailedMsg among msgs, if any
+func downloadFailure(msgs []tea.Msg) (player.DownloadFailedMsg, bool) {
+	for _, msg := range msgs {
+		if failed, ok := msg.(player.DownloadFailedMsg); ok {
+			return failed, true
+		}
+	}
+	return player.DownloadFailedMsg{}, false
+}
+
+func TestPlayerComponent_DownloadFailureEndsPlayback(t *testing.T) {
+	audioPlayer := &reportingPlayer{MockAudioPlayer: testutil.NewMockAudioPlayer()}
+	component := player.NewPlayerComponent(audioPlayer, &testutil.MockStreamExtractor{})
+	require.NotNil(t, audioPlayer.onError, "the component should listen for player failures")
+	track := &soundcloud.Track{ID: 1, Title: "Flaky"}
+	component.SetCurrentTrack(track)
+	component.SetState(player.StatePlaying)
+
+	audioPlayer.onError(errExhausted)
+	failed, ok := downloadFailure(quickMsgs(component.Init()))
+	require.True(t, ok, "the failure should arrive as a message")
+
+	_, cmd := component.Update(failed)
+
+	assert.Equal(t, player.StateError, component.GetState())
+	assert.ErrorIs(t, component.GetError(), errExhausted)
+	var playbackFailed *player.PlaybackFailedMsg
+	for _, msg := range quickMsgs(cmd) {
+		if m, ok := msg.(player.PlaybackFailedMsg); ok {
+			playbackFailed = &m
+		}
+	}
+	require.NotNil(t, playbackFailed)
+	assert.Equal(t, track, playbackFailed.Track)
+	assert.ErrorIs(t, playbackFailed.Error, errExhausted)
+}
+
+func TestPlayerComponent_DownloadFailureWhileIdleIsIgnored(t *testing.T) {
+	audioPlayer := &reportingPlayer{MockAudioPlayer: testutil.NewMockAudioPlayer()}
+	component := player.NewPlayerComponent(audioPlayer, &testutil.MockStreamExtractor{})
+
+	_, cmd := component.Update(player.DownloadFailedMsg{Error: errExhausted})
+
+	assert.Equal(t, player.StateIdle, component.GetState())
+	audioPlayer.onError(errExhausted)
+	_, ok := downloadFailure(quickMsgs(cmd))
+	assert.True(t, ok, "the component should keep listening")
+}
+
+func TestApp_DownloadFailureSkipsQueuedTrack(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	audioPlayer := &reportingPlayer{MockAudioPlayer: testutil.NewMockAudioPlayer()}
+	application := app.NewAppWithDependencies(&testutil.MockSoundCloudClient{}, audioPlayer, failingExtractor())
+	application.LoadQueue([]soundcloud.Track{{ID: 1, Title: "Flaky"}, {ID: 2, Title: "Solid"}})
+	playNext(application)
+	require.Equal(t, player.StatePlaying, application.GetPlayerComponent().GetState())
+
+	audioPlayer.onError(errExhausted)
+	failed, ok := downloadFailure(quickMsgs(application.Init()))
+	require.True(t, ok)
+	_, cmd := application.Update(failed)
+	settle(application, cmd)
+
+	assert.Equal(t, 1, application.GetQueueComponent().GetCurrentIndex())
+	assert.Equal(t, `Skipped "Flaky": stream download failed: failed to download stream after 5 attempts`, application.GetNotification())
+}
@@ -0,0 +1,110 @@
+package ui_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/app"
+	"soundcloud-tui/internal/ui/components/search"
+)
+
+// selectFirstResult shows a single search result and selects it
+func selectFirstResult(application *app.App, track soundcloud.Track) {
+	application.Update(search.SearchResultsMsg{Results: []soundcloud.Track{track}})
+	_, cmd := application.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	settle(application, cmd)
+}
+
+func TestApp_EnrichesSelectedTrack(t *testing.T) {
+	client := &testutil.MockSoundCloudClient{
+		EnrichFunc: func(track *soundcloud.Track) (*soundcloud.Track, error) {
+			enriched := *track
+			enriched.Genre = "Deep House"
+			enriched.Description = "Recorded live at the warehouse\nSecond line"
+			enriched.CommentCount = 1200
+			return &enriched, nil
+		},
+	}
+	application := createTestApp(t, client, nil)
+
+	selectFirstResult(application, soundcloud.Track{ID: 5, Title: "Warehouse Set"})
+
+	track := application.GetPlayerComponent().GetCurrentTrack()
+	require.NotNil(t, track)
+	assert.Equal(t, "Warehouse Set", track.Title)
+	assert.Equal(t, "Deep House", track.Genre)
+
+	application.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	view := application.View()
+	assert.Contains(t, view, "Deep House")
+	assert.Contains(t, view, "1.2K comments")
+	assert.Contains(t, view, "Recorded live at the warehouse")
+	assert.NotContains(t, view, "Second line")
+}
+
+func TestApp_FailedEnrichmentKeepsSearchMetadata(t *testing.T) {
+	client := &testutil.MockSoundCloudClient{
+		EnrichFunc: func(track *soundcloud.Track) (*soundcloud.Track, error) {
+			return nil, errors.New("lookup failed")
+		},
+	}
+	application := createTestApp(t, client, nil)
+
+	selectFirstResult(application, soundcloud.Track{ID: 5, Title: "Warehouse Set", Genre: "House"})
+
+	track := application.GetPlayerComponent().GetCurrentTrack()
+	require.NotNil(t, track)
+	assert.Equal(t, "Warehouse Set", track.Title)
+	assert.Equal(t, "House", track.Genre)
+}
+
+func TestApp_PrefetchesResultsAroundSelection(t *testing.T) {
+	var mu sync.Mutex
+	lookups := map[int64]int{}
+	client := &testutil.MockSoundCloudClient{
+		EnrichFunc: func(track *soundcloud.Track) (*soundcloud.Track, error) {
+			mu.Lock()
+			lookups[track.ID]++
+			mu.Unlock()
+			enriched := *track
+			enriched.Genre = "Techno"
+			return &enriched, nil
+		},
+	}
+	looked := func() map[int64]int {
+		mu.Lock()
+		defer mu.Unlock()
+		copied := map[int64]int{}
+		for id, count := range lookups {
+			copied[id] = count
+		}
+		return copied
+	}
+	application := createTestApp(t, client, nil)
+
+	var results []soundcloud.Track
+	for id := int64(1); id <= 10; id++ {
+		results = append(results, soundcloud.Track{ID: id, Title: "Result"})
+	}
+	application.Update(search.SearchResultsMsg{Results: results})
+
+	require.Eventually(t, func() bool { return len(looked()) == 5 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, map[int64]int{1: 1, 2: 1, 3: 1, 4: 1, 5: 1}, looked(), "only results near the selection are prefetched")
+
+	// Picking a prefetched result needs no further lookup
+	_, cmd := application.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	settle(application, cmd)
+
+	track := application.GetPlayerComponent().GetCurrentTrack()
+	require.NotNil(t, track)
+	assert.Equal(t, "Techno", track.Genre)
+	assert.Equal(t, 1, looked()[1])
+}
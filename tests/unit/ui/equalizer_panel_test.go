@@ -0,0 +1,68 @@
+package ui_test
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/components/player"
+)
+
+func runeKey(r string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(r)}
+}
+
+func TestPlayerComponent_EQPanelToggle(t *testing.T) {
+	component := player.NewPlayerComponent(testutil.NewMockAudioPlayer(), &testutil.MockStreamExtractor{})
+
+	component.Update(runeKey("e"))
+	assert.True(t, component.IsEQPanelOpen())
+	assert.Contains(t, component.View(), "Equalizer")
+	assert.Contains(t, component.View(), "bypassed")
+
+	component.Update(runeKey("e"))
+	assert.False(t, component.IsEQPanelOpen())
+
+	component.Update(runeKey("e"))
+	component.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, component.IsEQPanelOpen())
+}
+
+func TestPlayerComponent_EQPanelAdjustsSelectedBand(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{Volume: 0.5}
+	component := player.NewPlayerComponent(mockPlayer, &testutil.MockStreamExtractor{})
+	component.Update(runeKey("e"))
+
+	// Raise the low band, then move to mid and lower it
+	_, cmd := component.Update(runeKey("+"))
+	require.NotNil(t, cmd)
+	assert.Equal(t, player.EQChangedMsg{Low: 1}, cmd())
+
+	component.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd = component.Update(runeKey("-"))
+	require.NotNil(t, cmd)
+	assert.Equal(t, player.EQChangedMsg{Low: 1, Mid: -1}, cmd())
+
+	low, mid, high := mockPlayer.GetEQ()
+	assert.Equal(t, []float64{1, -1, 0}, []float64{low, mid, high})
+	assert.Equal(t, 0.5, mockPlayer.GetVolume(), "+/- should adjust the EQ, not the volume, while the panel is open")
+}
+
+func TestPlayerComponent_EQPanelClampsGain(t *testing.T) {
+	mockPlayer := testutil.NewMockAudioPlayer()
+	require.NoError(t, mockPlayer.SetEQ(0, 0, audio.MaxEQGain))
+	component := player.NewPlayerComponent(mockPlayer, &testutil.MockStreamExtractor{})
+	component.Update(runeKey("e"))
+	component.Update(tea.KeyMsg{Type: tea.KeyDown})
+	component.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+	_, cmd := component.Update(runeKey("+"))
+
+	assert.Nil(t, cmd, "no change should be reported at the limit")
+	_, _, high := mockPlayer.GetEQ()
+	assert.Equal(t, audio.MaxEQGain, high)
+}
@@ -0,0 +1,84 @@
+package ui_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/ui/app"
+	"soundcloud-tui/internal/ui/components/player"
+	"soundcloud-tui/internal/ui/components/search"
+)
+
+func failPlayback(application *app.App) {
+	application.Update(player.PlaybackFailedMsg{
+		Track: &soundcloud.Track{ID: 1, Title: "Broken"},
+		Error: errors.New("stream unavailable"),
+	})
+}
+
+func TestApp_PlaybackFailureShowsBannerInSearch(t *testing.T) {
+	application := createTestApp(t, nil, nil)
+	application.SetNotificationDuration(time.Millisecond)
+
+	failPlayback(application)
+
+	assert.Contains(t, application.GetErrorBanner(), `Couldn't play "Broken": stream unavailable`)
+	assert.Contains(t, application.View(), `Couldn't play "Broken"`)
+}
+
+func TestApp_PlaybackFailureInSearchShowsNoToast(t *testing.T) {
+	application := createTestApp(t, nil, nil)
+
+	_, cmd := application.Update(player.PlaybackFailedMsg{
+		Track: &soundcloud.Track{ID: 1, Title: "Broken"},
+		Error: errors.New("stream unavailable"),
+	})
+
+	assert.Nil(t, cmd)
+	assert.Empty(t, application.GetNotification())
+	assert.Equal(t, 1, strings.Count(application.View(), "stream unavailable"), "the failure should show once")
+}
+
+func TestApp_PlaybackFailureBannerOnlyInSearchView(t *testing.T) {
+	application := createTestApp(t, nil, nil)
+	failPlayback(application)
+
+	application.SetCurrentView(app.ViewQueue)
+
+	assert.NotContains(t, application.View(), `Couldn't play "Broken"`)
+}
+
+func TestApp_PlaybackFailureBannerClearedByPlayback(t *testing.T) {
+	application := createTestApp(t, nil, nil)
+	failPlayback(application)
+
+	application.Update(player.PlaybackStartedMsg{Track: &soundcloud.Track{ID: 2, Title: "Working"}})
+
+	assert.Empty(t, application.GetErrorBanner())
+}
+
+func TestApp_PlaybackFailureBannerClearedBySearch(t *testing.T) {
+	application := createTestApp(t, nil, nil)
+	failPlayback(application)
+
+	application.Update(search.SearchResultsMsg{Results: []soundcloud.Track{{ID: 3, Title: "Other"}}})
+
+	assert.Empty(t, application.GetErrorBanner())
+	assert.NotContains(t, application.View(), `Couldn't play "Broken"`)
+}
+
+func TestApp_PlaybackFailureBannerExpires(t *testing.T) {
+	application := createTestApp(t, nil, nil)
+	application.SetErrorBannerDuration(10 * time.Millisecond)
+	failPlayback(application)
+	assert.NotEmpty(t, application.GetErrorBanner())
+
+	time.Sleep(20 * time.Millisecond)
+
+	assert.Empty(t, application.GetErrorBanner())
+}
@@ -0,0 +1,70 @@
+package ui_test
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/config"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/app"
+)
+
+// focusPauseApp creates an app with pause-on-focus-loss set as given
+func focusPauseApp(t *testing.T, enabled bool, mockPlayer *testutil.MockAudioPlayer) *app.App {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	settings, err := config.LoadSettings(config.SettingsPath())
+	require.NoError(t, err)
+	settings.Playback.PauseOnFocusLoss = enabled
+	require.NoError(t, settings.Save())
+
+	return app.NewAppWithDependencies(&testutil.MockSoundCloudClient{}, mockPlayer, &testutil.MockStreamExtractor{})
+}
+
+// runCmd executes cmd, if any, and feeds its message back into the app
+func runCmd(application *app.App, cmd tea.Cmd) {
+	if cmd != nil {
+		application.Update(cmd())
+	}
+}
+
+func TestApp_PausesOnBlurAndResumesOnFocus(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePlaying}
+	application := focusPauseApp(t, true, mockPlayer)
+
+	_, cmd := application.Update(tea.BlurMsg{})
+	require.NotNil(t, cmd)
+	runCmd(application, cmd)
+	assert.Equal(t, audio.StatePaused, mockPlayer.GetState())
+
+	_, cmd = application.Update(tea.FocusMsg{})
+	require.NotNil(t, cmd)
+	runCmd(application, cmd)
+	assert.Equal(t, audio.StatePlaying, mockPlayer.GetState())
+}
+
+func TestApp_FocusDoesNotResumeManualPause(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePaused}
+	application := focusPauseApp(t, true, mockPlayer)
+
+	_, cmd := application.Update(tea.BlurMsg{})
+	assert.Nil(t, cmd)
+
+	_, cmd = application.Update(tea.FocusMsg{})
+	assert.Nil(t, cmd)
+	assert.Equal(t, audio.StatePaused, mockPlayer.GetState())
+}
+
+func TestApp_FocusPauseIsOptIn(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePlaying}
+	application := focusPauseApp(t, false, mockPlayer)
+
+	_, cmd := application.Update(tea.BlurMsg{})
+	assert.Nil(t, cmd)
+	assert.Equal(t, audio.StatePlaying, mockPlayer.GetState())
+}
@@ -0,0 +1,134 @@
+package ui_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/ui/components/search"
+	"soundcloud-tui/internal/ui/styles"
+)
+
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+func stripANSI(s string) string {
+	return ansiPattern.ReplaceAllString(s, "")
+}
+
+func withTrueColor(t *testing.T) {
+	originalProfile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	t.Cleanup(func() { lipgloss.SetColorProfile(originalProfile) })
+}
+
+func TestHighlightMatch(t *testing.T) {
+	withTrueColor(t)
+	
+	tests := []struct {
+		name        string
+		text        string
+		query       string
+		highlighted []string
+	}{
+		{
+			name:        "single token case-insensitive",
+			text:        "Lofi Hip Hop Radio",
+			query:       "HIP",
+			highlighted: []string{"Hip"},
+		},
+		{
+			name:        "multi-word query highlights each token",
+			text:        "Lofi Hip Hop Radio",
+			query:       "lofi radio",
+			highlighted: []string{"Lofi", "Radio"},
+		},
+		{
+			name:        "repeated occurrences",
+			text:        "Hop Hop Hooray",
+			query:       "hop",
+			highlighted: []string{"Hop", "Hop"},
+		},
+		{
+			name:        "no match leaves text untouched",
+			text:        "Ambient Drone",
+			query:       "techno",
+			highlighted: nil,
+		},
+		{
+			name:        "empty query leaves text untouched",
+			text:        "Ambient Drone",
+			query:       "  ",
+			highlighted: nil,
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := styles.HighlightMatch(tt.text, tt.query)
+			
+			// Visible text and width never change
+			assert.Equal(t, tt.text, stripANSI(result))
+			assert.Equal(t, lipgloss.Width(tt.text), lipgloss.Width(result))
+			
+			if tt.highlighted == nil {
+				assert.Equal(t, tt.text, result)
+				return
+			}
+			
+			for _, part := range tt.highlighted {
+				assert.Contains(t, result, styles.HighlightStyle.Render(part))
+			}
+		})
+	}
+}
+
+func TestHighlightMatch_RespectsTruncation(t *testing.T) {
+	withTrueColor(t)
+	
+	title := styles.TruncateText("A very long title that mentions lofi at the very end", 20)
+	result := styles.HighlightMatch(title, "very lofi")
+	
+	assert.Equal(t, title, stripANSI(result))
+	assert.Contains(t, result, styles.HighlightStyle.Render("very"))
+}
+
+func TestSearchComponent_HighlightKeepsColumnsAligned(t *testing.T) {
+	withTrueColor(t)
+	
+	component := search.NewSearchComponent(nil)
+	component.SetSize(120, 30)
+	
+	for _, char := range "hip" {
+		updatedComponent, _ := component.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{char}})
+		component = updatedComponent.(*search.SearchComponent)
+	}
+	
+	results := []soundcloud.Track{
+		{ID: 1, Title: "Selected first row", User: soundcloud.User{Username: "Artist"}, Duration: 60000},
+		{ID: 2, Title: "Hip", User: soundcloud.User{Username: "Artist"}, Duration: 60000},
+		{ID: 3, Title: "Hip hop with a much longer hip title", User: soundcloud.User{Username: "Artist"}, Duration: 60000},
+	}
+	updatedComponent, _ := component.Update(search.SearchResultsMsg{Results: results})
+	component = updatedComponent.(*search.SearchComponent)
+	
+	var rowWidths []int
+	var artistColumns []int
+	for _, line := range strings.Split(component.View(), "\n") {
+		plain := stripANSI(line)
+		if strings.Contains(plain, "Hip") && strings.Contains(plain, "Artist") {
+			rowWidths = append(rowWidths, lipgloss.Width(line))
+			artistColumns = append(artistColumns, strings.Index(plain, "Artist"))
+		}
+	}
+	
+	require.Len(t, rowWidths, 2)
+	assert.Equal(t, rowWidths[0], rowWidths[1])
+	assert.Equal(t, artistColumns[0], artistColumns[1])
+}
@@ -0,0 +1,117 @@
+package ui_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/app"
+	"soundcloud-tui/internal/ui/components/player"
+)
+
+// runIdleApp runs application from Init the way the Bubble Tea runtime does,
+// pressing a key every keyEvery (never when 0), for up to d. It reports
+// whether the app quit.
+func runIdleApp(application *app.App, d, keyEvery time.Duration) bool {
+	msgs := make(chan tea.Msg, 64)
+	done := make(chan struct{})
+	defer close(done)
+
+	var dispatch func(cmd tea.Cmd)
+	dispatch = func(cmd tea.Cmd) {
+		if cmd == nil {
+			return
+		}
+		go func() {
+			msg := cmd()
+			if batch, ok := msg.(tea.BatchMsg); ok {
+				for _, sub := range batch {
+					dispatch(sub)
+				}
+				return
+			}
+			select {
+			case msgs <- msg:
+			case <-done:
+			}
+		}()
+	}
+
+	var keys <-chan time.Time
+	if keyEvery > 0 {
+		ticker := time.NewTicker(keyEvery)
+		defer ticker.Stop()
+		keys = ticker.C
+	}
+
+	dispatch(application.Init())
+	deadline := time.After(d)
+	for {
+		select {
+		case msg := <-msgs:
+			if _, ok := msg.(tea.QuitMsg); ok {
+				return true
+			}
+			if msg != nil {
+				_, cmd := application.Update(msg)
+				dispatch(cmd)
+			}
+		case <-keys:
+			_, cmd := application.Update(tea.KeyMsg{Type: tea.KeyDown})
+			dispatch(cmd)
+		case <-deadline:
+			return false
+		}
+	}
+}
+
+func TestApp_IdleTimeoutQuits(t *testing.T) {
+	mockPlayer := testutil.NewMockAudioPlayer()
+	application := createTestApp(t, nil, mockPlayer)
+	application.SetIdleTimeout(50 * time.Millisecond)
+
+	start := time.Now()
+	require.True(t, runIdleApp(application, time.Second, 0))
+
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	assert.True(t, application.IsQuitting())
+	assert.Equal(t, 1, mockPlayer.CallCount("Close"), "audio should be stopped before quitting")
+}
+
+func TestApp_KeysResetIdleTimeout(t *testing.T) {
+	application := createTestApp(t, nil, nil)
+	application.SetIdleTimeout(100 * time.Millisecond)
+
+	assert.False(t, runIdleApp(application, 400*time.Millisecond, 20*time.Millisecond), "each key press should restart the timeout")
+	assert.False(t, application.IsQuitting())
+}
+
+func TestApp_PlaybackSuppressesIdleTimeout(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePlaying, Duration: time.Hour}
+	application := createTestApp(t, nil, mockPlayer)
+	application.GetPlayerComponent().SetState(player.StatePlaying)
+	application.SetIdleTimeout(50 * time.Millisecond)
+
+	assert.False(t, runIdleApp(application, 400*time.Millisecond, 0), "playing music counts as activity")
+	assert.Zero(t, mockPlayer.CallCount("Close"))
+}
+
+func TestApp_PausedPlaybackDoesNotSuppressIdleTimeout(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePaused, Duration: time.Hour}
+	application := createTestApp(t, nil, mockPlayer)
+	application.GetPlayerComponent().SetState(player.StatePaused)
+	application.SetIdleTimeout(50 * time.Millisecond)
+
+	assert.True(t, runIdleApp(application, time.Second, 0))
+}
+
+func TestApp_IdleTimeoutOffByDefault(t *testing.T) {
+	application := createTestApp(t, nil, nil)
+
+	assert.False(t, runIdleApp(application, 200*time.Millisecond, 0))
+}
@@ -0,0 +1,111 @@
+package ui_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/components/player"
+	"soundcloud-tui/internal/ui/components/search"
+)
+
+func TestPlayerComponent_LocalFileSkipsStreamExtraction(t *testing.T) {
+	mockPlayer := testutil.NewMockAudioPlayer()
+	extractor := &testutil.MockStreamExtractor{
+		ExtractFunc: func(ctx context.Context, trackID int64) (*audio.StreamInfo, error) {
+			t.Error("local files should not be extracted")
+			return nil, assert.AnError
+		},
+	}
+	component := player.NewPlayerComponent(mockPlayer, extractor)
+
+	_, cmd := component.Update(player.PlayTrackMsg{Track: player.LocalTrack("/music/Night Drive.mp3")})
+	assert.Equal(t, player.StateLoading, component.GetState())
+
+	var streamInfo tea.Msg
+	for _, msg := range quickMsgs(cmd) {
+		if _, ok := msg.(player.StreamInfoMsg); ok {
+			streamInfo = msg
+		}
+	}
+	require.NotNil(t, streamInfo)
+
+	_, cmd = component.Update(streamInfo)
+	require.NotNil(t, cmd)
+	cmd()
+
+	call, ok := mockPlayer.LastCall("Play")
+	require.True(t, ok)
+	assert.Equal(t, []interface{}{"/music/Night Drive.mp3"}, call.Args)
+}
+
+func TestPlayerComponent_LocalTrackTitledAfterFile(t *testing.T) {
+	track := player.LocalTrack("file:///music/Night%20Drive.mp3")
+
+	assert.Equal(t, "Night Drive", track.Title)
+	assert.Equal(t, "file:///music/Night%20Drive.mp3", track.StreamURL)
+}
+
+func TestApp_SearchInputPlaysLocalFile(t *testing.T) {
+	client := &testutil.MockSoundCloudClient{}
+	searched := false
+	client.SearchFunc = func(query string) ([]soundcloud.Track, error) {
+		searched = true
+		return nil, nil
+	}
+	application := createTestApp(t, client, nil)
+
+	application.Update(runeKey("/music/set.ogg"))
+	_, cmd := application.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	quickMsgs(cmd)
+
+	assert.False(t, searched, "a file path should not be searched for")
+	assert.Equal(t, search.StateTrackSelected, application.GetSearchComponent().GetState())
+	require.NotNil(t, application.GetPlayerComponent().GetCurrentTrack())
+	assert.Equal(t, "/music/set.ogg", application.GetPlayerComponent().GetCurrentTrack().StreamURL)
+}
+
+func TestPlayerComponent_RestartsFinishedLocalFile(t *testing.T) {
+	keys := []struct {
+		name string
+		key  tea.KeyMsg
+	}{
+		{"space", tea.KeyMsg{Type: tea.KeySpace}},
+		{"replay", runeKey("b")},
+	}
+
+	for _, tt := range keys {
+		t.Run(tt.name, func(t *testing.T) {
+			// Local files play without a stream extractor
+			mockPlayer := &testutil.MockAudioPlayer{State: audio.StateStopped}
+			component := player.NewPlayerComponent(mockPlayer, nil)
+			component.SetCurrentTrack(player.LocalTrack("/music/Night Drive.mp3"))
+			component.SetState(player.StateCompleted)
+
+			_, cmd := component.Update(tt.key)
+			assert.Equal(t, player.StateLoading, component.GetState())
+
+			var streamInfo tea.Msg
+			for _, msg := range quickMsgs(cmd) {
+				if _, ok := msg.(player.StreamInfoMsg); ok {
+					streamInfo = msg
+				}
+			}
+			require.NotNil(t, streamInfo)
+
+			_, cmd = component.Update(streamInfo)
+			require.NotNil(t, cmd)
+			cmd()
+
+			call, ok := mockPlayer.LastCall("Play")
+			require.True(t, ok)
+			assert.Equal(t, []interface{}{"/music/Night Drive.mp3"}, call.Args)
+		})
+	}
+}
@@ -2,11 +2,13 @@ package ui_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"soundcloud-tui/internal/audio"
 	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
 	"soundcloud-tui/internal/ui/components/player"
 )
 
@@ -74,8 +76,8 @@ func TestMetadataDisplay_TrackInformation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockPlayer := &MockAudioPlayer{
-				state: audio.StatePlaying,
+			mockPlayer := &testutil.MockAudioPlayer{
+				State: audio.StatePlaying,
 			}
 
 			playerComponent := player.NewPlayerComponent(mockPlayer, nil)
@@ -144,8 +146,8 @@ func TestMetadataDisplay_TrackStateIndication(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockPlayer := &MockAudioPlayer{
-				state: tt.audioState,
+			mockPlayer := &testutil.MockAudioPlayer{
+				State: tt.audioState,
 			}
 
 			playerComponent := player.NewPlayerComponent(mockPlayer, nil)
@@ -170,8 +172,8 @@ func TestMetadataDisplay_TrackStateIndication(t *testing.T) {
 }
 
 func TestMetadataDisplay_NoTrackLoaded(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{
-		state: audio.StateStopped,
+	mockPlayer := &testutil.MockAudioPlayer{
+		State: audio.StateStopped,
 	}
 
 	playerComponent := player.NewPlayerComponent(mockPlayer, nil)
@@ -193,11 +195,11 @@ func TestMetadataDisplay_NoTrackLoaded(t *testing.T) {
 }
 
 func TestMetadataDisplay_TrackProgress(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{
-		state:    audio.StatePlaying,
-		position: 90000, // 1:30
-		duration: 180000, // 3:00
-		volume:   0.75,
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:    audio.StatePlaying,
+		Position: 90 * time.Second,
+		Duration: 180 * time.Second,
+		Volume:   0.75,
 	}
 
 	playerComponent := player.NewPlayerComponent(mockPlayer, nil)
@@ -213,8 +215,8 @@ func TestMetadataDisplay_TrackProgress(t *testing.T) {
 
 	// Update with progress
 	progressMsg := player.ProgressUpdateMsg{
-		Position: 90000, // 1:30
-		Duration: 180000, // 3:00
+		Position: 90 * time.Second,
+		Duration: 180 * time.Second,
 	}
 
 	updatedComponent, _ := playerComponent.Update(progressMsg)
@@ -232,9 +234,9 @@ func TestMetadataDisplay_TrackProgress(t *testing.T) {
 }
 
 func TestMetadataDisplay_MetadataLayout(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{
-		state:  audio.StatePlaying,
-		volume: 0.8,
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:  audio.StatePlaying,
+		Volume: 0.8,
 	}
 
 	playerComponent := player.NewPlayerComponent(mockPlayer, nil)
@@ -268,8 +270,8 @@ func TestMetadataDisplay_MetadataLayout(t *testing.T) {
 }
 
 func TestMetadataDisplay_LongTitleTruncation(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{
-		state: audio.StatePlaying,
+	mockPlayer := &testutil.MockAudioPlayer{
+		State: audio.StatePlaying,
 	}
 
 	playerComponent := player.NewPlayerComponent(mockPlayer, nil)
@@ -304,8 +306,8 @@ func TestMetadataDisplay_LongTitleTruncation(t *testing.T) {
 }
 
 func TestMetadataDisplay_SpecialCharacters(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{
-		state: audio.StatePlaying,
+	mockPlayer := &testutil.MockAudioPlayer{
+		State: audio.StatePlaying,
 	}
 
 	playerComponent := player.NewPlayerComponent(mockPlayer, nil)
@@ -369,8 +371,8 @@ func TestMetadataDisplay_EmptyOrMissingFields(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockPlayer := &MockAudioPlayer{
-				state: audio.StatePlaying,
+			mockPlayer := &testutil.MockAudioPlayer{
+				State: audio.StatePlaying,
 			}
 
 			playerComponent := player.NewPlayerComponent(mockPlayer, nil)
@@ -390,8 +392,8 @@ func TestMetadataDisplay_EmptyOrMissingFields(t *testing.T) {
 }
 
 func TestMetadataDisplay_StateChangeMetadataConsistency(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{
-		state: audio.StateStopped,
+	mockPlayer := &testutil.MockAudioPlayer{
+		State: audio.StateStopped,
 	}
 
 	playerComponent := player.NewPlayerComponent(mockPlayer, nil)
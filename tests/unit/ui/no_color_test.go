@@ -0,0 +1,72 @@
+package ui_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/components/player"
+	"soundcloud-tui/internal/ui/styles"
+)
+
+func TestNoColor_PlayerViewHasNoEscapeSequences(t *testing.T) {
+	// Force a color profile so the test proves escapes are actually removed
+	originalProfile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	defer lipgloss.SetColorProfile(originalProfile)
+
+	styles.SetNoColor(true)
+	defer styles.SetNoColor(false)
+
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:    audio.StatePlaying,
+		Volume:   0.8,
+		Duration: 3 * time.Minute,
+	}
+	component := player.NewPlayerComponent(mockPlayer, &testutil.MockStreamExtractor{})
+	component.SetCurrentTrack(&soundcloud.Track{
+		ID:    1,
+		Title: "Plain Track",
+		User:  soundcloud.User{Username: "Plain Artist"},
+	})
+	component.SetState(player.StatePlaying)
+	component.SetSize(80, 24)
+
+	view := component.View()
+
+	assert.NotContains(t, view, "\x1b[", "plain mode should not emit ANSI escape sequences")
+	assert.Contains(t, view, "[playing]")
+	assert.NotContains(t, view, "▶")
+	assert.NotContains(t, view, "🔊")
+	assert.Contains(t, view, "Plain Track")
+}
+
+func TestNoColor_ColorModeRestored(t *testing.T) {
+	originalProfile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	defer lipgloss.SetColorProfile(originalProfile)
+
+	styles.SetNoColor(true)
+	assert.True(t, styles.NoColor())
+	assert.Equal(t, "[paused]", styles.Icon("⏸ Paused", "[paused]"))
+
+	styles.SetNoColor(false)
+	assert.False(t, styles.NoColor())
+	assert.Equal(t, termenv.TrueColor, lipgloss.ColorProfile())
+	assert.Equal(t, "⏸ Paused", styles.Icon("⏸ Paused", "[paused]"))
+}
+
+func TestNoColor_ProgressBarUsesASCII(t *testing.T) {
+	styles.SetNoColor(true)
+	defer styles.SetNoColor(false)
+
+	assert.Equal(t, "#####-----", styles.RenderProgressBar(10, 0.5))
+	assert.Equal(t, "----------", styles.RenderProgressBar(10, 0))
+	assert.Equal(t, "##########", styles.RenderProgressBar(10, 1))
+}
@@ -0,0 +1,56 @@
+package ui_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/config"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/app"
+	"soundcloud-tui/internal/ui/components/player"
+)
+
+func TestPlayerComponent_NTogglesNormalization(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePlaying}
+	component := playingComponent(mockPlayer)
+
+	_, cmd := component.Update(runeKey("N"))
+	require.NotNil(t, cmd)
+	assert.Equal(t, player.NormalizationChangedMsg{Enabled: true}, cmd())
+	assert.True(t, mockPlayer.GetNormalization())
+	assert.Contains(t, stripANSI(component.View()), "normalized")
+
+	_, cmd = component.Update(runeKey("N"))
+	require.NotNil(t, cmd)
+	assert.Equal(t, player.NormalizationChangedMsg{Enabled: false}, cmd())
+	assert.False(t, mockPlayer.GetNormalization())
+	assert.NotContains(t, stripANSI(component.View()), "normalized")
+}
+
+func TestApp_SavesNormalizationChoice(t *testing.T) {
+	application := createTestApp(t, nil, nil)
+
+	_, cmd := application.Update(player.NormalizationChangedMsg{Enabled: true})
+	quickMsgs(cmd)
+
+	assert.Equal(t, "Loudness normalization on", application.GetNotification())
+	settings, err := config.LoadSettings(config.SettingsPath())
+	require.NoError(t, err)
+	assert.True(t, settings.Playback.Normalize)
+}
+
+func TestApp_AppliesSavedNormalization(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	settings, err := config.LoadSettings(config.SettingsPath())
+	require.NoError(t, err)
+	settings.Playback.Normalize = true
+	require.NoError(t, settings.Save())
+
+	mockPlayer := testutil.NewMockAudioPlayer()
+	app.NewAppWithDependencies(&testutil.MockSoundCloudClient{}, mockPlayer, &testutil.MockStreamExtractor{})
+
+	assert.True(t, mockPlayer.GetNormalization())
+}
@@ -0,0 +1,102 @@
+package ui_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/app"
+	"soundcloud-tui/internal/ui/components/player"
+	"soundcloud-tui/internal/ui/components/search"
+)
+
+func TestApp_PlaybackFailedShowsToast(t *testing.T) {
+	application := createTestApp(t, nil, nil)
+	application.SetNotificationDuration(10 * time.Millisecond)
+	// Outside the search view there's no banner to show the failure
+	application.SetCurrentView(app.ViewPlayer)
+
+	_, cmd := application.Update(player.PlaybackFailedMsg{
+		Track: &soundcloud.Track{ID: 1, Title: "Broken"},
+		Error: errors.New("stream unavailable"),
+	})
+	require.NotNil(t, cmd, "toast should schedule its own dismissal")
+
+	assert.Equal(t, app.ViewPlayer, application.GetCurrentView())
+	assert.Contains(t, application.GetNotification(), "stream unavailable")
+	assert.Contains(t, application.View(), "stream unavailable")
+
+	// The tick fires after the notification duration and dismisses the toast
+	application.Update(cmd())
+	assert.Empty(t, application.GetNotification())
+	assert.NotContains(t, application.View(), "Playback failed: stream unavailable")
+}
+
+func TestApp_SearchErrorShowsToast(t *testing.T) {
+	application := createTestApp(t, nil, nil)
+
+	_, cmd := application.Update(search.SearchResultsMsg{Error: errors.New("rate limited")})
+
+	assert.NotNil(t, cmd)
+	assert.Contains(t, application.GetNotification(), "rate limited")
+}
+
+func TestApp_StaleToastExpiryKeepsNewerToast(t *testing.T) {
+	application := createTestApp(t, nil, nil)
+	application.SetNotificationDuration(10 * time.Millisecond)
+	application.SetCurrentView(app.ViewPlayer)
+
+	_, firstCmd := application.Update(player.PlaybackFailedMsg{Error: errors.New("first failure")})
+	require.NotNil(t, firstCmd)
+	firstExpired := firstCmd()
+
+	_, secondCmd := application.Update(player.PlaybackFailedMsg{Error: errors.New("second failure")})
+	require.NotNil(t, secondCmd)
+
+	// The first toast's expiry must not dismiss the second one
+	application.Update(firstExpired)
+	assert.Contains(t, application.GetNotification(), "second failure")
+
+	application.Update(secondCmd())
+	assert.Empty(t, application.GetNotification())
+}
+
+func TestPlayerComponent_FailedExtractionKeepsPreviousTrack(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePlaying}
+	component := player.NewPlayerComponent(mockPlayer, &testutil.MockStreamExtractor{})
+
+	current := &soundcloud.Track{ID: 1, Title: "Still Playing"}
+	component.SetCurrentTrack(current)
+	component.SetState(player.StatePlaying)
+
+	component.Update(player.PlayTrackMsg{Track: &soundcloud.Track{ID: 2, Title: "Broken"}})
+	assert.Equal(t, player.StateLoading, component.GetState())
+
+	_, cmd := component.Update(player.StreamInfoMsg{Error: errors.New("extraction failed")})
+	require.NotNil(t, cmd)
+
+	assert.Equal(t, player.StatePlaying, component.GetState())
+	assert.Equal(t, current, component.GetCurrentTrack())
+	assert.Nil(t, component.GetError())
+	assert.NotContains(t, component.View(), "Playback Error")
+}
+
+func TestPlayerComponent_FailedExtractionFromIdleShowsError(t *testing.T) {
+	component := player.NewPlayerComponent(testutil.NewMockAudioPlayer(), &testutil.MockStreamExtractor{})
+
+	component.Update(player.PlayTrackMsg{Track: &soundcloud.Track{ID: 2, Title: "Broken"}})
+	_, cmd := component.Update(player.StreamInfoMsg{Error: errors.New("extraction failed")})
+	require.NotNil(t, cmd)
+
+	msg := cmd()
+	failed, ok := msg.(player.PlaybackFailedMsg)
+	require.True(t, ok)
+	assert.Equal(t, int64(2), failed.Track.ID)
+	assert.Equal(t, player.StateError, component.GetState())
+}
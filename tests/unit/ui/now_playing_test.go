@@ -0,0 +1,63 @@
+package ui_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/app"
+	"soundcloud-tui/internal/ui/components/player"
+	"soundcloud-tui/internal/ui/styles"
+)
+
+func TestApp_NowPlayingHiddenWithoutTrack(t *testing.T) {
+	styles.SetNoColor(true)
+	defer styles.SetNoColor(false)
+	application := createTestApp(t, nil, nil)
+
+	view := application.View()
+
+	assert.NotContains(t, view, "[playing]")
+	assert.NotContains(t, view, "[stopped]")
+}
+
+func TestApp_NowPlayingShownInEveryView(t *testing.T) {
+	styles.SetNoColor(true)
+	defer styles.SetNoColor(false)
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePlaying, Position: 30 * time.Second, Duration: 2 * time.Minute}
+	application := createTestApp(t, nil, mockPlayer)
+	track := &soundcloud.Track{ID: 1, Title: "Night Drive", User: soundcloud.User{Username: "synthwave"}}
+	application.GetPlayerComponent().SetCurrentTrack(track)
+	application.GetPlayerComponent().SetState(player.StatePlaying)
+
+	application.Update(player.ProgressUpdateMsg{Position: 30 * time.Second, Duration: 2 * time.Minute})
+
+	for _, view := range []app.ViewType{app.ViewSearch, app.ViewPlayer, app.ViewQueue} {
+		application.SetCurrentView(view)
+		rendered := application.View()
+		assert.Contains(t, rendered, "[playing] Night Drive - synthwave", "view %s", view)
+		assert.Contains(t, rendered, "#####---------------", "view %s", view)
+		assert.Contains(t, rendered, "0:30 / 2:00", "view %s", view)
+	}
+}
+
+func TestApp_NowPlayingFollowsProgressAndPause(t *testing.T) {
+	styles.SetNoColor(true)
+	defer styles.SetNoColor(false)
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePlaying, Position: 30 * time.Second, Duration: 2 * time.Minute}
+	application := createTestApp(t, nil, mockPlayer)
+	application.GetPlayerComponent().SetCurrentTrack(&soundcloud.Track{ID: 1, Title: "Night Drive"})
+	application.GetPlayerComponent().SetState(player.StatePlaying)
+
+	mockPlayer.State = audio.StatePaused
+	mockPlayer.Position = time.Minute
+	application.Update(player.ProgressUpdateMsg{Position: time.Minute, Duration: 2 * time.Minute})
+
+	view := application.View()
+	assert.Contains(t, view, "[paused] Night Drive")
+	assert.Contains(t, view, "1:00 / 2:00")
+}
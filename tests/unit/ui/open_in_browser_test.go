@@ -0,0 +1,108 @@
+package ui_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/opener"
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/components/player"
+	"soundcloud-tui/internal/ui/components/search"
+)
+
+// stubOpener records opened URLs instead of launching a browser
+type stubOpener struct {
+	opened []string
+	err    error
+}
+
+func (s *stubOpener) Open(url string) error {
+	s.opened = append(s.opened, url)
+	return s.err
+}
+
+func searchWithResults(t *testing.T, tracks []soundcloud.Track) *search.SearchComponent {
+	t.Helper()
+	component := search.NewSearchComponent(&testutil.MockSoundCloudClient{})
+	component.Update(search.SearchResultsMsg{Results: tracks})
+	require.Equal(t, search.StateResults, component.GetState())
+	return component
+}
+
+func TestSearchComponent_OpenInBrowser(t *testing.T) {
+	stub := &stubOpener{}
+	component := searchWithResults(t, []soundcloud.Track{
+		{ID: 1, Title: "First", PermalinkURL: "https://soundcloud.com/artist/first"},
+		{ID: 2, Title: "Second", PermalinkURL: "https://soundcloud.com/artist/second"},
+	})
+	component.SetOpener(stub)
+
+	component.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	_, cmd := component.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	require.NotNil(t, cmd)
+
+	msg := cmd()
+	opened, ok := msg.(opener.OpenedMsg)
+	require.True(t, ok)
+	assert.Equal(t, "https://soundcloud.com/artist/second", opened.URL)
+	assert.NoError(t, opened.Error)
+	assert.Equal(t, []string{"https://soundcloud.com/artist/second"}, stub.opened)
+	assert.Equal(t, search.StateResults, component.GetState())
+}
+
+func TestSearchComponent_OpenInBrowserIgnoresEmptyPermalink(t *testing.T) {
+	stub := &stubOpener{}
+	component := searchWithResults(t, []soundcloud.Track{{ID: 1, Title: "No Link"}})
+	component.SetOpener(stub)
+
+	_, cmd := component.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+
+	assert.Nil(t, cmd)
+	assert.Empty(t, stub.opened)
+}
+
+func TestSearchComponent_OKeyTypesInInputState(t *testing.T) {
+	stub := &stubOpener{}
+	component := search.NewSearchComponent(&testutil.MockSoundCloudClient{})
+	component.SetOpener(stub)
+
+	component.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+
+	assert.Equal(t, "o", component.GetQuery())
+	assert.Empty(t, stub.opened)
+}
+
+func TestPlayerComponent_OpenInBrowser(t *testing.T) {
+	stub := &stubOpener{}
+	component := player.NewPlayerComponent(testutil.NewMockAudioPlayer(), &testutil.MockStreamExtractor{})
+	component.SetOpener(stub)
+
+	// No current track: the key is ignored
+	_, cmd := component.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	assert.Nil(t, cmd)
+
+	component.SetCurrentTrack(&soundcloud.Track{ID: 1, Title: "Now Playing", PermalinkURL: "https://soundcloud.com/artist/now"})
+	_, cmd = component.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	require.NotNil(t, cmd)
+
+	msg := cmd()
+	opened, ok := msg.(opener.OpenedMsg)
+	require.True(t, ok)
+	assert.Equal(t, "https://soundcloud.com/artist/now", opened.URL)
+	assert.Equal(t, []string{"https://soundcloud.com/artist/now"}, stub.opened)
+}
+
+func TestApp_OpenInBrowserNotification(t *testing.T) {
+	application := createTestApp(t, nil, nil)
+
+	application.Update(opener.OpenedMsg{URL: "https://soundcloud.com/artist/now"})
+	assert.Contains(t, application.View(), "Opened in browser")
+
+	application.Update(opener.OpenedMsg{URL: "https://soundcloud.com/artist/now", Error: errors.New("no browser")})
+	assert.Contains(t, application.View(), "no browser")
+}
@@ -0,0 +1,36 @@
+package ui_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/config"
+	"soundcloud-tui/internal/history"
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/app"
+	"soundcloud-tui/internal/ui/components/player"
+)
+
+func TestApp_RecordsPlaysAtHalfway(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	application := app.NewAppWithDependencies(&testutil.MockSoundCloudClient{}, testutil.NewMockAudioPlayer(), &testutil.MockStreamExtractor{})
+	track := &soundcloud.Track{ID: 1, Title: "First Light", User: soundcloud.User{Username: "dawn"}}
+
+	for i := 0; i < 2; i++ {
+		_, cmd := application.Update(player.TrackHalfwayMsg{Track: track})
+		require.NotNil(t, cmd)
+		settle(application, cmd)
+	}
+
+	recorder, err := history.LoadRecorder(filepath.Join(config.ConfigDir(), history.PlaysFileName), 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, recorder.Len())
+	assert.Equal(t, "First Light", recorder.Recent(1)[0].Title)
+	top := recorder.TopTracks(0)
+	require.Len(t, top, 1)
+	assert.Equal(t, 2, top[0].Count)
+}
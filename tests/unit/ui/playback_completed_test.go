@@ -0,0 +1,91 @@
+package ui_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/components/player"
+	"soundcloud-tui/internal/ui/components/queue"
+	"soundcloud-tui/internal/ui/components/search"
+)
+
+// completedMsgs runs cmd, including batched commands, and returns the
+// PlaybackCompletedMsgs it produces
+func completedMsgs(cmd tea.Cmd) []player.PlaybackCompletedMsg {
+	if cmd == nil {
+		return nil
+	}
+
+	var found []player.PlaybackCompletedMsg
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, sub := range msg {
+			found = append(found, completedMsgs(sub)...)
+		}
+	case player.PlaybackCompletedMsg:
+		found = append(found, msg)
+	}
+	return found
+}
+
+func TestPlayerComponent_EmitsPlaybackCompletedOnce(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StateStopped}
+	component := playingComponent(mockPlayer)
+
+	_, cmd := component.Update(player.ProgressUpdateMsg{})
+	completed := completedMsgs(cmd)
+
+	require.Len(t, completed, 1)
+	assert.Equal(t, int64(1), completed[0].Track.ID)
+	assert.Equal(t, player.StateCompleted, component.GetState())
+
+	_, cmd = component.Update(player.ProgressUpdateMsg{})
+	assert.Empty(t, completedMsgs(cmd), "completion should only be reported once")
+}
+
+func TestPlayerComponent_NoPlaybackCompletedWhilePlaying(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:    audio.StatePlaying,
+		Duration: 200 * time.Second,
+		Position: 42 * time.Second,
+	}
+	component := playingComponent(mockPlayer)
+
+	_, cmd := component.Update(player.ProgressUpdateMsg{})
+
+	assert.Empty(t, completedMsgs(cmd))
+}
+
+func TestApp_PlaybackCompletedAdvancesQueue(t *testing.T) {
+	application := createTestApp(t, nil, nil)
+	first := soundcloud.Track{ID: 1, Title: "First"}
+	application.Update(search.AddToQueueMsg{Track: first})
+	application.Update(search.AddToQueueMsg{Track: soundcloud.Track{ID: 2, Title: "Second"}})
+	application.Update(player.NextTrackMsg{})
+
+	application.Update(player.PlaybackCompletedMsg{Track: &first})
+
+	assert.Equal(t, 1, application.GetQueueComponent().GetCurrentIndex())
+	assert.Equal(t, int64(2), application.GetPlayerComponent().GetCurrentTrack().ID)
+}
+
+func TestApp_PlaybackCompletedRepeatsSingleTrack(t *testing.T) {
+	application := createTestApp(t, nil, nil)
+	first := soundcloud.Track{ID: 1, Title: "First"}
+	application.Update(search.AddToQueueMsg{Track: first})
+	application.Update(search.AddToQueueMsg{Track: soundcloud.Track{ID: 2, Title: "Second"}})
+	application.Update(player.NextTrackMsg{})
+	application.GetQueueComponent().SetRepeatMode(queue.RepeatOne)
+
+	application.Update(player.PlaybackCompletedMsg{Track: &first})
+
+	assert.Equal(t, 0, application.GetQueueComponent().GetCurrentIndex())
+	assert.Equal(t, int64(1), application.GetPlayerComponent().GetCurrentTrack().ID)
+}
@@ -0,0 +1,101 @@
+package ui_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/components/player"
+)
+
+// runLikeBubbleTea feeds keys to component the way the Bubble Tea runtime
+// does: Update and View on one goroutine, every command on its own, and
+// their messages back into Update. It runs for d after the last key. Run
+// with -race to catch commands that touch the component directly.
+func runLikeBubbleTea(component *player.PlayerComponent, keys []tea.KeyMsg, d time.Duration) {
+	msgs := make(chan tea.Msg, 64)
+	done := make(chan struct{})
+	defer close(done)
+
+	dispatch := func(cmd tea.Cmd) {
+		if cmd == nil {
+			return
+		}
+		go func() {
+			select {
+			case msgs <- cmd():
+			case <-done:
+			}
+		}()
+	}
+	handle := func(msg tea.Msg) {
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			for _, cmd := range batch {
+				dispatch(cmd)
+			}
+			return
+		}
+		if msg == nil {
+			return
+		}
+		_, cmd := component.Update(msg)
+		dispatch(cmd)
+		_ = component.View()
+	}
+
+	for _, key := range keys {
+		handle(key)
+		// Let earlier commands land between key presses
+		select {
+		case msg := <-msgs:
+			handle(msg)
+		default:
+		}
+	}
+
+	deadline := time.After(d)
+	for {
+		select {
+		case msg := <-msgs:
+			handle(msg)
+		case <-deadline:
+			return
+		}
+	}
+}
+
+func TestPlayerComponent_RapidVolumeAndSeekKeys(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePlaying, Volume: 0.5, Duration: 200 * time.Second, Position: 60 * time.Second}
+	component := playingComponent(mockPlayer)
+	component.SetTickInterval(10 * time.Millisecond)
+
+	var keys []tea.KeyMsg
+	for i := 0; i < 20; i++ {
+		keys = append(keys, runeKey("+"), tea.KeyMsg{Type: tea.KeyRight}, runeKey("-"), runeKey("5"), tea.KeyMsg{Type: tea.KeyLeft})
+	}
+	keys = append(keys, runeKey("+"), runeKey("+"), tea.KeyMsg{Type: tea.KeyHome})
+
+	runLikeBubbleTea(component, keys, 500*time.Millisecond)
+
+	assert.InDelta(t, mockPlayer.GetVolume(), component.GetVolume(), 0.001, "the component should end in sync with the player")
+	assert.Equal(t, player.StatePlaying, component.GetState())
+}
+
+func TestPlayerComponent_VolumeKeysWhileTrackChanges(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePlaying, Volume: 1, Duration: 200 * time.Second}
+	component := playingComponent(mockPlayer)
+	component.SetTickInterval(10 * time.Millisecond)
+
+	var keys []tea.KeyMsg
+	for i := 0; i < 10; i++ {
+		keys = append(keys, runeKey("-"), runeKey("r"), runeKey("+"), tea.KeyMsg{Type: tea.KeySpace})
+	}
+
+	runLikeBubbleTea(component, keys, 300*time.Millisecond)
+
+	assert.InDelta(t, mockPlayer.GetVolume(), component.GetVolume(), 0.001)
+}
@@ -11,6 +11,7 @@ import (
 
 	"soundcloud-tui/internal/audio"
 	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
 	"soundcloud-tui/internal/ui/components/player"
 )
 
@@ -25,8 +26,8 @@ func TestPlayerComponent_NewPlayerComponent(t *testing.T) {
 }
 
 func TestPlayerComponent_PlayTrack(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{}
-	mockExtractor := &MockStreamExtractor{
+	mockPlayer := testutil.NewMockAudioPlayer()
+	mockExtractor := &testutil.MockStreamExtractor{
 		ExtractFunc: func(ctx context.Context, trackID int64) (*audio.StreamInfo, error) {
 			return &audio.StreamInfo{
 				URL:      "https://example.com/stream.mp3",
@@ -56,9 +57,26 @@ func TestPlayerComponent_PlayTrack(t *testing.T) {
 	assert.NotNil(t, cmd) // Should return stream extraction command
 }
 
+// runUntilCalled executes cmd and feeds each resulting message back into the
+// component, the way the Bubble Tea runtime would, until the audio player
+// receives a call to method
+func runUntilCalled(component *player.PlayerComponent, mockPlayer *testutil.MockAudioPlayer, cmd tea.Cmd, method string) (testutil.Call, bool) {
+	before := mockPlayer.CallCount(method)
+	for i := 0; cmd != nil && i < 3; i++ {
+		_, cmd = component.Update(cmd())
+		if mockPlayer.CallCount(method) > before {
+			return mockPlayer.LastCall(method)
+		}
+	}
+	return testutil.Call{}, false
+}
+
 func TestPlayerComponent_PlaybackControls(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{
-		state: audio.StatePlaying,
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:    audio.StatePlaying,
+		Volume:   1.0,
+		Duration: 240 * time.Second,
+		Position: 60 * time.Second,
 	}
 	component := player.NewPlayerComponent(mockPlayer, nil)
 	
@@ -66,42 +84,48 @@ func TestPlayerComponent_PlaybackControls(t *testing.T) {
 	track := &soundcloud.Track{ID: 123, Title: "Test Track", User: soundcloud.User{Username: "Test Artist"}}
 	component.SetCurrentTrack(track)
 	component.SetState(player.StatePlaying)
+	component.Update(player.ProgressUpdateMsg{Position: 60 * time.Second, Duration: 240 * time.Second})
 	
 	tests := []struct {
 		name        string
 		key         tea.Key
-		expectedCmd bool
+		method      string
+		args        []interface{}
 		description string
 	}{
 		{
 			name:        "spacebar toggles play/pause",
 			key:         tea.Key{Type: tea.KeySpace},
-			expectedCmd: true,
+			method:      "Pause",
 			description: "should pause when playing",
 		},
 		{
 			name:        "left arrow seeks backward",
 			key:         tea.Key{Type: tea.KeyLeft},
-			expectedCmd: true,
-			description: "should seek backward",
+			method:      "Seek",
+			args:        []interface{}{50 * time.Second},
+			description: "should seek back by the seek step",
 		},
 		{
 			name:        "right arrow seeks forward",
 			key:         tea.Key{Type: tea.KeyRight},
-			expectedCmd: true,
-			description: "should seek forward",
+			method:      "Seek",
+			args:        []interface{}{60 * time.Second},
+			description: "should seek forward by the seek step",
 		},
 		{
 			name:        "plus increases volume",
 			key:         tea.Key{Type: tea.KeyRunes, Runes: []rune{'+'}},
-			expectedCmd: true,
-			description: "should increase volume",
+			method:      "SetVolume",
+			args:        []interface{}{1.0},
+			description: "should keep volume capped at full",
 		},
 		{
 			name:        "minus decreases volume",
 			key:         tea.Key{Type: tea.KeyRunes, Runes: []rune{'-'}},
-			expectedCmd: true,
-			description: "should decrease volume",
+			method:      "SetVolume",
+			args:        []interface{}{0.9},
+			description: "should lower volume by the volume step",
 		},
 	}
 	
@@ -109,66 +133,83 @@ func TestPlayerComponent_PlaybackControls(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			keyMsg := tea.KeyMsg(tt.key)
 			updatedComponent, cmd := component.Update(keyMsg)
+			component = updatedComponent.(*player.PlayerComponent)
+			require.NotNil(t, cmd, tt.description)
 			
-			if tt.expectedCmd {
-				assert.NotNil(t, cmd, tt.description)
-			} else {
-				assert.Nil(t, cmd, tt.description)
+			call, ok := runUntilCalled(component, mockPlayer, cmd, tt.method)
+			require.True(t, ok, "expected a call to %s: %s", tt.method, tt.description)
+			require.Len(t, call.Args, len(tt.args))
+			for i, want := range tt.args {
+				if volume, isFloat := want.(float64); isFloat {
+					assert.InDelta(t, volume, call.Args[i], 0.001, tt.description)
+				} else {
+					assert.Equal(t, want, call.Args[i], tt.description)
+				}
 			}
-			
-			component = updatedComponent.(*player.PlayerComponent)
 		})
 	}
 }
 
 func TestPlayerComponent_VolumeControl(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{}
+	mockPlayer := testutil.NewMockAudioPlayer()
 	component := player.NewPlayerComponent(mockPlayer, nil)
 	
 	initialVolume := component.GetVolume()
 	assert.Equal(t, float64(1.0), initialVolume)
 	
-	// Test volume increase
-	plusMsg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}}
-	updatedComponent, cmd := component.Update(plusMsg)
-	component = updatedComponent.(*player.PlayerComponent)
-	
-	assert.NotNil(t, cmd)
-	// Note: Volume change would be handled by the command result
-	
 	// Test volume decrease
 	minusMsg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'-'}}
-	updatedComponent, cmd = component.Update(minusMsg)
-	component = updatedComponent.(*player.PlayerComponent)
+	_, cmd := component.Update(minusMsg)
+	require.NotNil(t, cmd)
+	
+	call, ok := runUntilCalled(component, mockPlayer, cmd, "SetVolume")
+	require.True(t, ok)
+	assert.InDelta(t, 0.9, call.Args[0], 0.001)
+	assert.InDelta(t, 0.9, component.GetVolume(), 0.001)
 	
-	assert.NotNil(t, cmd)
+	// Test volume increase
+	plusMsg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}}
+	_, cmd = component.Update(plusMsg)
+	require.NotNil(t, cmd)
+	
+	call, ok = runUntilCalled(component, mockPlayer, cmd, "SetVolume")
+	require.True(t, ok)
+	assert.InDelta(t, 1.0, call.Args[0], 0.001)
+	assert.Equal(t, 2, mockPlayer.CallCount("SetVolume"))
 }
 
 func TestPlayerComponent_SeekControls(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{
-		state:    audio.StatePlaying,
-		duration: 240 * time.Second,
-		position: 60 * time.Second,
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:    audio.StatePlaying,
+		Duration: 240 * time.Second,
+		Position: 60 * time.Second,
 	}
 	component := player.NewPlayerComponent(mockPlayer, nil)
+	component.Update(player.ProgressUpdateMsg{Position: 60 * time.Second, Duration: 240 * time.Second})
 	
 	// Test seek backward
 	leftMsg := tea.KeyMsg{Type: tea.KeyLeft}
-	updatedComponent, cmd := component.Update(leftMsg)
-	component = updatedComponent.(*player.PlayerComponent)
+	_, cmd := component.Update(leftMsg)
+	require.NotNil(t, cmd) // Should return seek command
 	
-	assert.NotNil(t, cmd) // Should return seek command
+	call, ok := runUntilCalled(component, mockPlayer, cmd, "Seek")
+	require.True(t, ok)
+	assert.Equal(t, []interface{}{50 * time.Second}, call.Args)
+	assert.Equal(t, 50*time.Second, component.GetPosition())
 	
 	// Test seek forward
 	rightMsg := tea.KeyMsg{Type: tea.KeyRight}
-	updatedComponent, cmd = component.Update(rightMsg)
-	component = updatedComponent.(*player.PlayerComponent)
+	_, cmd = component.Update(rightMsg)
+	require.NotNil(t, cmd) // Should return seek command
 	
-	assert.NotNil(t, cmd) // Should return seek command
+	call, ok = runUntilCalled(component, mockPlayer, cmd, "Seek")
+	require.True(t, ok)
+	assert.Equal(t, []interface{}{60 * time.Second}, call.Args)
+	assert.Equal(t, 60*time.Second, component.GetPosition())
 }
 
 func TestPlayerComponent_StateTransitions(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{}
+	mockPlayer := testutil.NewMockAudioPlayer()
 	component := player.NewPlayerComponent(mockPlayer, nil)
 	
 	// Initial state
@@ -192,8 +233,8 @@ func TestPlayerComponent_StateTransitions(t *testing.T) {
 }
 
 func TestPlayerComponent_StreamInfoHandling(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{}
-	component := player.NewPlayerComponent(mockPlayer, nil)
+	mockPlayer := testutil.NewMockAudioPlayer()
+	component := player.NewPlayerComponent(mockPlayer, &testutil.MockStreamExtractor{})
 	
 	streamInfo := &audio.StreamInfo{
 		URL:      "https://example.com/stream.mp3",
@@ -202,17 +243,24 @@ func TestPlayerComponent_StreamInfoHandling(t *testing.T) {
 		Duration: 240000,
 	}
 	
+	component.Update(player.PlayTrackMsg{Track: &soundcloud.Track{ID: 1, Title: "Test Track"}})
+	
 	// Send stream info message
 	streamMsg := player.StreamInfoMsg{StreamInfo: streamInfo, Error: nil}
 	updatedComponent, cmd := component.Update(streamMsg)
 	component = updatedComponent.(*player.PlayerComponent)
 	
+	// Still loading until the audio player reports playback started
+	assert.Equal(t, player.StateLoading, component.GetState())
+	require.NotNil(t, cmd) // Should return play command
+	
+	component.Update(cmd())
 	assert.Equal(t, player.StatePlaying, component.GetState())
-	assert.NotNil(t, cmd) // Should return play command
+	assert.Equal(t, 1, mockPlayer.CallCount("Play"))
 }
 
 func TestPlayerComponent_ErrorHandling(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{}
+	mockPlayer := testutil.NewMockAudioPlayer()
 	component := player.NewPlayerComponent(mockPlayer, nil)
 	
 	// Send error message
@@ -229,10 +277,10 @@ func TestPlayerComponent_ErrorHandling(t *testing.T) {
 }
 
 func TestPlayerComponent_ProgressUpdates(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{
-		state:    audio.StatePlaying,
-		duration: 240 * time.Second,
-		position: 30 * time.Second,
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:    audio.StatePlaying,
+		Duration: 240 * time.Second,
+		Position: 30 * time.Second,
 	}
 	component := player.NewPlayerComponent(mockPlayer, nil)
 	
@@ -251,7 +299,7 @@ func TestPlayerComponent_ProgressUpdates(t *testing.T) {
 }
 
 func TestPlayerComponent_ViewRendering(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{}
+	mockPlayer := testutil.NewMockAudioPlayer()
 	component := player.NewPlayerComponent(mockPlayer, nil)
 	
 	// Test idle view
@@ -269,7 +317,7 @@ func TestPlayerComponent_ViewRendering(t *testing.T) {
 	component.SetCurrentTrack(track)
 	component.SetState(player.StatePlaying)
 	// Set the mock player to playing state
-	mockPlayer.state = audio.StatePlaying
+	mockPlayer.State = audio.StatePlaying
 	
 	view = component.View()
 	assert.Contains(t, view, "Test Track")
@@ -278,7 +326,7 @@ func TestPlayerComponent_ViewRendering(t *testing.T) {
 }
 
 func TestPlayerComponent_BubbleTeaIntegration(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{}
+	mockPlayer := testutil.NewMockAudioPlayer()
 	component := player.NewPlayerComponent(mockPlayer, nil)
 	
 	// Test that component implements tea.Model interface
@@ -296,86 +344,3 @@ func TestPlayerComponent_BubbleTeaIntegration(t *testing.T) {
 	view := component.View()
 	assert.NotEmpty(t, view)
 }
-
-// Mock implementations for testing
-type MockAudioPlayer struct {
-	state    audio.PlayerState
-	volume   float64
-	position time.Duration
-	duration time.Duration
-}
-
-func (m *MockAudioPlayer) Play(ctx context.Context, streamURL string) error {
-	m.state = audio.StatePlaying
-	return nil
-}
-
-func (m *MockAudioPlayer) Pause() error {
-	m.state = audio.StatePaused
-	return nil
-}
-
-func (m *MockAudioPlayer) Stop() error {
-	m.state = audio.StateStopped
-	m.position = 0
-	return nil
-}
-
-func (m *MockAudioPlayer) GetState() audio.PlayerState {
-	return m.state
-}
-
-func (m *MockAudioPlayer) SetVolume(volume float64) error {
-	if volume < 0 || volume > 1 {
-		return assert.AnError
-	}
-	m.volume = volume
-	return nil
-}
-
-func (m *MockAudioPlayer) GetVolume() float64 {
-	if m.volume == 0 {
-		return 1.0 // Default volume
-	}
-	return m.volume
-}
-
-func (m *MockAudioPlayer) Seek(position time.Duration) error {
-	if position < 0 || position > m.duration {
-		return assert.AnError
-	}
-	m.position = position
-	return nil
-}
-
-func (m *MockAudioPlayer) GetPosition() time.Duration {
-	return m.position
-}
-
-func (m *MockAudioPlayer) GetDuration() time.Duration {
-	return m.duration
-}
-
-func (m *MockAudioPlayer) Close() error {
-	m.state = audio.StateStopped
-	return nil
-}
-
-type MockStreamExtractor struct {
-	ExtractFunc func(ctx context.Context, trackID int64) (*audio.StreamInfo, error)
-}
-
-func (m *MockStreamExtractor) ExtractStreamURL(ctx context.Context, trackID int64) (*audio.StreamInfo, error) {
-	if m.ExtractFunc != nil {
-		return m.ExtractFunc(ctx, trackID)
-	}
-	return nil, assert.AnError
-}
-
-func (m *MockStreamExtractor) GetAvailableQualities(ctx context.Context, trackID int64) ([]string, error) {
-	return []string{"sq", "hq"}, nil
-}
-
-func (m *MockStreamExtractor) ValidateStreamURL(ctx context.Context, streamURL string) (bool, error) {
-	return true, nil
-}
\ No newline at end of file
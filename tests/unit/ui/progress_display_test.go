@@ -2,14 +2,18 @@ package ui_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"soundcloud-tui/internal/audio"
 	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
 	"soundcloud-tui/internal/ui/components/player"
 	"soundcloud-tui/internal/ui/styles"
 )
@@ -67,9 +71,13 @@ func TestProgressDisplay_ProgressBarAccuracy(t *testing.T) {
 			// Progress bar should not be empty
 			assert.NotEmpty(t, progressBar)
 			
-			// Progress bar width should match expected
-			// Note: This is a visual test - exact implementation may vary
-			assert.LessOrEqual(t, len(progressBar), tt.barWidth*2) // Account for styling
+			// The bar takes up exactly its width on screen, styling aside
+			assert.Equal(t, tt.barWidth, lipgloss.Width(progressBar))
+			
+			// Plain mode marks the filled part with #
+			styles.SetNoColor(true)
+			defer styles.SetNoColor(false)
+			assert.Equal(t, tt.expectedFillWidth, strings.Count(styles.RenderProgressBar(tt.barWidth, progress), "#"))
 		})
 	}
 }
@@ -121,11 +129,11 @@ func TestProgressDisplay_TimeFormatting(t *testing.T) {
 }
 
 func TestProgressDisplay_PlayerProgressRendering(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{
-		state:    audio.StatePlaying,
-		position: 45 * time.Second,
-		duration: 180 * time.Second,
-		volume:   0.75,
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:    audio.StatePlaying,
+		Position: 45 * time.Second,
+		Duration: 180 * time.Second,
+		Volume:   0.75,
 	}
 	
 	playerComponent := player.NewPlayerComponent(mockPlayer, nil)
@@ -228,42 +236,48 @@ func TestProgressDisplay_ProgressBarEdgeCases(t *testing.T) {
 
 func TestProgressDisplay_VolumeDisplay(t *testing.T) {
 	tests := []struct {
-		name           string
-		volume         float64
-		expectedFormat string
+		name         string
+		volume       float64
+		expectedIcon string
+		expectedText string
 	}{
 		{
-			name:           "muted",
-			volume:         0.0,
-			expectedFormat: "🔇 0%",
+			name:         "muted",
+			volume:       0.0,
+			expectedIcon: "🔇",
+			expectedText: "0%",
 		},
 		{
-			name:           "low volume",
-			volume:         0.25,
-			expectedFormat: "🔉 25%",
+			name:         "low volume",
+			volume:       0.25,
+			expectedIcon: "🔉",
+			expectedText: "25%",
 		},
 		{
-			name:           "medium volume",
-			volume:         0.50,
-			expectedFormat: "🔉 50%",
+			name:         "medium volume",
+			volume:       0.50,
+			expectedIcon: "🔊",
+			expectedText: "50%",
 		},
 		{
-			name:           "high volume",
-			volume:         0.75,
-			expectedFormat: "🔊 75%",
+			name:         "high volume",
+			volume:       0.75,
+			expectedIcon: "🔊",
+			expectedText: "75%",
 		},
 		{
-			name:           "max volume",
-			volume:         1.0,
-			expectedFormat: "🔊 100%",
+			name:         "max volume",
+			volume:       1.0,
+			expectedIcon: "🔊",
+			expectedText: "100%",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockPlayer := &MockAudioPlayer{
-				state:  audio.StatePlaying,
-				volume: tt.volume,
+			mockPlayer := &testutil.MockAudioPlayer{
+				State:  audio.StatePlaying,
+				Volume: tt.volume,
 			}
 			
 			playerComponent := player.NewPlayerComponent(mockPlayer, nil)
@@ -276,32 +290,24 @@ func TestProgressDisplay_VolumeDisplay(t *testing.T) {
 			
 			playerComponent.SetCurrentTrack(track)
 			playerComponent.SetState(player.StatePlaying)
+			// The first progress update syncs the volume from the audio player
+			playerComponent.Update(player.ProgressUpdateMsg{})
 			
 			view := playerComponent.View()
 			
-			// Should contain volume percentage
-			volumePercent := int(tt.volume * 100)
-			assert.Contains(t, view, "🔊") // Volume icon
-			
-			// Should show volume percentage somewhere in the view
-			// Note: Exact format may vary based on implementation
-			if volumePercent == 0 {
-				// Special case for muted
-				assert.Contains(t, view, "0%")
-			} else {
-				// Should contain the percentage
-				assert.Contains(t, view, "%")
-			}
+			// The icon follows the volume level, next to the percentage
+			assert.Contains(t, view, tt.expectedIcon)
+			assert.Contains(t, view, tt.expectedText)
 		})
 	}
 }
 
 func TestProgressDisplay_RealTimeUpdates(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{
-		state:    audio.StatePlaying,
-		position: 0,
-		duration: 180 * time.Second,
-		volume:   1.0,
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:    audio.StatePlaying,
+		Position: 0,
+		Duration: 180 * time.Second,
+		Volume:   1.0,
 	}
 	
 	playerComponent := player.NewPlayerComponent(mockPlayer, nil)
@@ -353,11 +359,11 @@ func TestProgressDisplay_RealTimeUpdates(t *testing.T) {
 }
 
 func TestProgressDisplay_SeekUpdatesProgress(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{
-		state:    audio.StatePlaying,
-		position: 60 * time.Second,
-		duration: 180 * time.Second,
-		volume:   1.0,
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:    audio.StatePlaying,
+		Position: 60 * time.Second,
+		Duration: 180 * time.Second,
+		Volume:   1.0,
 	}
 	
 	playerComponent := player.NewPlayerComponent(mockPlayer, nil)
@@ -414,4 +420,59 @@ func formatDurationForDisplay(d time.Duration) string {
 	seconds := totalSeconds % 60
 	
 	return fmt.Sprintf("%d:%02d", minutes, seconds)
-}
\ No newline at end of file
+}
+
+func TestPlayerComponent_StreamInfoSetsExpectedDuration(t *testing.T) {
+	mockPlayer := testutil.NewMockAudioPlayer()
+	component := player.NewPlayerComponent(mockPlayer, &testutil.MockStreamExtractor{})
+	component.Update(player.PlayTrackMsg{Track: &soundcloud.Track{ID: 1, Title: "Metadata"}})
+
+	component.Update(player.StreamInfoMsg{StreamInfo: &audio.StreamInfo{
+		URL:      "https://example.com/stream.mp3",
+		Duration: 180000,
+	}})
+
+	assert.Equal(t, 3*time.Minute, mockPlayer.ExpectedDuration)
+}
+
+func TestPlayerComponent_StreamInfoSetsExpectedFormat(t *testing.T) {
+	mockPlayer := testutil.NewMockAudioPlayer()
+	component := player.NewPlayerComponent(mockPlayer, &testutil.MockStreamExtractor{})
+	component.Update(player.PlayTrackMsg{Track: &soundcloud.Track{ID: 1, Title: "Metadata"}})
+
+	component.Update(player.StreamInfoMsg{StreamInfo: &audio.StreamInfo{
+		URL:    "https://example.com/stream",
+		Format: audio.FormatOgg,
+	}})
+
+	assert.Equal(t, audio.FormatOgg, mockPlayer.ExpectedFormat)
+}
+
+func TestPlayerComponent_MissingStreamInfoFailsPlayback(t *testing.T) {
+	mockPlayer := testutil.NewMockAudioPlayer()
+	component := player.NewPlayerComponent(mockPlayer, &testutil.MockStreamExtractor{})
+	component.Update(player.PlayTrackMsg{Track: &soundcloud.Track{ID: 1, Title: "Metadata"}})
+
+	_, cmd := component.Update(player.StreamInfoMsg{})
+	require.NotNil(t, cmd)
+
+	failed, ok := cmd().(player.PlaybackFailedMsg)
+	require.True(t, ok, "a missing stream should fail playback")
+	assert.Equal(t, int64(1), failed.Track.ID)
+	assert.Equal(t, player.StateError, component.GetState())
+	assert.Zero(t, mockPlayer.CallCount("Play"))
+}
+
+func TestPlayerComponent_PlayingViewShowsLevelMeters(t *testing.T) {
+	styles.SetNoColor(true)
+	defer styles.SetNoColor(false)
+
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePlaying, Levels: [2]float64{1, 0}}
+	component := playingComponent(mockPlayer)
+	component.SetSize(80, 24)
+
+	view := component.View()
+
+	assert.Contains(t, view, "L "+strings.Repeat("#", 30))
+	assert.Contains(t, view, "R "+strings.Repeat(".", 30))
+}
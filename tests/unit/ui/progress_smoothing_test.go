@@ -0,0 +1,68 @@
+package ui_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/components/player"
+)
+
+// smoothingComponent returns a playing component whose display shows 10s
+func smoothingComponent(t *testing.T) (*player.PlayerComponent, *testutil.MockAudioPlayer, tea.Cmd) {
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePlaying, Duration: 3 * time.Minute}
+	component := playingComponent(mockPlayer)
+	component.SetTickInterval(time.Millisecond)
+
+	_, cmd := component.Update(player.ProgressUpdateMsg{Position: 10 * time.Second, Duration: 3 * time.Minute})
+	require.Equal(t, 10*time.Second, component.GetDisplayedPosition())
+	return component, mockPlayer, cmd
+}
+
+func TestPlayerComponent_DisplayedPositionIsMonotonic(t *testing.T) {
+	component, mockPlayer, cmd := smoothingComponent(t)
+
+	reported := []time.Duration{10250, 10500, 10450, 10750, 10700, 11000, 10990, 11250}
+	displayed := component.GetDisplayedPosition()
+	for _, ms := range reported {
+		mockPlayer.Position = ms * time.Millisecond
+		progress := nextTick(t, cmd)
+		_, cmd = component.Update(progress)
+
+		assert.GreaterOrEqual(t, component.GetDisplayedPosition(), displayed, "display moved back at %v", progress.Position)
+		assert.LessOrEqual(t, component.GetDisplayedPosition(), 11250*time.Millisecond)
+		assert.Equal(t, progress.Position, component.GetPosition(), "the reported position is kept as is")
+		displayed = component.GetDisplayedPosition()
+	}
+
+	// The display catches up with the reported position
+	for i := 0; i < 20; i++ {
+		mockPlayer.Position += time.Millisecond
+		_, cmd = component.Update(nextTick(t, cmd))
+	}
+	assert.InDelta(t, float64(mockPlayer.Position), float64(component.GetDisplayedPosition()), float64(2*time.Millisecond))
+	assert.Contains(t, stripANSI(component.View()), "0:11 / 3:00")
+}
+
+func TestPlayerComponent_SeekMovesDisplayedPositionBack(t *testing.T) {
+	component, mockPlayer, _ := smoothingComponent(t)
+
+	mockPlayer.Position = 4 * time.Second
+	component.Update(player.ProgressUpdateMsg{Position: 4 * time.Second, Duration: 3 * time.Minute})
+
+	assert.Equal(t, 4*time.Second, component.GetDisplayedPosition())
+	assert.Contains(t, stripANSI(component.View()), "0:04 / 3:00")
+}
+
+func TestPlayerComponent_TrackChangeResetsDisplayedPosition(t *testing.T) {
+	component, _, _ := smoothingComponent(t)
+
+	component.Update(player.StopMsg{})
+
+	assert.Equal(t, time.Duration(0), component.GetDisplayedPosition())
+}
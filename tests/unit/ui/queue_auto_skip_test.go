@@ -0,0 +1,86 @@
+package ui_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/app"
+	"soundcloud-tui/internal/ui/components/player"
+)
+
+// failingExtractor fails to find a stream for the given track IDs
+func failingExtractor(failing ...int64) *testutil.MockStreamExtractor {
+	return &testutil.MockStreamExtractor{
+		ExtractFunc: func(ctx context.Context, trackID int64) (*audio.StreamInfo, error) {
+			for _, id := range failing {
+				if trackID == id {
+					return nil, fmt.Errorf("failed to get download URL: %w", errors.New("connection reset"))
+				}
+			}
+			return &audio.StreamInfo{URL: fmt.Sprintf("https://example.com/%d.mp3", trackID), Format: audio.FormatMP3, Duration: 240000}, nil
+		},
+	}
+}
+
+// playQueue queues tracks and starts the queue, letting every resulting
+// message play out
+func playQueue(t *testing.T, extractor *testutil.MockStreamExtractor, tracks []soundcloud.Track) (*app.App, *testutil.MockAudioPlayer) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	mockPlayer := testutil.NewMockAudioPlayer()
+	application := app.NewAppWithDependencies(&testutil.MockSoundCloudClient{}, mockPlayer, extractor)
+	application.LoadQueue(tracks)
+	_, cmd := application.Update(player.NextTrackMsg{})
+	settle(application, cmd)
+	return application, mockPlayer
+}
+
+func TestApp_SkipsFailedQueueTrack(t *testing.T) {
+	tracks := []soundcloud.Track{{ID: 1, Title: "Bad"}, {ID: 2, Title: "Good"}}
+
+	application, mockPlayer := playQueue(t, failingExtractor(1), tracks)
+
+	assert.Equal(t, 1, application.GetQueueComponent().GetCurrentIndex())
+	current := application.GetPlayerComponent().GetCurrentTrack()
+	require.NotNil(t, current)
+	assert.Equal(t, "Good", current.Title)
+	assert.Equal(t, 1, mockPlayer.CallCount("Play"), "only the good track should reach the player")
+	assert.Empty(t, application.GetErrorBanner(), "the banner clears once the next track plays")
+}
+
+func TestApp_StopsQueueAfterConsecutiveFailures(t *testing.T) {
+	var tracks []soundcloud.Track
+	var ids []int64
+	for id := int64(1); id <= app.MaxQueueFailures+2; id++ {
+		tracks = append(tracks, soundcloud.Track{ID: id, Title: fmt.Sprintf("Bad %d", id)})
+		ids = append(ids, id)
+	}
+
+	application, mockPlayer := playQueue(t, failingExtractor(ids...), tracks)
+
+	assert.Equal(t, app.MaxQueueFailures-1, application.GetQueueComponent().GetCurrentIndex(), "the queue should stop at the last failure")
+	assert.Zero(t, mockPlayer.CallCount("Play"))
+	assert.Equal(t, fmt.Sprintf("Stopped the queue after %d tracks in a row failed to play", app.MaxQueueFailures), application.GetNotification())
+}
+
+func TestApp_FailureCountResetsWhenATrackPlays(t *testing.T) {
+	// Two failures, a success, then two more failures stay under the limit
+	tracks := []soundcloud.Track{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}, {ID: 6}}
+	application, mockPlayer := playQueue(t, failingExtractor(1, 2, 4, 5), tracks)
+	require.Equal(t, 2, application.GetQueueComponent().GetCurrentIndex())
+
+	_, cmd := application.Update(player.PlaybackCompletedMsg{Track: &tracks[2]})
+	settle(application, cmd)
+
+	assert.Equal(t, 5, application.GetQueueComponent().GetCurrentIndex())
+	assert.Equal(t, 2, mockPlayer.CallCount("Play"))
+}
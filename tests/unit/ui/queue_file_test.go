@@ -0,0 +1,120 @@
+package ui_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/config"
+	"soundcloud-tui/internal/playlist"
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/app"
+	"soundcloud-tui/internal/ui/components/search"
+)
+
+// linkedTracks returns tracks with links, and a client that looks them up
+func linkedTracks() ([]soundcloud.Track, *testutil.MockSoundCloudClient) {
+	tracks := []soundcloud.Track{
+		{ID: 1, Title: "First Light", Duration: 185000, PermalinkURL: "https://soundcloud.com/dawn/first-light", User: soundcloud.User{Username: "dawn"}},
+		{ID: 2, Title: "Night Drive", Duration: 242000, PermalinkURL: "https://soundcloud.com/dusk/night-drive", User: soundcloud.User{Username: "dusk"}},
+	}
+	client := &testutil.MockSoundCloudClient{
+		TrackInfoFunc: func(url string) (*soundcloud.Track, error) {
+			for _, track := range tracks {
+				if track.PermalinkURL == url {
+					return &track, nil
+				}
+			}
+			return nil, errors.New("track not found")
+		},
+	}
+	return tracks, client
+}
+
+// queueViewApp returns an app showing the queue view with tracks queued
+func queueViewApp(client *testutil.MockSoundCloudClient, tracks []soundcloud.Track) *app.App {
+	application := app.NewAppWithDependencies(client, testutil.NewMockAudioPlayer(), &testutil.MockStreamExtractor{})
+	application.Update(search.AddTracksToQueueMsg{Tracks: tracks})
+	application.SetCurrentView(app.ViewQueue)
+	return application
+}
+
+func TestApp_SaveAndLoadQueue(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	tracks, client := linkedTracks()
+	path := filepath.Join(config.ConfigDir(), playlist.QueueFileName)
+
+	application := queueViewApp(client, tracks)
+	_, cmd := application.Update(runeKey("s"))
+	settle(application, cmd)
+
+	assert.Equal(t, "Saved 2 tracks to "+path, application.GetNotification())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "#EXTINF:185,dawn - First Light\nhttps://soundcloud.com/dawn/first-light\n")
+
+	restored := queueViewApp(client, nil)
+	_, cmd = restored.Update(runeKey("l"))
+	settle(restored, cmd)
+
+	assert.Equal(t, tracks, restored.GetQueueComponent().GetTracks())
+	assert.Equal(t, "Loaded 2 tracks from "+path, restored.GetNotification())
+}
+
+func TestApp_LoadQueueSkipsQueuedAndUnknownTracks(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	tracks, client := linkedTracks()
+	path := filepath.Join(config.ConfigDir(), playlist.QueueFileName)
+	saved := append(tracks, soundcloud.Track{ID: 3, Title: "Gone", PermalinkURL: "https://soundcloud.com/someone/gone"})
+	_, err := playlist.Write(path, saved)
+	require.NoError(t, err)
+
+	application := queueViewApp(client, tracks[:1])
+	_, cmd := application.Update(runeKey("l"))
+	settle(application, cmd)
+
+	assert.Equal(t, tracks, application.GetQueueComponent().GetTracks())
+	assert.Equal(t, "Loaded 1 track from "+path+" (1 could not be found)", application.GetNotification())
+}
+
+func TestApp_LoadQueueWithoutSavedQueue(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	_, client := linkedTracks()
+
+	application := queueViewApp(client, nil)
+	_, cmd := application.Update(runeKey("l"))
+	settle(application, cmd)
+
+	assert.Contains(t, application.GetNotification(), "No saved queue")
+	assert.Zero(t, application.GetQueueComponent().Len())
+}
+
+func TestApp_SaveQueueReportsUnwritablePath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	// A regular file where the config directory should be
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".config"), nil, 0o644))
+	tracks, client := linkedTracks()
+
+	application := queueViewApp(client, tracks)
+	_, cmd := application.Update(runeKey("s"))
+	settle(application, cmd)
+
+	assert.Contains(t, application.GetNotification(), "Could not save queue")
+}
+
+func TestApp_SaveEmptyQueueDoesNothing(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	_, client := linkedTracks()
+
+	application := queueViewApp(client, nil)
+	_, cmd := application.Update(runeKey("s"))
+
+	assert.Nil(t, cmd)
+	assert.NoFileExists(t, filepath.Join(config.ConfigDir(), playlist.QueueFileName))
+}
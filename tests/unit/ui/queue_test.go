@@ -0,0 +1,324 @@
+package ui_test
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/app"
+	"soundcloud-tui/internal/ui/components/player"
+	"soundcloud-tui/internal/ui/components/queue"
+	"soundcloud-tui/internal/ui/components/search"
+)
+
+func queueWithTracks(count int) *queue.QueueComponent {
+	q := queue.NewQueueComponent()
+	for i := 1; i <= count; i++ {
+		q.Add(soundcloud.Track{ID: int64(i), Title: "Track"})
+	}
+	return q
+}
+
+func TestQueue_NextStopsAtEndWithoutRepeat(t *testing.T) {
+	q := queueWithTracks(2)
+
+	track, ok := q.Next()
+	require.True(t, ok)
+	assert.Equal(t, int64(1), track.ID)
+
+	track, ok = q.Next()
+	require.True(t, ok)
+	assert.Equal(t, int64(2), track.ID)
+
+	_, ok = q.Next()
+	assert.False(t, ok)
+	assert.Equal(t, 1, q.GetCurrentIndex(), "current entry should not move past the end")
+}
+
+func TestQueue_NextWrapsUnderRepeatAll(t *testing.T) {
+	q := queueWithTracks(2)
+	q.SetRepeatMode(queue.RepeatAll)
+	q.Play(1)
+
+	track, ok := q.Next()
+	require.True(t, ok)
+	assert.Equal(t, int64(1), track.ID)
+	assert.Equal(t, 0, q.GetCurrentIndex())
+}
+
+func TestQueue_PreviousStopsAtStartWithoutRepeat(t *testing.T) {
+	q := queueWithTracks(2)
+	q.Play(0)
+
+	_, ok := q.Previous()
+	assert.False(t, ok)
+	assert.Equal(t, 0, q.GetCurrentIndex())
+}
+
+func TestQueue_PreviousWrapsUnderRepeatAll(t *testing.T) {
+	q := queueWithTracks(3)
+	q.SetRepeatMode(queue.RepeatAll)
+	q.Play(0)
+
+	track, ok := q.Previous()
+	require.True(t, ok)
+	assert.Equal(t, int64(3), track.ID)
+	assert.Equal(t, 2, q.GetCurrentIndex())
+}
+
+func TestQueue_EmptyQueueHasNoNextOrPrevious(t *testing.T) {
+	q := queue.NewQueueComponent()
+	q.SetRepeatMode(queue.RepeatAll)
+
+	_, ok := q.Next()
+	assert.False(t, ok)
+	_, ok = q.Previous()
+	assert.False(t, ok)
+}
+
+func queueIDs(q *queue.QueueComponent) []int64 {
+	var ids []int64
+	for _, track := range q.GetTracks() {
+		ids = append(ids, track.ID)
+	}
+	return ids
+}
+
+func TestQueue_MoveReordersEntries(t *testing.T) {
+	q := queueWithTracks(4)
+	q.Play(1)
+
+	require.True(t, q.Move(0, 3))
+	assert.Equal(t, []int64{2, 3, 4, 1}, queueIDs(q))
+	assert.Equal(t, 0, q.GetCurrentIndex(), "the playing entry should stay current")
+
+	require.True(t, q.Move(2, 0))
+	assert.Equal(t, []int64{4, 2, 3, 1}, queueIDs(q))
+	assert.Equal(t, 1, q.GetCurrentIndex())
+
+	require.True(t, q.Move(1, 2))
+	assert.Equal(t, []int64{4, 3, 2, 1}, queueIDs(q))
+	assert.Equal(t, 2, q.GetCurrentIndex())
+
+	assert.False(t, q.Move(0, 4))
+	assert.False(t, q.Move(-1, 0))
+	assert.Equal(t, []int64{4, 3, 2, 1}, queueIDs(q))
+}
+
+func TestQueue_ShiftArrowsMoveSelectedEntry(t *testing.T) {
+	q := queueWithTracks(3)
+
+	q.Update(tea.KeyMsg{Type: tea.KeyShiftDown})
+	assert.Equal(t, []int64{2, 1, 3}, queueIDs(q))
+	assert.Equal(t, 1, q.GetSelectedIndex(), "the selection should follow the moved entry")
+
+	q.Update(tea.KeyMsg{Type: tea.KeyShiftDown})
+	q.Update(tea.KeyMsg{Type: tea.KeyShiftDown})
+	assert.Equal(t, []int64{2, 3, 1}, queueIDs(q))
+	assert.Equal(t, 2, q.GetSelectedIndex())
+
+	q.Update(tea.KeyMsg{Type: tea.KeyShiftUp})
+	assert.Equal(t, []int64{2, 1, 3}, queueIDs(q))
+	assert.Equal(t, 1, q.GetSelectedIndex())
+}
+
+func TestQueue_JumpToNowPlaying(t *testing.T) {
+	q := queueWithTracks(30)
+	q.SetSize(80, 16) // 10 visible entries
+	q.Play(24)
+	for i := 0; i < 20; i++ {
+		q.Update(tea.KeyMsg{Type: tea.KeyUp})
+	}
+	require.Equal(t, 4, q.GetSelectedIndex())
+	require.Contains(t, stripANSI(q.View()), "[1-10 of 30]")
+
+	q.Update(runeKey("."))
+
+	assert.Equal(t, 24, q.GetSelectedIndex())
+	assert.Contains(t, stripANSI(q.View()), "[20-29 of 30]", "the window should scroll to the playing entry")
+}
+
+func TestQueue_JumpToNowPlayingWithNothingPlaying(t *testing.T) {
+	q := queueWithTracks(3)
+	q.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+	q.Update(runeKey("."))
+
+	assert.Equal(t, 1, q.GetSelectedIndex())
+}
+
+func TestQueue_RemoveKeepsCurrentEntry(t *testing.T) {
+	q := queueWithTracks(3)
+	q.Play(2)
+
+	require.True(t, q.Remove(0))
+	assert.Equal(t, []int64{2, 3}, queueIDs(q))
+	assert.Equal(t, 1, q.GetCurrentIndex())
+	assert.False(t, q.Remove(2))
+}
+
+func TestQueue_ClearNeedsConfirmation(t *testing.T) {
+	q := queueWithTracks(3)
+
+	q.Update(runeKey("c"))
+	assert.True(t, q.IsConfirmingClear())
+	assert.Contains(t, q.View(), "Press c again")
+	assert.Equal(t, 3, q.Len())
+
+	q.Update(tea.KeyMsg{Type: tea.KeyDown})
+	assert.False(t, q.IsConfirmingClear(), "any other key should cancel")
+	assert.Equal(t, 3, q.Len())
+
+	q.Update(runeKey("c"))
+	q.Update(runeKey("c"))
+	assert.Equal(t, 0, q.Len())
+	assert.Equal(t, -1, q.GetCurrentIndex())
+}
+
+func TestApp_RemovingPlayingEntryAdvances(t *testing.T) {
+	application := createTestApp(t, nil, nil)
+	for i := int64(1); i <= 3; i++ {
+		application.Update(search.AddToQueueMsg{Track: soundcloud.Track{ID: i, Title: "Track"}})
+	}
+	application.Update(player.NextTrackMsg{})
+	application.SetCurrentView(app.ViewQueue)
+
+	_, cmd := application.Update(runeKey("x"))
+	msgs := quickMsgs(cmd)
+	require.Contains(t, msgs, queue.RemovedCurrentMsg{})
+	application.Update(queue.RemovedCurrentMsg{})
+
+	assert.Equal(t, []int64{2, 3}, queueIDs(application.GetQueueComponent()))
+	assert.Equal(t, 0, application.GetQueueComponent().GetCurrentIndex())
+	require.NotNil(t, application.GetPlayerComponent().GetCurrentTrack())
+	assert.Equal(t, int64(2), application.GetPlayerComponent().GetCurrentTrack().ID)
+}
+
+func TestApp_RemovingOnlyEntryStopsPlayback(t *testing.T) {
+	mockPlayer := testutil.NewMockAudioPlayer()
+	application := createTestApp(t, nil, mockPlayer)
+	application.Update(search.AddToQueueMsg{Track: soundcloud.Track{ID: 1, Title: "Only"}})
+	application.Update(player.NextTrackMsg{})
+	application.SetCurrentView(app.ViewQueue)
+
+	_, cmd := application.Update(runeKey("x"))
+	require.Contains(t, quickMsgs(cmd), queue.RemovedCurrentMsg{})
+	_, cmd = application.Update(queue.RemovedCurrentMsg{})
+	quickMsgs(cmd)
+
+	assert.Equal(t, 0, application.GetQueueComponent().Len())
+	assert.Equal(t, -1, application.GetQueueComponent().GetCurrentIndex())
+	assert.Nil(t, application.GetPlayerComponent().GetCurrentTrack())
+	assert.Equal(t, player.StateIdle, application.GetPlayerComponent().GetState())
+	assert.Equal(t, 1, mockPlayer.CallCount("Stop"))
+}
+
+func TestPlayerComponent_NextPreviousKeysEmitMessages(t *testing.T) {
+	component := player.NewPlayerComponent(testutil.NewMockAudioPlayer(), &testutil.MockStreamExtractor{})
+
+	_, cmd := component.Update(runeKey("n"))
+	require.NotNil(t, cmd)
+	assert.Equal(t, player.NextTrackMsg{}, cmd())
+
+	_, cmd = component.Update(runeKey("p"))
+	require.NotNil(t, cmd)
+	assert.Equal(t, player.PreviousTrackMsg{}, cmd())
+}
+
+func TestApp_NextTrackAdvancesQueue(t *testing.T) {
+	application := createTestApp(t, nil, nil)
+	application.Update(search.AddToQueueMsg{Track: soundcloud.Track{ID: 1, Title: "First"}})
+	application.Update(search.AddToQueueMsg{Track: soundcloud.Track{ID: 2, Title: "Second"}})
+	assert.Equal(t, 2, application.GetQueueComponent().Len())
+
+	_, cmd := application.Update(player.NextTrackMsg{})
+	assert.NotNil(t, cmd, "starting the next track should begin stream extraction")
+	assert.Equal(t, 0, application.GetQueueComponent().GetCurrentIndex())
+	assert.Equal(t, int64(1), application.GetPlayerComponent().GetCurrentTrack().ID)
+
+	application.Update(player.NextTrackMsg{})
+	assert.Equal(t, 1, application.GetQueueComponent().GetCurrentIndex())
+	assert.Equal(t, int64(2), application.GetPlayerComponent().GetCurrentTrack().ID)
+
+	// Past the end without repeat the current track keeps playing
+	application.Update(player.NextTrackMsg{})
+	assert.Equal(t, 1, application.GetQueueComponent().GetCurrentIndex())
+	assert.Equal(t, int64(2), application.GetPlayerComponent().GetCurrentTrack().ID)
+	assert.Contains(t, application.GetNotification(), "End of queue")
+}
+
+func TestApp_LoadQueueStartsPlaybackOnInit(t *testing.T) {
+	application := createTestApp(t, nil, nil)
+	application.LoadQueue([]soundcloud.Track{
+		{ID: 1, Title: "First"},
+		{ID: 2, Title: "Second"},
+	})
+
+	assert.Equal(t, 2, application.GetQueueComponent().Len())
+	assert.Equal(t, app.ViewPlayer, application.GetCurrentView())
+
+	cmd := application.Init()
+	require.NotNil(t, cmd)
+
+	var msgs []tea.Msg
+	if batch, ok := cmd().(tea.BatchMsg); ok {
+		for _, c := range batch {
+			if c != nil {
+				msgs = append(msgs, c())
+			}
+		}
+	}
+	assert.Contains(t, msgs, player.NextTrackMsg{})
+}
+
+func TestQueue_AddAllSkipsQueuedTracks(t *testing.T) {
+	q := queueWithTracks(2)
+
+	added := q.AddAll([]soundcloud.Track{{ID: 2}, {ID: 3}, {ID: 1}, {ID: 4}, {ID: 3}})
+
+	assert.Equal(t, 2, added)
+	assert.Equal(t, []int64{1, 2, 3, 4}, queueIDs(q))
+}
+
+func TestQueue_AddAllKeepsLocalFiles(t *testing.T) {
+	q := queue.NewQueueComponent()
+
+	added := q.AddAll([]soundcloud.Track{{StreamURL: "/music/a.mp3"}, {StreamURL: "/music/b.mp3"}})
+
+	assert.Equal(t, 2, added)
+	assert.Equal(t, 2, q.Len())
+}
+
+func TestSearchComponent_AddAllEmitsDisplayedResults(t *testing.T) {
+	component := searchWithResults(t, []soundcloud.Track{
+		{ID: 1, Title: "Long", Duration: 300000},
+		{ID: 2, Title: "Short", Duration: 100000},
+	})
+	component.Update(runeKey("S")) // Sort by duration
+
+	_, cmd := component.Update(runeKey("A"))
+	require.NotNil(t, cmd)
+
+	msg, ok := cmd().(search.AddTracksToQueueMsg)
+	require.True(t, ok)
+	require.Len(t, msg.Tracks, 2)
+	assert.Equal(t, int64(2), msg.Tracks[0].ID)
+	assert.Equal(t, int64(1), msg.Tracks[1].ID)
+}
+
+func TestApp_AddTracksToQueueReportsAddedCount(t *testing.T) {
+	application := createTestApp(t, nil, nil)
+	application.Update(search.AddToQueueMsg{Track: soundcloud.Track{ID: 1, Title: "Queued"}})
+
+	application.Update(search.AddTracksToQueueMsg{Tracks: []soundcloud.Track{{ID: 1}, {ID: 2}, {ID: 3}}})
+
+	assert.Equal(t, []int64{1, 2, 3}, queueIDs(application.GetQueueComponent()))
+	assert.Equal(t, "Added 2 tracks to queue", application.GetNotification())
+
+	application.Update(search.AddTracksToQueueMsg{Tracks: []soundcloud.Track{{ID: 2}, {ID: 3}}})
+	assert.Equal(t, "All tracks are already in the queue", application.GetNotification())
+}
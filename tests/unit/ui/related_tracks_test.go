@@ -0,0 +1,196 @@
+package ui_test
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/app"
+	"soundcloud-tui/internal/ui/components/player"
+	"soundcloud-tui/internal/ui/components/search"
+)
+
+func TestPlayerComponent_RelatedKeyEmitsMessage(t *testing.T) {
+	component := playingComponent(testutil.NewMockAudioPlayer())
+
+	_, cmd := component.Update(runeKey("R"))
+	require.NotNil(t, cmd)
+
+	msg, ok := cmd().(player.ShowRelatedMsg)
+	require.True(t, ok)
+	assert.Equal(t, int64(1), msg.Track.ID)
+}
+
+func TestSearchComponent_BrowseRelated(t *testing.T) {
+	var requested int64
+	client := &testutil.MockSoundCloudClient{
+		RelatedFunc: func(trackID int64) ([]soundcloud.Track, error) {
+			requested = trackID
+			return []soundcloud.Track{{ID: 20, Title: "Similar"}, {ID: 21, Title: "Alike"}}, nil
+		},
+	}
+	component := search.NewSearchComponent(client)
+	component.SetSize(120, 40)
+
+	cmd := component.BrowseRelated(soundcloud.Track{ID: 5, Title: "Seed"})
+	require.NotNil(t, cmd)
+	assert.Equal(t, search.StateSearching, component.GetState())
+
+	component.Update(cmd())
+	assert.Equal(t, int64(5), requested)
+	assert.Equal(t, search.StateResults, component.GetState())
+	assert.Len(t, component.GetResults(), 2)
+	assert.Contains(t, component.View(), "Related to Seed")
+
+	// Nothing was shown before, so Esc goes back to the search input
+	component.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, search.StateInput, component.GetState())
+	assert.Nil(t, component.GetRelatedTo())
+	assert.Empty(t, component.GetResults())
+}
+
+func TestSearchComponent_NoRelatedTracks(t *testing.T) {
+	component := search.NewSearchComponent(&testutil.MockSoundCloudClient{})
+	component.SetSize(120, 40)
+
+	cmd := component.BrowseRelated(soundcloud.Track{ID: 5, Title: "Lonely"})
+	component.Update(cmd())
+
+	assert.Equal(t, search.StateResults, component.GetState())
+	assert.Contains(t, component.View(), "No related tracks found for: Lonely")
+}
+
+func TestApp_RadioRefillsQueueWithRelatedTracks(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	client := &testutil.MockSoundCloudClient{
+		RelatedFunc: func(trackID int64) ([]soundcloud.Track, error) {
+			return []soundcloud.Track{
+				{ID: trackID, Title: "Seed again"},
+				{ID: 2, Title: "Related"},
+				{ID: 3, Title: "Also related"},
+			}, nil
+		},
+	}
+	application := app.NewAppWithDependencies(client, testutil.NewMockAudioPlayer(), &testutil.MockStreamExtractor{})
+	application.Update(search.AddToQueueMsg{Track: soundcloud.Track{ID: 1, Title: "Seed"}})
+	application.Update(player.NextTrackMsg{})
+	application.GetQueueComponent().SetRadio(true)
+
+	_, cmd := application.Update(player.NextTrackMsg{})
+	require.NotNil(t, cmd)
+	application.Update(cmd())
+
+	queued := application.GetQueueComponent().GetTracks()
+	require.Len(t, queued, 3, "the seed should not be queued twice")
+	assert.Equal(t, int64(2), application.GetPlayerComponent().GetCurrentTrack().ID)
+	assert.Contains(t, application.GetNotification(), "added 2 related tracks")
+}
+
+func TestApp_RadioWithoutRelatedTracks(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	application := app.NewAppWithDependencies(&testutil.MockSoundCloudClient{}, testutil.NewMockAudioPlayer(), &testutil.MockStreamExtractor{})
+	application.Update(search.AddToQueueMsg{Track: soundcloud.Track{ID: 1, Title: "Seed"}})
+	application.Update(player.NextTrackMsg{})
+	application.GetQueueComponent().SetRadio(true)
+
+	_, cmd := application.Update(player.NextTrackMsg{})
+	require.NotNil(t, cmd)
+	application.Update(cmd())
+
+	assert.Equal(t, 1, application.GetQueueComponent().Len())
+	assert.Contains(t, application.GetNotification(), "no related tracks")
+}
+
+func TestApp_RadioContinuesAfterTrackWithEmptyQueue(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	var seeds []int64
+	client := &testutil.MockSoundCloudClient{
+		RelatedFunc: func(trackID int64) ([]soundcloud.Track, error) {
+			seeds = append(seeds, trackID)
+			return []soundcloud.Track{{ID: 2, Title: "Related"}}, nil
+		},
+	}
+	application := app.NewAppWithDependencies(client, testutil.NewMockAudioPlayer(), &testutil.MockStreamExtractor{})
+	application.GetQueueComponent().SetRadio(true)
+
+	seed := &soundcloud.Track{ID: 1, Title: "From search"}
+	application.Update(player.PlaybackStartedMsg{Track: seed})
+	_, cmd := application.Update(player.PlaybackCompletedMsg{Track: seed})
+	require.NotNil(t, cmd)
+	application.Update(cmd())
+
+	assert.Equal(t, []int64{1}, seeds)
+	assert.Equal(t, 1, application.GetQueueComponent().Len())
+	assert.Equal(t, int64(2), application.GetPlayerComponent().GetCurrentTrack().ID)
+}
+
+func TestApp_RadioOffLeavesFinishedTrackAlone(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	application := app.NewAppWithDependencies(&testutil.MockSoundCloudClient{}, testutil.NewMockAudioPlayer(), &testutil.MockStreamExtractor{})
+
+	_, cmd := application.Update(player.PlaybackCompletedMsg{Track: &soundcloud.Track{ID: 1}})
+
+	assert.Nil(t, cmd)
+}
+
+func TestApp_RadioSkipsPlayedTracks(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	client := &testutil.MockSoundCloudClient{
+		RelatedFunc: func(trackID int64) ([]soundcloud.Track, error) {
+			return []soundcloud.Track{
+				{ID: 1, Title: "Played earlier"},
+				{ID: 3, Title: "New"},
+			}, nil
+		},
+	}
+	application := app.NewAppWithDependencies(client, testutil.NewMockAudioPlayer(), &testutil.MockStreamExtractor{})
+	application.GetQueueComponent().SetRadio(true)
+
+	// Track 1 played, then the queue was cleared before track 2 finished
+	application.Update(player.PlaybackStartedMsg{Track: &soundcloud.Track{ID: 1}})
+	seed := &soundcloud.Track{ID: 2, Title: "Seed"}
+	application.Update(player.PlaybackStartedMsg{Track: seed})
+	_, cmd := application.Update(player.PlaybackCompletedMsg{Track: seed})
+	require.NotNil(t, cmd)
+	application.Update(cmd())
+
+	queued := application.GetQueueComponent().GetTracks()
+	require.Len(t, queued, 1)
+	assert.Equal(t, int64(3), queued[0].ID)
+}
+
+func TestApp_RadioStopsWhenNothingNew(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	client := &testutil.MockSoundCloudClient{
+		RelatedFunc: func(trackID int64) ([]soundcloud.Track, error) {
+			return []soundcloud.Track{{ID: 1, Title: "Played earlier"}}, nil
+		},
+	}
+	application := app.NewAppWithDependencies(client, testutil.NewMockAudioPlayer(), &testutil.MockStreamExtractor{})
+	application.GetQueueComponent().SetRadio(true)
+
+	application.Update(player.PlaybackStartedMsg{Track: &soundcloud.Track{ID: 1}})
+	seed := &soundcloud.Track{ID: 2, Title: "Seed"}
+	application.Update(player.PlaybackStartedMsg{Track: seed})
+	_, cmd := application.Update(player.PlaybackCompletedMsg{Track: seed})
+	require.NotNil(t, cmd)
+	application.Update(cmd())
+
+	assert.Equal(t, 0, application.GetQueueComponent().Len())
+	assert.Contains(t, application.GetNotification(), "no related tracks")
+}
+
+func TestApp_FooterShowsRadio(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	application := app.NewAppWithDependencies(&testutil.MockSoundCloudClient{}, testutil.NewMockAudioPlayer(), &testutil.MockStreamExtractor{})
+	application.Update(tea.WindowSizeMsg{Width: 300, Height: 40})
+
+	assert.NotContains(t, application.View(), "Radio: on")
+
+	application.GetQueueComponent().SetRadio(true)
+	assert.Contains(t, application.View(), "Radio: on")
+}
@@ -0,0 +1,140 @@
+package ui_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/components/player"
+)
+
+func TestPlayerComponent_RestartTrack(t *testing.T) {
+	tests := []struct {
+		name       string
+		audioState audio.PlayerState
+		uiState    player.State
+	}{
+		{"playing", audio.StatePlaying, player.StatePlaying},
+		{"paused", audio.StatePaused, player.StatePaused},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPlayer := &testutil.MockAudioPlayer{
+				State:    tt.audioState,
+				Duration: 200 * time.Second,
+				Position: 95 * time.Second,
+			}
+			component := playingComponent(mockPlayer)
+			component.SetState(tt.uiState)
+			component.Update(player.ProgressUpdateMsg{Position: 95 * time.Second, Duration: 200 * time.Second})
+
+			_, cmd := component.Update(runeKey("r"))
+			require.NotNil(t, cmd)
+
+			// The progress display resets before the seek completes
+			assert.Equal(t, time.Duration(0), component.GetPosition())
+
+			component.Update(cmd())
+			assert.Equal(t, time.Duration(0), mockPlayer.GetPosition())
+			assert.Equal(t, time.Duration(0), component.GetPosition())
+			assert.Equal(t, tt.uiState, component.GetState())
+			assert.Equal(t, tt.audioState, mockPlayer.GetState())
+		})
+	}
+}
+
+func TestPlayerComponent_RestartIgnoredWithoutTrack(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{Duration: 200 * time.Second, Position: 10 * time.Second}
+	component := player.NewPlayerComponent(mockPlayer, &testutil.MockStreamExtractor{})
+
+	_, cmd := component.Update(runeKey("r"))
+
+	assert.Nil(t, cmd)
+	assert.Equal(t, 10*time.Second, mockPlayer.GetPosition())
+}
+
+// countingExtractor counts stream extractions
+func countingExtractor(calls *int) *testutil.MockStreamExtractor {
+	return &testutil.MockStreamExtractor{
+		ExtractFunc: func(ctx context.Context, trackID int64) (*audio.StreamInfo, error) {
+			*calls++
+			return &audio.StreamInfo{URL: "https://example.com/stream.mp3", Duration: 200000}, nil
+		},
+	}
+}
+
+func TestPlayerComponent_ReplayReusesLoadedStream(t *testing.T) {
+	extractions := 0
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePaused, Duration: 200 * time.Second, Position: 95 * time.Second}
+	component := player.NewPlayerComponent(mockPlayer, countingExtractor(&extractions))
+	component.SetCurrentTrack(&soundcloud.Track{ID: 1, Title: "Loaded"})
+	component.SetState(player.StatePaused)
+
+	_, cmd := component.Update(runeKey("b"))
+	assert.Equal(t, time.Duration(0), component.GetPosition(), "the jump should show right away")
+	for _, msg := range quickMsgs(cmd) {
+		component.Update(msg)
+	}
+
+	assert.Zero(t, extractions, "a loaded stream should not be extracted again")
+	assert.Equal(t, 1, mockPlayer.CallCount("Seek"))
+	assert.Equal(t, 1, mockPlayer.CallCount("Resume"), "replay should play a paused track")
+	assert.Zero(t, mockPlayer.CallCount("Play"))
+	assert.Equal(t, time.Duration(0), mockPlayer.GetPosition())
+	assert.Equal(t, player.StatePlaying, component.GetState())
+}
+
+func TestPlayerComponent_ReplayReloadsFinishedOrStoppedTrack(t *testing.T) {
+	tests := []struct {
+		name    string
+		uiState player.State
+	}{
+		{"finished", player.StateCompleted},
+		{"stopped early", player.StatePlaying},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extractions := 0
+			mockPlayer := &testutil.MockAudioPlayer{State: audio.StateStopped, Duration: 200 * time.Second, Position: 120 * time.Second}
+			component := player.NewPlayerComponent(mockPlayer, countingExtractor(&extractions))
+			component.SetCurrentTrack(&soundcloud.Track{ID: 1, Title: "Stopped"})
+			component.SetState(tt.uiState)
+
+			_, cmd := component.Update(runeKey("b"))
+
+			assert.Equal(t, time.Duration(0), component.GetPosition(), "the jump should show right away")
+			assert.Equal(t, player.StateLoading, component.GetState())
+			for _, msg := range quickMsgs(cmd) {
+				_, next := component.Update(msg)
+				for _, msg := range quickMsgs(next) {
+					component.Update(msg)
+				}
+			}
+			assert.Equal(t, 1, extractions)
+			assert.Equal(t, 1, mockPlayer.CallCount("Play"))
+			assert.Equal(t, player.StatePlaying, component.GetState())
+		})
+	}
+}
+
+func TestPlayerComponent_RestartLeavesFinishedTrack(t *testing.T) {
+	extractions := 0
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StateStopped, Duration: 200 * time.Second, Position: 200 * time.Second}
+	component := player.NewPlayerComponent(mockPlayer, countingExtractor(&extractions))
+	component.SetCurrentTrack(&soundcloud.Track{ID: 1, Title: "Finished"})
+	component.SetState(player.StateCompleted)
+
+	_, cmd := component.Update(runeKey("r"))
+
+	assert.Nil(t, cmd, "r only seeks within a loaded stream; b plays a finished track again")
+	assert.Zero(t, extractions)
+	assert.Equal(t, player.StateCompleted, component.GetState())
+}
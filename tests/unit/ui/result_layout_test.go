@@ -0,0 +1,184 @@
+package ui_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/ui/components/search"
+	"soundcloud-tui/internal/ui/styles"
+)
+
+func manyTracks(count int) []soundcloud.Track {
+	tracks := make([]soundcloud.Track, count)
+	for i := range tracks {
+		tracks[i] = soundcloud.Track{
+			ID:            int64(i + 1),
+			Title:         fmt.Sprintf("Track %d", i+1),
+			Duration:      185000,
+			PlaybackCount: 1234,
+			LikesCount:    56,
+			User:          soundcloud.User{Username: "artist"},
+		}
+	}
+	return tracks
+}
+
+func TestSearchComponent_ToggleDetailedLayout(t *testing.T) {
+	styles.SetNoColor(true)
+	defer styles.SetNoColor(false)
+
+	component := searchWithResults(t, manyTracks(10))
+	component.SetSize(120, 12)
+
+	assert.False(t, component.IsDetailed())
+	assert.Contains(t, component.View(), "[1-4 of 10]")
+	assert.NotContains(t, component.View(), "1.2K plays")
+
+	component.Update(runeKey("v"))
+	assert.True(t, component.IsDetailed())
+	view := component.View()
+	assert.Contains(t, view, "artist • 3:05 • 1.2K plays • 56 likes")
+	assert.Contains(t, view, "[1-2 of 10]", "two-line rows halve the visible window")
+
+	component.Update(runeKey("v"))
+	assert.False(t, component.IsDetailed())
+}
+
+func TestSearchComponent_TinyTerminalShowsSelection(t *testing.T) {
+	component := searchWithResults(t, manyTracks(5))
+	component.SetSize(80, 4)
+	component.Update(runeKey("G"))
+
+	assert.Contains(t, component.View(), "Track 5")
+}
+
+func TestFormatCount(t *testing.T) {
+	tests := []struct {
+		count    int64
+		expected string
+	}{
+		{0, "0"},
+		{999, "999"},
+		{1000, "1K"},
+		{1234, "1.2K"},
+		{5600000, "5.6M"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, styles.FormatCount(tt.count))
+		})
+	}
+}
+
+func TestFormatThousands(t *testing.T) {
+	tests := []struct {
+		n        int64
+		expected string
+	}{
+		{0, "0"},
+		{999, "999"},
+		{1203, "1,203"},
+		{1234567, "1,234,567"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, styles.FormatThousands(tt.n))
+		})
+	}
+}
+
+func TestSearchComponent_ShowsTotalMatches(t *testing.T) {
+	component := searchWithResults(t, manyTracks(3))
+	component.SetSize(120, 20)
+	assert.Contains(t, component.View(), "3 found")
+
+	component.Update(search.SearchResultsMsg{Results: manyTracks(50), Total: 1203})
+	assert.Contains(t, component.View(), "showing 50 of 1,203")
+}
+
+func layoutTracks() []soundcloud.Track {
+	return []soundcloud.Track{
+		{ID: 1, Title: "Short", Duration: 185000, User: soundcloud.User{Username: "dj"}},
+		{ID: 2, Title: strings.Repeat("Very Long Extended Mix Title ", 8), Duration: 7325000, User: soundcloud.User{Username: strings.Repeat("Collective ", 10)}},
+	}
+}
+
+// resultRows returns the lines of view listing the layout tracks, without
+// the padding that joining them with the wider help line adds
+func resultRows(t *testing.T, view string) []string {
+	t.Helper()
+	var rows []string
+	for _, line := range strings.Split(view, "\n") {
+		if strings.Contains(line, "Short") || strings.Contains(line, "Very Long") {
+			rows = append(rows, strings.TrimRight(line, " "))
+		}
+	}
+	require.Len(t, rows, 2)
+	return rows
+}
+
+func TestSearchComponent_ResultRowsFitTerminalWidth(t *testing.T) {
+	styles.SetNoColor(true)
+	defer styles.SetNoColor(false)
+
+	for _, width := range []int{40, 80, 160} {
+		t.Run(fmt.Sprint(width), func(t *testing.T) {
+			component := searchWithResults(t, layoutTracks())
+			component.SetSize(width, 20)
+
+			for _, row := range resultRows(t, component.View()) {
+				assert.LessOrEqual(t, lipgloss.Width(row), width, "row overflows: %q", row)
+			}
+		})
+	}
+}
+
+func TestSearchComponent_NarrowResultRowsKeepDuration(t *testing.T) {
+	styles.SetNoColor(true)
+	defer styles.SetNoColor(false)
+
+	component := searchWithResults(t, layoutTracks())
+	component.SetSize(40, 20)
+
+	rows := resultRows(t, component.View())
+	assert.Contains(t, rows[0], "(3:05)")
+	assert.Contains(t, rows[1], "...")
+	assert.Contains(t, rows[1], "(122:05)")
+}
+
+func TestSearchComponent_WideResultRowsLimitPadding(t *testing.T) {
+	styles.SetNoColor(true)
+	defer styles.SetNoColor(false)
+
+	component := searchWithResults(t, layoutTracks())
+	component.SetSize(160, 20)
+
+	rows := resultRows(t, component.View())
+	title := strings.Index(rows[0], "Short")
+	artist := strings.Index(rows[0], " dj (3:05)")
+	require.True(t, title >= 0 && artist > title)
+	assert.Equal(t, search.DefaultMaxTitleWidth, artist-title, "the title column stops growing at its maximum")
+
+	assert.Contains(t, rows[1], "...")
+	assert.Less(t, lipgloss.Width(rows[1]), 160-30, "wide terminals leave the rest of the row empty")
+}
+
+func TestSearchComponent_ConfiguredTitleWidth(t *testing.T) {
+	styles.SetNoColor(true)
+	defer styles.SetNoColor(false)
+
+	component := searchWithResults(t, layoutTracks())
+	component.SetSize(160, 20)
+	component.SetTitleWidthRange(10, 30)
+
+	rows := resultRows(t, component.View())
+	assert.Equal(t, 30, strings.Index(rows[0], " dj (3:05)")-strings.Index(rows[0], "Short"))
+}
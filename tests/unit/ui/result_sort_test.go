@@ -0,0 +1,93 @@
+package ui_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/ui/components/search"
+)
+
+func sortableTracks() []soundcloud.Track {
+	day := func(d int) time.Time {
+		return time.Date(2024, time.January, d, 0, 0, 0, 0, time.UTC)
+	}
+	return []soundcloud.Track{
+		{ID: 1, Title: "Medium old", Duration: 200000, CreatedAt: day(1)},
+		{ID: 2, Title: "Long newest", Duration: 300000, CreatedAt: day(20)},
+		{ID: 3, Title: "Undated", Duration: 150000},
+		{ID: 4, Title: "Short recent", Duration: 100000, CreatedAt: day(10)},
+	}
+}
+
+// displayedIDs walks the results with Down and Enter to read their display order
+func displayedIDs(t *testing.T, component *search.SearchComponent) []int64 {
+	t.Helper()
+	component.Update(runeKey("g"))
+
+	var ids []int64
+	for range component.GetResults() {
+		component.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		require.NotNil(t, component.GetSelectedTrack())
+		ids = append(ids, component.GetSelectedTrack().ID)
+		component.ResetToResults()
+		component.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	return ids
+}
+
+func TestSearchComponent_SortByRelevanceKeepsAPIOrder(t *testing.T) {
+	component := searchWithResults(t, sortableTracks())
+
+	assert.Equal(t, search.SortRelevance, component.GetSortMode())
+	assert.Equal(t, []int64{1, 2, 3, 4}, displayedIDs(t, component))
+}
+
+func TestSearchComponent_SortByDuration(t *testing.T) {
+	component := searchWithResults(t, sortableTracks())
+
+	component.Update(runeKey("S"))
+
+	assert.Equal(t, search.SortDuration, component.GetSortMode())
+	assert.Equal(t, []int64{4, 3, 1, 2}, displayedIDs(t, component))
+	assert.Contains(t, component.View(), "duration first")
+}
+
+func TestSearchComponent_SortByNewest(t *testing.T) {
+	component := searchWithResults(t, sortableTracks())
+
+	component.Update(runeKey("S"))
+	component.Update(runeKey("S"))
+
+	assert.Equal(t, search.SortNewest, component.GetSortMode())
+	assert.Equal(t, []int64{2, 4, 1, 3}, displayedIDs(t, component), "undated tracks should sort last")
+}
+
+func TestSearchComponent_SortCyclesBackToRelevance(t *testing.T) {
+	component := searchWithResults(t, sortableTracks())
+
+	for i := 0; i < 3; i++ {
+		component.Update(runeKey("S"))
+	}
+
+	assert.Equal(t, search.SortRelevance, component.GetSortMode())
+	assert.Equal(t, []int64{1, 2, 3, 4}, displayedIDs(t, component))
+}
+
+func TestSearchComponent_SortKeepsResultsAndSelection(t *testing.T) {
+	component := searchWithResults(t, sortableTracks())
+	component.Update(tea.KeyMsg{Type: tea.KeyDown}) // "Long newest"
+
+	component.Update(runeKey("S"))
+
+	assert.Equal(t, 3, component.GetSelectedIndex(), "the highlighted track should stay highlighted")
+	ids := make([]int64, 0, 4)
+	for _, track := range component.GetResults() {
+		ids = append(ids, track.ID)
+	}
+	assert.Equal(t, []int64{1, 2, 3, 4}, ids, "the underlying results should keep their order")
+}
@@ -0,0 +1,69 @@
+package ui_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/config"
+	"soundcloud-tui/internal/session"
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/components/player"
+)
+
+func TestApp_ResumesSavedSession(t *testing.T) {
+	client := &testutil.MockSoundCloudClient{
+		TrackByIDFunc: func(id int64) (*soundcloud.Track, error) {
+			return &soundcloud.Track{ID: id, Title: "Half Heard", Duration: 300000}, nil
+		},
+	}
+	mockPlayer := testutil.NewMockAudioPlayer()
+	mockPlayer.Duration = 5 * time.Minute
+	application := createTestApp(t, client, mockPlayer)
+
+	store := session.NewStore(filepath.Join(config.ConfigDir(), session.FileName))
+	require.NoError(t, store.SaveSession(77, 2*time.Minute))
+
+	settle(application, application.Init())
+	assert.Contains(t, application.View(), "Press Enter to resume Half Heard at 2:00")
+
+	_, cmd := application.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	track := application.GetPlayerComponent().GetCurrentTrack()
+	require.NotNil(t, track)
+	assert.Equal(t, int64(77), track.ID)
+
+	var streamInfo tea.Msg
+	for _, msg := range quickMsgs(cmd) {
+		if _, ok := msg.(player.StreamInfoMsg); ok {
+			streamInfo = msg
+		}
+	}
+	require.NotNil(t, streamInfo)
+
+	_, cmd = application.Update(streamInfo)
+	quickMsgs(cmd)
+
+	seek, ok := mockPlayer.LastCall("Seek")
+	require.True(t, ok, "playback should continue from the saved position")
+	assert.Equal(t, []interface{}{2 * time.Minute}, seek.Args)
+}
+
+func TestApp_DismissesResumeOffer(t *testing.T) {
+	application := createTestApp(t, nil, nil)
+
+	store := session.NewStore(filepath.Join(config.ConfigDir(), session.FileName))
+	require.NoError(t, store.SaveSession(77, 2*time.Minute))
+
+	settle(application, application.Init())
+	require.Contains(t, application.View(), "Press Enter to resume")
+
+	application.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	assert.NotContains(t, application.View(), "Press Enter to resume")
+	assert.Nil(t, application.GetPlayerComponent().GetCurrentTrack())
+}
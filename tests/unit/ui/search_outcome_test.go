@@ -0,0 +1,91 @@
+package ui_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/components/search"
+)
+
+// searchFor types query into a new search component backed by client, runs
+// the search and delivers its result
+func searchFor(t *testing.T, client *testutil.MockSoundCloudClient, query string) *search.SearchComponent {
+	t.Helper()
+	component := search.NewSearchComponent(client)
+	component.SetSize(100, 30)
+	for _, char := range query {
+		component.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{char}})
+	}
+
+	_, cmd := component.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	var delivered bool
+	for _, msg := range quickMsgs(cmd) {
+		if results, ok := msg.(search.SearchResultsMsg); ok {
+			component.Update(results)
+			delivered = true
+		}
+	}
+	require.True(t, delivered, "search should deliver results")
+	return component
+}
+
+func TestSearchComponent_NoMatchesShowsEmptyResults(t *testing.T) {
+	for name, err := range map[string]error{
+		"no results error": fmt.Errorf("%w for %q", soundcloud.ErrNoResults, "zzz"),
+		"empty success":    nil,
+	} {
+		t.Run(name, func(t *testing.T) {
+			client := &testutil.MockSoundCloudClient{
+				SearchFunc: func(query string) ([]soundcloud.Track, error) {
+					return []soundcloud.Track{}, err
+				},
+			}
+
+			component := searchFor(t, client, "zzz")
+
+			assert.Equal(t, search.StateResults, component.GetState())
+			assert.NoError(t, component.GetError())
+			view := stripANSI(component.View())
+			assert.Contains(t, view, "No results found for: zzz")
+			assert.NotContains(t, view, "Search Error")
+		})
+	}
+}
+
+func TestSearchComponent_FailedSearchShowsError(t *testing.T) {
+	client := &testutil.MockSoundCloudClient{
+		SearchFunc: func(query string) ([]soundcloud.Track, error) {
+			return []soundcloud.Track{}, fmt.Errorf("%w: %w", soundcloud.ErrSearchUnavailable, errors.New("503"))
+		},
+	}
+
+	component := searchFor(t, client, "lofi")
+
+	assert.Equal(t, search.StateError, component.GetState())
+	assert.ErrorIs(t, component.GetError(), soundcloud.ErrSearchUnavailable)
+	assert.Empty(t, component.GetResults())
+	view := stripANSI(component.View())
+	assert.Contains(t, view, "Search Error")
+	assert.Contains(t, view, "Search failed for: lofi")
+	assert.NotContains(t, view, "No results found")
+}
+
+func TestSearchComponent_ErrorWinsOverPartialResults(t *testing.T) {
+	component := search.NewSearchComponent(nil)
+
+	component.Update(search.SearchResultsMsg{
+		Results: []soundcloud.Track{{ID: 1, Title: "Partial"}},
+		Error:   assert.AnError,
+		Total:   10,
+	})
+
+	assert.Equal(t, search.StateError, component.GetState())
+	assert.Empty(t, component.GetResults())
+}
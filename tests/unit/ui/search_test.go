@@ -7,7 +7,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"soundcloud-tui/internal/history"
 	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
 	"soundcloud-tui/internal/ui/components/search"
 )
 
@@ -38,7 +40,7 @@ func TestSearchComponent_InputHandling(t *testing.T) {
 
 func TestSearchComponent_SearchExecution(t *testing.T) {
 	// Mock SoundCloud client
-	mockClient := &MockSoundCloudClient{
+	mockClient := &testutil.MockSoundCloudClient{
 		SearchFunc: func(query string) ([]soundcloud.Track, error) {
 			return []soundcloud.Track{
 				{
@@ -139,6 +141,65 @@ func TestSearchComponent_ResultNavigation(t *testing.T) {
 	assert.Equal(t, 1, component.GetSelectedIndex())
 }
 
+func TestSearchComponent_VimNavigation(t *testing.T) {
+	component := search.NewSearchComponent(nil)
+	
+	results := []soundcloud.Track{
+		{ID: 1, Title: "Track 1", User: soundcloud.User{Username: "Artist 1"}},
+		{ID: 2, Title: "Track 2", User: soundcloud.User{Username: "Artist 2"}},
+		{ID: 3, Title: "Track 3", User: soundcloud.User{Username: "Artist 3"}},
+		{ID: 4, Title: "Track 4", User: soundcloud.User{Username: "Artist 4"}},
+	}
+	updatedComponent, _ := component.Update(search.SearchResultsMsg{Results: results})
+	component = updatedComponent.(*search.SearchComponent)
+	
+	press := func(key string) {
+		updatedComponent, _ := component.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		component = updatedComponent.(*search.SearchComponent)
+	}
+	
+	// k at the top stays at the top
+	press("k")
+	assert.Equal(t, 0, component.GetSelectedIndex())
+	
+	// j moves down
+	press("j")
+	assert.Equal(t, 1, component.GetSelectedIndex())
+	
+	// G jumps to the bottom
+	press("G")
+	assert.Equal(t, 3, component.GetSelectedIndex())
+	
+	// j at the bottom stays at the bottom
+	press("j")
+	assert.Equal(t, 3, component.GetSelectedIndex())
+	
+	// k moves up
+	press("k")
+	assert.Equal(t, 2, component.GetSelectedIndex())
+	
+	// g jumps to the top
+	press("g")
+	assert.Equal(t, 0, component.GetSelectedIndex())
+	
+	// Navigation keys must not leak into the query
+	assert.Equal(t, "", component.GetQuery())
+	assert.Equal(t, search.StateResults, component.GetState())
+}
+
+func TestSearchComponent_VimKeysTypeInInputState(t *testing.T) {
+	component := search.NewSearchComponent(nil)
+	
+	for _, char := range "jkgG" {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{char}}
+		updatedComponent, _ := component.Update(msg)
+		component = updatedComponent.(*search.SearchComponent)
+	}
+	
+	assert.Equal(t, "jkgG", component.GetQuery())
+	assert.Equal(t, search.StateInput, component.GetState())
+}
+
 func TestSearchComponent_TrackSelection(t *testing.T) {
 	component := search.NewSearchComponent(nil)
 	
@@ -166,6 +227,68 @@ func TestSearchComponent_TrackSelection(t *testing.T) {
 	assert.Equal(t, "Selected Track", selectedTrack.Title)
 }
 
+func TestSearchComponent_HistoryNavigation(t *testing.T) {
+	searchHistory := history.NewStore("", 10)
+	searchHistory.Add("older")
+	searchHistory.Add("newer")
+	
+	component := search.NewSearchComponent(nil)
+	component.SetHistory(searchHistory)
+	
+	press := func(keyType tea.KeyType) {
+		updatedComponent, _ := component.Update(tea.KeyMsg{Type: keyType})
+		component = updatedComponent.(*search.SearchComponent)
+	}
+	
+	// Up from an empty input recalls the newest query first
+	press(tea.KeyUp)
+	assert.Equal(t, "newer", component.GetQuery())
+	
+	press(tea.KeyUp)
+	assert.Equal(t, "older", component.GetQuery())
+	
+	// Up at the oldest entry stays there
+	press(tea.KeyUp)
+	assert.Equal(t, "older", component.GetQuery())
+	
+	// Down walks back to an empty input
+	press(tea.KeyDown)
+	assert.Equal(t, "newer", component.GetQuery())
+	press(tea.KeyDown)
+	assert.Equal(t, "", component.GetQuery())
+	assert.Equal(t, search.StateInput, component.GetState())
+}
+
+func TestSearchComponent_HistoryIgnoredWhileTyping(t *testing.T) {
+	searchHistory := history.NewStore("", 10)
+	searchHistory.Add("previous")
+	
+	component := search.NewSearchComponent(nil)
+	component.SetHistory(searchHistory)
+	
+	updatedComponent, _ := component.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("draft")})
+	component = updatedComponent.(*search.SearchComponent)
+	
+	// A non-empty draft is not replaced by history
+	updatedComponent, _ = component.Update(tea.KeyMsg{Type: tea.KeyUp})
+	component = updatedComponent.(*search.SearchComponent)
+	assert.Equal(t, "draft", component.GetQuery())
+}
+
+func TestSearchComponent_SearchAddsToHistory(t *testing.T) {
+	searchHistory := history.NewStore("", 10)
+	
+	component := search.NewSearchComponent(nil)
+	component.SetHistory(searchHistory)
+	
+	updatedComponent, _ := component.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("lofi")})
+	component = updatedComponent.(*search.SearchComponent)
+	updatedComponent, _ = component.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	component = updatedComponent.(*search.SearchComponent)
+	
+	assert.Equal(t, []string{"lofi"}, searchHistory.Recent())
+}
+
 func TestSearchComponent_ErrorHandling(t *testing.T) {
 	component := search.NewSearchComponent(nil)
 	
@@ -255,19 +378,3 @@ func TestSearchComponent_ViewRendering(t *testing.T) {
 	view = component.View()
 	assert.Contains(t, view, "Test Track") // Should show results
 }
-
-// Mock SoundCloud client for testing
-type MockSoundCloudClient struct {
-	SearchFunc func(query string) ([]soundcloud.Track, error)
-}
-
-func (m *MockSoundCloudClient) Search(query string) ([]soundcloud.Track, error) {
-	if m.SearchFunc != nil {
-		return m.SearchFunc(query)
-	}
-	return []soundcloud.Track{}, nil
-}
-
-func (m *MockSoundCloudClient) GetTrackInfo(url string) (*soundcloud.Track, error) {
-	return nil, nil
-}
\ No newline at end of file
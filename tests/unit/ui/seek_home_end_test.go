@@ -0,0 +1,87 @@
+package ui_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/components/player"
+)
+
+func TestPlayerComponent_HomeEndSeek(t *testing.T) {
+	tests := []struct {
+		name       string
+		key        tea.KeyType
+		duration   time.Duration
+		expected   time.Duration
+		audioState audio.PlayerState
+		uiState    player.State
+	}{
+		{"home while playing", tea.KeyHome, 2 * time.Hour, 0, audio.StatePlaying, player.StatePlaying},
+		{"home while paused", tea.KeyHome, 2 * time.Hour, 0, audio.StatePaused, player.StatePaused},
+		{"end while playing", tea.KeyEnd, 2 * time.Hour, 2*time.Hour - 10*time.Second, audio.StatePlaying, player.StatePlaying},
+		{"end while paused", tea.KeyEnd, 2 * time.Hour, 2*time.Hour - 10*time.Second, audio.StatePaused, player.StatePaused},
+		{"end of short track", tea.KeyEnd, 6 * time.Second, 0, audio.StatePlaying, player.StatePlaying},
+		{"end of track as long as the margin", tea.KeyEnd, 10 * time.Second, 0, audio.StatePlaying, player.StatePlaying},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPlayer := &testutil.MockAudioPlayer{
+				State:    tt.audioState,
+				Duration: tt.duration,
+				Position: 3 * time.Second,
+			}
+			component := playingComponent(mockPlayer)
+			component.SetState(tt.uiState)
+
+			_, cmd := component.Update(tea.KeyMsg{Type: tt.key})
+			require.NotNil(t, cmd)
+
+			msg := cmd()
+			progress, ok := msg.(player.ProgressUpdateMsg)
+			require.True(t, ok, "seek should succeed, got %v", msg)
+			component.Update(progress)
+
+			assert.Equal(t, tt.expected, progress.Position)
+			assert.Equal(t, tt.expected, mockPlayer.GetPosition())
+			assert.Equal(t, tt.uiState, component.GetState())
+			assert.Equal(t, tt.audioState, mockPlayer.GetState())
+		})
+	}
+}
+
+func TestPlayerComponent_EndSeekIgnoredWithoutDuration(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePlaying}
+	component := playingComponent(mockPlayer)
+
+	_, cmd := component.Update(tea.KeyMsg{Type: tea.KeyEnd})
+
+	assert.Nil(t, cmd)
+}
+
+func TestPlayerComponent_HomeEndIgnoredOnFinishedTrack(t *testing.T) {
+	for _, key := range []tea.KeyType{tea.KeyHome, tea.KeyEnd} {
+		t.Run(tea.KeyMsg{Type: key}.String(), func(t *testing.T) {
+			extractions := 0
+			mockPlayer := &testutil.MockAudioPlayer{State: audio.StateStopped, Duration: 200 * time.Second, Position: 200 * time.Second}
+			component := player.NewPlayerComponent(mockPlayer, countingExtractor(&extractions))
+			component.SetCurrentTrack(&soundcloud.Track{ID: 1, Title: "Finished"})
+			component.SetState(player.StateCompleted)
+
+			_, cmd := component.Update(tea.KeyMsg{Type: key})
+			quickMsgs(cmd)
+
+			assert.Zero(t, extractions, "the track should not be extracted again")
+			assert.Zero(t, mockPlayer.CallCount("Play"))
+			assert.Zero(t, mockPlayer.CallCount("Seek"))
+			assert.Equal(t, player.StateCompleted, component.GetState())
+		})
+	}
+}
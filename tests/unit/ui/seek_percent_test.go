@@ -0,0 +1,72 @@
+package ui_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/components/player"
+)
+
+func playingComponent(mockPlayer *testutil.MockAudioPlayer) *player.PlayerComponent {
+	component := player.NewPlayerComponent(mockPlayer, &testutil.MockStreamExtractor{})
+	component.SetCurrentTrack(&soundcloud.Track{ID: 1, Title: "Seekable"})
+	component.SetState(player.StatePlaying)
+	return component
+}
+
+func TestPlayerComponent_DigitSeeksToPercentage(t *testing.T) {
+	tests := []struct {
+		key      string
+		expected time.Duration
+	}{
+		{"0", 0},
+		{"1", 20 * time.Second},
+		{"5", 100 * time.Second},
+		{"9", 180 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			mockPlayer := &testutil.MockAudioPlayer{
+				State:    audio.StatePlaying,
+				Duration: 200 * time.Second,
+				Position: 42 * time.Second,
+			}
+			component := playingComponent(mockPlayer)
+
+			_, cmd := component.Update(runeKey(tt.key))
+			require.NotNil(t, cmd)
+
+			msg := cmd()
+			progress, ok := msg.(player.ProgressUpdateMsg)
+			require.True(t, ok, "seek should succeed, got %v", msg)
+			assert.Equal(t, tt.expected, progress.Position)
+			assert.Equal(t, tt.expected, mockPlayer.GetPosition())
+		})
+	}
+}
+
+func TestPlayerComponent_DigitSeekIgnoredWithoutDuration(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePlaying}
+	component := playingComponent(mockPlayer)
+
+	_, cmd := component.Update(runeKey("5"))
+
+	assert.Nil(t, cmd)
+}
+
+func TestPlayerComponent_DigitSeekIgnoredWithoutTrack(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{Duration: 200 * time.Second}
+	component := player.NewPlayerComponent(mockPlayer, &testutil.MockStreamExtractor{})
+
+	_, cmd := component.Update(runeKey("5"))
+
+	assert.Nil(t, cmd)
+	assert.Equal(t, time.Duration(0), mockPlayer.GetPosition())
+}
@@ -0,0 +1,164 @@
+package ui_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/components/player"
+	"soundcloud-tui/internal/ui/styles"
+)
+
+func TestPlayerComponent_ScrubbingAccumulatesPreview(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:    audio.StatePlaying,
+		Duration: 200 * time.Second,
+		Position: 60 * time.Second,
+	}
+	component := playingComponent(mockPlayer)
+	component.Update(player.ProgressUpdateMsg{Position: 60 * time.Second, Duration: 200 * time.Second})
+
+	var ticks []tea.Cmd
+	for i := 0; i < 3; i++ {
+		_, cmd := component.Update(tea.KeyMsg{Type: tea.KeyRight})
+		require.NotNil(t, cmd)
+		ticks = append(ticks, cmd)
+	}
+
+	// Nothing is seeked while the keys are held
+	require.NotNil(t, component.GetSeekPreview())
+	assert.Equal(t, 90*time.Second, *component.GetSeekPreview())
+	assert.Equal(t, 60*time.Second, mockPlayer.GetPosition())
+	assert.Contains(t, component.View(), "1:30")
+
+	// Only the last tick commits
+	_, cmd := component.Update(ticks[0]())
+	assert.Nil(t, cmd)
+	_, cmd = component.Update(ticks[2]())
+	require.NotNil(t, cmd)
+
+	progress := cmd()
+	assert.Equal(t, 90*time.Second, mockPlayer.GetPosition())
+	require.NotNil(t, component.GetSeekPreview(), "preview stays until progress arrives")
+
+	component.Update(progress)
+	assert.Nil(t, component.GetSeekPreview())
+	assert.Equal(t, 90*time.Second, component.GetPosition())
+}
+
+func TestPlayerComponent_RapidScrubbingSeeksOnce(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:    audio.StatePlaying,
+		Duration: 200 * time.Second,
+		Position: 60 * time.Second,
+	}
+	component := playingComponent(mockPlayer)
+	component.Update(player.ProgressUpdateMsg{Position: 60 * time.Second, Duration: 200 * time.Second})
+
+	// Net +30s: five forward, two back
+	keys := []tea.KeyType{tea.KeyRight, tea.KeyRight, tea.KeyLeft, tea.KeyRight, tea.KeyRight, tea.KeyLeft, tea.KeyRight}
+	var ticks []tea.Cmd
+	for _, key := range keys {
+		_, cmd := component.Update(tea.KeyMsg{Type: key})
+		require.NotNil(t, cmd)
+		ticks = append(ticks, cmd)
+	}
+	require.NotNil(t, component.GetSeekPreview())
+	assert.Equal(t, 90*time.Second, *component.GetSeekPreview(), "the preview shows the target right away")
+
+	// Every tick fires once the keys go idle, but only the last one seeks
+	msgs := make([]tea.Msg, len(ticks))
+	var wg sync.WaitGroup
+	for i, tick := range ticks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			msgs[i] = tick()
+		}()
+	}
+	wg.Wait()
+
+	var seeks []tea.Cmd
+	for _, msg := range msgs {
+		if _, cmd := component.Update(msg); cmd != nil {
+			seeks = append(seeks, cmd)
+		}
+	}
+	require.Len(t, seeks, 1)
+	component.Update(seeks[0]())
+
+	assert.Equal(t, 1, mockPlayer.CallCount("Seek"))
+	assert.Equal(t, 90*time.Second, mockPlayer.GetPosition())
+}
+
+func TestPlayerComponent_StaleProgressKeepsSeekPreview(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:    audio.StatePlaying,
+		Duration: 200 * time.Second,
+		Position: 60 * time.Second,
+	}
+	component := playingComponent(mockPlayer)
+	component.Update(player.ProgressUpdateMsg{Position: 60 * time.Second, Duration: 200 * time.Second})
+	component.SetTickInterval(time.Millisecond)
+	progressTick := component.Init()
+
+	_, scrubTick := component.Update(tea.KeyMsg{Type: tea.KeyRight})
+	_, seek := component.Update(scrubTick())
+	require.NotNil(t, seek)
+
+	// A progress tick read before the seek landed still has the old position
+	component.Update(progressTick())
+	require.NotNil(t, component.GetSeekPreview(), "a stale tick must not drop the pending target")
+	assert.Equal(t, 70*time.Second, *component.GetSeekPreview())
+
+	component.Update(seek())
+	assert.Nil(t, component.GetSeekPreview())
+	assert.Equal(t, 70*time.Second, component.GetPosition())
+}
+
+func TestPlayerComponent_ScrubbingClampsToTrack(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:    audio.StatePlaying,
+		Duration: 200 * time.Second,
+	}
+	component := playingComponent(mockPlayer)
+	component.Update(player.ProgressUpdateMsg{Position: 5 * time.Second, Duration: 200 * time.Second})
+
+	component.Update(tea.KeyMsg{Type: tea.KeyLeft})
+
+	require.NotNil(t, component.GetSeekPreview())
+	assert.Equal(t, time.Duration(0), *component.GetSeekPreview())
+}
+
+func TestPlayerComponent_DirectSeekCancelsPreview(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:    audio.StatePlaying,
+		Duration: 200 * time.Second,
+	}
+	component := playingComponent(mockPlayer)
+	component.Update(player.ProgressUpdateMsg{Position: 60 * time.Second, Duration: 200 * time.Second})
+
+	_, tick := component.Update(tea.KeyMsg{Type: tea.KeyRight})
+	component.Update(runeKey("5"))
+	assert.Nil(t, component.GetSeekPreview())
+
+	// The stale scrub tick no longer seeks
+	_, cmd := component.Update(tick())
+	assert.Nil(t, cmd)
+}
+
+func TestRenderSeekPreviewBar(t *testing.T) {
+	styles.SetNoColor(true)
+	defer styles.SetNoColor(false)
+
+	assert.Equal(t, "##--|-----", styles.RenderSeekPreviewBar(10, 0.2, 0.4))
+	assert.Equal(t, "#####----|", styles.RenderSeekPreviewBar(10, 0.5, 1.0))
+	assert.Equal(t, "|####-----", styles.RenderSeekPreviewBar(10, 0.5, 0))
+	assert.Equal(t, "", styles.RenderSeekPreviewBar(0, 0.5, 0.5))
+}
@@ -0,0 +1,87 @@
+package ui_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/config"
+	"soundcloud-tui/internal/history"
+	"soundcloud-tui/internal/session"
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/app"
+	"soundcloud-tui/internal/ui/components/player"
+)
+
+func TestApp_CtrlCStopsAudioAndSavesState(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePlaying}
+	application := app.NewAppWithDependencies(&testutil.MockSoundCloudClient{}, mockPlayer, &testutil.MockStreamExtractor{})
+
+	_, cmd := application.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	require.NotNil(t, cmd)
+	assert.True(t, application.IsQuitting())
+	assert.Equal(t, 0, mockPlayer.CallCount("Close"), "teardown should run in the command, not in Update")
+
+	assert.Equal(t, tea.Quit(), cmd())
+	assert.Equal(t, 1, mockPlayer.CallCount("Close"))
+	assert.Equal(t, audio.StateStopped, mockPlayer.GetState())
+
+	assert.FileExists(t, config.SettingsPath())
+	_, err := os.Stat(filepath.Join(config.ConfigDir(), history.SearchFileName))
+	assert.NoError(t, err, "search history should be flushed on quit")
+}
+
+func TestApp_RepeatedCtrlCClosesPlayerOnce(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePlaying}
+	application := app.NewAppWithDependencies(&testutil.MockSoundCloudClient{}, mockPlayer, &testutil.MockStreamExtractor{})
+
+	_, first := application.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	_, second := application.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	require.NotNil(t, first)
+	require.NotNil(t, second)
+
+	assert.Equal(t, tea.Quit(), first())
+	assert.Equal(t, tea.Quit(), second())
+	assert.Equal(t, 1, mockPlayer.CallCount("Close"))
+}
+
+func TestApp_ShutdownStopsAudioAndSavesSession(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePlaying}
+	application := app.NewAppWithDependencies(&testutil.MockSoundCloudClient{}, mockPlayer, &testutil.MockStreamExtractor{})
+	application.GetPlayerComponent().SetCurrentTrack(&soundcloud.Track{ID: 31, Title: "Interrupted"})
+	application.GetPlayerComponent().SetState(player.StatePlaying)
+	application.GetPlayerComponent().Update(player.ProgressUpdateMsg{Position: 90 * time.Second, Duration: 5 * time.Minute})
+
+	application.Shutdown()
+
+	assert.True(t, application.IsQuitting())
+	assert.Equal(t, 1, mockPlayer.CallCount("Close"))
+	saved, err := session.NewStore(filepath.Join(config.ConfigDir(), session.FileName)).LoadSession()
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.Equal(t, int64(31), saved.TrackID)
+	assert.Equal(t, 90*time.Second, saved.Position)
+	assert.FileExists(t, config.SettingsPath())
+}
+
+func TestApp_ShutdownAfterQuitDoesNotRepeatTeardown(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePlaying}
+	application := app.NewAppWithDependencies(&testutil.MockSoundCloudClient{}, mockPlayer, &testutil.MockStreamExtractor{})
+
+	_, cmd := application.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	cmd()
+	application.Shutdown()
+
+	assert.Equal(t, 1, mockPlayer.CallCount("Close"))
+}
@@ -0,0 +1,104 @@
+package ui_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/app"
+	"soundcloud-tui/internal/ui/components/player"
+	"soundcloud-tui/internal/ui/styles"
+)
+
+var tinySizes = [][2]int{{10, 3}, {1, 1}, {0, 0}, {80, 2}, {5, 40}}
+
+func TestPlayerComponent_TinyTerminal(t *testing.T) {
+	states := []player.State{player.StateIdle, player.StateLoading, player.StatePlaying, player.StatePaused, player.StateCompleted}
+
+	for _, size := range tinySizes {
+		for _, state := range states {
+			t.Run(fmt.Sprintf("%dx%d/%s", size[0], size[1], state), func(t *testing.T) {
+				mockPlayer := &testutil.MockAudioPlayer{
+					State:    audio.StatePlaying,
+					Duration: 200 * time.Second,
+					Position: 50 * time.Second,
+				}
+				component := playingComponent(mockPlayer)
+				component.SetState(state)
+				component.SetSize(size[0], size[1])
+
+				var view string
+				assert.NotPanics(t, func() { view = component.View() })
+				assert.NotEmpty(t, view)
+			})
+		}
+	}
+}
+
+func TestPlayerComponent_TooSmallMessage(t *testing.T) {
+	component := playingComponent(testutil.NewMockAudioPlayer())
+	component.SetSize(40, 5)
+
+	assert.Equal(t, styles.TooSmallMessage, stripANSI(component.View()))
+
+	component.SetSize(80, 20)
+	assert.NotContains(t, component.View(), styles.TooSmallMessage)
+}
+
+func TestSearchComponent_TinyTerminal(t *testing.T) {
+	for _, size := range tinySizes {
+		t.Run(fmt.Sprintf("%dx%d", size[0], size[1]), func(t *testing.T) {
+			component := searchWithResults(t, manyTracks(5))
+			component.SetSize(size[0], size[1])
+
+			var view string
+			assert.NotPanics(t, func() { view = component.View() })
+			assert.NotEmpty(t, view)
+
+			component.Update(runeKey("v"))
+			assert.NotPanics(t, func() { view = component.View() })
+			assert.NotEmpty(t, view)
+		})
+	}
+}
+
+func TestSearchComponent_TooSmallMessage(t *testing.T) {
+	component := searchWithResults(t, manyTracks(5))
+	component.SetSize(10, 20)
+
+	assert.Equal(t, "Terminal t", stripANSI(component.View()))
+}
+
+func TestApp_TinyTerminal(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	for _, size := range tinySizes {
+		for _, view := range []app.ViewType{app.ViewSearch, app.ViewPlayer, app.ViewQueue} {
+			t.Run(fmt.Sprintf("%dx%d/%s", size[0], size[1], view), func(t *testing.T) {
+				application := app.NewAppWithDependencies(&testutil.MockSoundCloudClient{}, testutil.NewMockAudioPlayer(), &testutil.MockStreamExtractor{})
+				application.Update(tea.WindowSizeMsg{Width: size[0], Height: size[1]})
+				application.SetCurrentView(view)
+
+				var rendered string
+				assert.NotPanics(t, func() { rendered = application.View() })
+				assert.NotEmpty(t, rendered)
+			})
+		}
+	}
+}
+
+func TestApp_TooSmallMessage(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	application := app.NewAppWithDependencies(&testutil.MockSoundCloudClient{}, testutil.NewMockAudioPlayer(), &testutil.MockStreamExtractor{})
+
+	application.Update(tea.WindowSizeMsg{Width: 60, Height: 4})
+	assert.Equal(t, styles.TooSmallMessage, stripANSI(application.View()))
+
+	application.Update(tea.WindowSizeMsg{Width: 60, Height: 24})
+	assert.Contains(t, application.View(), "SoundCloud TUI")
+}
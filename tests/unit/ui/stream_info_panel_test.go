@@ -0,0 +1,221 @@
+package ui_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/gopxl/beep"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/clipboard"
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/components/player"
+)
+
+const longStreamURL = "https://cf-media.sndcdn.com/abc123def.128.mp3?Policy=eyJTdGF0ZW1lbnQiOlt7IlJlc291cmNlIjoiaHR0cHM6Ly9jZi1tZWRpYS5zbmRjZG4uY29tIn1dfQ__&Signature=abc123def&Key-Pair-Id=APKAJ123DEF456"
+
+// streamingComponent returns a player that resolved the stream in info for a
+// track and is playing it
+func streamingComponent(mockPlayer *testutil.MockAudioPlayer, info *audio.StreamInfo) *player.PlayerComponent {
+	component := player.NewPlayerComponent(mockPlayer, &testutil.MockStreamExtractor{})
+	component.SetSize(120, 30)
+	component.Update(player.PlayTrackMsg{Track: &soundcloud.Track{ID: 1, Title: "Debugged"}})
+	component.Update(player.StreamInfoMsg{StreamInfo: info})
+	component.SetState(player.StatePlaying)
+	return component
+}
+
+func TestPlayerComponent_InfoPanelShowsStream(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:           audio.StatePlaying,
+		SampleRate:      44100,
+		BufferAvailable: 512 * 1024,
+		BufferTotal:     4 * 1024 * 1024,
+	}
+	component := streamingComponent(mockPlayer, &audio.StreamInfo{
+		URL:     longStreamURL,
+		Format:  audio.FormatMP3,
+		Quality: audio.QualityProgressive,
+		Bitrate: 128,
+	})
+
+	component.Update(runeKey("i"))
+	require.True(t, component.IsInfoPanelOpen())
+
+	view := stripANSI(component.View())
+	assert.Contains(t, view, "Stream Info")
+	assert.Contains(t, view, "https://cf-media.sndcdn.com/")
+	assert.NotContains(t, view, "Key-Pair-Id", "the URL should be truncated")
+	assert.Contains(t, view, "Format:      mp3")
+	assert.Contains(t, view, "Quality:     progressive")
+	assert.Contains(t, view, "Bitrate:     128 kbps")
+	assert.Contains(t, view, "Sample rate: 44100 Hz")
+	assert.Contains(t, view, "Buffer:      512 KB of 4096 KB ahead")
+}
+
+func TestPlayerComponent_InfoPanelMarksUnknowns(t *testing.T) {
+	component := streamingComponent(&testutil.MockAudioPlayer{State: audio.StatePlaying}, &audio.StreamInfo{URL: "/music/local.wav"})
+
+	component.Update(runeKey("i"))
+	view := stripANSI(component.View())
+
+	assert.Equal(t, 5, strings.Count(view, "unknown"), "format, quality, bitrate, sample rate and channels")
+	assert.Contains(t, view, "not buffered")
+}
+
+func TestPlayerComponent_InfoPanelWithoutStream(t *testing.T) {
+	component := player.NewPlayerComponent(testutil.NewMockAudioPlayer(), &testutil.MockStreamExtractor{})
+
+	component.Update(runeKey("i"))
+	assert.Contains(t, component.View(), "No stream loaded")
+
+	_, cmd := component.Update(runeKey("c"))
+	assert.Nil(t, cmd)
+}
+
+func TestPlayerComponent_InfoPanelCopiesFullStreamURL(t *testing.T) {
+	stub := &stubClipboard{}
+	component := streamingComponent(&testutil.MockAudioPlayer{State: audio.StatePlaying}, &audio.StreamInfo{URL: longStreamURL})
+	component.SetClipboard(stub)
+	component.Update(runeKey("i"))
+
+	_, cmd := component.Update(runeKey("c"))
+	require.NotNil(t, cmd)
+
+	assert.Equal(t, clipboard.CopiedMsg{Text: longStreamURL}, cmd())
+	assert.Equal(t, []string{longStreamURL}, stub.copied)
+	assert.True(t, component.IsInfoPanelOpen())
+}
+
+func TestPlayerComponent_InfoPanelCloses(t *testing.T) {
+	component := streamingComponent(&testutil.MockAudioPlayer{State: audio.StatePlaying}, &audio.StreamInfo{URL: longStreamURL})
+
+	component.Update(runeKey("i"))
+	component.Update(runeKey("i"))
+	assert.False(t, component.IsInfoPanelOpen())
+
+	component.Update(runeKey("i"))
+	component.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, component.IsInfoPanelOpen())
+
+	component.Update(runeKey("i"))
+	component.Update(runeKey("e"))
+	assert.False(t, component.IsInfoPanelOpen(), "opening the equalizer replaces the info panel")
+	assert.True(t, component.IsEQPanelOpen())
+}
+
+func TestPlayerComponent_FailedStreamClearsInfo(t *testing.T) {
+	component := player.NewPlayerComponent(testutil.NewMockAudioPlayer(), &testutil.MockStreamExtractor{})
+	component.SetSize(120, 30)
+	component.Update(player.PlayTrackMsg{Track: &soundcloud.Track{ID: 1}})
+	component.Update(player.StreamInfoMsg{StreamInfo: &audio.StreamInfo{URL: longStreamURL}})
+	component.SetState(player.StateIdle)
+	component.Update(player.PlayTrackMsg{Track: &soundcloud.Track{ID: 2}})
+	component.Update(player.StreamInfoMsg{Error: assert.AnError})
+
+	component.Update(runeKey("i"))
+	assert.Contains(t, component.View(), "No stream loaded")
+}
+
+func TestPlayerComponent_QualityBadge(t *testing.T) {
+	tests := []struct {
+		name     string
+		info     *audio.StreamInfo
+		expected string
+	}{
+		{"preset", &audio.StreamInfo{Format: audio.FormatMP3, Preset: "mp3_1_0"}, "[MP3 128]"},
+		{"opus preset", &audio.StreamInfo{Format: audio.FormatHLS, Preset: "opus_0_0"}, "[Opus 64]"},
+		{"format without preset", &audio.StreamInfo{Format: audio.FormatWAV}, "[WAV]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.info.URL = "https://cf-media.sndcdn.com/track"
+			component := streamingComponent(&testutil.MockAudioPlayer{State: audio.StatePlaying}, tt.info)
+
+			assert.Contains(t, stripANSI(component.View()), tt.expected)
+		})
+	}
+}
+
+func TestPlayerComponent_NoQualityBadgeWhenUnknown(t *testing.T) {
+	unresolved := playingComponent(&testutil.MockAudioPlayer{State: audio.StatePlaying})
+	unlabeled := streamingComponent(&testutil.MockAudioPlayer{State: audio.StatePlaying}, &audio.StreamInfo{URL: "/music/track"})
+
+	assert.NotContains(t, stripANSI(unresolved.View()), "[]")
+	assert.NotContains(t, stripANSI(unlabeled.View()), "[]")
+}
+
+// formatReportingPlayer is a mock audio player that reports stream formats
+type formatReportingPlayer struct {
+	*testutil.MockAudioPlayer
+	onFormatChange func(beep.Format)
+}
+
+func (p *formatReportingPlayer) SetFormatChangeCallback(callback func(beep.Format)) {
+	p.onFormatChange = callback
+}
+
+func TestPlayerComponent_ShowsReportedFormat(t *testing.T) {
+	mockPlayer := &formatReportingPlayer{MockAudioPlayer: &testutil.MockAudioPlayer{State: audio.StatePlaying, SampleRate: 44100}}
+	component := player.NewPlayerComponent(mockPlayer, &testutil.MockStreamExtractor{})
+	component.SetSize(120, 30)
+	component.Update(player.PlayTrackMsg{Track: &soundcloud.Track{ID: 1, Title: "Debugged"}})
+	component.Update(player.StreamInfoMsg{StreamInfo: &audio.StreamInfo{URL: longStreamURL, Preset: "mp3_1_0"}})
+	component.SetState(player.StatePlaying)
+	require.NotNil(t, mockPlayer.onFormatChange, "the component should listen for formats")
+
+	// The player switches to a different stream mid-session
+	mockPlayer.onFormatChange(beep.Format{SampleRate: 44100, NumChannels: 2})
+	mockPlayer.onFormatChange(beep.Format{SampleRate: 48000, NumChannels: 1})
+	msgs := quickMsgs(component.Init())
+	var changes []player.FormatChangedMsg
+	for _, msg := range msgs {
+		if change, ok := msg.(player.FormatChangedMsg); ok {
+			changes = append(changes, change)
+			component.Update(change)
+		}
+	}
+	require.Len(t, changes, 1, "only the latest format is delivered")
+
+	assert.Contains(t, stripANSI(component.View()), "[MP3 128] • 48 kHz mono")
+	component.Update(runeKey("i"))
+	view := stripANSI(component.View())
+	assert.Contains(t, view, "Sample rate: 48000 Hz", "the reported format wins over the player's rate")
+	assert.Contains(t, view, "Channels:    1")
+}
+
+func TestPlayerComponent_FormatLabels(t *testing.T) {
+	tests := []struct {
+		format   beep.Format
+		expected string
+	}{
+		{beep.Format{SampleRate: 44100, NumChannels: 2}, "44.1 kHz stereo"},
+		{beep.Format{SampleRate: 22050, NumChannels: 1}, "22.05 kHz mono"},
+		{beep.Format{SampleRate: 96000, NumChannels: 6}, "96 kHz 6 channels"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			component := streamingComponent(&testutil.MockAudioPlayer{State: audio.StatePlaying}, &audio.StreamInfo{URL: longStreamURL})
+			component.Update(player.FormatChangedMsg{Format: tt.format})
+
+			assert.Contains(t, stripANSI(component.View()), tt.expected)
+		})
+	}
+}
+
+func TestPlayerComponent_NewTrackClearsFormat(t *testing.T) {
+	component := streamingComponent(&testutil.MockAudioPlayer{State: audio.StatePlaying}, &audio.StreamInfo{URL: longStreamURL})
+	component.Update(player.FormatChangedMsg{Format: beep.Format{SampleRate: 44100, NumChannels: 2}})
+	component.SetState(player.StateIdle)
+
+	component.Update(player.PlayTrackMsg{Track: &soundcloud.Track{ID: 2, Title: "Next"}})
+	component.SetState(player.StatePlaying)
+
+	assert.NotContains(t, stripANSI(component.View()), "44.1 kHz", "the previous track's format is stale")
+}
@@ -0,0 +1,110 @@
+package ui_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/components/player"
+)
+
+func TestPlayerComponent_TickInterval(t *testing.T) {
+	component := player.NewPlayerComponent(testutil.NewMockAudioPlayer(), &testutil.MockStreamExtractor{})
+	assert.Equal(t, player.DefaultTickInterval, component.TickInterval())
+
+	component.SetTickInterval(time.Second / 2)
+	assert.Equal(t, time.Second/2, component.TickInterval())
+
+	component.SetTickInterval(0)
+	assert.Equal(t, player.DefaultTickInterval, component.TickInterval(), "non-positive intervals should restore the default")
+}
+
+func TestPlayerComponent_PausedTicksLessOften(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePaused, Duration: 3 * time.Minute}
+	component := player.NewPlayerComponent(mockPlayer, &testutil.MockStreamExtractor{})
+	component.SetTickInterval(10 * time.Millisecond)
+	component.SetCurrentTrack(&soundcloud.Track{ID: 1, Title: "Paused"})
+	component.SetState(player.StatePaused)
+
+	_, cmd := component.Update(player.ProgressUpdateMsg{Position: time.Minute, Duration: 3 * time.Minute})
+	require.NotNil(t, cmd)
+	assert.Equal(t, player.HeartbeatInterval, component.TickInterval())
+
+	// The tick doesn't fire at the playback interval while paused
+	done := make(chan struct{})
+	go func(tick tea.Cmd) {
+		tick()
+		close(done)
+	}(cmd)
+	select {
+	case <-done:
+		t.Fatal("paused progress ticked at the playback interval")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	mockPlayer.State = audio.StatePlaying
+	component.SetState(player.StatePlaying)
+	_, cmd = component.Update(player.ProgressUpdateMsg{Position: time.Minute + time.Second, Duration: 3 * time.Minute})
+	require.NotNil(t, cmd)
+
+	start := time.Now()
+	cmd()
+	assert.Less(t, time.Since(start), player.HeartbeatInterval, "playback should tick at the configured interval")
+}
+
+// nextTick runs a progress tick command and returns its update
+func nextTick(t *testing.T, cmd tea.Cmd) player.ProgressUpdateMsg {
+	t.Helper()
+	require.NotNil(t, cmd)
+	msg, ok := cmd().(player.ProgressUpdateMsg)
+	require.True(t, ok, "the tick should report progress")
+	return msg
+}
+
+func TestPlayerComponent_StandingPositionUsesHeartbeat(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePlaying, Position: time.Minute, Duration: 3 * time.Minute}
+	component := player.NewPlayerComponent(mockPlayer, &testutil.MockStreamExtractor{})
+	component.SetTickInterval(10 * time.Millisecond)
+	component.SetCurrentTrack(&soundcloud.Track{ID: 1, Title: "Stuck"})
+	component.SetState(player.StatePlaying)
+
+	_, cmd := component.Update(player.ProgressUpdateMsg{Position: 59 * time.Second, Duration: 3 * time.Minute})
+	assert.Equal(t, 10*time.Millisecond, component.TickInterval())
+
+	// The first tick moves the position; the next one finds it standing still
+	_, cmd = component.Update(nextTick(t, cmd))
+	assert.Equal(t, 10*time.Millisecond, component.TickInterval())
+
+	component.Update(nextTick(t, cmd))
+	assert.Equal(t, player.HeartbeatInterval, component.TickInterval())
+}
+
+func TestPlayerComponent_ResumeRestartsTicking(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePaused, Position: time.Minute, Duration: 3 * time.Minute}
+	component := player.NewPlayerComponent(mockPlayer, &testutil.MockStreamExtractor{})
+	component.SetTickInterval(10 * time.Millisecond)
+	component.SetCurrentTrack(&soundcloud.Track{ID: 1, Title: "Paused"})
+	component.SetState(player.StatePaused)
+
+	_, heartbeat := component.Update(player.ProgressUpdateMsg{Position: time.Minute, Duration: 3 * time.Minute})
+	require.NotNil(t, heartbeat)
+	assert.Equal(t, player.HeartbeatInterval, component.TickInterval())
+
+	// Resuming ticks at full rate straight away, without waiting for the heartbeat
+	_, cmd := component.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	require.NotNil(t, cmd)
+	_, cmd = component.Update(cmd())
+	assert.Equal(t, player.StatePlaying, component.GetState())
+	assert.Equal(t, 10*time.Millisecond, component.TickInterval())
+
+	// The heartbeat scheduled while paused no longer drives progress
+	_, staleCmd := component.Update(heartbeat())
+	assert.Nil(t, staleCmd)
+	assert.NotNil(t, cmd)
+}
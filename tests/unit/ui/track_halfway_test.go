@@ -0,0 +1,74 @@
+package ui_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/components/player"
+)
+
+// startTrack loads track in component and reports it playing at the start
+// of a 200 second stream
+func startTrack(t *testing.T, component *player.PlayerComponent, track *soundcloud.Track) {
+	t.Helper()
+	component.Update(player.PlayTrackMsg{Track: track})
+	component.Update(player.StreamInfoMsg{StreamInfo: &audio.StreamInfo{URL: "https://example.com/stream.mp3", Duration: 200000}})
+	component.Update(player.ProgressUpdateMsg{Duration: 200 * time.Second})
+	require.Equal(t, player.StatePlaying, component.GetState())
+}
+
+// halfwayAt reports progress at position and returns the tracks of the
+// TrackHalfwayMsgs it sends
+func halfwayAt(component *player.PlayerComponent, position time.Duration) []*soundcloud.Track {
+	_, cmd := component.Update(player.ProgressUpdateMsg{Position: position, Duration: 200 * time.Second})
+
+	var tracks []*soundcloud.Track
+	for _, msg := range quickMsgs(cmd) {
+		if halfway, ok := msg.(player.TrackHalfwayMsg); ok {
+			tracks = append(tracks, halfway.Track)
+		}
+	}
+	return tracks
+}
+
+func TestPlayerComponent_TrackHalfwaySentOnce(t *testing.T) {
+	mockPlayer := testutil.NewMockAudioPlayer()
+	mockPlayer.State = audio.StatePlaying
+	component := player.NewPlayerComponent(mockPlayer, &testutil.MockStreamExtractor{})
+	first := &soundcloud.Track{ID: 1, Title: "First"}
+	startTrack(t, component, first)
+
+	assert.Empty(t, halfwayAt(component, 60*time.Second))
+	assert.Empty(t, halfwayAt(component, 99*time.Second))
+	assert.Equal(t, []*soundcloud.Track{first}, halfwayAt(component, 100*time.Second))
+	assert.Empty(t, halfwayAt(component, 150*time.Second), "the event should fire only once per track")
+
+	// Seeking back and crossing again doesn't count twice
+	assert.Empty(t, halfwayAt(component, 10*time.Second))
+	assert.Empty(t, halfwayAt(component, 120*time.Second))
+
+	second := &soundcloud.Track{ID: 2, Title: "Second"}
+	startTrack(t, component, second)
+
+	assert.Empty(t, halfwayAt(component, 50*time.Second))
+	assert.Equal(t, []*soundcloud.Track{second}, halfwayAt(component, 110*time.Second), "a new track resets the event")
+}
+
+func TestPlayerComponent_TrackHalfwayNotSentWhilePaused(t *testing.T) {
+	mockPlayer := testutil.NewMockAudioPlayer()
+	mockPlayer.State = audio.StatePlaying
+	component := player.NewPlayerComponent(mockPlayer, &testutil.MockStreamExtractor{})
+	startTrack(t, component, &soundcloud.Track{ID: 1})
+
+	mockPlayer.State = audio.StatePaused
+	component.Update(player.ProgressUpdateMsg{Position: 20 * time.Second, Duration: 200 * time.Second})
+	require.Equal(t, player.StatePaused, component.GetState())
+
+	assert.Empty(t, halfwayAt(component, 120*time.Second))
+}
@@ -0,0 +1,69 @@
+package ui_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/app"
+	"soundcloud-tui/internal/ui/components/player"
+	"soundcloud-tui/internal/ui/components/search"
+)
+
+// queuePlayingApp returns an app with two tracks queued, the first playing
+func queuePlayingApp(t *testing.T, extractor *testutil.MockStreamExtractor) (*app.App, []soundcloud.Track) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	tracks := []soundcloud.Track{{ID: 1, Title: "Blocked"}, {ID: 2, Title: "Fine"}}
+	application := app.NewAppWithDependencies(&testutil.MockSoundCloudClient{}, testutil.NewMockAudioPlayer(), extractor)
+	application.Update(search.AddTracksToQueueMsg{Tracks: tracks})
+	application.Update(player.NextTrackMsg{})
+	require.Equal(t, 0, application.GetQueueComponent().GetCurrentIndex())
+	return application, tracks
+}
+
+func unavailable(reason error) error {
+	return fmt.Errorf("%w: request failed with status 403 Forbidden", reason)
+}
+
+func hasNextTrackMsg(msgs []tea.Msg) bool {
+	for _, msg := range msgs {
+		if _, ok := msg.(player.NextTrackMsg); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func TestApp_UnavailableTrackShowsReason(t *testing.T) {
+	application, _ := queuePlayingApp(t, &testutil.MockStreamExtractor{})
+	application.GetQueueComponent().ClearCurrent() // Played from search
+
+	_, cmd := application.Update(player.PlaybackFailedMsg{Track: &soundcloud.Track{ID: 9, Title: "Searched"}, Error: unavailable(audio.ErrPurchaseRequired)})
+
+	assert.False(t, hasNextTrackMsg(quickMsgs(cmd)), "a track played from search has nothing to skip to")
+	assert.NotContains(t, application.GetNotification(), "Playback failed", "the banner already shows the reason")
+	assert.Equal(t, `Couldn't play "Searched": track requires a purchase or Go+ subscription`, application.GetErrorBanner())
+}
+
+func TestApp_SkipsUnavailableQueueTrack(t *testing.T) {
+	application, tracks := queuePlayingApp(t, &testutil.MockStreamExtractor{})
+
+	_, cmd := application.Update(player.PlaybackFailedMsg{Track: &tracks[0], Error: unavailable(audio.ErrGeoBlocked)})
+
+	msgs := quickMsgs(cmd)
+	assert.True(t, hasNextTrackMsg(msgs))
+	assert.Equal(t, `Skipped "Blocked": track is not available in your region`, application.GetNotification())
+
+	for _, msg := range msgs {
+		application.Update(msg)
+	}
+	assert.Equal(t, 1, application.GetQueueComponent().GetCurrentIndex())
+}
@@ -0,0 +1,22 @@
+package ui_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+)
+
+func TestUnknownArtistIsNeverBlank(t *testing.T) {
+	component := searchWithResults(t, []soundcloud.Track{{ID: 1, Title: "Nameless", Duration: 185000}})
+	component.SetSize(120, 20)
+	assert.Contains(t, component.View(), "Unknown Artist (3:05)")
+
+	component.Update(runeKey("v"))
+	assert.Contains(t, component.View(), "Unknown Artist • 3:05")
+
+	playing := playingComponent(testutil.NewMockAudioPlayer())
+	assert.Contains(t, playing.View(), soundcloud.UnknownArtist)
+}
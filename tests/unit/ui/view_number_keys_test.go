@@ -0,0 +1,74 @@
+package ui_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/app"
+	"soundcloud-tui/internal/ui/components/player"
+	"soundcloud-tui/internal/ui/components/search"
+)
+
+func TestApp_NumberKeysJumpToViews(t *testing.T) {
+	application := createTestApp(t, nil, nil)
+	application.SetCurrentView(app.ViewQueue)
+
+	application.Update(runeKey("1"))
+	assert.Equal(t, app.ViewSearch, application.GetCurrentView())
+
+	application.SetCurrentView(app.ViewQueue)
+	application.Update(runeKey("2"))
+	assert.Equal(t, app.ViewPlayer, application.GetCurrentView())
+}
+
+func TestApp_NumberKeysWorkFromSearchResults(t *testing.T) {
+	application := createTestApp(t, nil, nil)
+	application.Update(search.SearchResultsMsg{Results: []soundcloud.Track{{ID: 1, Title: "Result"}}})
+
+	application.Update(runeKey("3"))
+
+	assert.Equal(t, app.ViewQueue, application.GetCurrentView())
+}
+
+func TestApp_NumberKeysTypeIntoSearchInput(t *testing.T) {
+	application := createTestApp(t, nil, nil)
+
+	application.Update(runeKey("2"))
+
+	assert.Equal(t, app.ViewSearch, application.GetCurrentView())
+	assert.Contains(t, application.View(), "2█")
+}
+
+func TestApp_NumberKeysStillSeekInPlayer(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePlaying, Duration: 100 * time.Second}
+	application := createTestApp(t, nil, mockPlayer)
+	application.GetPlayerComponent().SetCurrentTrack(&soundcloud.Track{ID: 1, Title: "Playing"})
+	application.GetPlayerComponent().SetState(player.StatePlaying)
+	application.SetCurrentView(app.ViewPlayer)
+
+	_, cmd := application.Update(runeKey("3"))
+	if cmd != nil {
+		cmd()
+	}
+
+	assert.Equal(t, app.ViewPlayer, application.GetCurrentView())
+	assert.Equal(t, 30*time.Second, mockPlayer.GetPosition())
+}
+
+func TestApp_AltNumberKeysSwitchFromAnyView(t *testing.T) {
+	application := createTestApp(t, nil, nil)
+	application.SetCurrentView(app.ViewPlayer)
+
+	application.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3"), Alt: true})
+	assert.Equal(t, app.ViewQueue, application.GetCurrentView())
+
+	application.SetCurrentView(app.ViewSearch)
+	application.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2"), Alt: true})
+	assert.Equal(t, app.ViewPlayer, application.GetCurrentView())
+}
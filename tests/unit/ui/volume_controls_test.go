@@ -2,13 +2,13 @@ package ui_test
 
 import (
 	"testing"
-	"time"
 
 	"github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
 
 	"soundcloud-tui/internal/audio"
 	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
 	"soundcloud-tui/internal/ui/components/player"
 )
 
@@ -47,9 +47,9 @@ func TestVolumeControls_IncrementVolume(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockPlayer := &MockAudioPlayer{
-				state:  audio.StatePlaying,
-				volume: tt.initialVolume,
+			mockPlayer := &testutil.MockAudioPlayer{
+				State:  audio.StatePlaying,
+				Volume: tt.initialVolume,
 			}
 
 			playerComponent := player.NewPlayerComponent(mockPlayer, nil)
@@ -117,9 +117,9 @@ func TestVolumeControls_DecrementVolume(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockPlayer := &MockAudioPlayer{
-				state:  audio.StatePlaying,
-				volume: tt.initialVolume,
+			mockPlayer := &testutil.MockAudioPlayer{
+				State:  audio.StatePlaying,
+				Volume: tt.initialVolume,
 			}
 
 			playerComponent := player.NewPlayerComponent(mockPlayer, nil)
@@ -192,9 +192,9 @@ func TestVolumeControls_VolumeDisplay(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockPlayer := &MockAudioPlayer{
-				state:  audio.StatePlaying,
-				volume: tt.volume,
+			mockPlayer := &testutil.MockAudioPlayer{
+				State:  audio.StatePlaying,
+				Volume: tt.volume,
 			}
 
 			playerComponent := player.NewPlayerComponent(mockPlayer, nil)
@@ -206,6 +206,8 @@ func TestVolumeControls_VolumeDisplay(t *testing.T) {
 			}
 			playerComponent.SetCurrentTrack(track)
 			playerComponent.SetState(player.StatePlaying)
+			// The first progress update syncs the volume from the audio player
+			playerComponent.Update(player.ProgressUpdateMsg{})
 
 			view := playerComponent.View()
 
@@ -218,10 +220,28 @@ func TestVolumeControls_VolumeDisplay(t *testing.T) {
 	}
 }
 
+func TestVolumeControls_InitialVolume(t *testing.T) {
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:  audio.StatePlaying,
+		Volume: 0.3,
+	}
+
+	playerComponent := player.NewPlayerComponent(mockPlayer, nil)
+	playerComponent.SetCurrentTrack(&soundcloud.Track{
+		ID:    123,
+		Title: "Test Track",
+		User:  soundcloud.User{Username: "Test Artist"},
+	})
+	playerComponent.SetState(player.StatePlaying)
+
+	// The audio player's volume shows before any progress update
+	assert.Contains(t, playerComponent.View(), "30%")
+}
+
 func TestVolumeControls_KeyboardShortcuts(t *testing.T) {
-	mockPlayer := &MockAudioPlayer{
-		state:  audio.StatePlaying,
-		volume: 0.5,
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:  audio.StatePlaying,
+		Volume: 0.5,
 	}
 
 	playerComponent := player.NewPlayerComponent(mockPlayer, nil)
@@ -283,9 +303,9 @@ func TestVolumeControls_KeyboardShortcuts(t *testing.T) {
 
 func TestVolumeControls_VolumeSteps(t *testing.T) {
 	// Test that volume changes in appropriate increments
-	mockPlayer := &MockAudioPlayer{
-		state:  audio.StatePlaying,
-		volume: 0.5,
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:  audio.StatePlaying,
+		Volume: 0.5,
 	}
 
 	playerComponent := player.NewPlayerComponent(mockPlayer, nil)
@@ -325,9 +345,9 @@ func TestVolumeControls_VolumeSteps(t *testing.T) {
 
 func TestVolumeControls_VolumeInIdleState(t *testing.T) {
 	// Test that volume controls work even when no track is playing
-	mockPlayer := &MockAudioPlayer{
-		state:  audio.StateStopped,
-		volume: 0.5,
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:  audio.StateStopped,
+		Volume: 0.5,
 	}
 
 	playerComponent := player.NewPlayerComponent(mockPlayer, nil)
@@ -346,9 +366,9 @@ func TestVolumeControls_VolumeInIdleState(t *testing.T) {
 
 func TestVolumeControls_VolumeInErrorState(t *testing.T) {
 	// Test volume controls when player is in error state
-	mockPlayer := &MockAudioPlayer{
-		state:  audio.StateStopped,
-		volume: 0.5,
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:  audio.StateStopped,
+		Volume: 0.5,
 	}
 
 	playerComponent := player.NewPlayerComponent(mockPlayer, nil)
@@ -365,9 +385,9 @@ func TestVolumeControls_VolumeInErrorState(t *testing.T) {
 
 func TestVolumeControls_RapidVolumeChanges(t *testing.T) {
 	// Test rapid volume changes don't cause issues
-	mockPlayer := &MockAudioPlayer{
-		state:  audio.StatePlaying,
-		volume: 0.5,
+	mockPlayer := &testutil.MockAudioPlayer{
+		State:  audio.StatePlaying,
+		Volume: 0.5,
 	}
 
 	playerComponent := player.NewPlayerComponent(mockPlayer, nil)
@@ -0,0 +1,103 @@
+package ui_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/config"
+	"soundcloud-tui/internal/soundcloud"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/trackvolume"
+	"soundcloud-tui/internal/ui/app"
+	"soundcloud-tui/internal/ui/components/player"
+)
+
+// volumeMemoryApp returns an app with volume memory set to enabled and
+// tracks queued
+func volumeMemoryApp(t *testing.T, enabled bool, tracks []soundcloud.Track) (*app.App, *testutil.MockAudioPlayer) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	settings, err := config.LoadSettings(config.SettingsPath())
+	require.NoError(t, err)
+	settings.Playback.RememberVolume = enabled
+	require.NoError(t, settings.Save())
+
+	mockPlayer := testutil.NewMockAudioPlayer()
+	application := app.NewAppWithDependencies(&testutil.MockSoundCloudClient{}, mockPlayer, failingExtractor())
+	application.LoadQueue(tracks)
+	return application, mockPlayer
+}
+
+// playNext starts the next queued track and lets it play out
+func playNext(application *app.App) {
+	_, cmd := application.Update(player.NextTrackMsg{})
+	settle(application, cmd)
+}
+
+// turnDown presses '-' times times while a track plays
+func turnDown(application *app.App, times int) {
+	for i := 0; i < times; i++ {
+		_, cmd := application.Update(runeKey("-"))
+		settle(application, cmd)
+	}
+}
+
+func TestApp_RemembersTrackVolume(t *testing.T) {
+	tracks := []soundcloud.Track{
+		{ID: 1, Title: "Talk", User: soundcloud.User{ID: 10}},
+		{ID: 2, Title: "Song", User: soundcloud.User{ID: 20}},
+		{ID: 3, Title: "More talk", User: soundcloud.User{ID: 10}},
+		{ID: 1, Title: "Talk", User: soundcloud.User{ID: 10}},
+	}
+	application, mockPlayer := volumeMemoryApp(t, true, tracks)
+
+	playNext(application)
+	turnDown(application, 5)
+	require.InDelta(t, 0.5, mockPlayer.GetVolume(), 0.001)
+
+	playNext(application)
+	assert.InDelta(t, 1.0, mockPlayer.GetVolume(), 0.001, "a track without a remembered volume keeps the default")
+
+	playNext(application)
+	assert.InDelta(t, 0.5, mockPlayer.GetVolume(), 0.001, "another track by the same artist uses the artist's volume")
+
+	playNext(application)
+	assert.InDelta(t, 0.5, mockPlayer.GetVolume(), 0.001)
+	assert.InDelta(t, 0.5, application.GetPlayerComponent().GetVolume(), 0.001)
+
+	// Remembered across runs
+	saved, err := trackvolume.Load(filepath.Join(config.ConfigDir(), trackvolume.FileName), 0)
+	require.NoError(t, err)
+	volume, ok := saved.Lookup(1, 10)
+	require.True(t, ok)
+	assert.InDelta(t, 0.5, volume, 0.001)
+}
+
+func TestApp_VolumeSetBeforePlayingIsTheDefault(t *testing.T) {
+	tracks := []soundcloud.Track{{ID: 1, Title: "Talk"}, {ID: 2, Title: "Song"}}
+	application, mockPlayer := volumeMemoryApp(t, true, tracks)
+	turnDown(application, 2)
+
+	playNext(application)
+	turnDown(application, 3)
+	playNext(application)
+
+	assert.InDelta(t, 0.8, mockPlayer.GetVolume(), 0.001)
+}
+
+func TestApp_VolumeMemoryIsOptIn(t *testing.T) {
+	tracks := []soundcloud.Track{{ID: 1, Title: "Talk"}, {ID: 2, Title: "Song"}, {ID: 1, Title: "Talk"}}
+	application, mockPlayer := volumeMemoryApp(t, false, tracks)
+
+	playNext(application)
+	turnDown(application, 5)
+	playNext(application)
+	playNext(application)
+
+	assert.InDelta(t, 0.5, mockPlayer.GetVolume(), 0.001, "the volume should carry over unchanged")
+	assert.NoFileExists(t, filepath.Join(config.ConfigDir(), trackvolume.FileName))
+}
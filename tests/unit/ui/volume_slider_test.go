@@ -0,0 +1,76 @@
+package ui_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"soundcloud-tui/internal/audio"
+	"soundcloud-tui/internal/testutil"
+	"soundcloud-tui/internal/ui/components/player"
+	"soundcloud-tui/internal/ui/styles"
+)
+
+// componentAtVolume returns a playing component synced to the given volume
+func componentAtVolume(volume float64, width int) *player.PlayerComponent {
+	mockPlayer := &testutil.MockAudioPlayer{State: audio.StatePlaying, Volume: volume}
+	component := playingComponent(mockPlayer)
+	component.SetSize(width, 24)
+	component.Update(player.ProgressUpdateMsg{})
+	return component
+}
+
+func TestPlayerComponent_VolumeSlider(t *testing.T) {
+	styles.SetNoColor(true)
+	defer styles.SetNoColor(false)
+
+	tests := []struct {
+		volume   float64
+		expected string
+	}{
+		{0, "[muted] ---------- 0%"},
+		{0.3, "[vol] ###------- 30%"},
+		{0.8, "[vol] ########-- 80%"},
+		{1, "[vol] ########## 100%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			component := componentAtVolume(tt.volume, 80)
+
+			assert.Contains(t, component.View(), tt.expected)
+		})
+	}
+}
+
+func TestPlayerComponent_VolumeSliderFollowsVolumeKeys(t *testing.T) {
+	styles.SetNoColor(true)
+	defer styles.SetNoColor(false)
+
+	component := componentAtVolume(0.5, 80)
+	assert.Contains(t, component.View(), "#####----- 50%")
+
+	_, cmd := component.Update(runeKey("+"))
+	require.NotNil(t, cmd)
+	component.Update(cmd())
+
+	assert.Contains(t, component.View(), "######---- 60%")
+}
+
+func TestPlayerComponent_VolumeSliderFitsNarrowWidths(t *testing.T) {
+	styles.SetNoColor(true)
+	defer styles.SetNoColor(false)
+
+	for _, width := range []int{24, 28, 32, 40} {
+		view := componentAtVolume(1, width).View()
+		assert.Contains(t, view, "100%", "width %d", width)
+		for _, line := range strings.Split(view, "\n") {
+			if strings.Contains(line, "100%") {
+				assert.LessOrEqual(t, lipgloss.Width(line), width, "width %d: %q", width, line)
+			}
+		}
+	}
+}
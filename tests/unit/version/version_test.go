@@ -0,0 +1,32 @@
+package version_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"soundcloud-tui/internal/version"
+)
+
+// stamp sets the build version and commit for the test
+func stamp(t *testing.T, v, commit string) {
+	t.Helper()
+	oldVersion, oldCommit := version.Version, version.Commit
+	t.Cleanup(func() {
+		version.Version, version.Commit = oldVersion, oldCommit
+	})
+	version.Version, version.Commit = v, commit
+}
+
+func TestString(t *testing.T) {
+	stamp(t, "v1.4.0", "abc1234")
+
+	assert.Equal(t, "sctui v1.4.0 (commit abc1234, "+runtime.Version()+")", version.String())
+	assert.Equal(t, "sctui/v1.4.0", version.UserAgent())
+}
+
+func TestString_DefaultsToDev(t *testing.T) {
+	assert.Equal(t, "dev", version.Version)
+	assert.Contains(t, version.String(), "sctui dev (commit ")
+}